@@ -10,7 +10,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
 	bootstrap "github.com/FabioRocha231/saas-core/internal/infra/http"
+	"github.com/FabioRocha231/saas-core/internal/infra/scheduler"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -51,7 +53,30 @@ func main() {
 	}))
 
 	// Rotas
-	bootstrap.RegisterRoutes(r)
+	app, err := bootstrap.RegisterRoutes(r, db.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("[saas-core] bootstrap error: %v", err)
+	}
+	defer func() {
+		if err := app.Repos.Close(); err != nil {
+			log.Printf("[saas-core] database close error: %v\n", err)
+		}
+	}()
+
+	// ===== Jobs em background =====
+	schedulerConfig, err := scheduler.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("[saas-core] scheduler config error: %v", err)
+	}
+	// mesmos gateways e relógio das rotas
+	jobs := scheduler.New(scheduler.ExpirationJobs(
+		schedulerConfig,
+		app.Repos,
+		app.Gateways,
+		app.UUID,
+		app.Clock,
+	)...)
+	jobs.Start(context.Background())
 
 	// ===== HTTP Server com timeouts =====
 	srv := &http.Server{
@@ -88,5 +113,10 @@ func main() {
 		_ = srv.Close()
 	}
 
+	// depois do HTTP: nada novo chega, só termina o lote em andamento
+	if err := jobs.Shutdown(ctx); err != nil {
+		log.Printf("[saas-core] scheduler shutdown error: %v\n", err)
+	}
+
 	log.Println("[saas-core] server stopped gracefully")
 }
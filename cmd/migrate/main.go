@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/migrations"
+	"github.com/joho/godotenv"
+)
+
+const usage = `usage: migrate [-steps N] <up|down|status|redo>
+
+  up      aplica todas as migrations pendentes
+  down    desfaz as últimas N migrations (padrão 1)
+  status  lista as migrations e quando foram aplicadas
+  redo    desfaz e reaplica a última migration
+
+O banco vem de DB_DRIVER (postgres | sqlite), DATABASE_URL e SQLITE_PATH.
+`
+
+func main() {
+	steps := flag.Int("steps", 1, "quantidade de migrations desfeitas pelo down")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// .env é opcional aqui: em CI/deploy as variáveis já vêm do ambiente
+	_ = godotenv.Load()
+
+	conn, err := db.Open(db.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("[migrate] open database: %v", err)
+	}
+	defer conn.Close()
+
+	migrator, err := migrations.New(conn)
+	if err != nil {
+		log.Fatalf("[migrate] load migrations: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	switch flag.Arg(0) {
+	case "up":
+		ran, err := migrator.Up(ctx)
+		printRan("up", ran)
+		if err != nil {
+			log.Fatalf("[migrate] up: %v", err)
+		}
+		if len(ran) == 0 {
+			log.Println("[migrate] nothing to apply")
+		}
+	case "down":
+		ran, err := migrator.Down(ctx, *steps)
+		printRan("down", ran)
+		if err != nil {
+			log.Fatalf("[migrate] down: %v", err)
+		}
+		if len(ran) == 0 {
+			log.Println("[migrate] nothing to roll back")
+		}
+	case "redo":
+		mig, err := migrator.Redo(ctx)
+		if err != nil {
+			log.Fatalf("[migrate] redo: %v", err)
+		}
+		log.Printf("[migrate] redo %04d_%s\n", mig.Version, mig.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("[migrate] status: %v", err)
+		}
+		for _, st := range statuses {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Local().Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", st.Version, st.Name, applied)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printRan(direction string, ran []migrations.Migration) {
+	for _, mig := range ran {
+		log.Printf("[migrate] %s %04d_%s\n", direction, mig.Version, mig.Name)
+	}
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type AuditAction string
+
+const (
+	// login travado por excesso de falhas (email ou IP)
+	AuditActionLoginLockout AuditAction = "auth.login_lockout"
+)
+
+// AuditEntry registra um evento de segurança. Só cresce: nada aqui é
+// alterado ou apagado pela aplicação.
+type AuditEntry struct {
+	ID     string
+	Action AuditAction
+	// usuário afetado, quando conhecido
+	UserID string
+	// o que foi afetado (ex.: "email:fulano@x.com", "ip:10.0.0.1")
+	Subject string
+	IP      string
+	Detail  string
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// LoginThrottle conta as falhas de login recentes de uma chave (email ou IP).
+// Passando do limite, a chave fica travada até LockedUntil.
+type LoginThrottle struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && t.LockedUntil.After(now)
+}
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+)
+
+const (
+	minutesPerDay = 24 * 60
+	dateLayout    = "2006-01-02"
+)
+
+// Os horários são minutos desde 00:00 no fuso da loja. Closes <= Opens
+// atravessa a meia-noite (18:00-02:00); 00:00-24:00 é o dia inteiro.
+type WeeklyWindow struct {
+	Weekday time.Weekday
+	Opens   int
+	Closes  int
+}
+
+// HoursException substitui o horário semanal numa data (feriado, evento).
+// Closed fecha o dia inteiro; senão cada exceção da data é uma janela.
+type HoursException struct {
+	Date   string // AAAA-MM-DD no fuso da loja
+	Closed bool
+	Opens  int
+	Closes int
+	Note   string
+}
+
+// OpeningHours sem janelas semanais é "sempre aberta", salvo as exceções.
+type OpeningHours struct {
+	Weekly     []WeeklyWindow
+	Exceptions []HoursException
+}
+
+type span struct {
+	opens  int
+	closes int
+}
+
+func (s span) overnight() bool {
+	return s.closes <= s.opens
+}
+
+// OpenAt diz se o horário cobre o instante local informado, incluindo a
+// sobra da madrugada de uma janela do dia anterior.
+func (h OpeningHours) OpenAt(local time.Time) bool {
+	minute := local.Hour()*60 + local.Minute()
+
+	for _, s := range h.spansOn(local) {
+		if minute >= s.opens && (s.overnight() || minute < s.closes) {
+			return true
+		}
+	}
+	for _, s := range h.spansOn(local.AddDate(0, 0, -1)) {
+		if s.overnight() && minute < s.closes {
+			return true
+		}
+	}
+	return false
+}
+
+func (h OpeningHours) spansOn(day time.Time) []span {
+	date := day.Format(dateLayout)
+
+	var spans []span
+	found := false
+	for _, e := range h.Exceptions {
+		if e.Date != date {
+			continue
+		}
+		if e.Closed {
+			return nil
+		}
+		found = true
+		spans = append(spans, span{opens: e.Opens, closes: e.Closes})
+	}
+	if found {
+		return spans
+	}
+
+	if len(h.Weekly) == 0 {
+		return []span{{opens: 0, closes: minutesPerDay}}
+	}
+	for _, w := range h.Weekly {
+		if w.Weekday == day.Weekday() {
+			spans = append(spans, span{opens: w.Opens, closes: w.Closes})
+		}
+	}
+	return spans
+}
+
+func (h OpeningHours) Validate() error {
+	for _, w := range h.Weekly {
+		if w.Weekday < time.Sunday || w.Weekday > time.Saturday {
+			return errx.New(errx.CodeInvalid, "invalid weekday")
+		}
+		if err := validateSpan(w.Opens, w.Closes); err != nil {
+			return err
+		}
+	}
+
+	closedDates := make(map[string]bool)
+	openDates := make(map[string]bool)
+	for _, e := range h.Exceptions {
+		if _, err := time.Parse(dateLayout, e.Date); err != nil {
+			return errx.F(errx.CodeInvalid, "invalid exception date %q, expected YYYY-MM-DD", e.Date)
+		}
+		if e.Closed {
+			closedDates[e.Date] = true
+			continue
+		}
+		openDates[e.Date] = true
+		if err := validateSpan(e.Opens, e.Closes); err != nil {
+			return err
+		}
+	}
+	for date := range closedDates {
+		if openDates[date] {
+			return errx.F(errx.CodeInvalid, "exception date %s is both closed and open", date)
+		}
+	}
+	return nil
+}
+
+func validateSpan(opens, closes int) error {
+	if opens < 0 || opens >= minutesPerDay || closes < 0 || closes > minutesPerDay {
+		return errx.New(errx.CodeInvalid, "opening hours must be between 00:00 and 24:00")
+	}
+	if opens == closes {
+		return errx.New(errx.CodeInvalid, "opening and closing times must differ")
+	}
+	return nil
+}
+
+// ParseClock lê "HH:MM" (aceita "24:00" como fim do dia) em minutos.
+func ParseClock(s string) (int, error) {
+	invalid := errx.F(errx.CodeInvalid, "invalid time %q, expected HH:MM", s)
+	if len(s) != 5 || s[2] != ':' {
+		return 0, invalid
+	}
+	digits := []byte{s[0], s[1], s[3], s[4]}
+	for _, d := range digits {
+		if d < '0' || d > '9' {
+			return 0, invalid
+		}
+	}
+	h := int(digits[0]-'0')*10 + int(digits[1]-'0')
+	m := int(digits[2]-'0')*10 + int(digits[3]-'0')
+	if m > 59 || h*60+m > minutesPerDay {
+		return 0, invalid
+	}
+	return h*60 + m, nil
+}
+
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
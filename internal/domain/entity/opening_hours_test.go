@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/stretchr/testify/require"
+)
+
+func clock(t *testing.T, s string) int {
+	t.Helper()
+	m, err := ParseClock(s)
+	require.NoError(t, err)
+	return m
+}
+
+func TestOpeningHours_OpenAt(t *testing.T) {
+	loc, err := time.LoadLocation(DefaultStoreTimezone)
+	require.NoError(t, err)
+	// 2026-10-16 é uma sexta-feira
+	at := func(day int, hhmm string) time.Time {
+		m := clock(t, hhmm)
+		return time.Date(2026, 10, day, m/60, m%60, 0, 0, loc)
+	}
+
+	hours := OpeningHours{
+		Weekly: []WeeklyWindow{
+			{Weekday: time.Friday, Opens: clock(t, "11:00"), Closes: clock(t, "14:00")},
+			{Weekday: time.Friday, Opens: clock(t, "18:00"), Closes: clock(t, "02:00")},
+			{Weekday: time.Saturday, Opens: clock(t, "18:00"), Closes: clock(t, "23:00")},
+		},
+		Exceptions: []HoursException{
+			{Date: "2026-10-24", Closed: true, Note: "Feriado"},
+			{Date: "2026-10-25", Opens: clock(t, "10:00"), Closes: clock(t, "12:00")},
+		},
+	}
+
+	t.Run("test inside and outside the weekly windows", func(t *testing.T) {
+		require.False(t, hours.OpenAt(at(16, "10:59")))
+		require.True(t, hours.OpenAt(at(16, "11:00")))
+		require.False(t, hours.OpenAt(at(16, "14:00")))
+		require.True(t, hours.OpenAt(at(16, "23:30")))
+	})
+
+	t.Run("test a window that crosses midnight", func(t *testing.T) {
+		require.True(t, hours.OpenAt(at(17, "01:59")))
+		require.False(t, hours.OpenAt(at(17, "02:00")))
+		require.True(t, hours.OpenAt(at(17, "18:00")))
+	})
+
+	t.Run("test exceptions replace the weekly hours", func(t *testing.T) {
+		require.False(t, hours.OpenAt(at(24, "19:00")))
+		require.True(t, hours.OpenAt(at(25, "11:00")))
+		require.False(t, hours.OpenAt(at(25, "12:00")))
+	})
+
+	t.Run("test empty weekly hours mean always open except for exceptions", func(t *testing.T) {
+		open := OpeningHours{Exceptions: []HoursException{{Date: "2026-12-25", Closed: true}}}
+
+		require.True(t, open.OpenAt(at(16, "03:00")))
+		require.False(t, open.OpenAt(time.Date(2026, 12, 25, 12, 0, 0, 0, loc)))
+	})
+}
+
+func TestStore_IsOpenAt(t *testing.T) {
+	store := &Store{
+		IsOpen:   true,
+		Timezone: "America/Sao_Paulo",
+		Hours: OpeningHours{Weekly: []WeeklyWindow{
+			{Weekday: time.Friday, Opens: clock(t, "11:00"), Closes: clock(t, "14:00")},
+		}},
+	}
+	// 13:30 UTC = 10:30 em São Paulo
+	early := time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC)
+
+	t.Run("test the schedule uses the store timezone", func(t *testing.T) {
+		require.False(t, store.IsOpenAt(early))
+		require.True(t, store.IsOpenAt(early.Add(time.Hour)))
+	})
+
+	t.Run("test the manual toggle closes the store", func(t *testing.T) {
+		closed := *store
+		closed.IsOpen = false
+
+		require.False(t, closed.IsOpenAt(early.Add(time.Hour)))
+	})
+
+	t.Run("test a pause closes the store until it ends", func(t *testing.T) {
+		paused := *store
+		until := early.Add(90 * time.Minute)
+		paused.PausedUntil = &until
+
+		require.False(t, paused.IsOpenAt(early.Add(time.Hour)))
+		require.True(t, paused.IsOpenAt(until))
+	})
+}
+
+func TestOpeningHours_Validate(t *testing.T) {
+	t.Run("test valid hours", func(t *testing.T) {
+		hours := OpeningHours{
+			Weekly:     []WeeklyWindow{{Weekday: time.Monday, Opens: 0, Closes: 24 * 60}},
+			Exceptions: []HoursException{{Date: "2026-12-25", Closed: true}},
+		}
+		require.NoError(t, hours.Validate())
+	})
+
+	invalid := map[string]OpeningHours{
+		"weekday":       {Weekly: []WeeklyWindow{{Weekday: 7, Opens: 60, Closes: 120}}},
+		"same times":    {Weekly: []WeeklyWindow{{Weekday: time.Monday, Opens: 60, Closes: 60}}},
+		"out of range":  {Weekly: []WeeklyWindow{{Weekday: time.Monday, Opens: 60, Closes: 24*60 + 1}}},
+		"date":          {Exceptions: []HoursException{{Date: "25/12/2026", Closed: true}}},
+		"closed + open": {Exceptions: []HoursException{{Date: "2026-12-25", Closed: true}, {Date: "2026-12-25", Opens: 60, Closes: 120}}},
+	}
+	for name, hours := range invalid {
+		t.Run("test invalid "+name, func(t *testing.T) {
+			err := hours.Validate()
+			require.Error(t, err)
+			require.Equal(t, errx.CodeInvalid, errx.CodeOf(err))
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	require.Equal(t, 0, clock(t, "00:00"))
+	require.Equal(t, 18*60+30, clock(t, "18:30"))
+	require.Equal(t, 24*60, clock(t, "24:00"))
+	require.Equal(t, "08:05", FormatClock(8*60+5))
+
+	for _, s := range []string{"8:00", "24:01", "12:60", "-1:00", "ab:cd"} {
+		_, err := ParseClock(s)
+		require.Error(t, err, s)
+	}
+}
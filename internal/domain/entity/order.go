@@ -5,10 +5,17 @@ import "time"
 type OrderStatus string
 
 const (
-	OrderCreated  OrderStatus = "CREATED"
-	OrderPlaced   OrderStatus = "PLACED"
-	OrderPaid     OrderStatus = "PAID"
-	OrderCanceled OrderStatus = "CANCELED"
+	OrderCreated        OrderStatus = "CREATED"
+	OrderPlaced         OrderStatus = "PLACED"
+	OrderPaid           OrderStatus = "PAID"
+	OrderAccepted       OrderStatus = "ACCEPTED"
+	OrderPreparing      OrderStatus = "PREPARING"
+	OrderReady          OrderStatus = "READY"
+	OrderOutForDelivery OrderStatus = "OUT_FOR_DELIVERY"
+	OrderDelivered      OrderStatus = "DELIVERED"
+	OrderRejected       OrderStatus = "REJECTED"
+	OrderCanceled       OrderStatus = "CANCELED"
+	OrderRefunded       OrderStatus = "REFUNDED"
 )
 
 // Dinheiro SEMPRE em centavos (int64)
@@ -28,6 +35,19 @@ type Order struct {
 	Fees     MoneyCents
 	Total    MoneyCents
 
+	// controle de concorrência otimista: o repo incrementa a cada Update
+	// e recusa updates feitos a partir de uma versão antiga
+	Version int64
+
+	// histórico de mudanças de status (ver TransitionTo)
+	Transitions []OrderTransition
+
+	// preenchido quando o pedido é cancelado ou recusado (ver Cancel)
+	Cancellation *OrderCancellation
+
+	// pedido agendado pelo cliente; nil é "o quanto antes"
+	ScheduledFor *time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
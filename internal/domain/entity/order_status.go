@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+)
+
+// OrderActor identifica quem disparou uma mudança de status do pedido.
+type OrderActor string
+
+const (
+	OrderActorCustomer OrderActor = "customer"
+	OrderActorStore    OrderActor = "store"
+	OrderActorSystem   OrderActor = "system" // pagamentos, jobs, webhooks
+)
+
+type OrderTransition struct {
+	From    OrderStatus
+	To      OrderStatus
+	Actor   OrderActor
+	ActorID string // vazio quando Actor == system
+	At      time.Time
+}
+
+type OrderCancellation struct {
+	Actor   OrderActor
+	ActorID string
+	Reason  string
+	At      time.Time
+}
+
+// orderTransitions é a máquina de estados do pedido: origem -> destino -> quem pode.
+var orderTransitions = map[OrderStatus]map[OrderStatus][]OrderActor{
+	OrderCreated: {
+		OrderPlaced:   {OrderActorCustomer},
+		OrderCanceled: {OrderActorCustomer},
+	},
+	OrderPlaced: {
+		OrderPaid:     {OrderActorSystem},
+		OrderRejected: {OrderActorStore},
+		OrderCanceled: {OrderActorCustomer, OrderActorStore, OrderActorSystem},
+	},
+	OrderPaid: {
+		OrderAccepted: {OrderActorStore},
+		OrderRejected: {OrderActorStore},
+		OrderCanceled: {OrderActorCustomer},
+	},
+	OrderAccepted: {
+		OrderPreparing: {OrderActorStore},
+		OrderCanceled:  {OrderActorStore},
+	},
+	OrderPreparing: {
+		OrderReady:    {OrderActorStore},
+		OrderCanceled: {OrderActorStore},
+	},
+	OrderReady: {
+		OrderOutForDelivery: {OrderActorStore},
+		OrderDelivered:      {OrderActorStore}, // retirada no balcão
+	},
+	OrderOutForDelivery: {
+		OrderDelivered: {OrderActorStore},
+	},
+	OrderRejected: {
+		OrderRefunded: {OrderActorStore, OrderActorSystem},
+	},
+	OrderCanceled: {
+		OrderRefunded: {OrderActorStore, OrderActorSystem},
+	},
+	OrderDelivered: {
+		OrderRefunded: {OrderActorStore, OrderActorSystem},
+	},
+}
+
+var OrderStatusMap = map[string]OrderStatus{
+	"CREATED":          OrderCreated,
+	"PLACED":           OrderPlaced,
+	"PAID":             OrderPaid,
+	"ACCEPTED":         OrderAccepted,
+	"PREPARING":        OrderPreparing,
+	"READY":            OrderReady,
+	"OUT_FOR_DELIVERY": OrderOutForDelivery,
+	"DELIVERED":        OrderDelivered,
+	"REJECTED":         OrderRejected,
+	"CANCELED":         OrderCanceled,
+	"REFUNDED":         OrderRefunded,
+}
+
+// CanTransitionTo diz se o actor pode levar o pedido do status s para `to`.
+func (s OrderStatus) CanTransitionTo(to OrderStatus, actor OrderActor) bool {
+	for _, a := range orderTransitions[s][to] {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal indica que o pedido não sai mais desse status.
+func (s OrderStatus) IsFinal() bool {
+	return len(orderTransitions[s]) == 0
+}
+
+// IsEditable indica que o carrinho ainda aceita mudanças nos itens.
+func (o *Order) IsEditable() bool {
+	return o.Status == OrderCreated
+}
+
+// TransitionTo aplica a mudança de status validando a máquina de estados e
+// registra a transição no histórico do pedido.
+func (o *Order) TransitionTo(to OrderStatus, actor OrderActor, actorID string, at time.Time) error {
+	allowed, ok := orderTransitions[o.Status][to]
+	if !ok || len(allowed) == 0 {
+		return errx.F(errx.CodeConflict, "order cannot go from %s to %s", o.Status, to)
+	}
+	if !o.Status.CanTransitionTo(to, actor) {
+		return errx.F(errx.CodeForbidden, "%s cannot move order from %s to %s", actor, o.Status, to)
+	}
+
+	o.Transitions = append(o.Transitions, OrderTransition{
+		From:    o.Status,
+		To:      to,
+		Actor:   actor,
+		ActorID: actorID,
+		At:      at,
+	})
+	o.Status = to
+	o.UpdatedAt = at
+	return nil
+}
+
+// Cancel encerra o pedido registrando quem cancelou e por quê. A loja recusa
+// (REJECTED) o que ainda não aceitou e cancela o que já estava em preparo.
+func (o *Order) Cancel(actor OrderActor, actorID, reason string, at time.Time) error {
+	to := OrderCanceled
+	if actor == OrderActorStore && o.Status.CanTransitionTo(OrderRejected, actor) {
+		to = OrderRejected
+	}
+
+	if err := o.TransitionTo(to, actor, actorID, at); err != nil {
+		return err
+	}
+
+	o.Cancellation = &OrderCancellation{
+		Actor:   actor,
+		ActorID: actorID,
+		Reason:  reason,
+		At:      at,
+	}
+	return nil
+}
+
+// IsCanceled indica que o pedido foi encerrado sem entrega.
+func (s OrderStatus) IsCanceled() bool {
+	return s == OrderCanceled || s == OrderRejected
+}
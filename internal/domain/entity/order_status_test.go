@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderTransitionTo_FollowsTheHappyPath(t *testing.T) {
+	o := &Order{Status: OrderCreated}
+	at := time.Now()
+
+	steps := []struct {
+		to    OrderStatus
+		actor OrderActor
+	}{
+		{OrderPlaced, OrderActorCustomer},
+		{OrderPaid, OrderActorSystem},
+		{OrderAccepted, OrderActorStore},
+		{OrderPreparing, OrderActorStore},
+		{OrderReady, OrderActorStore},
+		{OrderOutForDelivery, OrderActorStore},
+		{OrderDelivered, OrderActorStore},
+	}
+	for i, step := range steps {
+		require.NoError(t, o.TransitionTo(step.to, step.actor, "actor-id", at.Add(time.Duration(i)*time.Minute)))
+	}
+
+	require.Equal(t, OrderDelivered, o.Status)
+	require.Len(t, o.Transitions, len(steps))
+	require.Equal(t, OrderCreated, o.Transitions[0].From)
+	require.Equal(t, OrderPlaced, o.Transitions[0].To)
+	require.Equal(t, at.Add(6*time.Minute), o.UpdatedAt)
+}
+
+func TestOrderTransitionTo_RejectsUnknownTransition(t *testing.T) {
+	o := &Order{Status: OrderPlaced}
+
+	err := o.TransitionTo(OrderDelivered, OrderActorStore, "", time.Now())
+	require.Error(t, err)
+	require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	require.Equal(t, OrderPlaced, o.Status)
+	require.Empty(t, o.Transitions)
+}
+
+func TestOrderTransitionTo_RejectsActorWithoutPermission(t *testing.T) {
+	tests := []struct {
+		from  OrderStatus
+		to    OrderStatus
+		actor OrderActor
+	}{
+		{OrderPlaced, OrderPaid, OrderActorCustomer},   // só o pagamento marca PAID
+		{OrderPaid, OrderAccepted, OrderActorCustomer}, // cliente não aceita o próprio pedido
+		{OrderCreated, OrderPlaced, OrderActorStore},   // loja não fecha carrinho
+		{OrderAccepted, OrderCanceled, OrderActorCustomer},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			o := &Order{Status: tt.from}
+
+			err := o.TransitionTo(tt.to, tt.actor, "", time.Now())
+			require.Error(t, err)
+			require.Equal(t, errx.CodeForbidden, errx.CodeOf(err))
+			require.Equal(t, tt.from, o.Status)
+		})
+	}
+}
+
+func TestOrderStatus_IsFinal(t *testing.T) {
+	require.True(t, OrderRefunded.IsFinal())
+	require.False(t, OrderDelivered.IsFinal())
+	require.False(t, OrderCreated.IsFinal())
+}
+
+func TestOrderCancel_RecordsWhoAndWhy(t *testing.T) {
+	at := time.Now()
+
+	o := &Order{Status: OrderPaid}
+	require.NoError(t, o.Cancel(OrderActorStore, "owner-1", "sem estoque", at))
+	require.Equal(t, OrderRejected, o.Status)
+	require.Equal(t, &OrderCancellation{Actor: OrderActorStore, ActorID: "owner-1", Reason: "sem estoque", At: at}, o.Cancellation)
+
+	o = &Order{Status: OrderPreparing}
+	require.NoError(t, o.Cancel(OrderActorStore, "owner-1", "acabou o gás", at))
+	require.Equal(t, OrderCanceled, o.Status)
+
+	o = &Order{Status: OrderPlaced}
+	require.NoError(t, o.Cancel(OrderActorCustomer, "user-1", "", at))
+	require.Equal(t, OrderCanceled, o.Status)
+
+	o = &Order{Status: OrderDelivered}
+	require.Error(t, o.Cancel(OrderActorCustomer, "user-1", "", at))
+	require.Nil(t, o.Cancellation)
+}
@@ -12,6 +12,9 @@ const (
 	PaymentStatusPaid     PaymentStatus = "PAID"
 	PaymentStatusFailed   PaymentStatus = "FAILED"
 	PaymentStatusCanceled PaymentStatus = "CANCELED"
+	PaymentStatusRefunded PaymentStatus = "REFUNDED"
+
+	PaymentStatusPartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
 )
 
 const (
@@ -29,10 +32,27 @@ func (p PaymentStatus) String() string {
 	return string(p)
 }
 
+// IsExpired indica que a cobrança venceu sem ser paga.
+func (p *Payment) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
+}
+
+// IsRefundable indica que ainda existe valor pago que pode ser estornado.
+func (p PaymentStatus) IsRefundable() bool {
+	return p == PaymentStatusPaid || p == PaymentStatusPartiallyRefunded
+}
+
 func (p PaymentMethod) String() string {
 	return string(p)
 }
 
+var PaymentMethodMap = map[string]PaymentMethod{
+	"CREDIT_CARD": PaymentMethodCreditCard,
+	"DEBIT_CARD":  PaymentMethodDebitCard,
+	"PIX":         PaymentMethodPix,
+	"MOCK":        PaymentMethodMock,
+}
+
 func (p PaymentProvider) String() string {
 	return string(p)
 }
@@ -47,11 +67,23 @@ type Payment struct {
 	Provider PaymentProvider
 	Status   PaymentStatus
 
+	// id da cobrança no provider (vazio até o gateway autorizar)
+	ProviderRef string
+
+	// PIX: txid e "copia e cola" (BR Code) devolvidos pelo provider
+	PixTxID string
+	PixCode string
+	// depois disso a cobrança não pode mais ser paga (PIX vence)
+	ExpiresAt *time.Time
+
 	Amount   int64 // centavos (sempre = order.Total no momento da criação)
 	Currency string
 
 	IdempotencyKey string
 
+	// controle de concorrência otimista (ver Order.Version)
+	Version int64
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	PaidAt    *time.Time
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// PaymentEvent é uma notificação (webhook) recebida do provider, guardada
+// crua para auditoria. (Provider, EventID) é único: cada evento só é aplicado
+// uma vez, mesmo que o provider reenvie.
+type PaymentEvent struct {
+	ID       string
+	Provider PaymentProvider
+	EventID  string // id do evento no provider
+
+	PaymentRef string
+	PaymentID  string // vazio quando nenhum pagamento tem a referência
+	Status     PaymentStatus
+	Amount     int64
+
+	Payload string // corpo recebido, sem alteração
+	Result  string // o que foi feito com o evento (ex.: "applied", "ignored: ...")
+
+	OccurredAt time.Time // quando aconteceu no provider
+	ReceivedAt time.Time
+}
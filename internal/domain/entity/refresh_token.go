@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// RefreshToken é o segredo opaco trocado por um novo access token. Todos os
+// tokens de um mesmo login formam uma família identificada pelo SessionID.
+type RefreshToken struct {
+	ID        string
+	SessionID string
+	UserID    string
+	// só o hash é persistido, nunca o token em si
+	TokenHash string
+	ExpiresAt time.Time
+	// preenchido na rotação; reapresentar um token usado é sinal de vazamento
+	UsedAt *time.Time
+
+	CreatedAt time.Time
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.After(now)
+}
+
+func (t *RefreshToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "PENDING"
+	RefundStatusSucceeded RefundStatus = "SUCCEEDED"
+	RefundStatusFailed    RefundStatus = "FAILED"
+)
+
+func (r RefundStatus) String() string {
+	return string(r)
+}
+
+// Refund é um estorno (total ou parcial) de um pagamento. A soma dos estornos
+// que não falharam nunca passa de Payment.Amount.
+type Refund struct {
+	ID        string
+	PaymentID string
+	OrderID   string
+	StoreID   string
+
+	Amount   int64 // centavos
+	Currency string
+	Reason   string
+	Status   RefundStatus
+
+	IdempotencyKey string
+
+	// id do estorno no provider do pagamento
+	ProviderRef string
+
+	RequestedBy string // userID da loja; vazio quando o sistema estornou (cancelamento)
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -8,5 +8,9 @@ type Session struct {
 	Role      string
 	ExpiresAt time.Time
 
+	// metadados do login, exibidos em GET /sessions
+	UserAgent string
+	IP        string
+
 	CreatedAt time.Time
 }
@@ -1,10 +1,58 @@
 package entity
 
+import (
+	"time"
+	// embute a base de fusos: a imagem de produção pode não ter /usr/share/zoneinfo
+	_ "time/tzdata"
+)
+
+const DefaultStoreTimezone = "America/Sao_Paulo"
+
 type Store struct {
-	ID      string
-	Name    string
-	Slug    string
-	IsOpen  bool
-	Cnpj    string
-	OwnerID string
+	ID   string
+	Name string
+	Slug string
+	// chave manual do lojista; com ela desligada a loja fica fechada mesmo dentro do horário
+	IsOpen   bool
+	Cnpj     string
+	OwnerID  string
+	Phone    string
+	LogoURL  string
+	Address  StoreAddress
+	Timezone string // IANA, ex.: America/Sao_Paulo
+	Hours    OpeningHours
+	// pausa temporária (ex.: cozinha lotada); a loja volta sozinha quando passa
+	PausedUntil *time.Time
+}
+
+type StoreAddress struct {
+	Street     string
+	Number     string
+	Complement string
+	District   string
+	City       string
+	State      string // UF
+	ZipCode    string // só dígitos
+}
+
+// Location devolve o fuso da loja; vazio ou inválido cai no padrão.
+func (s *Store) Location() *time.Location {
+	tz := s.Timezone
+	if tz == "" {
+		tz = DefaultStoreTimezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		loc, _ = time.LoadLocation(DefaultStoreTimezone)
+	}
+	return loc
+}
+
+func (s *Store) IsPausedAt(now time.Time) bool {
+	return s.PausedUntil != nil && now.Before(*s.PausedUntil)
+}
+
+// IsOpenAt combina a chave manual, a pausa e o horário de funcionamento no fuso da loja.
+func (s *Store) IsOpenAt(now time.Time) bool {
+	return s.IsOpen && !s.IsPausedAt(now) && s.Hours.OpenAt(now.In(s.Location()))
 }
@@ -0,0 +1,65 @@
+package entity
+
+import "time"
+
+// StoreMemberRole é o papel do funcionário dentro de uma loja específica; o
+// dono não aparece aqui, ele vem de Store.OwnerID.
+type StoreMemberRole string
+
+func (r StoreMemberRole) String() string {
+	return string(r)
+}
+
+const (
+	StoreMemberRoleManager StoreMemberRole = "manager"
+	StoreMemberRoleCashier StoreMemberRole = "cashier"
+	StoreMemberRoleKitchen StoreMemberRole = "kitchen"
+)
+
+var StoreMemberRoleMap = map[string]StoreMemberRole{
+	"manager": StoreMemberRoleManager,
+	"cashier": StoreMemberRoleCashier,
+	"kitchen": StoreMemberRoleKitchen,
+}
+
+// StoreMember liga um usuário à equipe de uma loja.
+type StoreMember struct {
+	ID      string
+	StoreID string
+	UserID  string
+	Role    StoreMemberRole
+	// quem convidou (dono da loja)
+	InvitedBy string
+
+	CreatedAt time.Time
+}
+
+// StoreInvitation é o convite por email para entrar na equipe. O token em
+// claro vai só para o convidado; aqui fica o hash.
+type StoreInvitation struct {
+	ID        string
+	StoreID   string
+	Email     string
+	Role      StoreMemberRole
+	TokenHash string
+	InvitedBy string
+	ExpiresAt time.Time
+
+	AcceptedAt *time.Time
+	AcceptedBy string
+	RevokedAt  *time.Time
+
+	CreatedAt time.Time
+}
+
+func (i *StoreInvitation) IsExpired(now time.Time) bool {
+	return !i.ExpiresAt.After(now)
+}
+
+func (i *StoreInvitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
+
+func (i *StoreInvitation) IsRevoked() bool {
+	return i.RevokedAt != nil
+}
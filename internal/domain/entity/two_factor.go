@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// TwoFactor é o cadastro TOTP do usuário. Nasce pendente no setup e só passa
+// a valer quando o primeiro código é confirmado (EnabledAt).
+type TwoFactor struct {
+	UserID string
+	// segredo base32; precisa ser legível para validar os códigos
+	Secret    string
+	EnabledAt *time.Time
+	// último passo TOTP aceito; o mesmo código não entra duas vezes
+	LastUsedStep int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (t *TwoFactor) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// RecoveryCode substitui o app autenticador uma única vez. Só o hash é guardado.
+type RecoveryCode struct {
+	ID       string
+	UserID   string
+	CodeHash string
+	UsedAt   *time.Time
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// VerificationPurpose diz o que o código confirma.
+type VerificationPurpose string
+
+const (
+	VerificationPurposeEmail VerificationPurpose = "verify_email"
+	VerificationPurposePhone VerificationPurpose = "verify_phone"
+	// token de "esqueci minha senha", enviado por email
+	VerificationPurposePasswordReset VerificationPurpose = "reset_password"
+	// desafio entre a senha certa e o código do app autenticador no login
+	VerificationPurposeLogin2FA VerificationPurpose = "login_2fa"
+)
+
+// VerificationCode é um código de uso único enviado ao usuário. Só o hash é
+// persistido; Attempts conta os palpites errados para barrar força bruta.
+type VerificationCode struct {
+	ID      string
+	UserID  string
+	Purpose VerificationPurpose
+	// email ou telefone para onde o código foi enviado
+	Target    string
+	CodeHash  string
+	Attempts  int
+	ExpiresAt time.Time
+	// preenchido quando o código é usado; não vale de novo
+	ConsumedAt *time.Time
+
+	CreatedAt time.Time
+}
+
+func (c *VerificationCode) IsExpired(now time.Time) bool {
+	return !c.ExpiresAt.After(now)
+}
+
+func (c *VerificationCode) IsConsumed() bool {
+	return c.ConsumedAt != nil
+}
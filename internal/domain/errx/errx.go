@@ -13,9 +13,14 @@ const (
 	CodeConflict     Code = "conflict"
 	CodeUnauthorized Code = "unauthorized"
 	CodeForbidden    Code = "forbidden"
+	CodeRateLimited  Code = "rate_limited"
 	CodeInternal     Code = "internal"
 )
 
+// ErrStaleVersion acompanha o CodeConflict quando um update parte de uma
+// versão desatualizada da entidade (alguém gravou antes).
+var ErrStaleVersion = errors.New("stale version")
+
 type Error struct {
 	Code    Code
 	Message string
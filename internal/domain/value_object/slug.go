@@ -0,0 +1,70 @@
+package valueobject
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugMaxLength deixa espaço para o sufixo de desempate (ex.: "-12").
+const SlugMaxLength = 60
+
+type Slug struct {
+	value string // sempre minúsculo, sem acento, palavras separadas por hífen
+}
+
+var (
+	ErrSlugEmpty   = errors.New("slug must have at least one letter or digit")
+	ErrSlugTooLong = errors.New("slug must have at most 60 characters")
+)
+
+// NewSlug normaliza um texto livre: "Pizzaria São João" -> "pizzaria-sao-joao".
+func NewSlug(value string) *Slug {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range norm.NFD.String(strings.ToLower(value)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// acento separado da letra pelo NFD
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		default:
+			hyphen = true
+		}
+	}
+	return &Slug{value: b.String()}
+}
+
+func (s *Slug) Validate() error {
+	if s.value == "" {
+		return ErrSlugEmpty
+	}
+	if len(s.value) > SlugMaxLength {
+		return ErrSlugTooLong
+	}
+	return nil
+}
+
+// Truncate corta no limite sem deixar hífen sobrando no fim.
+func (s *Slug) Truncate() *Slug {
+	if len(s.value) <= SlugMaxLength {
+		return s
+	}
+	return &Slug{value: strings.TrimRight(s.value[:SlugMaxLength], "-")}
+}
+
+// WithSuffix gera a variação usada quando o slug já existe: "loja" -> "loja-2".
+func (s *Slug) WithSuffix(n int) *Slug {
+	return &Slug{value: s.value + "-" + strconv.Itoa(n)}
+}
+
+func (s *Slug) String() string {
+	return s.value
+}
@@ -0,0 +1,41 @@
+package valueobject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlug_NewSlug(t *testing.T) {
+	cases := map[string]string{
+		"Loja Teste":              "loja-teste",
+		"Pizzaria São João":       "pizzaria-sao-joao",
+		"  Açaí & Cia.  ":         "acai-cia",
+		"Café--Pão_de Queijo 24h": "cafe-pao-de-queijo-24h",
+		"ÁÉÍÓÚ ÂÊÔ ÃÕ Ç":          "aeiou-aeo-ao-c",
+		"🍕🍔":                      "",
+	}
+	for in, want := range cases {
+		require.Equal(t, want, NewSlug(in).String(), in)
+	}
+}
+
+func TestSlug_Validate(t *testing.T) {
+	require.NoError(t, NewSlug("loja").Validate())
+	require.ErrorIs(t, NewSlug("!!!").Validate(), ErrSlugEmpty)
+	require.ErrorIs(t, NewSlug(strings.Repeat("a", SlugMaxLength+1)).Validate(), ErrSlugTooLong)
+}
+
+func TestSlug_Truncate(t *testing.T) {
+	long := NewSlug(strings.Repeat("a", SlugMaxLength-1) + " b")
+
+	got := long.Truncate()
+
+	require.Equal(t, strings.Repeat("a", SlugMaxLength-1), got.String())
+	require.NoError(t, got.Validate())
+}
+
+func TestSlug_WithSuffix(t *testing.T) {
+	require.Equal(t, "loja-teste-2", NewSlug("Loja Teste").WithSuffix(2).String())
+}
@@ -0,0 +1,280 @@
+// Package auth lê a configuração de emissão de tokens.
+package auth
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	"github.com/FabioRocha231/saas-core/pkg"
+)
+
+// LegacyKID identifica o segredo HS256 de JWT_SECRET dentro do keyset.
+const LegacyKID = "hs256"
+
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+	DefaultInvitationTTL   = 72 * time.Hour
+
+	DefaultVerificationCodeTTL     = 10 * time.Minute
+	DefaultVerificationResendAfter = time.Minute
+	DefaultVerificationMaxAttempts = 5
+	DefaultPasswordResetTTL        = 30 * time.Minute
+
+	DefaultLoginMaxFailures   = 5
+	DefaultLoginIPMaxFailures = 20
+	DefaultLoginFailureWindow = time.Hour
+	DefaultLoginLockout       = time.Minute
+	DefaultLoginMaxLockout    = time.Hour
+
+	DefaultTwoFactorChallengeTTL = 5 * time.Minute
+	DefaultTwoFactorIssuer       = "saas-core"
+)
+
+// DefaultTwoFactorRequiredRoles são os papéis que mexem em dinheiro e cardápio.
+var DefaultTwoFactorRequiredRoles = []entity.UserRole{entity.UserRoleStoreOwner, entity.UserRoleAdmin}
+
+type Config struct {
+	// diretório com as chaves <kid>.pem (RSA ou Ed25519)
+	KeysDir string
+	// kid que assina os tokens novos; os demais só verificam
+	SigningKID string
+	// segredo HS256 legado; com KeysDir continua aceito para os tokens antigos
+	Secret string
+	// validade do JWT; curto porque só o refresh token renova
+	AccessTokenTTL time.Duration
+	// validade do login: refresh não estende, depois disso é preciso logar de novo
+	RefreshTokenTTL time.Duration
+	// validade do convite para a equipe de uma loja
+	InvitationTTL time.Duration
+
+	// códigos de verificação de email/telefone
+	VerificationCodeTTL     time.Duration
+	VerificationResendAfter time.Duration
+	VerificationMaxAttempts int
+	// validade do token de "esqueci minha senha"; tentativas e reenvio seguem
+	// os limites da verificação
+	PasswordResetTTL time.Duration
+	// contatos que o cliente precisa ter verificado para fechar pedido
+	CheckoutRequires policy.Verification
+
+	// trava de login: falhas por conta e por IP dentro da janela; a trava
+	// começa em LoginLockout e dobra a cada falha extra até LoginMaxLockout
+	LoginMaxFailures   int
+	LoginIPMaxFailures int
+	LoginFailureWindow time.Duration
+	LoginLockout       time.Duration
+	LoginMaxLockout    time.Duration
+	// proxies (IPs ou CIDRs) cujo X-Forwarded-For é aceito como IP do
+	// cliente; vazio não confia em ninguém e vale o IP da conexão
+	TrustedProxies []string
+
+	// nome exibido no app autenticador
+	TwoFactorIssuer string
+	// validade do desafio entre a senha e o código; as tentativas seguem
+	// VerificationMaxAttempts
+	TwoFactorChallengeTTL time.Duration
+	// papéis que só agem com 2FA ativo
+	TwoFactorRequiredRoles []entity.UserRole
+}
+
+// ConfigFromEnv lê JWT_KEYS_DIR, JWT_SIGNING_KID, JWT_SECRET, ACCESS_TOKEN_TTL,
+// REFRESH_TOKEN_TTL, STORE_INVITATION_TTL, VERIFICATION_CODE_TTL,
+// VERIFICATION_RESEND_AFTER, PASSWORD_RESET_TTL, LOGIN_FAILURE_WINDOW,
+// LOGIN_LOCKOUT, LOGIN_MAX_LOCKOUT e TWO_FACTOR_CHALLENGE_TTL (durações Go,
+// ex.: 15m, 720h), os limites VERIFICATION_MAX_ATTEMPTS, LOGIN_MAX_FAILURES e
+// LOGIN_IP_MAX_FAILURES, ORDER_REQUIRE_VERIFIED (none | email | phone | both),
+// TWO_FACTOR_ISSUER, TWO_FACTOR_REQUIRED_ROLES (papéis separados por vírgula
+// ou none) e TRUSTED_PROXIES (IPs/CIDRs separados por vírgula).
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		KeysDir:         strings.TrimSpace(os.Getenv("JWT_KEYS_DIR")),
+		SigningKID:      strings.TrimSpace(os.Getenv("JWT_SIGNING_KID")),
+		Secret:          os.Getenv("JWT_SECRET"),
+		AccessTokenTTL:  DefaultAccessTokenTTL,
+		RefreshTokenTTL: DefaultRefreshTokenTTL,
+		InvitationTTL:   DefaultInvitationTTL,
+
+		VerificationCodeTTL:     DefaultVerificationCodeTTL,
+		VerificationResendAfter: DefaultVerificationResendAfter,
+		VerificationMaxAttempts: DefaultVerificationMaxAttempts,
+		PasswordResetTTL:        DefaultPasswordResetTTL,
+
+		LoginMaxFailures:   DefaultLoginMaxFailures,
+		LoginIPMaxFailures: DefaultLoginIPMaxFailures,
+		LoginFailureWindow: DefaultLoginFailureWindow,
+		LoginLockout:       DefaultLoginLockout,
+		LoginMaxLockout:    DefaultLoginMaxLockout,
+
+		TwoFactorIssuer:        DefaultTwoFactorIssuer,
+		TwoFactorChallengeTTL:  DefaultTwoFactorChallengeTTL,
+		TwoFactorRequiredRoles: DefaultTwoFactorRequiredRoles,
+	}
+	if issuer := strings.TrimSpace(os.Getenv("TWO_FACTOR_ISSUER")); issuer != "" {
+		cfg.TwoFactorIssuer = issuer
+	}
+
+	for _, v := range []struct {
+		env string
+		dst *time.Duration
+	}{
+		{"ACCESS_TOKEN_TTL", &cfg.AccessTokenTTL},
+		{"REFRESH_TOKEN_TTL", &cfg.RefreshTokenTTL},
+		{"STORE_INVITATION_TTL", &cfg.InvitationTTL},
+		{"VERIFICATION_CODE_TTL", &cfg.VerificationCodeTTL},
+		{"VERIFICATION_RESEND_AFTER", &cfg.VerificationResendAfter},
+		{"PASSWORD_RESET_TTL", &cfg.PasswordResetTTL},
+		{"LOGIN_FAILURE_WINDOW", &cfg.LoginFailureWindow},
+		{"LOGIN_LOCKOUT", &cfg.LoginLockout},
+		{"LOGIN_MAX_LOCKOUT", &cfg.LoginMaxLockout},
+		{"TWO_FACTOR_CHALLENGE_TTL", &cfg.TwoFactorChallengeTTL},
+	} {
+		raw := strings.TrimSpace(os.Getenv(v.env))
+		if raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return Config{}, errx.F(errx.CodeInvalid, "invalid %s %q", v.env, raw)
+		}
+		*v.dst = d
+	}
+
+	for _, v := range []struct {
+		env string
+		dst *int
+	}{
+		{"VERIFICATION_MAX_ATTEMPTS", &cfg.VerificationMaxAttempts},
+		{"LOGIN_MAX_FAILURES", &cfg.LoginMaxFailures},
+		{"LOGIN_IP_MAX_FAILURES", &cfg.LoginIPMaxFailures},
+	} {
+		raw := strings.TrimSpace(os.Getenv(v.env))
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return Config{}, errx.F(errx.CodeInvalid, "invalid %s %q", v.env, raw)
+		}
+		*v.dst = n
+	}
+
+	switch raw := strings.ToLower(strings.TrimSpace(os.Getenv("ORDER_REQUIRE_VERIFIED"))); raw {
+	case "", "none":
+	case "email":
+		cfg.CheckoutRequires.Email = true
+	case "phone":
+		cfg.CheckoutRequires.Phone = true
+	case "both":
+		cfg.CheckoutRequires = policy.Verification{Email: true, Phone: true}
+	default:
+		return Config{}, errx.F(errx.CodeInvalid, "invalid ORDER_REQUIRE_VERIFIED %q", raw)
+	}
+
+	switch raw := strings.ToLower(strings.TrimSpace(os.Getenv("TWO_FACTOR_REQUIRED_ROLES"))); raw {
+	case "":
+	case "none":
+		cfg.TwoFactorRequiredRoles = nil
+	default:
+		cfg.TwoFactorRequiredRoles = nil
+		for _, name := range strings.Split(raw, ",") {
+			role, ok := entity.UserRoleMap[strings.TrimSpace(name)]
+			if !ok {
+				return Config{}, errx.F(errx.CodeInvalid, "invalid TWO_FACTOR_REQUIRED_ROLES %q", raw)
+			}
+			cfg.TwoFactorRequiredRoles = append(cfg.TwoFactorRequiredRoles, role)
+		}
+	}
+
+	for _, raw := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		proxy := strings.TrimSpace(raw)
+		if proxy == "" {
+			continue
+		}
+		if !validProxy(proxy) {
+			return Config{}, errx.F(errx.CodeInvalid, "invalid TRUSTED_PROXIES %q", proxy)
+		}
+		cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+	}
+
+	if cfg.LoginMaxLockout < cfg.LoginLockout {
+		return Config{}, errx.New(errx.CodeInvalid, "LOGIN_MAX_LOCKOUT must be >= LOGIN_LOCKOUT")
+	}
+	if cfg.RefreshTokenTTL < cfg.AccessTokenTTL {
+		return Config{}, errx.New(errx.CodeInvalid, "REFRESH_TOKEN_TTL must be >= ACCESS_TOKEN_TTL")
+	}
+	return cfg, nil
+}
+
+func validProxy(proxy string) bool {
+	if strings.Contains(proxy, "/") {
+		_, _, err := net.ParseCIDR(proxy)
+		return err == nil
+	}
+	return net.ParseIP(proxy) != nil
+}
+
+// Keyset carrega as chaves configuradas. Sem nenhuma chave a API não sobe:
+// um segredo vazio aceitaria tokens assinados por qualquer um.
+func (c Config) Keyset() (*pkg.Keyset, error) {
+	var keys []pkg.JwtKey
+
+	if c.KeysDir != "" {
+		loaded, err := pkg.LoadKeyDir(c.KeysDir)
+		if err != nil {
+			return nil, errx.F(errx.CodeInvalid, "invalid JWT_KEYS_DIR: %v", err)
+		}
+		if len(loaded) == 0 {
+			return nil, errx.F(errx.CodeInvalid, "no *.pem key in JWT_KEYS_DIR %q", c.KeysDir)
+		}
+		keys = append(keys, loaded...)
+	}
+
+	if c.Secret != "" {
+		hmac, err := pkg.NewHMACKey(LegacyKID, c.Secret)
+		if err != nil {
+			return nil, errx.F(errx.CodeInvalid, "invalid JWT_SECRET: %v", err)
+		}
+		keys = append(keys, hmac)
+	}
+
+	if len(keys) == 0 {
+		return nil, errx.New(errx.CodeInvalid, "no JWT key configured: set JWT_KEYS_DIR or JWT_SECRET")
+	}
+
+	signingKID := c.SigningKID
+	if signingKID == "" {
+		signingKID = defaultSigningKID(keys)
+	}
+
+	ks, err := pkg.NewKeyset(keys, signingKID)
+	if err != nil {
+		return nil, errx.F(errx.CodeInvalid, "invalid JWT keyset: %v", err)
+	}
+	return ks, nil
+}
+
+// defaultSigningKID só escolhe sozinho quando não há ambiguidade: uma única
+// chave assimétrica privada, ou apenas o segredo legado.
+func defaultSigningKID(keys []pkg.JwtKey) string {
+	var asymmetric []string
+	for _, k := range keys {
+		if k.ID != LegacyKID && k.CanSign() {
+			asymmetric = append(asymmetric, k.ID)
+		}
+	}
+	switch {
+	case len(asymmetric) == 1:
+		return asymmetric[0]
+	case len(asymmetric) == 0 && len(keys) == 1:
+		return keys[0].ID
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEd25519Key(t *testing.T, dir, kid string) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("test startup fails without any key", func(t *testing.T) {
+		t.Setenv("JWT_SECRET", "")
+		t.Setenv("JWT_KEYS_DIR", "")
+
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+
+		_, err = cfg.Keyset()
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: no JWT key configured: set JWT_KEYS_DIR or JWT_SECRET", err.Error())
+	})
+
+	t.Run("test empty keys dir fails", func(t *testing.T) {
+		_, err := Config{KeysDir: t.TempDir()}.Keyset()
+		require.Error(t, err)
+	})
+
+	t.Run("test single key in the dir signs by default", func(t *testing.T) {
+		dir := t.TempDir()
+		writeEd25519Key(t, dir, "2026-01")
+
+		// o segredo legado só verifica tokens antigos
+		ks, err := Config{KeysDir: dir, Secret: "secret"}.Keyset()
+		require.NoError(t, err)
+		require.Len(t, ks.JWKS().Keys, 1)
+		require.Equal(t, "2026-01", ks.JWKS().Keys[0].Kid)
+	})
+
+	t.Run("test rotation needs an explicit signing kid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeEd25519Key(t, dir, "2026-01")
+		writeEd25519Key(t, dir, "2026-02")
+
+		_, err := Config{KeysDir: dir}.Keyset()
+		require.Error(t, err)
+
+		ks, err := Config{KeysDir: dir, SigningKID: "2026-02"}.Keyset()
+		require.NoError(t, err)
+		require.Len(t, ks.JWKS().Keys, 2)
+
+		_, err = Config{KeysDir: dir, SigningKID: "2025-12"}.Keyset()
+		require.Error(t, err)
+	})
+
+	t.Run("test invalid ttl", func(t *testing.T) {
+		t.Setenv("ACCESS_TOKEN_TTL", "soon")
+
+		_, err := ConfigFromEnv()
+		require.Error(t, err)
+	})
+
+	t.Run("test two-factor required roles", func(t *testing.T) {
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		require.Equal(t, []entity.UserRole{entity.UserRoleStoreOwner, entity.UserRoleAdmin}, cfg.TwoFactorRequiredRoles)
+
+		t.Setenv("TWO_FACTOR_REQUIRED_ROLES", "none")
+		cfg, err = ConfigFromEnv()
+		require.NoError(t, err)
+		require.Empty(t, cfg.TwoFactorRequiredRoles)
+
+		t.Setenv("TWO_FACTOR_REQUIRED_ROLES", "admin, support")
+		cfg, err = ConfigFromEnv()
+		require.NoError(t, err)
+		require.Equal(t, []entity.UserRole{entity.UserRoleAdmin, entity.UserRoleSupport}, cfg.TwoFactorRequiredRoles)
+
+		t.Setenv("TWO_FACTOR_REQUIRED_ROLES", "owner")
+		_, err = ConfigFromEnv()
+		require.Error(t, err)
+	})
+
+	t.Run("test trusted proxies", func(t *testing.T) {
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		require.Empty(t, cfg.TrustedProxies)
+
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 127.0.0.1")
+		cfg, err = ConfigFromEnv()
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.0/8", "127.0.0.1"}, cfg.TrustedProxies)
+
+		t.Setenv("TRUSTED_PROXIES", "proxy.local")
+		_, err = ConfigFromEnv()
+		require.Error(t, err)
+	})
+}
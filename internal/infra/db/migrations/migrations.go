@@ -0,0 +1,305 @@
+// Package migrations versiona o schema dos bancos SQL. Cada dialeto tem seus
+// arquivos embutidos em <dialeto>/NNNN_nome.(up|down).sql e o controle do que
+// já rodou fica na tabela schema_migrations.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// lock global do Postgres para duas instâncias não migrarem ao mesmo tempo
+const advisoryLockID = 7_240_519_001
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sqldb.DB
+	migrations []Migration
+}
+
+func New(db *sqldb.DB) (*Migrator, error) {
+	migrations, err := load(db.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func (m *Migrator) Migrations() []Migration {
+	out := make([]Migration, len(m.migrations))
+	copy(out, m.migrations)
+	return out
+}
+
+// Up aplica todas as migrations pendentes, em ordem, e devolve as que rodaram.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+
+		done, err := m.run(ctx, mig, true)
+		if err != nil {
+			return ran, err
+		}
+		if done {
+			ran = append(ran, mig)
+		}
+	}
+
+	return ran, nil
+}
+
+// Down desfaz as últimas `steps` migrations aplicadas.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "steps must be > 0")
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(ran) < steps; i-- {
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+
+		done, err := m.run(ctx, mig, false)
+		if err != nil {
+			return ran, err
+		}
+		if done {
+			ran = append(ran, mig)
+		}
+	}
+
+	return ran, nil
+}
+
+// Redo desfaz e reaplica a última migration.
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	ran, err := m.Down(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(ran) == 0 {
+		return nil, errx.New(errx.CodeNotFound, "no applied migration to redo")
+	}
+
+	mig := ran[0]
+	if _, err := m.run(ctx, mig, true); err != nil {
+		return nil, err
+	}
+	return &mig, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st := Status{Version: mig.Version, Name: mig.Name}
+		if at, ok := applied[mig.Version]; ok {
+			st.AppliedAt = &at
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+// run executa uma migration e o registro em schema_migrations na mesma tx.
+// Retorna false se outra instância já tinha feito o trabalho.
+func (m *Migrator) run(ctx context.Context, mig Migration, up bool) (bool, error) {
+	op := "migrate up"
+	script := mig.Up
+	if !up {
+		op = "migrate down"
+		script = mig.Down
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, sqldb.Internal(op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if m.db.Dialect == sqldb.DialectPostgres {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, advisoryLockID); err != nil {
+			return false, sqldb.Internal(op, err)
+		}
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, m.db.Rebind(`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`), mig.Version).Scan(&count)
+	if err != nil {
+		return false, sqldb.Internal(op, err)
+	}
+	if (up && count > 0) || (!up && count == 0) {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return false, errx.Wrap(errx.CodeInternal, op+" "+mig.label(), err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, m.db.Rebind(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`),
+			mig.Version, mig.Name, sqldb.Time(time.Now()))
+	} else {
+		_, err = tx.ExecContext(ctx, m.db.Rebind(`DELETE FROM schema_migrations WHERE version = ?`), mig.Version)
+	}
+	if err != nil {
+		return false, sqldb.Internal(op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, sqldb.Internal(op, err)
+	}
+	return true, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, sqldb.Internal("list migrations", err)
+	}
+	defer rows.Close()
+
+	out := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt sql.NullTime
+		)
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, sqldb.Internal("list migrations", err)
+		}
+		out[version] = appliedAt.Time
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list migrations", err)
+	}
+	return out, nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	timestamp := "TIMESTAMPTZ"
+	if m.db.Dialect == sqldb.DialectSQLite {
+		timestamp = "TIMESTAMP"
+	}
+
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at `+timestamp+` NOT NULL
+		)`)
+	if err != nil {
+		return sqldb.Internal("create schema_migrations", err)
+	}
+	return nil
+}
+
+func (mig Migration) label() string {
+	return strconv.FormatInt(mig.Version, 10) + "_" + mig.Name
+}
+
+func load(dialect sqldb.Dialect) ([]Migration, error) {
+	dir := "postgres"
+	if dialect == sqldb.DialectSQLite {
+		dir = "sqlite"
+	}
+
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, errx.F(errx.CodeInternal, "invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, errx.F(errx.CodeInternal, "invalid migration version %q", entry.Name())
+		}
+
+		body, err := fs.ReadFile(files, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != match[2] {
+			return nil, errx.F(errx.CodeInternal, "migration %d has conflicting names", version)
+		}
+
+		if match[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, errx.F(errx.CodeInternal, "migration %s must have up and down files", mig.label())
+		}
+		out = append(out, *mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package migrations
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	"github.com/stretchr/testify/require"
+)
+
+func openSQLite(t *testing.T) *sqldb.DB {
+	t.Helper()
+
+	db, err := sqldb.Open(sqldb.DialectSQLite, sqldb.SQLiteDSN(filepath.Join(t.TempDir(), "migrations.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sqldb.DB, name string) bool {
+	t.Helper()
+
+	var count int
+	err := db.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&count)
+	require.NoError(t, err)
+	return count > 0
+}
+
+func TestLoad(t *testing.T) {
+	for _, dialect := range []sqldb.Dialect{sqldb.DialectPostgres, sqldb.DialectSQLite} {
+		t.Run("test every migration of "+string(dialect)+" has up and down", func(t *testing.T) {
+			migrations, err := load(dialect)
+
+			require.NoError(t, err)
+			require.NotEmpty(t, migrations)
+			for i, mig := range migrations {
+				require.NotEmpty(t, mig.Up)
+				require.NotEmpty(t, mig.Down)
+				if i > 0 {
+					require.Greater(t, mig.Version, migrations[i-1].Version)
+				}
+			}
+		})
+	}
+
+	t.Run("test both dialects ship the same versions", func(t *testing.T) {
+		pg, err := load(sqldb.DialectPostgres)
+		require.NoError(t, err)
+		lite, err := load(sqldb.DialectSQLite)
+		require.NoError(t, err)
+
+		require.Len(t, lite, len(pg))
+		for i := range pg {
+			require.Equal(t, pg[i].Version, lite[i].Version)
+			require.Equal(t, pg[i].Name, lite[i].Name)
+		}
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	db := openSQLite(t)
+	m, err := New(db)
+	require.NoError(t, err)
+	total := len(m.Migrations())
+
+	t.Run("test status before any migration", func(t *testing.T) {
+		statuses, err := m.Status(t.Context())
+
+		require.NoError(t, err)
+		require.Len(t, statuses, total)
+		for _, st := range statuses {
+			require.Nil(t, st.AppliedAt)
+		}
+	})
+
+	t.Run("test up applies everything once", func(t *testing.T) {
+		ran, err := m.Up(t.Context())
+		require.NoError(t, err)
+		require.Len(t, ran, total)
+		require.True(t, tableExists(t, db, "orders"))
+
+		ran, err = m.Up(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, ran)
+
+		statuses, err := m.Status(t.Context())
+		require.NoError(t, err)
+		for _, st := range statuses {
+			require.NotNil(t, st.AppliedAt)
+		}
+	})
+
+	t.Run("test redo rolls back and reapplies the last migration", func(t *testing.T) {
+		mig, err := m.Redo(t.Context())
+
+		require.NoError(t, err)
+		require.Equal(t, m.Migrations()[total-1].Version, mig.Version)
+		require.True(t, tableExists(t, db, "orders"))
+	})
+
+	t.Run("test down rolls back every migration", func(t *testing.T) {
+		ran, err := m.Down(t.Context(), total)
+		require.NoError(t, err)
+		require.Len(t, ran, total)
+		require.False(t, tableExists(t, db, "users"))
+
+		ran, err = m.Down(t.Context(), 1)
+		require.NoError(t, err)
+		require.Empty(t, ran)
+	})
+
+	t.Run("test down with invalid steps", func(t *testing.T) {
+		_, err := m.Down(t.Context(), 0)
+
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: steps must be > 0", err.Error())
+	})
+
+	t.Run("test redo without applied migrations", func(t *testing.T) {
+		_, err := m.Redo(t.Context())
+
+		require.Error(t, err)
+		require.Equal(t, "not_found: no applied migration to redo", err.Error())
+	})
+}
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS order_item_addons;
+DROP TABLE IF EXISTS order_item_variants;
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS addon_options;
+DROP TABLE IF EXISTS item_addon_groups;
+DROP TABLE IF EXISTS variant_options;
+DROP TABLE IF EXISTS item_variant_groups;
+DROP TABLE IF EXISTS category_items;
+DROP TABLE IF EXISTS menu_categories;
+DROP TABLE IF EXISTS store_menus;
+DROP TABLE IF EXISTS stores;
+DROP TABLE IF EXISTS users;
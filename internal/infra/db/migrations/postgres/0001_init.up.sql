@@ -0,0 +1,198 @@
+CREATE TABLE IF NOT EXISTS users (
+    id                TEXT PRIMARY KEY,
+    name              TEXT NOT NULL,
+    cpf               TEXT NOT NULL,
+    email             TEXT NOT NULL,
+    phone             TEXT NOT NULL DEFAULT '',
+    status            TEXT NOT NULL,
+    role              TEXT NOT NULL,
+    password          TEXT NOT NULL,
+    email_verified_at TIMESTAMPTZ,
+    phone_verified_at TIMESTAMPTZ,
+    last_login_at     TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    deleted_at        TIMESTAMPTZ,
+    CONSTRAINT users_cpf_key UNIQUE (cpf),
+    CONSTRAINT users_email_key UNIQUE (email)
+);
+
+CREATE TABLE IF NOT EXISTS stores (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    slug       TEXT NOT NULL,
+    is_open    BOOLEAN NOT NULL DEFAULT TRUE,
+    cnpj       TEXT NOT NULL,
+    owner_id   TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT stores_slug_key UNIQUE (slug)
+);
+CREATE INDEX IF NOT EXISTS stores_owner_id_idx ON stores (owner_id);
+
+CREATE TABLE IF NOT EXISTS store_menus (
+    id         TEXT PRIMARY KEY,
+    store_id   TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    is_active  BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS store_menus_store_id_idx ON store_menus (store_id);
+
+CREATE TABLE IF NOT EXISTS menu_categories (
+    id         TEXT PRIMARY KEY,
+    menu_id    TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    is_active  BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS menu_categories_menu_id_idx ON menu_categories (menu_id);
+
+CREATE TABLE IF NOT EXISTS category_items (
+    id          TEXT PRIMARY KEY,
+    category_id TEXT NOT NULL,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    base_price  BIGINT NOT NULL,
+    image_url   TEXT NOT NULL DEFAULT '',
+    is_active   BOOLEAN NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS category_items_category_id_idx ON category_items (category_id);
+
+CREATE TABLE IF NOT EXISTS item_variant_groups (
+    id               TEXT PRIMARY KEY,
+    category_item_id TEXT NOT NULL,
+    name             TEXT NOT NULL,
+    required         BOOLEAN NOT NULL,
+    min_select       INTEGER NOT NULL,
+    max_select       INTEGER NOT NULL,
+    sort_order       INTEGER NOT NULL,
+    is_active        BOOLEAN NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS item_variant_groups_item_idx ON item_variant_groups (category_item_id);
+
+CREATE TABLE IF NOT EXISTS variant_options (
+    id               TEXT PRIMARY KEY,
+    variant_group_id TEXT NOT NULL,
+    name             TEXT NOT NULL,
+    price_delta      BIGINT NOT NULL,
+    is_default       BOOLEAN NOT NULL,
+    sort_order       INTEGER NOT NULL,
+    is_active        BOOLEAN NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS variant_options_group_idx ON variant_options (variant_group_id);
+
+CREATE TABLE IF NOT EXISTS item_addon_groups (
+    id               TEXT PRIMARY KEY,
+    category_item_id TEXT NOT NULL,
+    name             TEXT NOT NULL,
+    required         BOOLEAN NOT NULL,
+    min_select       INTEGER NOT NULL,
+    max_select       INTEGER NOT NULL,
+    sort_order       INTEGER NOT NULL,
+    is_active        BOOLEAN NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS item_addon_groups_item_idx ON item_addon_groups (category_item_id);
+
+CREATE TABLE IF NOT EXISTS addon_options (
+    id             TEXT PRIMARY KEY,
+    addon_group_id TEXT NOT NULL,
+    name           TEXT NOT NULL,
+    price          BIGINT NOT NULL,
+    sort_order     INTEGER NOT NULL,
+    is_active      BOOLEAN NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS addon_options_group_idx ON addon_options (addon_group_id);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id         TEXT PRIMARY KEY,
+    store_id   TEXT NOT NULL,
+    menu_id    TEXT NOT NULL DEFAULT '',
+    user_id    TEXT NOT NULL,
+    status     TEXT NOT NULL,
+    subtotal   BIGINT NOT NULL,
+    fees       BIGINT NOT NULL,
+    total      BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+-- carrinho único: um draft (CREATED) por (user, store)
+CREATE UNIQUE INDEX IF NOT EXISTS orders_active_draft_key ON orders (user_id, store_id) WHERE status = 'CREATED';
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id         TEXT NOT NULL,
+    order_id   TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    position   INTEGER NOT NULL,
+    item_id    TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    qty        BIGINT NOT NULL,
+    base_price BIGINT NOT NULL,
+    line_total BIGINT NOT NULL,
+    note       TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (order_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS order_item_variants (
+    order_id          TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    order_item_id     TEXT NOT NULL,
+    position          INTEGER NOT NULL,
+    variant_group_id  TEXT NOT NULL,
+    variant_group     TEXT NOT NULL,
+    variant_option_id TEXT NOT NULL,
+    option_name       TEXT NOT NULL,
+    price_delta       BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS order_item_variants_order_id_idx ON order_item_variants (order_id);
+
+CREATE TABLE IF NOT EXISTS order_item_addons (
+    order_id        TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    order_item_id   TEXT NOT NULL,
+    position        INTEGER NOT NULL,
+    addon_group_id  TEXT NOT NULL,
+    addon_group     TEXT NOT NULL,
+    addon_option_id TEXT NOT NULL,
+    option_name     TEXT NOT NULL,
+    qty             BIGINT NOT NULL,
+    unit_price      BIGINT NOT NULL,
+    line_total      BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS order_item_addons_order_id_idx ON order_item_addons (order_id);
+
+CREATE TABLE IF NOT EXISTS payments (
+    id              TEXT PRIMARY KEY,
+    order_id        TEXT NOT NULL,
+    user_id         TEXT NOT NULL,
+    store_id        TEXT NOT NULL,
+    method          TEXT NOT NULL,
+    provider        TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    amount          BIGINT NOT NULL,
+    currency        TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    paid_at         TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS payments_order_id_idx ON payments (order_id);
+-- idempotência: 1 cobrança por (order + key)
+CREATE UNIQUE INDEX IF NOT EXISTS payments_order_idempotency_key ON payments (order_id, idempotency_key) WHERE idempotency_key <> '';
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL,
+    role       TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS sessions_expires_at_idx ON sessions (expires_at);
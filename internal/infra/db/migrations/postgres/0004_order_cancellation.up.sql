@@ -0,0 +1,4 @@
+ALTER TABLE orders ADD COLUMN cancel_actor TEXT NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN cancel_actor_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN cancel_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN canceled_at TIMESTAMPTZ;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS refunds (
+    id              TEXT PRIMARY KEY,
+    payment_id      TEXT NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    order_id        TEXT NOT NULL,
+    store_id        TEXT NOT NULL,
+    amount          BIGINT NOT NULL,
+    currency        TEXT NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    status          TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL DEFAULT '',
+    requested_by    TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS refunds_payment_id_idx ON refunds (payment_id);
+-- idempotência: 1 estorno por (payment + key)
+CREATE UNIQUE INDEX IF NOT EXISTS refunds_payment_idempotency_key ON refunds (payment_id, idempotency_key) WHERE idempotency_key <> '';
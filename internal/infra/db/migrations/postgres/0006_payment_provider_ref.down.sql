@@ -0,0 +1,2 @@
+ALTER TABLE refunds DROP COLUMN provider_ref;
+ALTER TABLE payments DROP COLUMN provider_ref;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS payments_provider_ref_idx;
+DROP TABLE IF EXISTS payment_events;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS payment_events (
+    id          TEXT PRIMARY KEY,
+    provider    TEXT NOT NULL,
+    event_id    TEXT NOT NULL,
+    payment_ref TEXT NOT NULL DEFAULT '',
+    payment_id  TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL DEFAULT '',
+    amount      BIGINT NOT NULL DEFAULT 0,
+    payload     TEXT NOT NULL,
+    result      TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL
+);
+-- dedup: cada evento do provider é aplicado uma vez só
+CREATE UNIQUE INDEX IF NOT EXISTS payment_events_provider_event_id ON payment_events (provider, event_id);
+CREATE INDEX IF NOT EXISTS payment_events_payment_id_idx ON payment_events (payment_id);
+
+-- webhooks procuram o pagamento pela referência do provider
+CREATE INDEX IF NOT EXISTS payments_provider_ref_idx ON payments (provider, provider_ref) WHERE provider_ref <> '';
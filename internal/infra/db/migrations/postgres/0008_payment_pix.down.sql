@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS payments_status_expires_at_idx;
+ALTER TABLE payments DROP COLUMN expires_at;
+ALTER TABLE payments DROP COLUMN pix_code;
+ALTER TABLE payments DROP COLUMN pix_txid;
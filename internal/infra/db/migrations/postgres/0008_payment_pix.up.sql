@@ -0,0 +1,6 @@
+ALTER TABLE payments ADD COLUMN pix_txid TEXT NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN pix_code TEXT NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN expires_at TIMESTAMPTZ;
+
+-- job de expiração procura cobranças pendentes vencidas
+CREATE INDEX IF NOT EXISTS payments_status_expires_at_idx ON payments (status, expires_at) WHERE expires_at IS NOT NULL;
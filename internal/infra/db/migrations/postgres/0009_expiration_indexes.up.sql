@@ -0,0 +1,3 @@
+-- jobs de expiração: pedidos parados num status e cobranças em aberto sem prazo próprio
+CREATE INDEX IF NOT EXISTS orders_status_updated_at_idx ON orders (status, updated_at);
+CREATE INDEX IF NOT EXISTS payments_status_created_at_idx ON payments (status, created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE sessions ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN ip TEXT NOT NULL DEFAULT '';
+
+-- GET /sessions e "sair de todos os dispositivos"
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS store_invitations;
+DROP TABLE IF EXISTS store_members;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    user_id        TEXT PRIMARY KEY,
+    secret         TEXT NOT NULL,
+    enabled_at     TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS two_factor_recovery_codes (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL,
+    code_hash  TEXT NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS two_factor_recovery_codes_user_id_idx ON two_factor_recovery_codes (user_id);
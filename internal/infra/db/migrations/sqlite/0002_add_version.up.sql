@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE payments ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS orders_store_id_status_idx;
+DROP TABLE IF EXISTS order_transitions;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS order_transitions (
+    order_id    TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status   TEXT NOT NULL,
+    actor       TEXT NOT NULL,
+    actor_id    TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY (order_id, position)
+);
+
+-- painel da loja lista pedidos por loja/status
+CREATE INDEX IF NOT EXISTS orders_store_id_status_idx ON orders (store_id, status);
@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN canceled_at;
+ALTER TABLE orders DROP COLUMN cancel_reason;
+ALTER TABLE orders DROP COLUMN cancel_actor_id;
+ALTER TABLE orders DROP COLUMN cancel_actor;
@@ -0,0 +1,2 @@
+ALTER TABLE payments ADD COLUMN provider_ref TEXT NOT NULL DEFAULT '';
+ALTER TABLE refunds ADD COLUMN provider_ref TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS payments_status_created_at_idx;
+DROP INDEX IF EXISTS orders_status_updated_at_idx;
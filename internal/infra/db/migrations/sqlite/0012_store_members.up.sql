@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS store_members (
+    id         TEXT PRIMARY KEY,
+    store_id   TEXT NOT NULL,
+    user_id    TEXT NOT NULL,
+    role       TEXT NOT NULL,
+    invited_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS store_members_store_id_user_id_key ON store_members (store_id, user_id);
+-- lojas em que o usuário trabalha (login / next_step)
+CREATE INDEX IF NOT EXISTS store_members_user_id_idx ON store_members (user_id);
+
+CREATE TABLE IF NOT EXISTS store_invitations (
+    id          TEXT PRIMARY KEY,
+    store_id    TEXT NOT NULL,
+    email       TEXT NOT NULL,
+    role        TEXT NOT NULL,
+    token_hash  TEXT NOT NULL,
+    invited_by  TEXT NOT NULL DEFAULT '',
+    expires_at  TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    accepted_by TEXT NOT NULL DEFAULT '',
+    revoked_at  TIMESTAMP,
+    created_at  TIMESTAMP NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS store_invitations_token_hash_key ON store_invitations (token_hash);
+CREATE INDEX IF NOT EXISTS store_invitations_store_id_idx ON store_invitations (store_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS verification_codes (
+    id          TEXT PRIMARY KEY,
+    user_id     TEXT NOT NULL,
+    purpose     TEXT NOT NULL,
+    target      TEXT NOT NULL DEFAULT '',
+    code_hash   TEXT NOT NULL,
+    attempts    INTEGER NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP,
+    created_at  TIMESTAMP NOT NULL
+);
+-- código vigente = o mais recente do usuário para o propósito
+CREATE INDEX IF NOT EXISTS verification_codes_user_id_purpose_idx ON verification_codes (user_id, purpose, created_at);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS login_throttles (
+    throttle_key    TEXT PRIMARY KEY,
+    failures        INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until    TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id         TEXT PRIMARY KEY,
+    action     TEXT NOT NULL,
+    user_id    TEXT NOT NULL DEFAULT '',
+    subject    TEXT NOT NULL DEFAULT '',
+    ip         TEXT NOT NULL DEFAULT '',
+    detail     TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_action_created_at_idx ON audit_log (action, created_at);
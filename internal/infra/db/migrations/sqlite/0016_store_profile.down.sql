@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS store_hour_exceptions;
+DROP TABLE IF EXISTS store_weekly_hours;
+ALTER TABLE stores DROP COLUMN timezone;
+ALTER TABLE stores DROP COLUMN address_zip_code;
+ALTER TABLE stores DROP COLUMN address_state;
+ALTER TABLE stores DROP COLUMN address_city;
+ALTER TABLE stores DROP COLUMN address_district;
+ALTER TABLE stores DROP COLUMN address_complement;
+ALTER TABLE stores DROP COLUMN address_number;
+ALTER TABLE stores DROP COLUMN address_street;
+ALTER TABLE stores DROP COLUMN logo_url;
+ALTER TABLE stores DROP COLUMN phone;
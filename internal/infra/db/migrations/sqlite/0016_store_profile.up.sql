@@ -0,0 +1,32 @@
+ALTER TABLE stores ADD COLUMN phone TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN logo_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN address_street TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN address_number TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN address_complement TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN address_district TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN address_city TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN address_state TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN address_zip_code TEXT NOT NULL DEFAULT '';
+ALTER TABLE stores ADD COLUMN timezone TEXT NOT NULL DEFAULT 'America/Sao_Paulo';
+
+-- horário semanal; minutos desde 00:00 no fuso da loja
+CREATE TABLE IF NOT EXISTS store_weekly_hours (
+    store_id TEXT NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    weekday  INTEGER NOT NULL,
+    opens    INTEGER NOT NULL,
+    closes   INTEGER NOT NULL,
+    PRIMARY KEY (store_id, position)
+);
+
+-- feriados e datas especiais; substituem o horário semanal do dia
+CREATE TABLE IF NOT EXISTS store_hour_exceptions (
+    store_id TEXT NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    date     TEXT NOT NULL,
+    closed   BOOLEAN NOT NULL,
+    opens    INTEGER NOT NULL,
+    closes   INTEGER NOT NULL,
+    note     TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (store_id, position)
+);
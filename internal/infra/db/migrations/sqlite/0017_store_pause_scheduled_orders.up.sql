@@ -0,0 +1,5 @@
+-- pausa temporária; a loja volta sozinha quando passa
+ALTER TABLE stores ADD COLUMN paused_until TIMESTAMP;
+
+-- pedido agendado; NULL é "o quanto antes"
+ALTER TABLE orders ADD COLUMN scheduled_for TIMESTAMP;
@@ -0,0 +1,242 @@
+// Package db escolhe e monta o conjunto de repositórios (memória ou SQL)
+// a partir da configuração da aplicação.
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/migrations"
+	memoryaddonoption "github.com/FabioRocha231/saas-core/internal/infra/db/repository/addon_option"
+	memoryaudit "github.com/FabioRocha231/saas-core/internal/infra/db/repository/audit"
+	memorycategoryitem "github.com/FabioRocha231/saas-core/internal/infra/db/repository/category_item"
+	memoryitemaddongroup "github.com/FabioRocha231/saas-core/internal/infra/db/repository/item_addon_group"
+	memoryitemvariantgroup "github.com/FabioRocha231/saas-core/internal/infra/db/repository/item_variant_group"
+	memoryloginthrottle "github.com/FabioRocha231/saas-core/internal/infra/db/repository/login_throttle"
+	memorymenucategory "github.com/FabioRocha231/saas-core/internal/infra/db/repository/menu_category"
+	memorymenuread "github.com/FabioRocha231/saas-core/internal/infra/db/repository/menu_read"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memorypaymentevent "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment_event"
+	memoryrefreshtoken "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refresh_token"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoreinvitation "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_invitation"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memorystoremenu "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_menu"
+	memorytwofactor "github.com/FabioRocha231/saas-core/internal/infra/db/repository/two_factor"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	memoryvariantoption "github.com/FabioRocha231/saas-core/internal/infra/db/repository/variant_option"
+	memoryverificationcode "github.com/FabioRocha231/saas-core/internal/infra/db/repository/verification_code"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	sqladdonoption "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/addon_option"
+	sqlaudit "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/audit"
+	sqlcategoryitem "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/category_item"
+	sqlitemaddongroup "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/item_addon_group"
+	sqlitemvariantgroup "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/item_variant_group"
+	sqlloginthrottle "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/login_throttle"
+	sqlmenucategory "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/menu_category"
+	sqlorder "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/order"
+	sqlpayment "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/payment"
+	sqlpaymentevent "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/payment_event"
+	sqlrefreshtoken "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/refresh_token"
+	sqlrefund "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/refund"
+	sqlsession "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/session"
+	sqlstore "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/store"
+	sqlstoreinvitation "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/store_invitation"
+	sqlstoremember "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/store_member"
+	sqlstoremenu "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/store_menu"
+	sqltwofactor "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/two_factor"
+	sqluser "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/user"
+	sqlvariantoption "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/variant_option"
+	sqlverificationcode "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/verification_code"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Driver string
+
+const (
+	DriverMemory   Driver = "memory"
+	DriverPostgres Driver = "postgres"
+	DriverSQLite   Driver = "sqlite"
+)
+
+const defaultSQLitePath = "data/saas-core.db"
+
+type Config struct {
+	Driver Driver
+	// DSN do Postgres
+	DSN string
+	// arquivo do banco quando Driver = sqlite
+	SQLitePath string
+}
+
+// ConfigFromEnv lê DB_DRIVER (memory | postgres | sqlite), DATABASE_URL e SQLITE_PATH.
+func ConfigFromEnv() Config {
+	driver := Driver(strings.ToLower(strings.TrimSpace(os.Getenv("DB_DRIVER"))))
+	if driver == "" {
+		driver = DriverMemory
+	}
+
+	sqlitePath := strings.TrimSpace(os.Getenv("SQLITE_PATH"))
+	if sqlitePath == "" {
+		sqlitePath = defaultSQLitePath
+	}
+
+	return Config{
+		Driver:     driver,
+		DSN:        strings.TrimSpace(os.Getenv("DATABASE_URL")),
+		SQLitePath: sqlitePath,
+	}
+}
+
+type Repositories struct {
+	User             repository.UserRepository
+	Store            repository.StoreRepository
+	StoreMember      repository.StoreMemberRepository
+	StoreInvitation  repository.StoreInvitationRepository
+	Session          repository.SessionRepository
+	RefreshToken     repository.RefreshTokenRepository
+	VerificationCode repository.VerificationCodeRepository
+	TwoFactor        repository.TwoFactorRepository
+	LoginThrottle    repository.LoginThrottleRepository
+	Audit            repository.AuditRepository
+	StoreMenu        repository.StoreMenuRepository
+	MenuCategory     repository.MenuCategoryRepository
+	CategoryItem     repository.CategoryItemRepository
+	ItemAddonGroup   repository.ItemAddonGroupRepository
+	AddonOption      repository.AddonOptionRepository
+	ItemVariantGroup repository.ItemVariantGroupRepository
+	VariantOption    repository.VariantOptionRepository
+	Order            repository.OrderRepository
+	Payment          repository.PaymentRepository
+	Refund           repository.RefundRepository
+	PaymentEvent     repository.PaymentEventRepository
+	MenuRead         repository.MenuReadRepository
+
+	// unidade de trabalho sobre os repos acima
+	Tx ports.TxManager
+
+	conn *sqldb.DB
+}
+
+// clock é repassado aos repos que carimbam created_at/updated_at.
+func NewRepositories(ctx context.Context, cfg Config, clock ports.Clock) (*Repositories, error) {
+	switch cfg.Driver {
+	case DriverMemory:
+		return NewMemoryRepositories(clock), nil
+	case DriverPostgres, DriverSQLite:
+		conn, err := Open(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		migrator, err := migrations.New(conn)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		if _, err := migrator.Up(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		return NewSQLRepositories(conn, clock), nil
+	default:
+		return nil, errx.F(errx.CodeInvalid, "unsupported DB_DRIVER %q", cfg.Driver)
+	}
+}
+
+// Open abre a conexão SQL do driver configurado, sem aplicar migrations.
+func Open(cfg Config) (*sqldb.DB, error) {
+	switch cfg.Driver {
+	case DriverPostgres:
+		return sqldb.Open(sqldb.DialectPostgres, cfg.DSN)
+	case DriverSQLite:
+		if cfg.SQLitePath == "" {
+			return nil, errx.New(errx.CodeInvalid, "missing SQLITE_PATH")
+		}
+		if err := os.MkdirAll(filepath.Dir(cfg.SQLitePath), 0o755); err != nil {
+			return nil, err
+		}
+		return sqldb.Open(sqldb.DialectSQLite, sqldb.SQLiteDSN(cfg.SQLitePath))
+	default:
+		return nil, errx.F(errx.CodeInvalid, "DB_DRIVER %q has no SQL database", cfg.Driver)
+	}
+}
+
+func NewMemoryRepositories(clock ports.Clock) *Repositories {
+	r := &Repositories{
+		User:             memoryuser.New(clock),
+		Store:            memorystore.New(),
+		StoreMember:      memorystoremember.New(clock),
+		StoreInvitation:  memorystoreinvitation.New(clock),
+		Session:          memorysession.New(clock),
+		RefreshToken:     memoryrefreshtoken.New(clock),
+		VerificationCode: memoryverificationcode.New(clock),
+		TwoFactor:        memorytwofactor.New(clock),
+		LoginThrottle:    memoryloginthrottle.New(),
+		Audit:            memoryaudit.New(clock),
+		StoreMenu:        memorystoremenu.New(clock),
+		MenuCategory:     memorymenucategory.New(clock),
+		CategoryItem:     memorycategoryitem.New(clock),
+		ItemAddonGroup:   memoryitemaddongroup.New(clock),
+		AddonOption:      memoryaddonoption.New(clock),
+		ItemVariantGroup: memoryitemvariantgroup.New(clock),
+		VariantOption:    memoryvariantoption.New(clock),
+		Order:            memoryorder.New(clock),
+		Payment:          memorypayment.New(clock),
+		Refund:           memoryrefund.New(clock),
+		PaymentEvent:     memorypaymentevent.New(clock),
+	}
+	r.MenuRead = memorymenuread.New(r.StoreMenu, r.MenuCategory, r.CategoryItem, r.ItemAddonGroup, r.AddonOption, r.ItemVariantGroup, r.VariantOption)
+
+	// os repos registram no ctx como desfazer o que escrevem dentro da tx
+	r.Tx = memorytx.New()
+
+	return r
+}
+
+func NewSQLRepositories(conn *sqldb.DB, clock ports.Clock) *Repositories {
+	r := &Repositories{
+		User:             sqluser.New(conn, clock),
+		Store:            sqlstore.New(conn),
+		StoreMember:      sqlstoremember.New(conn, clock),
+		StoreInvitation:  sqlstoreinvitation.New(conn, clock),
+		Session:          sqlsession.New(conn, clock),
+		RefreshToken:     sqlrefreshtoken.New(conn, clock),
+		VerificationCode: sqlverificationcode.New(conn, clock),
+		TwoFactor:        sqltwofactor.New(conn, clock),
+		LoginThrottle:    sqlloginthrottle.New(conn),
+		Audit:            sqlaudit.New(conn, clock),
+		StoreMenu:        sqlstoremenu.New(conn, clock),
+		MenuCategory:     sqlmenucategory.New(conn, clock),
+		CategoryItem:     sqlcategoryitem.New(conn, clock),
+		ItemAddonGroup:   sqlitemaddongroup.New(conn, clock),
+		AddonOption:      sqladdonoption.New(conn, clock),
+		ItemVariantGroup: sqlitemvariantgroup.New(conn, clock),
+		VariantOption:    sqlvariantoption.New(conn, clock),
+		Order:            sqlorder.New(conn, clock),
+		Payment:          sqlpayment.New(conn, clock),
+		Refund:           sqlrefund.New(conn, clock),
+		PaymentEvent:     sqlpaymentevent.New(conn, clock),
+		Tx:               conn,
+		conn:             conn,
+	}
+	// o read model só compõe os repos do cardápio, serve para qualquer driver
+	r.MenuRead = memorymenuread.New(r.StoreMenu, r.MenuCategory, r.CategoryItem, r.ItemAddonGroup, r.AddonOption, r.ItemVariantGroup, r.VariantOption)
+	return r
+}
+
+func (r *Repositories) Close() error {
+	if r == nil || r.conn == nil {
+		return nil
+	}
+	return r.conn.Close()
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepositories(t *testing.T) {
+	t.Run("test unsupported driver", func(t *testing.T) {
+		_, err := NewRepositories(t.Context(), Config{Driver: "mongo"}, pkg.NewClock())
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeInvalid, errx.CodeOf(err))
+	})
+
+	t.Run("test memory driver has no connection to close", func(t *testing.T) {
+		repos, err := NewRepositories(t.Context(), Config{Driver: DriverMemory}, pkg.NewClock())
+
+		require.NoError(t, err)
+		require.NoError(t, repos.Close())
+	})
+
+	t.Run("test sqlite driver keeps data across restarts", func(t *testing.T) {
+		cfg := Config{Driver: DriverSQLite, SQLitePath: filepath.Join(t.TempDir(), "nested", "saas.db")}
+
+		repos, err := NewRepositories(t.Context(), cfg, pkg.NewClock())
+		require.NoError(t, err)
+
+		var mode string
+		require.NoError(t, repos.conn.QueryRowContext(t.Context(), "PRAGMA journal_mode").Scan(&mode))
+		require.Equal(t, "wal", mode)
+
+		require.NoError(t, repos.Session.Create(t.Context(), &entity.Session{
+			ID:        "jti-1",
+			UserID:    "user-1",
+			Role:      string(entity.UserRoleCostumer),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}))
+		require.NoError(t, repos.Order.Create(t.Context(), &entity.Order{
+			ID:      "order-1",
+			StoreID: "store-1",
+			UserID:  "user-1",
+			Items: []entity.OrderItem{{
+				ID: "line-1", ItemID: "item-1", Name: "Burger", Qty: 1, BasePrice: 2000,
+				Variants: []entity.OrderItemVariant{{VariantGroupID: "vg-1", VariantOptionID: "vo-1", OptionName: "Mal passado"}},
+				Addons:   []entity.OrderItemAddon{{AddonGroupID: "ag-1", AddonOptionID: "ao-1", OptionName: "Bacon", Qty: 1, UnitPrice: 500}},
+			}},
+		}))
+		require.NoError(t, repos.Payment.Create(t.Context(), &entity.Payment{
+			ID: "pay-1", OrderID: "order-1", UserID: "user-1", StoreID: "store-1", Amount: 2500, IdempotencyKey: "key-1",
+		}))
+		require.NoError(t, repos.Close())
+
+		repos, err = NewRepositories(t.Context(), cfg, pkg.NewClock())
+		require.NoError(t, err)
+		defer repos.Close()
+
+		_, err = repos.Session.GetByID(t.Context(), "jti-1")
+		require.NoError(t, err)
+
+		order, err := repos.Order.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Len(t, order.Items, 1)
+		require.Len(t, order.Items[0].Variants, 1)
+		require.Len(t, order.Items[0].Addons, 1)
+
+		payment, err := repos.Payment.GetByOrderAndKey(t.Context(), "order-1", "key-1")
+		require.NoError(t, err)
+		require.Equal(t, "pay-1", payment.ID)
+
+		err = repos.Payment.Create(t.Context(), &entity.Payment{
+			ID: "pay-2", OrderID: "order-1", UserID: "user-1", StoreID: "store-1", IdempotencyKey: "key-1",
+		})
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+}
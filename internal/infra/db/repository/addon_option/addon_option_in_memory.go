@@ -3,21 +3,24 @@ package memoryaddonoption
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu      sync.RWMutex
 	byID    map[string]*entity.AddonOption
 	byGroup map[string][]string // groupID -> []optionID
 }
 
-func New() repository.AddonOptionRepository {
+func New(clock ports.Clock) repository.AddonOptionRepository {
 	return &Repo{
+		clock:   clock,
 		byID:    make(map[string]*entity.AddonOption),
 		byGroup: make(map[string][]string),
 	}
@@ -42,7 +45,7 @@ func (r *Repo) Create(ctx context.Context, o *entity.AddonOption) error {
 		return errx.New(errx.CodeInvalid, "price must be >= 0")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -0,0 +1,70 @@
+package memoryaudit
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	entries []*entity.AuditEntry
+}
+
+func New(clock ports.Clock) repository.AuditRepository {
+	return &Repo{clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, e *entity.AuditEntry) error {
+	_ = ctx
+
+	if e == nil {
+		return errx.New(errx.CodeInvalid, "missing audit entry")
+	}
+	if e.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if e.Action == "" {
+		return errx.New(errx.CodeInvalid, "missing action")
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = r.clock.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *e
+	r.entries = append(r.entries, &cp)
+	return nil
+}
+
+func (r *Repo) ListByAction(ctx context.Context, action entity.AuditAction, limit int) ([]*entity.AuditEntry, error) {
+	_ = ctx
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// de trás para frente: no empate de horário, a última gravada vem antes
+	out := make([]*entity.AuditEntry, 0)
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if e := r.entries[i]; e.Action == action {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
@@ -3,21 +3,24 @@ package memorycategoryitem
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu         sync.RWMutex
 	byID       map[string]*entity.CategoryItem
 	byCategory map[string][]string // categoryID -> []itemID
 }
 
-func New() repository.CategoryItemRepository {
+func New(clock ports.Clock) repository.CategoryItemRepository {
 	return &Repo{
+		clock:      clock,
 		byID:       make(map[string]*entity.CategoryItem),
 		byCategory: make(map[string][]string),
 	}
@@ -42,7 +45,7 @@ func (r *Repo) Create(ctx context.Context, i *entity.CategoryItem) error {
 		return errx.New(errx.CodeInvalid, "basePrice must be >= 0")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
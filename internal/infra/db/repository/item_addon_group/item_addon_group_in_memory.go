@@ -3,21 +3,24 @@ package memoryitemaddongroup
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu             sync.RWMutex
 	byID           map[string]*entity.ItemAddonGroup
 	ByCategoryItem map[string][]string // itemID -> []groupID
 }
 
-func New() repository.ItemAddonGroupRepository {
+func New(clock ports.Clock) repository.ItemAddonGroupRepository {
 	return &Repo{
+		clock:          clock,
 		byID:           make(map[string]*entity.ItemAddonGroup),
 		ByCategoryItem: make(map[string][]string),
 	}
@@ -42,7 +45,7 @@ func (r *Repo) Create(ctx context.Context, g *entity.ItemAddonGroup) error {
 		return errx.New(errx.CodeInvalid, "invalid min/max select")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
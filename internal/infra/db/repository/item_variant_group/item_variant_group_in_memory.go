@@ -3,21 +3,24 @@ package memoryitemvariantgroup
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu             sync.RWMutex
 	byID           map[string]*entity.ItemVariantGroup
 	ByCategoryItem map[string][]string // itemID -> []groupID
 }
 
-func New() repository.ItemVariantGroupRepository {
+func New(clock ports.Clock) repository.ItemVariantGroupRepository {
 	return &Repo{
+		clock:          clock,
 		byID:           make(map[string]*entity.ItemVariantGroup),
 		ByCategoryItem: make(map[string][]string),
 	}
@@ -42,7 +45,7 @@ func (r *Repo) Create(ctx context.Context, g *entity.ItemVariantGroup) error {
 		return errx.New(errx.CodeInvalid, "invalid min/max select")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
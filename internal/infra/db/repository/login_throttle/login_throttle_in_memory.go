@@ -0,0 +1,95 @@
+package memoryloginthrottle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Repo struct {
+	mu sync.Mutex
+
+	byKey map[string]*entity.LoginThrottle
+}
+
+func New() repository.LoginThrottleRepository {
+	return &Repo{byKey: make(map[string]*entity.LoginThrottle)}
+}
+
+func (r *Repo) Get(ctx context.Context, key string) (*entity.LoginThrottle, error) {
+	_ = ctx
+	if key == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing key")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.byKey[key]
+	if !ok {
+		return nil, errx.New(errx.CodeNotFound, "login throttle not found")
+	}
+	return cloneThrottle(t), nil
+}
+
+func (r *Repo) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entity.LoginThrottle, error) {
+	_ = ctx
+	if key == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing key")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.byKey[key]
+	if !ok {
+		t = &entity.LoginThrottle{Key: key}
+		r.byKey[key] = t
+	}
+	if t.LastFailureAt.Before(at.Add(-window)) {
+		t.Failures = 0
+	}
+	t.Failures++
+	t.LastFailureAt = at
+	return cloneThrottle(t), nil
+}
+
+func (r *Repo) Lock(ctx context.Context, key string, until time.Time) error {
+	_ = ctx
+	if key == "" {
+		return errx.New(errx.CodeInvalid, "missing key")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.byKey[key]
+	if !ok {
+		return errx.New(errx.CodeNotFound, "login throttle not found")
+	}
+	t.LockedUntil = &until
+	return nil
+}
+
+func (r *Repo) Reset(ctx context.Context, key string) error {
+	_ = ctx
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.byKey, key)
+	return nil
+}
+
+func cloneThrottle(t *entity.LoginThrottle) *entity.LoginThrottle {
+	cp := *t
+	if t.LockedUntil != nil {
+		until := *t.LockedUntil
+		cp.LockedUntil = &until
+	}
+	return &cp
+}
@@ -3,21 +3,24 @@ package memorymenucategory
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu     sync.RWMutex
 	byID   map[string]*entity.MenuCategory
 	byMenu map[string][]string // menuID -> []categoryID
 }
 
-func New() repository.MenuCategoryRepository {
+func New(clock ports.Clock) repository.MenuCategoryRepository {
 	return &Repo{
+		clock:  clock,
 		byID:   make(map[string]*entity.MenuCategory),
 		byMenu: make(map[string][]string),
 	}
@@ -39,7 +42,7 @@ func (r *Repo) Create(ctx context.Context, c *entity.MenuCategory) error {
 		return errx.New(errx.CodeInvalid, "missing name")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -8,6 +8,8 @@ import (
 )
 
 type Repo struct {
+	menus        repository.StoreMenuRepository
+	categories   repository.MenuCategoryRepository
 	items        repository.CategoryItemRepository
 	addonGroups  repository.ItemAddonGroupRepository
 	addonOptions repository.AddonOptionRepository
@@ -16,6 +18,8 @@ type Repo struct {
 }
 
 func New(
+	menus repository.StoreMenuRepository,
+	categories repository.MenuCategoryRepository,
 	items repository.CategoryItemRepository,
 	addonGroups repository.ItemAddonGroupRepository,
 	addonOptions repository.AddonOptionRepository,
@@ -23,6 +27,8 @@ func New(
 	varOptions repository.VariantOptionRepository,
 ) repository.MenuReadRepository {
 	return &Repo{
+		menus:        menus,
+		categories:   categories,
 		items:        items,
 		addonGroups:  addonGroups,
 		addonOptions: addonOptions,
@@ -35,6 +41,23 @@ func (r *Repo) GetCategoryItemByID(ctx context.Context, id string) (*entity.Cate
 	return r.items.GetByID(ctx, id)
 }
 
+// GetStoreIDByItemID sobe item → categoria → cardápio até a loja.
+func (r *Repo) GetStoreIDByItemID(ctx context.Context, itemID string) (string, error) {
+	item, err := r.items.GetByID(ctx, itemID)
+	if err != nil {
+		return "", err
+	}
+	category, err := r.categories.GetByID(ctx, item.CategoryID)
+	if err != nil {
+		return "", err
+	}
+	menu, err := r.menus.GetByID(ctx, category.MenuID)
+	if err != nil {
+		return "", err
+	}
+	return menu.StoreID, nil
+}
+
 func (r *Repo) ListItemAddonGroupsByItemID(ctx context.Context, itemID string) ([]*entity.ItemAddonGroup, error) {
 	return r.addonGroups.ListByCategoryItemID(ctx, itemID)
 }
@@ -2,11 +2,14 @@ package memoryorder
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
@@ -16,7 +19,8 @@ type userStoreKey struct {
 }
 
 type Repo struct {
-	mu sync.RWMutex
+	mu    sync.RWMutex
+	clock ports.Clock
 
 	byID map[string]*entity.Order
 
@@ -24,16 +28,15 @@ type Repo struct {
 	activeDraftByUserIDAndStoreID map[userStoreKey]string
 }
 
-func New() repository.OrderRepository {
+func New(clock ports.Clock) repository.OrderRepository {
 	return &Repo{
+		clock:                         clock,
 		byID:                          make(map[string]*entity.Order),
 		activeDraftByUserIDAndStoreID: make(map[userStoreKey]string),
 	}
 }
 
 func (r *Repo) Create(ctx context.Context, o *entity.Order) error {
-	_ = ctx
-
 	if o == nil {
 		return errx.New(errx.CodeInvalid, "missing order")
 	}
@@ -50,7 +53,7 @@ func (r *Repo) Create(ctx context.Context, o *entity.Order) error {
 		o.Status = entity.OrderCreated
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -72,16 +75,16 @@ func (r *Repo) Create(ctx context.Context, o *entity.Order) error {
 		o.CreatedAt = now
 	}
 	o.UpdatedAt = now
+	o.Version = 1
 
 	cp := cloneOrder(o)
 	r.byID[cp.ID] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, cp, r.restore)
 
 	return nil
 }
 
 func (r *Repo) Update(ctx context.Context, o *entity.Order) error {
-	_ = ctx
-
 	if o == nil {
 		return errx.New(errx.CodeInvalid, "missing order")
 	}
@@ -95,7 +98,7 @@ func (r *Repo) Update(ctx context.Context, o *entity.Order) error {
 		return errx.New(errx.CodeInvalid, "missing userId")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -104,6 +107,9 @@ func (r *Repo) Update(ctx context.Context, o *entity.Order) error {
 	if !ok || current == nil {
 		return errx.New(errx.CodeNotFound, "order not found")
 	}
+	if o.Version != current.Version {
+		return errx.Wrap(errx.CodeConflict, "order was modified concurrently", errx.ErrStaleVersion)
+	}
 
 	prevStatus := current.Status
 	nextStatus := o.Status
@@ -128,13 +134,34 @@ func (r *Repo) Update(ctx context.Context, o *entity.Order) error {
 	if o.CreatedAt.IsZero() {
 		o.CreatedAt = current.CreatedAt
 	}
+	o.Version = current.Version + 1
 
 	cp := cloneOrder(o)
 	r.byID[cp.ID] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, current, cp, r.restore)
 
 	return nil
 }
 
+// restore troca written por prev (nil = ausente) em byID e no índice de
+// carrinhos. É o rollback de uma escrita feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.Order) {
+	if written.Status == entity.OrderCreated {
+		key := userStoreKey{UserID: written.UserID, StoreID: written.StoreID}
+		if r.activeDraftByUserIDAndStoreID[key] == written.ID {
+			delete(r.activeDraftByUserIDAndStoreID, key)
+		}
+	}
+	if prev == nil {
+		delete(r.byID, written.ID)
+		return
+	}
+	r.byID[prev.ID] = prev
+	if prev.Status == entity.OrderCreated {
+		r.activeDraftByUserIDAndStoreID[userStoreKey{UserID: prev.UserID, StoreID: prev.StoreID}] = prev.ID
+	}
+}
+
 func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Order, error) {
 	_ = ctx
 
@@ -181,6 +208,62 @@ func (r *Repo) GetActiveDraftByUserIDAndStoreID(ctx context.Context, userID, sto
 	return cloneOrder(o), nil
 }
 
+func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.Order, error) {
+	_ = ctx
+
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing storeId")
+	}
+
+	r.mu.RLock()
+	out := make([]*entity.Order, 0)
+	for _, o := range r.byID {
+		if o != nil && o.StoreID == storeID {
+			out = append(out, cloneOrder(o))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) ListStale(ctx context.Context, status entity.OrderStatus, updatedBefore time.Time, limit int) ([]*entity.Order, error) {
+	_ = ctx
+
+	if status == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing status")
+	}
+	if limit <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "limit must be > 0")
+	}
+
+	r.mu.RLock()
+	out := make([]*entity.Order, 0)
+	for _, o := range r.byID {
+		if o != nil && o.Status == status && !o.UpdatedAt.After(updatedBefore) {
+			out = append(out, cloneOrder(o))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].UpdatedAt.Before(out[j].UpdatedAt)
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
 // clone profundo do pedido (porque tem slices)
 func cloneOrder(o *entity.Order) *entity.Order {
 	if o == nil {
@@ -194,6 +277,18 @@ func cloneOrder(o *entity.Order) *entity.Order {
 			cp.Items[i] = cloneOrderItem(o.Items[i])
 		}
 	}
+	if o.Transitions != nil {
+		cp.Transitions = make([]entity.OrderTransition, len(o.Transitions))
+		copy(cp.Transitions, o.Transitions)
+	}
+	if o.Cancellation != nil {
+		c := *o.Cancellation
+		cp.Cancellation = &c
+	}
+	if o.ScheduledFor != nil {
+		at := *o.ScheduledFor
+		cp.ScheduledFor = &at
+	}
 
 	return &cp
 }
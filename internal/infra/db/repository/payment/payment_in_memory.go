@@ -2,11 +2,15 @@ package memorypayment
 
 import (
 	"context"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
@@ -15,25 +19,32 @@ type orderKey struct {
 	Key     string
 }
 
+type providerRefKey struct {
+	Provider entity.PaymentProvider
+	Ref      string
+}
+
 type Repo struct {
-	mu sync.RWMutex
+	mu    sync.RWMutex
+	clock ports.Clock
 
-	byID       map[string]*entity.Payment
-	byOrder    map[string][]string
-	byOrderKey map[orderKey]string
+	byID          map[string]*entity.Payment
+	byOrder       map[string][]string
+	byOrderKey    map[orderKey]string
+	byProviderRef map[providerRefKey]string
 }
 
-func New() repository.PaymentRepository {
+func New(clock ports.Clock) repository.PaymentRepository {
 	return &Repo{
-		byID:       make(map[string]*entity.Payment),
-		byOrder:    make(map[string][]string),
-		byOrderKey: make(map[orderKey]string),
+		clock:         clock,
+		byID:          make(map[string]*entity.Payment),
+		byOrder:       make(map[string][]string),
+		byOrderKey:    make(map[orderKey]string),
+		byProviderRef: make(map[providerRefKey]string),
 	}
 }
 
 func (r *Repo) Create(ctx context.Context, p *entity.Payment) error {
-	_ = ctx
-
 	if p == nil {
 		return errx.New(errx.CodeInvalid, "missing payment")
 	}
@@ -59,7 +70,7 @@ func (r *Repo) Create(ctx context.Context, p *entity.Payment) error {
 		p.Status = entity.PaymentStatusCreated
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -80,17 +91,20 @@ func (r *Repo) Create(ctx context.Context, p *entity.Payment) error {
 		p.CreatedAt = now
 	}
 	p.UpdatedAt = now
+	p.Version = 1
 
 	cp := clonePayment(p)
 	r.byID[cp.ID] = cp
 	r.byOrder[cp.OrderID] = append(r.byOrder[cp.OrderID], cp.ID)
+	if cp.ProviderRef != "" {
+		r.byProviderRef[providerRefKey{Provider: cp.Provider, Ref: cp.ProviderRef}] = cp.ID
+	}
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, cp, r.restore)
 
 	return nil
 }
 
 func (r *Repo) Update(ctx context.Context, p *entity.Payment) error {
-	_ = ctx
-
 	if p == nil {
 		return errx.New(errx.CodeInvalid, "missing payment")
 	}
@@ -98,7 +112,7 @@ func (r *Repo) Update(ctx context.Context, p *entity.Payment) error {
 		return errx.New(errx.CodeInvalid, "missing id")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -107,17 +121,56 @@ func (r *Repo) Update(ctx context.Context, p *entity.Payment) error {
 	if !ok || cur == nil {
 		return errx.New(errx.CodeNotFound, "payment not found")
 	}
+	if p.Version != cur.Version {
+		return errx.Wrap(errx.CodeConflict, "payment was modified concurrently", errx.ErrStaleVersion)
+	}
 
 	p.UpdatedAt = now
 	if p.CreatedAt.IsZero() {
 		p.CreatedAt = cur.CreatedAt
 	}
+	p.Version = cur.Version + 1
 
+	// a referência nasce quando o gateway autoriza, depois do Create
+	if cur.ProviderRef != "" {
+		delete(r.byProviderRef, providerRefKey{Provider: cur.Provider, Ref: cur.ProviderRef})
+	}
 	cp := clonePayment(p)
 	r.byID[cp.ID] = cp
+	if cp.ProviderRef != "" {
+		r.byProviderRef[providerRefKey{Provider: cp.Provider, Ref: cp.ProviderRef}] = cp.ID
+	}
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, cur, cp, r.restore)
 	return nil
 }
 
+// restore troca written por prev (nil = ausente) em byID e nos índices. É o
+// rollback de uma escrita feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.Payment) {
+	if written.ProviderRef != "" {
+		k := providerRefKey{Provider: written.Provider, Ref: written.ProviderRef}
+		if r.byProviderRef[k] == written.ID {
+			delete(r.byProviderRef, k)
+		}
+	}
+	if prev != nil {
+		r.byID[prev.ID] = prev
+		if prev.ProviderRef != "" {
+			r.byProviderRef[providerRefKey{Provider: prev.Provider, Ref: prev.ProviderRef}] = prev.ID
+		}
+		return
+	}
+
+	delete(r.byID, written.ID)
+	if written.IdempotencyKey != "" {
+		k := orderKey{OrderID: written.OrderID, Key: written.IdempotencyKey}
+		if r.byOrderKey[k] == written.ID {
+			delete(r.byOrderKey, k)
+		}
+	}
+	r.byOrder[written.OrderID] = slices.DeleteFunc(r.byOrder[written.OrderID], func(id string) bool { return id == written.ID })
+}
+
 func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
 	_ = ctx
 	if id == "" {
@@ -157,6 +210,22 @@ func (r *Repo) GetByOrderAndKey(ctx context.Context, orderID, key string) (*enti
 	return clonePayment(p), nil
 }
 
+func (r *Repo) GetByProviderRef(ctx context.Context, provider entity.PaymentProvider, ref string) (*entity.Payment, error) {
+	_ = ctx
+	if provider == "" || ref == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing provider or ref")
+	}
+
+	r.mu.RLock()
+	p := r.byID[r.byProviderRef[providerRefKey{Provider: provider, Ref: ref}]]
+	r.mu.RUnlock()
+
+	if p == nil {
+		return nil, errx.New(errx.CodeNotFound, "payment not found")
+	}
+	return clonePayment(p), nil
+}
+
 func (r *Repo) ListByOrderID(ctx context.Context, orderID string) ([]*entity.Payment, error) {
 	_ = ctx
 	if orderID == "" {
@@ -176,6 +245,36 @@ func (r *Repo) ListByOrderID(ctx context.Context, orderID string) ([]*entity.Pay
 	return out, nil
 }
 
+func (r *Repo) ListExpired(ctx context.Context, now, createdBefore time.Time, limit int) ([]*entity.Payment, error) {
+	_ = ctx
+	if limit <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "limit must be > 0")
+	}
+
+	r.mu.RLock()
+	out := make([]*entity.Payment, 0)
+	for _, p := range r.byID {
+		if p.Status != entity.PaymentStatusCreated && p.Status != entity.PaymentStatusPending {
+			continue
+		}
+		if p.IsExpired(now) || (p.ExpiresAt == nil && !p.CreatedAt.After(createdBefore)) {
+			out = append(out, clonePayment(p))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
 func clonePayment(p *entity.Payment) *entity.Payment {
 	if p == nil {
 		return nil
@@ -185,5 +284,9 @@ func clonePayment(p *entity.Payment) *entity.Payment {
 		t := *p.PaidAt
 		cp.PaidAt = &t
 	}
+	if p.ExpiresAt != nil {
+		t := *p.ExpiresAt
+		cp.ExpiresAt = &t
+	}
 	return &cp
 }
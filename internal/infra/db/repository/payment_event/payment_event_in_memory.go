@@ -0,0 +1,126 @@
+package memorypaymentevent
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type providerEventKey struct {
+	Provider entity.PaymentProvider
+	EventID  string
+}
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	byID            map[string]*entity.PaymentEvent
+	byProviderEvent map[providerEventKey]string
+	byPayment       map[string][]string
+}
+
+func New(clock ports.Clock) repository.PaymentEventRepository {
+	return &Repo{
+		clock:           clock,
+		byID:            make(map[string]*entity.PaymentEvent),
+		byProviderEvent: make(map[providerEventKey]string),
+		byPayment:       make(map[string][]string),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, e *entity.PaymentEvent) error {
+	if e == nil {
+		return errx.New(errx.CodeInvalid, "missing payment event")
+	}
+	if e.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if e.Provider == "" {
+		return errx.New(errx.CodeInvalid, "missing provider")
+	}
+	if e.EventID == "" {
+		return errx.New(errx.CodeInvalid, "missing eventId")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[e.ID]; ok {
+		return errx.New(errx.CodeConflict, "payment event already exists")
+	}
+	k := providerEventKey{Provider: e.Provider, EventID: e.EventID}
+	if existing := r.byProviderEvent[k]; existing != "" {
+		return errx.New(errx.CodeConflict, "payment event already received")
+	}
+
+	if e.ReceivedAt.IsZero() {
+		e.ReceivedAt = r.clock.Now()
+	}
+
+	cp := *e
+	r.byID[cp.ID] = &cp
+	r.byProviderEvent[k] = cp.ID
+	if cp.PaymentID != "" {
+		r.byPayment[cp.PaymentID] = append(r.byPayment[cp.PaymentID], cp.ID)
+	}
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, &cp, r.restore)
+
+	return nil
+}
+
+// restore desfaz um Create (eventos não mudam depois de gravados). É o
+// rollback de uma escrita feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, _ *entity.PaymentEvent) {
+	delete(r.byID, written.ID)
+	k := providerEventKey{Provider: written.Provider, EventID: written.EventID}
+	if r.byProviderEvent[k] == written.ID {
+		delete(r.byProviderEvent, k)
+	}
+	if written.PaymentID != "" {
+		r.byPayment[written.PaymentID] = slices.DeleteFunc(r.byPayment[written.PaymentID], func(id string) bool { return id == written.ID })
+	}
+}
+
+func (r *Repo) GetByProviderEventID(ctx context.Context, provider entity.PaymentProvider, eventID string) (*entity.PaymentEvent, error) {
+	_ = ctx
+	if provider == "" || eventID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing provider or eventId")
+	}
+
+	r.mu.RLock()
+	e := r.byID[r.byProviderEvent[providerEventKey{Provider: provider, EventID: eventID}]]
+	r.mu.RUnlock()
+
+	if e == nil {
+		return nil, errx.New(errx.CodeNotFound, "payment event not found")
+	}
+	cp := *e
+	return &cp, nil
+}
+
+func (r *Repo) ListByPaymentID(ctx context.Context, paymentID string) ([]*entity.PaymentEvent, error) {
+	_ = ctx
+	if paymentID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing paymentId")
+	}
+
+	r.mu.RLock()
+	ids := r.byPayment[paymentID]
+	out := make([]*entity.PaymentEvent, 0, len(ids))
+	for _, id := range ids {
+		if e := r.byID[id]; e != nil {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	r.mu.RUnlock()
+
+	return out, nil
+}
@@ -0,0 +1,153 @@
+package memoryrefreshtoken
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	byID   map[string]*entity.RefreshToken
+	byHash map[string]string // hash -> id
+}
+
+func New(clock ports.Clock) repository.RefreshTokenRepository {
+	return &Repo{
+		clock:  clock,
+		byID:   make(map[string]*entity.RefreshToken),
+		byHash: make(map[string]string),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, t *entity.RefreshToken) error {
+	if t == nil {
+		return errx.New(errx.CodeInvalid, "missing refresh token")
+	}
+	if t.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if t.SessionID == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+	if t.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if t.TokenHash == "" {
+		return errx.New(errx.CodeInvalid, "missing token hash")
+	}
+	if t.ExpiresAt.IsZero() {
+		return errx.New(errx.CodeInvalid, "missing expiresAt")
+	}
+
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[t.ID]; ok {
+		return errx.New(errx.CodeConflict, "refresh token already exists")
+	}
+	if _, ok := r.byHash[t.TokenHash]; ok {
+		return errx.New(errx.CodeConflict, "refresh token already exists")
+	}
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
+
+	cp := cloneToken(t)
+	r.byID[cp.ID] = cp
+	r.byHash[cp.TokenHash] = cp.ID
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, cp, r.restore)
+	return nil
+}
+
+func (r *Repo) GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	_ = ctx
+	if tokenHash == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing token hash")
+	}
+
+	r.mu.RLock()
+	t := r.byID[r.byHash[tokenHash]]
+	r.mu.RUnlock()
+
+	if t == nil {
+		return nil, errx.New(errx.CodeNotFound, "refresh token not found")
+	}
+	return cloneToken(t), nil
+}
+
+func (r *Repo) MarkUsed(ctx context.Context, id string, usedAt time.Time) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.byID[id]
+	if !ok || t == nil {
+		return errx.New(errx.CodeNotFound, "refresh token not found")
+	}
+	if t.UsedAt != nil {
+		return errx.New(errx.CodeConflict, "refresh token already used")
+	}
+
+	cp := cloneToken(t)
+	cp.UsedAt = &usedAt
+	r.byID[id] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, id, t, cp, r.restore)
+	return nil
+}
+
+func (r *Repo) DeleteBySessionID(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, t := range r.byID {
+		if t.SessionID == sessionID {
+			delete(r.byHash, t.TokenHash)
+			delete(r.byID, id)
+			memorytx.Undo(ctx, &r.mu, r.byID, id, t, nil, r.restore)
+		}
+	}
+	return nil
+}
+
+// restore troca written por prev (nil = ausente). É o rollback de uma escrita
+// feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.RefreshToken) {
+	if written != nil {
+		delete(r.byID, written.ID)
+		if r.byHash[written.TokenHash] == written.ID {
+			delete(r.byHash, written.TokenHash)
+		}
+	}
+	if prev != nil {
+		r.byID[prev.ID] = prev
+		r.byHash[prev.TokenHash] = prev.ID
+	}
+}
+
+func cloneToken(t *entity.RefreshToken) *entity.RefreshToken {
+	cp := *t
+	if t.UsedAt != nil {
+		usedAt := *t.UsedAt
+		cp.UsedAt = &usedAt
+	}
+	return &cp
+}
@@ -0,0 +1,221 @@
+package memoryrefund
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type paymentKey struct {
+	PaymentID string
+	Key       string
+}
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	byID         map[string]*entity.Refund
+	byPayment    map[string][]string
+	byPaymentKey map[paymentKey]string
+}
+
+func New(clock ports.Clock) repository.RefundRepository {
+	return &Repo{
+		clock:        clock,
+		byID:         make(map[string]*entity.Refund),
+		byPayment:    make(map[string][]string),
+		byPaymentKey: make(map[paymentKey]string),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, rf *entity.Refund) error {
+	if rf == nil {
+		return errx.New(errx.CodeInvalid, "missing refund")
+	}
+	if rf.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if rf.PaymentID == "" {
+		return errx.New(errx.CodeInvalid, "missing paymentId")
+	}
+	if rf.OrderID == "" {
+		return errx.New(errx.CodeInvalid, "missing orderId")
+	}
+	if rf.Amount <= 0 {
+		return errx.New(errx.CodeInvalid, "amount must be > 0")
+	}
+	if rf.Currency == "" {
+		rf.Currency = "BRL"
+	}
+	if rf.Status == "" {
+		rf.Status = entity.RefundStatusPending
+	}
+
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[rf.ID]; ok {
+		return errx.New(errx.CodeConflict, "refund already exists")
+	}
+
+	if rf.IdempotencyKey != "" {
+		k := paymentKey{PaymentID: rf.PaymentID, Key: rf.IdempotencyKey}
+		if existing := r.byPaymentKey[k]; existing != "" {
+			return errx.New(errx.CodeConflict, "refund already exists for idempotency key")
+		}
+		r.byPaymentKey[k] = rf.ID
+	}
+
+	if rf.CreatedAt.IsZero() {
+		rf.CreatedAt = now
+	}
+	rf.UpdatedAt = now
+
+	cp := *rf
+	r.byID[cp.ID] = &cp
+	r.byPayment[cp.PaymentID] = append(r.byPayment[cp.PaymentID], cp.ID)
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, &cp, r.restore)
+
+	return nil
+}
+
+func (r *Repo) Update(ctx context.Context, rf *entity.Refund) error {
+	if rf == nil {
+		return errx.New(errx.CodeInvalid, "missing refund")
+	}
+	if rf.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cur, ok := r.byID[rf.ID]
+	if !ok || cur == nil {
+		return errx.New(errx.CodeNotFound, "refund not found")
+	}
+
+	// só o resultado do provider muda; valor, pagamento e chave ficam
+	cp := *cur
+	cp.Status = rf.Status
+	cp.ProviderRef = rf.ProviderRef
+	cp.UpdatedAt = now
+	r.byID[cp.ID] = &cp
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, cur, &cp, r.restore)
+
+	rf.UpdatedAt = now
+	return nil
+}
+
+// restore troca written por prev (nil = ausente). Update não mexe em
+// pagamento nem chave, então os índices só mudam ao desfazer um Create. É o
+// rollback de uma escrita feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.Refund) {
+	if prev != nil {
+		r.byID[prev.ID] = prev
+		return
+	}
+	delete(r.byID, written.ID)
+	if written.IdempotencyKey != "" {
+		k := paymentKey{PaymentID: written.PaymentID, Key: written.IdempotencyKey}
+		if r.byPaymentKey[k] == written.ID {
+			delete(r.byPaymentKey, k)
+		}
+	}
+	r.byPayment[written.PaymentID] = slices.DeleteFunc(r.byPayment[written.PaymentID], func(id string) bool { return id == written.ID })
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Refund, error) {
+	_ = ctx
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.RLock()
+	rf, ok := r.byID[id]
+	r.mu.RUnlock()
+
+	if !ok || rf == nil {
+		return nil, errx.New(errx.CodeNotFound, "refund not found")
+	}
+	cp := *rf
+	return &cp, nil
+}
+
+func (r *Repo) GetByPaymentAndKey(ctx context.Context, paymentID, key string) (*entity.Refund, error) {
+	_ = ctx
+	if paymentID == "" || key == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing paymentId or key")
+	}
+
+	r.mu.RLock()
+	rf := r.byID[r.byPaymentKey[paymentKey{PaymentID: paymentID, Key: key}]]
+	r.mu.RUnlock()
+
+	if rf == nil {
+		return nil, errx.New(errx.CodeNotFound, "refund not found")
+	}
+	cp := *rf
+	return &cp, nil
+}
+
+func (r *Repo) ListByPaymentID(ctx context.Context, paymentID string) ([]*entity.Refund, error) {
+	_ = ctx
+	if paymentID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing paymentId")
+	}
+
+	r.mu.RLock()
+	ids := r.byPayment[paymentID]
+	out := make([]*entity.Refund, 0, len(ids))
+	for _, id := range ids {
+		if rf := r.byID[id]; rf != nil {
+			cp := *rf
+			out = append(out, &cp)
+		}
+	}
+	r.mu.RUnlock()
+
+	return out, nil
+}
+
+func (r *Repo) ListPending(ctx context.Context, updatedBefore time.Time, limit int) ([]*entity.Refund, error) {
+	_ = ctx
+	if limit <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "limit must be > 0")
+	}
+
+	r.mu.RLock()
+	out := make([]*entity.Refund, 0)
+	for _, rf := range r.byID {
+		if rf.Status == entity.RefundStatusPending && !rf.UpdatedAt.After(updatedBefore) {
+			cp := *rf
+			out = append(out, &cp)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].UpdatedAt.Before(out[j].UpdatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
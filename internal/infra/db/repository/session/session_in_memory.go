@@ -2,22 +2,27 @@ package memorysession
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu   sync.RWMutex
 	byID map[string]*entity.Session // jti -> session
 }
 
-func New() repository.SessionRepository {
+func New(clock ports.Clock) repository.SessionRepository {
 	return &Repo{
-		byID: make(map[string]*entity.Session),
+		clock: clock,
+		byID:  make(map[string]*entity.Session),
 	}
 }
 
@@ -37,7 +42,7 @@ func (r *Repo) Create(ctx context.Context, s *entity.Session) error {
 		return errx.New(errx.CodeInvalid, "missing expiresAt")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -69,10 +74,52 @@ func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Session, error)
 	return cloneSession(s), nil
 }
 
+func (r *Repo) ListByUserID(ctx context.Context, userID string) ([]*entity.Session, error) {
+	_ = ctx
+
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	r.mu.RLock()
+	out := make([]*entity.Session, 0)
+	for _, s := range r.byID {
+		if s != nil && s.UserID == userID {
+			out = append(out, cloneSession(s))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id string) error {
+	_ = ctx
+
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return errx.New(errx.CodeNotFound, "session not found")
+	}
+	delete(r.byID, id)
+	return nil
+}
+
 func (r *Repo) DeleteExpired(ctx context.Context, now time.Time) error {
 	_ = ctx
 	if now.IsZero() {
-		now = time.Now()
+		now = r.clock.Now()
 	}
 
 	r.mu.Lock()
@@ -2,6 +2,7 @@ package memorystore
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
@@ -37,14 +38,38 @@ func (r *Repo) Create(ctx context.Context, s *entity.Store) error {
 		return errx.F(errx.CodeConflict, "Slug %s already exists", s.Slug)
 	}
 
-	cp := *s
-	r.byID[cp.ID] = &cp
+	cp := clone(s)
+	r.byID[cp.ID] = cp
 	r.bySlug[cp.Slug] = cp.ID
 	r.byOwnerID[cp.OwnerID] = append(r.byOwnerID[cp.OwnerID], cp.ID)
 
 	return nil
 }
 
+func (r *Repo) Update(ctx context.Context, s *entity.Store) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.byID[s.ID]
+	if !ok {
+		return errx.New(errx.CodeNotFound, "store not found")
+	}
+
+	if id, exists := r.bySlug[s.Slug]; exists && id != s.ID {
+		return errx.F(errx.CodeConflict, "Slug %s already exists", s.Slug)
+	}
+
+	cp := clone(s)
+	// dono e cnpj não mudam pelo perfil
+	cp.OwnerID = current.OwnerID
+	cp.Cnpj = current.Cnpj
+	delete(r.bySlug, current.Slug)
+	r.byID[cp.ID] = cp
+	r.bySlug[cp.Slug] = cp.ID
+
+	return nil
+}
+
 func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Store, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -54,8 +79,7 @@ func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Store, error) {
 		return nil, errx.New(errx.CodeNotFound, "store not found")
 	}
 
-	cp := *s
-	return &cp, nil
+	return clone(s), nil
 }
 
 func (r *Repo) GetBySlug(ctx context.Context, slug string) (*entity.Store, error) {
@@ -72,8 +96,7 @@ func (r *Repo) GetBySlug(ctx context.Context, slug string) (*entity.Store, error
 		return nil, errx.New(errx.CodeNotFound, "store not found")
 	}
 
-	cp := *s
-	return &cp, nil
+	return clone(s), nil
 }
 
 func (r *Repo) CountByOwnerID(ctx context.Context, ownerID string) (int, error) {
@@ -105,9 +128,20 @@ func (r *Repo) ListByOwnerID(ctx context.Context, ownerID string) ([]*entity.Sto
 		if !ok {
 			continue
 		}
-		cp := *s
-		stores = append(stores, &cp)
+		stores = append(stores, clone(s))
 	}
 
 	return stores, nil
 }
+
+// clone copia também as listas do horário para ninguém alterar o estado do repo por fora.
+func clone(s *entity.Store) *entity.Store {
+	cp := *s
+	cp.Hours.Weekly = slices.Clone(s.Hours.Weekly)
+	cp.Hours.Exceptions = slices.Clone(s.Hours.Exceptions)
+	if s.PausedUntil != nil {
+		at := *s.PausedUntil
+		cp.PausedUntil = &at
+	}
+	return &cp
+}
@@ -0,0 +1,195 @@
+package memorystoreinvitation
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	byID   map[string]*entity.StoreInvitation
+	byHash map[string]string // hash -> id
+}
+
+func New(clock ports.Clock) repository.StoreInvitationRepository {
+	return &Repo{
+		clock:  clock,
+		byID:   make(map[string]*entity.StoreInvitation),
+		byHash: make(map[string]string),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, i *entity.StoreInvitation) error {
+	if i == nil {
+		return errx.New(errx.CodeInvalid, "missing store invitation")
+	}
+	if i.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if i.StoreID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if i.Email == "" {
+		return errx.New(errx.CodeInvalid, "missing email")
+	}
+	if i.TokenHash == "" {
+		return errx.New(errx.CodeInvalid, "missing token hash")
+	}
+	if i.ExpiresAt.IsZero() {
+		return errx.New(errx.CodeInvalid, "missing expiresAt")
+	}
+
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[i.ID]; ok {
+		return errx.New(errx.CodeConflict, "store invitation already exists")
+	}
+	if _, ok := r.byHash[i.TokenHash]; ok {
+		return errx.New(errx.CodeConflict, "store invitation already exists")
+	}
+
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = now
+	}
+
+	cp := cloneInvitation(i)
+	r.byID[cp.ID] = cp
+	r.byHash[cp.TokenHash] = cp.ID
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, cp, r.restore)
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.StoreInvitation, error) {
+	_ = ctx
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.RLock()
+	i := r.byID[id]
+	r.mu.RUnlock()
+
+	if i == nil {
+		return nil, errx.New(errx.CodeNotFound, "store invitation not found")
+	}
+	return cloneInvitation(i), nil
+}
+
+func (r *Repo) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.StoreInvitation, error) {
+	_ = ctx
+	if tokenHash == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing token hash")
+	}
+
+	r.mu.RLock()
+	i := r.byID[r.byHash[tokenHash]]
+	r.mu.RUnlock()
+
+	if i == nil {
+		return nil, errx.New(errx.CodeNotFound, "store invitation not found")
+	}
+	return cloneInvitation(i), nil
+}
+
+func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.StoreInvitation, error) {
+	_ = ctx
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing store id")
+	}
+
+	r.mu.RLock()
+	out := make([]*entity.StoreInvitation, 0)
+	for _, i := range r.byID {
+		if i.StoreID == storeID {
+			out = append(out, cloneInvitation(i))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].CreatedAt.Equal(out[b].CreatedAt) {
+			return out[a].ID < out[b].ID
+		}
+		return out[a].CreatedAt.Before(out[b].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) MarkAccepted(ctx context.Context, id, userID string, at time.Time) error {
+	return r.close(ctx, id, func(i *entity.StoreInvitation) {
+		i.AcceptedAt = &at
+		i.AcceptedBy = userID
+	})
+}
+
+func (r *Repo) MarkRevoked(ctx context.Context, id string, at time.Time) error {
+	return r.close(ctx, id, func(i *entity.StoreInvitation) {
+		i.RevokedAt = &at
+	})
+}
+
+// close encerra um convite ainda pendente; aceito ou revogado é definitivo.
+func (r *Repo) close(ctx context.Context, id string, apply func(i *entity.StoreInvitation)) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.byID[id]
+	if !ok || i == nil {
+		return errx.New(errx.CodeNotFound, "store invitation not found")
+	}
+	if i.IsAccepted() {
+		return errx.New(errx.CodeConflict, "store invitation already accepted")
+	}
+	if i.IsRevoked() {
+		return errx.New(errx.CodeConflict, "store invitation revoked")
+	}
+
+	cp := cloneInvitation(i)
+	apply(cp)
+	r.byID[id] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, id, i, cp, r.restore)
+	return nil
+}
+
+// restore troca written por prev (nil = ausente). O hash não muda depois do
+// Create. É o rollback de uma escrita feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.StoreInvitation) {
+	if prev != nil {
+		r.byID[prev.ID] = prev
+		return
+	}
+	delete(r.byID, written.ID)
+	if r.byHash[written.TokenHash] == written.ID {
+		delete(r.byHash, written.TokenHash)
+	}
+}
+
+func cloneInvitation(i *entity.StoreInvitation) *entity.StoreInvitation {
+	cp := *i
+	if i.AcceptedAt != nil {
+		at := *i.AcceptedAt
+		cp.AcceptedAt = &at
+	}
+	if i.RevokedAt != nil {
+		at := *i.RevokedAt
+		cp.RevokedAt = &at
+	}
+	return &cp
+}
@@ -0,0 +1,173 @@
+package memorystoremember
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	byID      map[string]*entity.StoreMember
+	byStoreID map[string]map[string]string // storeID -> userID -> id
+}
+
+func New(clock ports.Clock) repository.StoreMemberRepository {
+	return &Repo{
+		clock:     clock,
+		byID:      make(map[string]*entity.StoreMember),
+		byStoreID: make(map[string]map[string]string),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, m *entity.StoreMember) error {
+	if m == nil {
+		return errx.New(errx.CodeInvalid, "missing store member")
+	}
+	if m.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if m.StoreID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if m.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if _, ok := entity.StoreMemberRoleMap[m.Role.String()]; !ok {
+		return errx.New(errx.CodeInvalid, "invalid store member role")
+	}
+
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[m.ID]; ok {
+		return errx.New(errx.CodeConflict, "store member already exists")
+	}
+	if _, ok := r.byStoreID[m.StoreID][m.UserID]; ok {
+		return errx.New(errx.CodeConflict, "user is already a member of this store")
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+
+	cp := *m
+	r.put(&cp)
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, &cp, r.restore)
+	return nil
+}
+
+func (r *Repo) GetByStoreAndUser(ctx context.Context, storeID, userID string) (*entity.StoreMember, error) {
+	_ = ctx
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byStoreID[storeID][userID]
+	if !ok {
+		return nil, errx.New(errx.CodeNotFound, "store member not found")
+	}
+	cp := *r.byID[id]
+	return &cp, nil
+}
+
+func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.StoreMember, error) {
+	_ = ctx
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing store id")
+	}
+
+	r.mu.RLock()
+	out := make([]*entity.StoreMember, 0, len(r.byStoreID[storeID]))
+	for _, id := range r.byStoreID[storeID] {
+		cp := *r.byID[id]
+		out = append(out, &cp)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) CountByUserID(ctx context.Context, userID string) (int, error) {
+	_ = ctx
+	if userID == "" {
+		return 0, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, m := range r.byID {
+		if m.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, storeID, userID string) error {
+	if storeID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byStoreID[storeID][userID]
+	if !ok {
+		return errx.New(errx.CodeNotFound, "store member not found")
+	}
+	prev := r.byID[id]
+	delete(r.byStoreID[storeID], userID)
+	delete(r.byID, id)
+	memorytx.Undo(ctx, &r.mu, r.byID, id, prev, nil, r.restore)
+	return nil
+}
+
+func (r *Repo) put(m *entity.StoreMember) {
+	r.byID[m.ID] = m
+	if r.byStoreID[m.StoreID] == nil {
+		r.byStoreID[m.StoreID] = make(map[string]string)
+	}
+	r.byStoreID[m.StoreID][m.UserID] = m.ID
+}
+
+// restore troca written por prev (nil = ausente). É o rollback de uma escrita
+// feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.StoreMember) {
+	if written != nil {
+		delete(r.byID, written.ID)
+		if r.byStoreID[written.StoreID][written.UserID] == written.ID {
+			delete(r.byStoreID[written.StoreID], written.UserID)
+		}
+	}
+	if prev != nil {
+		r.put(prev)
+	}
+}
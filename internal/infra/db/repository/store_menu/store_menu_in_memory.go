@@ -3,21 +3,24 @@ package memorystoremenu
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu      sync.RWMutex
 	byID    map[string]*entity.StoreMenu
 	byStore map[string][]string // storeID -> []menuID
 }
 
-func New() repository.StoreMenuRepository {
+func New(clock ports.Clock) repository.StoreMenuRepository {
 	return &Repo{
+		clock:   clock,
 		byID:    make(map[string]*entity.StoreMenu),
 		byStore: make(map[string][]string),
 	}
@@ -39,7 +42,7 @@ func (r *Repo) Create(ctx context.Context, m *entity.StoreMenu) error {
 		return errx.New(errx.CodeInvalid, "missing name")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -86,6 +89,7 @@ func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.Sto
 
 	r.mu.RLock()
 	if _, ok := r.byStore[storeID]; !ok {
+		r.mu.RUnlock()
 		return nil, errx.New(errx.CodeNotFound, "store not found")
 	}
 	r.mu.RUnlock()
@@ -0,0 +1,252 @@
+package memorytwofactor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	byUserID map[string]*entity.TwoFactor
+	// userID -> códigos de recuperação
+	codes map[string]*recoveryCodes
+}
+
+// recoveryCodes é trocado inteiro a cada escrita: o ponteiro identifica a
+// versão da lista no rollback.
+type recoveryCodes struct {
+	list []*entity.RecoveryCode
+}
+
+func New(clock ports.Clock) repository.TwoFactorRepository {
+	return &Repo{
+		clock:    clock,
+		byUserID: make(map[string]*entity.TwoFactor),
+		codes:    make(map[string]*recoveryCodes),
+	}
+}
+
+func (r *Repo) SavePending(ctx context.Context, tf *entity.TwoFactor) error {
+	if tf == nil {
+		return errx.New(errx.CodeInvalid, "missing two factor")
+	}
+	if tf.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if tf.Secret == "" {
+		return errx.New(errx.CodeInvalid, "missing secret")
+	}
+
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cur := r.byUserID[tf.UserID]
+	if cur != nil && cur.IsEnabled() {
+		return errx.New(errx.CodeConflict, "two-factor authentication already enabled")
+	}
+
+	if tf.CreatedAt.IsZero() {
+		tf.CreatedAt = now
+	}
+	tf.UpdatedAt = now
+	tf.EnabledAt = nil
+	tf.LastUsedStep = 0
+
+	cp := *tf
+	r.byUserID[tf.UserID] = &cp
+	memorytx.Undo(ctx, &r.mu, r.byUserID, tf.UserID, cur, &cp, r.restore)
+	return nil
+}
+
+func (r *Repo) GetByUserID(ctx context.Context, userID string) (*entity.TwoFactor, error) {
+	_ = ctx
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tf, ok := r.byUserID[userID]
+	if !ok {
+		return nil, errx.New(errx.CodeNotFound, "two factor not found")
+	}
+	return cloneTwoFactor(tf), nil
+}
+
+func (r *Repo) Enable(ctx context.Context, userID string, at time.Time) error {
+	return r.update(ctx, userID, func(tf *entity.TwoFactor) error {
+		if tf.IsEnabled() {
+			return errx.New(errx.CodeConflict, "two-factor authentication already enabled")
+		}
+		tf.EnabledAt = &at
+		return nil
+	})
+}
+
+func (r *Repo) UseStep(ctx context.Context, userID string, step int64) error {
+	return r.update(ctx, userID, func(tf *entity.TwoFactor) error {
+		if step <= tf.LastUsedStep {
+			return errx.New(errx.CodeConflict, "code already used")
+		}
+		tf.LastUsedStep = step
+		return nil
+	})
+}
+
+func (r *Repo) Delete(ctx context.Context, userID string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cur, ok := r.byUserID[userID]
+	if !ok {
+		return errx.New(errx.CodeNotFound, "two factor not found")
+	}
+	delete(r.byUserID, userID)
+	memorytx.Undo(ctx, &r.mu, r.byUserID, userID, cur, nil, r.restore)
+	r.setCodes(ctx, userID, nil)
+	return nil
+}
+
+func (r *Repo) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*entity.RecoveryCode) error {
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	now := r.clock.Now()
+	list := make([]*entity.RecoveryCode, 0, len(codes))
+	for _, c := range codes {
+		if c == nil || c.ID == "" || c.CodeHash == "" {
+			return errx.New(errx.CodeInvalid, "invalid recovery code")
+		}
+		cp := *c
+		cp.UserID = userID
+		if cp.CreatedAt.IsZero() {
+			cp.CreatedAt = now
+		}
+		list = append(list, &cp)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.setCodes(ctx, userID, &recoveryCodes{list: list})
+	return nil
+}
+
+func (r *Repo) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var list []*entity.RecoveryCode
+	if cur := r.codes[userID]; cur != nil {
+		list = cur.list
+	}
+	for i, c := range list {
+		if c.CodeHash != codeHash || c.UsedAt != nil {
+			continue
+		}
+		cp := *c
+		cp.UsedAt = &at
+
+		// troca a lista inteira: o rollback da tx volta para a anterior
+		next := make([]*entity.RecoveryCode, len(list))
+		copy(next, list)
+		next[i] = &cp
+		r.setCodes(ctx, userID, &recoveryCodes{list: next})
+		return nil
+	}
+	return errx.New(errx.CodeNotFound, "recovery code not found")
+}
+
+func (r *Repo) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	_ = ctx
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n := 0
+	cur := r.codes[userID]
+	if cur == nil {
+		return 0, nil
+	}
+	for _, c := range cur.list {
+		if c.UsedAt == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *Repo) update(ctx context.Context, userID string, fn func(tf *entity.TwoFactor) error) error {
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cur, ok := r.byUserID[userID]
+	if !ok {
+		return errx.New(errx.CodeNotFound, "two factor not found")
+	}
+
+	cp := cloneTwoFactor(cur)
+	if err := fn(cp); err != nil {
+		return err
+	}
+	cp.UpdatedAt = r.clock.Now()
+	r.byUserID[userID] = cp
+	memorytx.Undo(ctx, &r.mu, r.byUserID, userID, cur, cp, r.restore)
+	return nil
+}
+
+// setCodes troca a lista de códigos do usuário (nil apaga). Chamado com o
+// lock tomado.
+func (r *Repo) setCodes(ctx context.Context, userID string, next *recoveryCodes) {
+	prev := r.codes[userID]
+	if next == nil {
+		delete(r.codes, userID)
+	} else {
+		r.codes[userID] = next
+	}
+	memorytx.Undo(ctx, &r.mu, r.codes, userID, prev, next, func(_, prev *recoveryCodes) {
+		if prev == nil {
+			delete(r.codes, userID)
+			return
+		}
+		r.codes[userID] = prev
+	})
+}
+
+// restore troca written por prev (nil = ausente). É o rollback de uma escrita
+// feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.TwoFactor) {
+	if prev == nil {
+		delete(r.byUserID, written.UserID)
+		return
+	}
+	r.byUserID[prev.UserID] = prev
+}
+
+func cloneTwoFactor(tf *entity.TwoFactor) *entity.TwoFactor {
+	cp := *tf
+	if tf.EnabledAt != nil {
+		at := *tf.EnabledAt
+		cp.EnabledAt = &at
+	}
+	return &cp
+}
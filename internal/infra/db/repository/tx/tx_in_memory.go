@@ -0,0 +1,94 @@
+package memorytx
+
+import (
+	"context"
+	"sync"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+// undoLog guarda, na ordem em que aconteceram, como desfazer as escritas
+// feitas dentro de uma tx.
+type undoLog struct {
+	mu      sync.Mutex
+	entries []func()
+}
+
+func (l *undoLog) add(undo ...func()) {
+	l.mu.Lock()
+	l.entries = append(l.entries, undo...)
+	l.mu.Unlock()
+}
+
+func (l *undoLog) rollback() {
+	l.mu.Lock()
+	entries := l.entries
+	l.entries = nil
+	l.mu.Unlock()
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i]()
+	}
+}
+
+type txKey struct{}
+
+// Manager emula transações sobre os repos in-memory. Cada repo registra em
+// ctx como desfazer o que escreveu (OnRollback/Undo) e, se fn falhar, só essas
+// chaves voltam: escritas feitas fora de WithinTx enquanto a tx rodava ficam.
+// Transações são serializadas entre si.
+type Manager struct {
+	mu sync.Mutex
+}
+
+func New() ports.TxManager {
+	return &Manager{}
+}
+
+func (m *Manager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// aninhada: já estamos com o lock, funciona como savepoint
+	if parent, ok := ctx.Value(txKey{}).(*undoLog); ok {
+		return run(ctx, parent, fn)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return run(ctx, nil, fn)
+}
+
+func run(ctx context.Context, parent *undoLog, fn func(ctx context.Context) error) error {
+	log := &undoLog{}
+	if err := fn(context.WithValue(ctx, txKey{}, log)); err != nil {
+		log.rollback()
+		return err
+	}
+	// savepoint confirmado: se a tx de fora falhar, desfaz junto
+	if parent != nil {
+		parent.add(log.entries...)
+	}
+	return nil
+}
+
+// OnRollback agenda undo para quando a tx de ctx for desfeita. Fora de tx
+// não faz nada: a escrita já está valendo.
+func OnRollback(ctx context.Context, undo func()) {
+	if log, ok := ctx.Value(txKey{}).(*undoLog); ok {
+		log.add(undo)
+	}
+}
+
+// Undo registra como desfazer a escrita que trocou prev por written em
+// m[key] (nil = ausente). No rollback, com mu tomado, restore(written, prev)
+// só roda se m[key] ainda for written: se outra escrita de fora da tx mexeu
+// na mesma chave depois, ela prevalece.
+func Undo[K comparable, V any](ctx context.Context, mu sync.Locker, m map[K]*V, key K, prev, written *V, restore func(written, prev *V)) {
+	OnRollback(ctx, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if m[key] != written {
+			return
+		}
+		restore(written, prev)
+	})
+}
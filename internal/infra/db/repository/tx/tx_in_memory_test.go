@@ -0,0 +1,154 @@
+package memorytx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager(t *testing.T) {
+	orders := memoryorder.New(pkg.NewClock())
+	payments := memorypayment.New(pkg.NewClock())
+	tx := memorytx.New()
+
+	errBoom := errors.New("boom")
+
+	t.Run("test commit keeps every write", func(t *testing.T) {
+		err := tx.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := orders.Create(ctx, &entity.Order{ID: "order-1", StoreID: "store-1", UserID: "user-1"}); err != nil {
+				return err
+			}
+			return payments.Create(ctx, &entity.Payment{ID: "pay-1", OrderID: "order-1", UserID: "user-1", StoreID: "store-1", IdempotencyKey: "key-1"})
+		})
+		require.NoError(t, err)
+
+		_, err = orders.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		_, err = payments.GetByID(t.Context(), "pay-1")
+		require.NoError(t, err)
+	})
+
+	t.Run("test error rolls back every repository", func(t *testing.T) {
+		err := tx.WithinTx(t.Context(), func(ctx context.Context) error {
+			o, err := orders.GetByID(ctx, "order-1")
+			if err != nil {
+				return err
+			}
+			o.Status = entity.OrderPlaced
+			if err := orders.Update(ctx, o); err != nil {
+				return err
+			}
+			if err := payments.Create(ctx, &entity.Payment{ID: "pay-2", OrderID: "order-1", UserID: "user-1", StoreID: "store-1", IdempotencyKey: "key-2"}); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		require.ErrorIs(t, err, errBoom)
+
+		o, err := orders.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderCreated, o.Status)
+
+		_, err = orders.GetActiveDraftByUserIDAndStoreID(t.Context(), "user-1", "store-1")
+		require.NoError(t, err)
+
+		_, err = payments.GetByOrderAndKey(t.Context(), "order-1", "key-2")
+		require.Error(t, err)
+
+		list, err := payments.ListByOrderID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+	})
+
+	t.Run("test nested error rolls back only the inner block", func(t *testing.T) {
+		err := tx.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := payments.Create(ctx, &entity.Payment{ID: "pay-3", OrderID: "order-1", UserID: "user-1", StoreID: "store-1"}); err != nil {
+				return err
+			}
+
+			inner := tx.WithinTx(ctx, func(ctx context.Context) error {
+				if err := payments.Create(ctx, &entity.Payment{ID: "pay-4", OrderID: "order-1", UserID: "user-1", StoreID: "store-1"}); err != nil {
+					return err
+				}
+				return errBoom
+			})
+			require.ErrorIs(t, inner, errBoom)
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = payments.GetByID(t.Context(), "pay-3")
+		require.NoError(t, err)
+		_, err = payments.GetByID(t.Context(), "pay-4")
+		require.Error(t, err)
+	})
+
+	t.Run("test rollback keeps writes made outside the tx", func(t *testing.T) {
+		written := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			<-written
+			// escrita fora de tx enquanto a tx ainda está aberta
+			o, err := orders.GetByID(context.Background(), "order-1")
+			require.NoError(t, err)
+			o.Items = append(o.Items, entity.OrderItem{ID: "item-1", Name: "Coca", Qty: 1})
+			require.NoError(t, orders.Update(context.Background(), o))
+			require.NoError(t, payments.Create(context.Background(), &entity.Payment{ID: "pay-5", OrderID: "order-1", UserID: "user-1", StoreID: "store-1"}))
+		}()
+
+		err := tx.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := payments.Create(ctx, &entity.Payment{ID: "pay-6", OrderID: "order-1", UserID: "user-1", StoreID: "store-1"}); err != nil {
+				return err
+			}
+			close(written)
+			<-done
+			return errBoom
+		})
+		require.ErrorIs(t, err, errBoom)
+
+		o, err := orders.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Len(t, o.Items, 1)
+		_, err = payments.GetByID(t.Context(), "pay-5")
+		require.NoError(t, err)
+		_, err = payments.GetByID(t.Context(), "pay-6")
+		require.Error(t, err)
+	})
+
+	t.Run("test rollback leaves a key rewritten outside the tx", func(t *testing.T) {
+		err := tx.WithinTx(t.Context(), func(ctx context.Context) error {
+			o, err := orders.GetByID(ctx, "order-1")
+			if err != nil {
+				return err
+			}
+			o.Fees = 100
+			if err := orders.Update(ctx, o); err != nil {
+				return err
+			}
+
+			// outra escrita, fora da tx, em cima da versão gravada pela tx
+			o, err = orders.GetByID(context.Background(), "order-1")
+			if err != nil {
+				return err
+			}
+			o.Fees = 200
+			if err := orders.Update(context.Background(), o); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		require.ErrorIs(t, err, errBoom)
+
+		o, err := orders.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.MoneyCents(200), o.Fees)
+	})
+}
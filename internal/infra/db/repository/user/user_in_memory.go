@@ -6,20 +6,25 @@ import (
 	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu     sync.RWMutex
 	byID   map[string]*entity.User
 	byCpf  map[string]string // cpf -> id
 	byMail map[string]string // email -> id
 }
 
-func New() repository.UserRepository {
+func New(clock ports.Clock) repository.UserRepository {
 	return &Repo{
+		clock:  clock,
 		byID:   make(map[string]*entity.User),
 		byCpf:  make(map[string]string),
 		byMail: make(map[string]string),
@@ -27,8 +32,6 @@ func New() repository.UserRepository {
 }
 
 func (r *Repo) Create(ctx context.Context, u *entity.User) error {
-	_ = ctx
-
 	if u == nil {
 		return errx.New(errx.CodeInvalid, "missing user")
 	}
@@ -42,7 +45,7 @@ func (r *Repo) Create(ctx context.Context, u *entity.User) error {
 		return errx.New(errx.CodeInvalid, "missing email")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -75,6 +78,7 @@ func (r *Repo) Create(ctx context.Context, u *entity.User) error {
 	r.byID[cp.ID] = cp
 	r.byCpf[cp.Cpf] = cp.ID
 	r.byMail[cp.Email] = cp.ID
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, cp, r.restore)
 
 	return nil
 }
@@ -140,6 +144,50 @@ func (r *Repo) GetByMail(ctx context.Context, mail string) (*entity.User, error)
 	return cloneUser(u), nil
 }
 
+func (r *Repo) Update(ctx context.Context, u *entity.User) error {
+	if u == nil {
+		return errx.New(errx.CodeInvalid, "missing user")
+	}
+	if u.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.byID[u.ID]
+	if !ok || current == nil {
+		return errx.New(errx.CodeNotFound, "user not found")
+	}
+
+	u.UpdatedAt = r.clock.Now()
+
+	cp := cloneUser(u)
+	cp.Email = current.Email
+	cp.Cpf = current.Cpf
+	cp.CreatedAt = current.CreatedAt
+	r.byID[cp.ID] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, current, cp, r.restore)
+	return nil
+}
+
+// restore troca written por prev (nil = ausente). Update não troca email nem
+// cpf, então os índices só mudam ao desfazer um Create. É o rollback de uma
+// escrita feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.User) {
+	if prev != nil {
+		r.byID[prev.ID] = prev
+		return
+	}
+	delete(r.byID, written.ID)
+	if r.byCpf[written.Cpf] == written.ID {
+		delete(r.byCpf, written.Cpf)
+	}
+	if r.byMail[written.Email] == written.ID {
+		delete(r.byMail, written.Email)
+	}
+}
+
 func cloneUser(u *entity.User) *entity.User {
 	if u == nil {
 		return nil
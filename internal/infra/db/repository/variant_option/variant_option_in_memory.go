@@ -3,21 +3,24 @@ package memoryvariantoption
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 )
 
 type Repo struct {
+	clock ports.Clock
+
 	mu      sync.RWMutex
 	byID    map[string]*entity.VariantOption
 	byGroup map[string][]string // groupID -> []optionID
 }
 
-func New() repository.VariantOptionRepository {
+func New(clock ports.Clock) repository.VariantOptionRepository {
 	return &Repo{
+		clock:   clock,
 		byID:    make(map[string]*entity.VariantOption),
 		byGroup: make(map[string][]string),
 	}
@@ -39,7 +42,7 @@ func (r *Repo) Create(ctx context.Context, o *entity.VariantOption) error {
 		return errx.New(errx.CodeInvalid, "missing name")
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -0,0 +1,169 @@
+package memoryverificationcode
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Repo struct {
+	mu    sync.RWMutex
+	clock ports.Clock
+
+	byID map[string]*entity.VerificationCode
+}
+
+func New(clock ports.Clock) repository.VerificationCodeRepository {
+	return &Repo{
+		clock: clock,
+		byID:  make(map[string]*entity.VerificationCode),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, c *entity.VerificationCode) error {
+	if c == nil {
+		return errx.New(errx.CodeInvalid, "missing verification code")
+	}
+	if c.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if c.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if c.Purpose == "" {
+		return errx.New(errx.CodeInvalid, "missing purpose")
+	}
+	if c.CodeHash == "" {
+		return errx.New(errx.CodeInvalid, "missing code hash")
+	}
+	if c.ExpiresAt.IsZero() {
+		return errx.New(errx.CodeInvalid, "missing expiresAt")
+	}
+
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[c.ID]; ok {
+		return errx.New(errx.CodeConflict, "verification code already exists")
+	}
+
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = now
+	}
+
+	cp := cloneCode(c)
+	r.byID[cp.ID] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, cp.ID, nil, cp, r.restore)
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.VerificationCode, error) {
+	_ = ctx
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.byID[id]
+	if !ok {
+		return nil, errx.New(errx.CodeNotFound, "verification code not found")
+	}
+	return cloneCode(c), nil
+}
+
+func (r *Repo) GetLatest(ctx context.Context, userID string, purpose entity.VerificationPurpose) (*entity.VerificationCode, error) {
+	_ = ctx
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *entity.VerificationCode
+	for _, c := range r.byID {
+		if c.UserID != userID || c.Purpose != purpose {
+			continue
+		}
+		if latest == nil || c.CreatedAt.After(latest.CreatedAt) ||
+			(c.CreatedAt.Equal(latest.CreatedAt) && c.ID > latest.ID) {
+			latest = c
+		}
+	}
+	if latest == nil {
+		return nil, errx.New(errx.CodeNotFound, "verification code not found")
+	}
+	return cloneCode(latest), nil
+}
+
+func (r *Repo) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	if id == "" {
+		return 0, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.byID[id]
+	if !ok || c == nil {
+		return 0, errx.New(errx.CodeNotFound, "verification code not found")
+	}
+
+	cp := cloneCode(c)
+	cp.Attempts++
+	r.byID[id] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, id, c, cp, r.restore)
+	return cp.Attempts, nil
+}
+
+func (r *Repo) MarkConsumed(ctx context.Context, id string, at time.Time) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.byID[id]
+	if !ok || c == nil {
+		return errx.New(errx.CodeNotFound, "verification code not found")
+	}
+	if c.ConsumedAt != nil {
+		return errx.New(errx.CodeConflict, "verification code already used")
+	}
+
+	cp := cloneCode(c)
+	cp.ConsumedAt = &at
+	r.byID[id] = cp
+	memorytx.Undo(ctx, &r.mu, r.byID, id, c, cp, r.restore)
+	return nil
+}
+
+// restore troca written por prev (nil = ausente). É o rollback de uma escrita
+// feita em tx; roda com o lock tomado.
+func (r *Repo) restore(written, prev *entity.VerificationCode) {
+	if prev == nil {
+		delete(r.byID, written.ID)
+		return
+	}
+	r.byID[prev.ID] = prev
+}
+
+func cloneCode(c *entity.VerificationCode) *entity.VerificationCode {
+	cp := *c
+	if c.ConsumedAt != nil {
+		at := *c.ConsumedAt
+		cp.ConsumedAt = &at
+	}
+	return &cp
+}
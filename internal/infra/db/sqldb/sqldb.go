@@ -0,0 +1,137 @@
+// Package sqldb reúne o acesso a bancos relacionais via database/sql,
+// compartilhado pelos repositórios SQL (Postgres e SQLite).
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite3"
+)
+
+type DB struct {
+	*sql.DB
+	Dialect Dialect
+}
+
+// Querier é o subconjunto comum entre *sql.DB e *sql.Tx usado pelos repos.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+func Open(dialect Dialect, dsn string) (*DB, error) {
+	switch dialect {
+	case DialectPostgres, DialectSQLite:
+	default:
+		return nil, errx.F(errx.CodeInvalid, "unsupported dialect %q", dialect)
+	}
+	if strings.TrimSpace(dsn) == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing dsn")
+	}
+
+	conn, err := sql.Open(string(dialect), dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if dialect == DialectSQLite {
+		// SQLite só aceita um escritor por vez
+		conn.SetMaxOpenConns(1)
+	}
+
+	if err := conn.Ping(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return &DB{DB: conn, Dialect: dialect}, nil
+}
+
+// SQLiteDSN monta o DSN de um arquivo SQLite em modo WAL, com foreign keys
+// ligadas e espera em vez de falhar imediatamente quando o arquivo está travado.
+func SQLiteDSN(path string) string {
+	return "file:" + path + "?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on"
+}
+
+// Rebind troca os placeholders "?" pelo formato do dialeto ($1, $2... no Postgres).
+func (db *DB) Rebind(query string) string {
+	if db.Dialect != DialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	for _, ch := range query {
+		if ch == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+
+	var liteErr sqlite3.Error
+	if errors.As(err, &liteErr) {
+		return liteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+			liteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+
+	return false
+}
+
+func IsNoRows(err error) bool {
+	return errors.Is(err, sql.ErrNoRows)
+}
+
+// Internal embrulha falhas inesperadas do banco como errx.CodeInternal.
+func Internal(op string, err error) error {
+	return errx.Wrap(errx.CodeInternal, op, err)
+}
+
+func Time(t time.Time) time.Time {
+	return t.UTC()
+}
+
+func NullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func TimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
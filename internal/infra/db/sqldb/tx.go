@@ -0,0 +1,66 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+type txKey struct{}
+
+type txState struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+// Q devolve a tx aberta por WithinTx no ctx ou, fora dela, o próprio banco.
+// Todo repo SQL deve passar por aqui para participar da unidade de trabalho.
+func (db *DB) Q(ctx context.Context) Querier {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return st.tx
+	}
+	return db.DB
+}
+
+// WithinTx executa fn numa transação (implementa ports.TxManager).
+// Chamadas aninhadas viram SAVEPOINT: um erro interno desfaz só o trecho
+// interno e a tx externa continua utilizável.
+func (db *DB) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return db.withinSavepoint(ctx, st, fn)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return Internal("begin tx", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{tx: tx})); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Internal("commit tx", err)
+	}
+	return nil
+}
+
+func (db *DB) withinSavepoint(ctx context.Context, st *txState, fn func(ctx context.Context) error) error {
+	st.savepoints++
+	name := "sp_" + strconv.Itoa(st.savepoints)
+
+	if _, err := st.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return Internal("savepoint", err)
+	}
+
+	if err := fn(ctx); err != nil {
+		_, _ = st.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		return err
+	}
+
+	if _, err := st.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return Internal("release savepoint", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package sqldb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	sqlpayment "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/payment"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newPayment(id, key string) *entity.Payment {
+	return &entity.Payment{ID: id, OrderID: "order-1", UserID: "user-1", StoreID: "store-1", IdempotencyKey: key}
+}
+
+func TestWithinTx(t *testing.T) {
+	db := testkit.NewTestDB(t)
+	payments := sqlpayment.New(db, pkg.NewClock())
+	errBoom := errors.New("boom")
+
+	t.Run("test error rolls back the writes", func(t *testing.T) {
+		err := db.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := payments.Create(ctx, newPayment("pay-1", "")); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		require.ErrorIs(t, err, errBoom)
+
+		_, err = payments.GetByID(t.Context(), "pay-1")
+		require.Error(t, err)
+	})
+
+	t.Run("test commit keeps the writes", func(t *testing.T) {
+		err := db.WithinTx(t.Context(), func(ctx context.Context) error {
+			return payments.Create(ctx, newPayment("pay-2", "key-2"))
+		})
+		require.NoError(t, err)
+
+		_, err = payments.GetByID(t.Context(), "pay-2")
+		require.NoError(t, err)
+	})
+
+	t.Run("test conflict inside a tx keeps the tx usable", func(t *testing.T) {
+		err := db.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := payments.Create(ctx, newPayment("pay-3", "key-2")); err == nil {
+				return errors.New("expected conflict")
+			}
+			return payments.Create(ctx, newPayment("pay-4", ""))
+		})
+		require.NoError(t, err)
+
+		_, err = payments.GetByID(t.Context(), "pay-4")
+		require.NoError(t, err)
+	})
+
+	t.Run("test nested error rolls back only the inner block", func(t *testing.T) {
+		err := db.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := payments.Create(ctx, newPayment("pay-5", "")); err != nil {
+				return err
+			}
+			inner := db.WithinTx(ctx, func(ctx context.Context) error {
+				if err := payments.Create(ctx, newPayment("pay-6", "")); err != nil {
+					return err
+				}
+				return errBoom
+			})
+			require.ErrorIs(t, inner, errBoom)
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = payments.GetByID(t.Context(), "pay-5")
+		require.NoError(t, err)
+		_, err = payments.GetByID(t.Context(), "pay-6")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+package sqladdonoption
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, addon_group_id, name, price, sort_order, is_active, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.AddonOptionRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, o *entity.AddonOption) error {
+	if o == nil {
+		return errx.New(errx.CodeInvalid, "missing addon option")
+	}
+	if o.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if o.AddonGroupID == "" {
+		return errx.New(errx.CodeInvalid, "missing groupId")
+	}
+	if o.Name == "" {
+		return errx.New(errx.CodeInvalid, "missing name")
+	}
+	if o.Price < 0 {
+		return errx.New(errx.CodeInvalid, "price must be >= 0")
+	}
+
+	now := r.clock.Now()
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = now
+	}
+	o.UpdatedAt = now
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO addon_options (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		o.ID, o.AddonGroupID, o.Name, o.Price, o.Order, o.IsActive,
+		sqldb.Time(o.CreatedAt), sqldb.Time(o.UpdatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "addon option already exists")
+		}
+		return sqldb.Internal("create addon option", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.AddonOption, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM addon_options WHERE id = ?`), id)
+	o, err := scanAddonOption(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "addon option not found")
+		}
+		return nil, sqldb.Internal("get addon option", err)
+	}
+	return o, nil
+}
+
+func (r *Repo) ListByAddonGroupID(ctx context.Context, groupID string) ([]*entity.AddonOption, error) {
+	if groupID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing groupId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM addon_options
+		WHERE addon_group_id = ?
+		ORDER BY created_at, id`), groupID)
+	if err != nil {
+		return nil, sqldb.Internal("list addon options", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.AddonOption, 0)
+	for rows.Next() {
+		o, err := scanAddonOption(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list addon options", err)
+		}
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list addon options", err)
+	}
+
+	return out, nil
+}
+
+func scanAddonOption(s sqldb.Scanner) (*entity.AddonOption, error) {
+	var o entity.AddonOption
+	err := s.Scan(&o.ID, &o.AddonGroupID, &o.Name, &o.Price, &o.Order, &o.IsActive, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
@@ -0,0 +1,83 @@
+package sqlaudit
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, action, user_id, subject, ip, detail, created_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.AuditRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, e *entity.AuditEntry) error {
+	if e == nil {
+		return errx.New(errx.CodeInvalid, "missing audit entry")
+	}
+	if e.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if e.Action == "" {
+		return errx.New(errx.CodeInvalid, "missing action")
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = r.clock.Now()
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO audit_log (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		e.ID, string(e.Action), e.UserID, e.Subject, e.IP, e.Detail, sqldb.Time(e.CreatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "audit entry already exists")
+		}
+		return sqldb.Internal("create audit entry", err)
+	}
+	return nil
+}
+
+func (r *Repo) ListByAction(ctx context.Context, action entity.AuditAction, limit int) ([]*entity.AuditEntry, error) {
+	query := `SELECT ` + columns + ` FROM audit_log WHERE action = ? ORDER BY created_at DESC, id DESC`
+	args := []any{string(action)}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, sqldb.Internal("list audit entries", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.AuditEntry, 0)
+	for rows.Next() {
+		var (
+			e      entity.AuditEntry
+			action string
+		)
+		if err := rows.Scan(&e.ID, &action, &e.UserID, &e.Subject, &e.IP, &e.Detail, &e.CreatedAt); err != nil {
+			return nil, sqldb.Internal("list audit entries", err)
+		}
+		e.Action = entity.AuditAction(action)
+		out = append(out, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list audit entries", err)
+	}
+	return out, nil
+}
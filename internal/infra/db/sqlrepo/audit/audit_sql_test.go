@@ -0,0 +1,37 @@
+package sqlaudit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditSQLRepository(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	repo := New(testkit.NewTestDB(t), clock)
+
+	t.Run("test list by action newest first", func(t *testing.T) {
+		for _, id := range []string{"a-1", "a-2", "a-3"} {
+			require.NoError(t, repo.Create(t.Context(), &entity.AuditEntry{
+				ID: id, Action: entity.AuditActionLoginLockout, Subject: "email:a@example.com", IP: "10.0.0.1",
+			}))
+			clock.Advance(time.Minute)
+		}
+		require.NoError(t, repo.Create(t.Context(), &entity.AuditEntry{ID: "b-1", Action: "other"}))
+
+		got, err := repo.ListByAction(t.Context(), entity.AuditActionLoginLockout, 2)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, "a-3", got[0].ID)
+		require.Equal(t, "a-2", got[1].ID)
+		require.Equal(t, "10.0.0.1", got[0].IP)
+
+		all, err := repo.ListByAction(t.Context(), entity.AuditActionLoginLockout, 0)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+	})
+}
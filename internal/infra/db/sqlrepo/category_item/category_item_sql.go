@@ -0,0 +1,115 @@
+package sqlcategoryitem
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, category_id, name, description, base_price, image_url, is_active, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.CategoryItemRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, i *entity.CategoryItem) error {
+	if i == nil {
+		return errx.New(errx.CodeInvalid, "missing item")
+	}
+	if i.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if i.CategoryID == "" {
+		return errx.New(errx.CodeInvalid, "missing categoryId")
+	}
+	if i.Name == "" {
+		return errx.New(errx.CodeInvalid, "missing name")
+	}
+	if i.BasePrice < 0 {
+		return errx.New(errx.CodeInvalid, "basePrice must be >= 0")
+	}
+
+	now := r.clock.Now()
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = now
+	}
+	i.UpdatedAt = now
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO category_items (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		i.ID, i.CategoryID, i.Name, i.Description, i.BasePrice, i.ImageURL, i.IsActive,
+		sqldb.Time(i.CreatedAt), sqldb.Time(i.UpdatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "item already exists")
+		}
+		return sqldb.Internal("create item", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.CategoryItem, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM category_items WHERE id = ?`), id)
+	i, err := scanItem(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "item not found")
+		}
+		return nil, sqldb.Internal("get item", err)
+	}
+	return i, nil
+}
+
+func (r *Repo) ListByCategoryID(ctx context.Context, categoryID string) ([]*entity.CategoryItem, error) {
+	if categoryID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing categoryId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM category_items
+		WHERE category_id = ?
+		ORDER BY created_at, id`), categoryID)
+	if err != nil {
+		return nil, sqldb.Internal("list items", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.CategoryItem, 0)
+	for rows.Next() {
+		i, err := scanItem(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list items", err)
+		}
+		out = append(out, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list items", err)
+	}
+
+	return out, nil
+}
+
+func scanItem(s sqldb.Scanner) (*entity.CategoryItem, error) {
+	var i entity.CategoryItem
+	err := s.Scan(&i.ID, &i.CategoryID, &i.Name, &i.Description, &i.BasePrice, &i.ImageURL, &i.IsActive, &i.CreatedAt, &i.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
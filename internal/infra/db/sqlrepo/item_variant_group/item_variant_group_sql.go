@@ -0,0 +1,115 @@
+package sqlitemvariantgroup
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, category_item_id, name, required, min_select, max_select, sort_order, is_active, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.ItemVariantGroupRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, g *entity.ItemVariantGroup) error {
+	if g == nil {
+		return errx.New(errx.CodeInvalid, "missing variant group")
+	}
+	if g.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if g.CategoryItemID == "" {
+		return errx.New(errx.CodeInvalid, "missing category item ID")
+	}
+	if g.Name == "" {
+		return errx.New(errx.CodeInvalid, "missing name")
+	}
+	if g.MinSelect < 0 || g.MaxSelect < 0 || (g.MaxSelect > 0 && g.MinSelect > g.MaxSelect) {
+		return errx.New(errx.CodeInvalid, "invalid min/max select")
+	}
+
+	now := r.clock.Now()
+	if g.CreatedAt.IsZero() {
+		g.CreatedAt = now
+	}
+	g.UpdatedAt = now
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO item_variant_groups (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		g.ID, g.CategoryItemID, g.Name, g.Required, g.MinSelect, g.MaxSelect, g.Order, g.IsActive,
+		sqldb.Time(g.CreatedAt), sqldb.Time(g.UpdatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "variant group already exists")
+		}
+		return sqldb.Internal("create variant group", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.ItemVariantGroup, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM item_variant_groups WHERE id = ?`), id)
+	g, err := scanVariantGroup(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "variant group not found")
+		}
+		return nil, sqldb.Internal("get variant group", err)
+	}
+	return g, nil
+}
+
+func (r *Repo) ListByCategoryItemID(ctx context.Context, itemID string) ([]*entity.ItemVariantGroup, error) {
+	if itemID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing category item ID")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM item_variant_groups
+		WHERE category_item_id = ?
+		ORDER BY created_at, id`), itemID)
+	if err != nil {
+		return nil, sqldb.Internal("list variant groups", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.ItemVariantGroup, 0)
+	for rows.Next() {
+		g, err := scanVariantGroup(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list variant groups", err)
+		}
+		out = append(out, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list variant groups", err)
+	}
+
+	return out, nil
+}
+
+func scanVariantGroup(s sqldb.Scanner) (*entity.ItemVariantGroup, error) {
+	var g entity.ItemVariantGroup
+	err := s.Scan(&g.ID, &g.CategoryItemID, &g.Name, &g.Required, &g.MinSelect, &g.MaxSelect, &g.Order, &g.IsActive, &g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
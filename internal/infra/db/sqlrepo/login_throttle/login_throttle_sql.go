@@ -0,0 +1,99 @@
+package sqlloginthrottle
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `throttle_key, failures, last_failure_at, locked_until`
+
+type Repo struct {
+	db *sqldb.DB
+}
+
+func New(db *sqldb.DB) repository.LoginThrottleRepository {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Get(ctx context.Context, key string) (*entity.LoginThrottle, error) {
+	if key == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing key")
+	}
+
+	t, err := scanThrottle(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM login_throttles WHERE throttle_key = ?`), key))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "login throttle not found")
+		}
+		return nil, sqldb.Internal("get login throttle", err)
+	}
+	return t, nil
+}
+
+func (r *Repo) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entity.LoginThrottle, error) {
+	if key == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing key")
+	}
+
+	// upsert num comando só: tentativas em paralelo não perdem contagem
+	t, err := scanThrottle(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		INSERT INTO login_throttles (`+columns+`)
+		VALUES (?, 1, ?, NULL)
+		ON CONFLICT (throttle_key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failure_at < ? THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failure_at = excluded.last_failure_at
+		RETURNING `+columns),
+		key, sqldb.Time(at), sqldb.Time(at.Add(-window)),
+	))
+	if err != nil {
+		return nil, sqldb.Internal("record login failure", err)
+	}
+	return t, nil
+}
+
+func (r *Repo) Lock(ctx context.Context, key string, until time.Time) error {
+	if key == "" {
+		return errx.New(errx.CodeInvalid, "missing key")
+	}
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE login_throttles SET locked_until = ? WHERE throttle_key = ?`), sqldb.Time(until), key)
+	if err != nil {
+		return sqldb.Internal("lock login", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("lock login", err)
+	}
+	if n == 0 {
+		return errx.New(errx.CodeNotFound, "login throttle not found")
+	}
+	return nil
+}
+
+func (r *Repo) Reset(ctx context.Context, key string) error {
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM login_throttles WHERE throttle_key = ?`), key)
+	if err != nil {
+		return sqldb.Internal("reset login throttle", err)
+	}
+	return nil
+}
+
+func scanThrottle(s sqldb.Scanner) (*entity.LoginThrottle, error) {
+	var (
+		t           entity.LoginThrottle
+		lockedUntil sql.NullTime
+	)
+	if err := s.Scan(&t.Key, &t.Failures, &t.LastFailureAt, &lockedUntil); err != nil {
+		return nil, err
+	}
+	t.LockedUntil = sqldb.TimePtr(lockedUntil)
+	return &t, nil
+}
@@ -0,0 +1,50 @@
+package sqlloginthrottle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginThrottleSQLRepository(t *testing.T) {
+	repo := New(testkit.NewTestDB(t))
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("test failures accumulate inside the window", func(t *testing.T) {
+		_, err := repo.Get(t.Context(), "email:a@example.com")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		for i := 1; i <= 3; i++ {
+			got, err := repo.RecordFailure(t.Context(), "email:a@example.com", now.Add(time.Duration(i)*time.Second), time.Hour)
+			require.NoError(t, err)
+			require.Equal(t, i, got.Failures)
+			require.Nil(t, got.LockedUntil)
+		}
+	})
+
+	t.Run("test failures restart after the window", func(t *testing.T) {
+		got, err := repo.RecordFailure(t.Context(), "email:a@example.com", now.Add(2*time.Hour), time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 1, got.Failures)
+	})
+
+	t.Run("test lock and reset", func(t *testing.T) {
+		until := now.Add(3 * time.Hour)
+		require.NoError(t, repo.Lock(t.Context(), "email:a@example.com", until))
+
+		got, err := repo.Get(t.Context(), "email:a@example.com")
+		require.NoError(t, err)
+		require.True(t, got.IsLocked(now.Add(2*time.Hour)))
+		require.False(t, got.IsLocked(until))
+
+		err = repo.Lock(t.Context(), "ip:10.0.0.1", until)
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		require.NoError(t, repo.Reset(t.Context(), "email:a@example.com"))
+		_, err = repo.Get(t.Context(), "email:a@example.com")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+}
@@ -0,0 +1,110 @@
+package sqlmenucategory
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, menu_id, name, is_active, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.MenuCategoryRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, c *entity.MenuCategory) error {
+	if c == nil {
+		return errx.New(errx.CodeInvalid, "missing category")
+	}
+	if c.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if c.MenuID == "" {
+		return errx.New(errx.CodeInvalid, "missing menuId")
+	}
+	if c.Name == "" {
+		return errx.New(errx.CodeInvalid, "missing name")
+	}
+
+	now := r.clock.Now()
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = now
+	}
+	c.UpdatedAt = now
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO menu_categories (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		c.ID, c.MenuID, c.Name, c.IsActive, sqldb.Time(c.CreatedAt), sqldb.Time(c.UpdatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "category already exists")
+		}
+		return sqldb.Internal("create category", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.MenuCategory, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM menu_categories WHERE id = ?`), id)
+	c, err := scanCategory(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "category not found")
+		}
+		return nil, sqldb.Internal("get category", err)
+	}
+	return c, nil
+}
+
+func (r *Repo) ListByMenuID(ctx context.Context, menuID string) ([]*entity.MenuCategory, error) {
+	if menuID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing menuId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM menu_categories
+		WHERE menu_id = ?
+		ORDER BY created_at, id`), menuID)
+	if err != nil {
+		return nil, sqldb.Internal("list categories", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.MenuCategory, 0)
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list categories", err)
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list categories", err)
+	}
+
+	return out, nil
+}
+
+func scanCategory(s sqldb.Scanner) (*entity.MenuCategory, error) {
+	var c entity.MenuCategory
+	if err := s.Scan(&c.ID, &c.MenuID, &c.Name, &c.IsActive, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,506 @@
+package sqlorder
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, store_id, menu_id, user_id, status, subtotal, fees, total, version, created_at, updated_at,
+	cancel_actor, cancel_actor_id, cancel_reason, canceled_at, scheduled_for`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.OrderRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, o *entity.Order) error {
+	if err := validate(o); err != nil {
+		return err
+	}
+	if o.Status == "" {
+		o.Status = entity.OrderCreated
+	}
+
+	now := r.clock.Now()
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = now
+	}
+	o.UpdatedAt = now
+	o.Version = 1
+
+	cancelActor, cancelActorID, cancelReason, canceledAt := cancellationColumns(o)
+
+	var conflict bool
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO orders (`+columns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			o.ID, o.StoreID, o.MenuID, o.UserID, string(o.Status),
+			int64(o.Subtotal), int64(o.Fees), int64(o.Total), o.Version,
+			sqldb.Time(o.CreatedAt), sqldb.Time(o.UpdatedAt),
+			cancelActor, cancelActorID, cancelReason, canceledAt, sqldb.NullTime(o.ScheduledFor),
+		)
+		if err != nil {
+			if sqldb.IsUniqueViolation(err) {
+				conflict = true
+				return err
+			}
+			return sqldb.Internal("create order", err)
+		}
+
+		if err := r.insertItems(ctx, o); err != nil {
+			return err
+		}
+		return r.insertTransitions(ctx, o, 0)
+	})
+	if conflict {
+		// o insert já foi desfeito (tx/savepoint): dá para consultar o motivo
+		return r.conflictFor(ctx, o.ID)
+	}
+	return err
+}
+
+func (r *Repo) Update(ctx context.Context, o *entity.Order) error {
+	if err := validate(o); err != nil {
+		return err
+	}
+
+	now := r.clock.Now()
+	cancelActor, cancelActorID, cancelReason, canceledAt := cancellationColumns(o)
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		q := r.db.Q(ctx)
+
+		var createdAt time.Time
+		err := q.QueryRowContext(ctx, r.db.Rebind(`SELECT created_at FROM orders WHERE id = ?`), o.ID).Scan(&createdAt)
+		if err != nil {
+			if sqldb.IsNoRows(err) {
+				return errx.New(errx.CodeNotFound, "order not found")
+			}
+			return sqldb.Internal("update order", err)
+		}
+
+		// a condição na versão é o que garante o lock otimista, mesmo entre
+		// transações concorrentes (o SELECT acima não trava nada)
+		res, err := q.ExecContext(ctx, r.db.Rebind(`
+			UPDATE orders
+			SET store_id = ?, menu_id = ?, user_id = ?, status = ?,
+			    subtotal = ?, fees = ?, total = ?, updated_at = ?, version = version + 1,
+			    cancel_actor = ?, cancel_actor_id = ?, cancel_reason = ?, canceled_at = ?, scheduled_for = ?
+			WHERE id = ? AND version = ?`),
+			o.StoreID, o.MenuID, o.UserID, string(o.Status),
+			int64(o.Subtotal), int64(o.Fees), int64(o.Total), sqldb.Time(now),
+			cancelActor, cancelActorID, cancelReason, canceledAt, sqldb.NullTime(o.ScheduledFor),
+			o.ID, o.Version,
+		)
+		if err != nil {
+			if sqldb.IsUniqueViolation(err) {
+				return errx.New(errx.CodeConflict, "active draft already exists")
+			}
+			return sqldb.Internal("update order", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return sqldb.Internal("update order", err)
+		} else if n == 0 {
+			return errx.Wrap(errx.CodeConflict, "order was modified concurrently", errx.ErrStaleVersion)
+		}
+
+		o.UpdatedAt = now
+		o.Version++
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = createdAt
+		}
+
+		// itens são snapshots: regrava tudo
+		for _, table := range []string{"order_item_addons", "order_item_variants", "order_items"} {
+			if _, err := q.ExecContext(ctx, r.db.Rebind(`DELETE FROM `+table+` WHERE order_id = ?`), o.ID); err != nil {
+				return sqldb.Internal("update order", err)
+			}
+		}
+
+		if err := r.insertItems(ctx, o); err != nil {
+			return err
+		}
+
+		// histórico é append-only: grava só as transições novas
+		var saved int
+		err = q.QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) FROM order_transitions WHERE order_id = ?`), o.ID).Scan(&saved)
+		if err != nil {
+			return sqldb.Internal("update order", err)
+		}
+		return r.insertTransitions(ctx, o, saved)
+	})
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM orders WHERE id = ?`), id)
+	o, err := scanOrder(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "order not found")
+		}
+		return nil, sqldb.Internal("get order", err)
+	}
+
+	if err := r.load(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (r *Repo) GetActiveDraftByUserIDAndStoreID(ctx context.Context, userID, storeID string) (*entity.Order, error) {
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing userId")
+	}
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing storeId")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM orders
+		WHERE user_id = ? AND store_id = ? AND status = ?`),
+		userID, storeID, string(entity.OrderCreated),
+	)
+	o, err := scanOrder(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "active draft not found")
+		}
+		return nil, sqldb.Internal("get active draft", err)
+	}
+
+	if err := r.load(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.Order, error) {
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing storeId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM orders
+		WHERE store_id = ?
+		ORDER BY created_at, id`), storeID)
+	if err != nil {
+		return nil, sqldb.Internal("list orders", err)
+	}
+	return r.scanAll(ctx, rows)
+}
+
+func (r *Repo) ListStale(ctx context.Context, status entity.OrderStatus, updatedBefore time.Time, limit int) ([]*entity.Order, error) {
+	if status == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing status")
+	}
+	if limit <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "limit must be > 0")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM orders
+		WHERE status = ? AND updated_at <= ?
+		ORDER BY updated_at, id
+		LIMIT ?`), string(status), sqldb.Time(updatedBefore), limit)
+	if err != nil {
+		return nil, sqldb.Internal("list orders", err)
+	}
+	return r.scanAll(ctx, rows)
+}
+
+// scanAll fecha o rows antes de carregar itens e transições de cada pedido.
+func (r *Repo) scanAll(ctx context.Context, rows *sql.Rows) ([]*entity.Order, error) {
+	out := []*entity.Order{}
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			_ = rows.Close()
+			return nil, sqldb.Internal("list orders", err)
+		}
+		out = append(out, o)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, sqldb.Internal("list orders", err)
+	}
+
+	for _, o := range out {
+		if err := r.load(ctx, o); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (r *Repo) conflictFor(ctx context.Context, id string) error {
+	var n int
+	err := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) FROM orders WHERE id = ?`), id).Scan(&n)
+	if err == nil && n > 0 {
+		return errx.New(errx.CodeConflict, "order already exists")
+	}
+	return errx.New(errx.CodeConflict, "active draft already exists")
+}
+
+func (r *Repo) insertItems(ctx context.Context, o *entity.Order) error {
+	q := r.db.Q(ctx)
+	for i, it := range o.Items {
+		_, err := q.ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO order_items (id, order_id, position, item_id, name, qty, base_price, line_total, note)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			it.ID, o.ID, i, it.ItemID, it.Name, it.Qty, int64(it.BasePrice), int64(it.LineTotal), it.Note,
+		)
+		if err != nil {
+			if sqldb.IsUniqueViolation(err) {
+				return errx.New(errx.CodeConflict, "order item already exists")
+			}
+			return sqldb.Internal("save order items", err)
+		}
+
+		for j, v := range it.Variants {
+			_, err := q.ExecContext(ctx, r.db.Rebind(`
+				INSERT INTO order_item_variants
+					(order_id, order_item_id, position, variant_group_id, variant_group, variant_option_id, option_name, price_delta)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+				o.ID, it.ID, j, v.VariantGroupID, v.VariantGroup, v.VariantOptionID, v.OptionName, int64(v.PriceDelta),
+			)
+			if err != nil {
+				return sqldb.Internal("save order item variants", err)
+			}
+		}
+
+		for j, a := range it.Addons {
+			_, err := q.ExecContext(ctx, r.db.Rebind(`
+				INSERT INTO order_item_addons
+					(order_id, order_item_id, position, addon_group_id, addon_group, addon_option_id, option_name, qty, unit_price, line_total)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				o.ID, it.ID, j, a.AddonGroupID, a.AddonGroup, a.AddonOptionID, a.OptionName, a.Qty, int64(a.UnitPrice), int64(a.LineTotal),
+			)
+			if err != nil {
+				return sqldb.Internal("save order item addons", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Repo) insertTransitions(ctx context.Context, o *entity.Order, from int) error {
+	q := r.db.Q(ctx)
+	for i := from; i < len(o.Transitions); i++ {
+		tr := o.Transitions[i]
+		_, err := q.ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO order_transitions (order_id, position, from_status, to_status, actor, actor_id, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`),
+			o.ID, i, string(tr.From), string(tr.To), string(tr.Actor), tr.ActorID, sqldb.Time(tr.At),
+		)
+		if err != nil {
+			return sqldb.Internal("save order transitions", err)
+		}
+	}
+	return nil
+}
+
+func (r *Repo) load(ctx context.Context, o *entity.Order) error {
+	if err := r.loadItems(ctx, o); err != nil {
+		return err
+	}
+	return r.loadTransitions(ctx, o)
+}
+
+func (r *Repo) loadTransitions(ctx context.Context, o *entity.Order) error {
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT from_status, to_status, actor, actor_id, created_at
+		FROM order_transitions WHERE order_id = ?
+		ORDER BY position`), o.ID)
+	if err != nil {
+		return sqldb.Internal("load order transitions", err)
+	}
+	for rows.Next() {
+		var (
+			tr              entity.OrderTransition
+			from, to, actor string
+		)
+		if err := rows.Scan(&from, &to, &actor, &tr.ActorID, &tr.At); err != nil {
+			_ = rows.Close()
+			return sqldb.Internal("load order transitions", err)
+		}
+		tr.From = entity.OrderStatus(from)
+		tr.To = entity.OrderStatus(to)
+		tr.Actor = entity.OrderActor(actor)
+		o.Transitions = append(o.Transitions, tr)
+	}
+	if err := closeRows(rows); err != nil {
+		return sqldb.Internal("load order transitions", err)
+	}
+	return nil
+}
+
+func (r *Repo) loadItems(ctx context.Context, o *entity.Order) error {
+	o.Items = []entity.OrderItem{}
+	index := map[string]int{} // orderItemID -> posição em o.Items
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT id, item_id, name, qty, base_price, line_total, note
+		FROM order_items WHERE order_id = ?
+		ORDER BY position`), o.ID)
+	if err != nil {
+		return sqldb.Internal("load order items", err)
+	}
+	for rows.Next() {
+		var (
+			it              entity.OrderItem
+			base, lineTotal int64
+		)
+		if err := rows.Scan(&it.ID, &it.ItemID, &it.Name, &it.Qty, &base, &lineTotal, &it.Note); err != nil {
+			_ = rows.Close()
+			return sqldb.Internal("load order items", err)
+		}
+		it.BasePrice = entity.MoneyCents(base)
+		it.LineTotal = entity.MoneyCents(lineTotal)
+		index[it.ID] = len(o.Items)
+		o.Items = append(o.Items, it)
+	}
+	if err := closeRows(rows); err != nil {
+		return sqldb.Internal("load order items", err)
+	}
+
+	if len(o.Items) == 0 {
+		return nil
+	}
+
+	rows, err = r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT order_item_id, variant_group_id, variant_group, variant_option_id, option_name, price_delta
+		FROM order_item_variants WHERE order_id = ?
+		ORDER BY order_item_id, position`), o.ID)
+	if err != nil {
+		return sqldb.Internal("load order item variants", err)
+	}
+	for rows.Next() {
+		var (
+			itemID string
+			v      entity.OrderItemVariant
+			delta  int64
+		)
+		if err := rows.Scan(&itemID, &v.VariantGroupID, &v.VariantGroup, &v.VariantOptionID, &v.OptionName, &delta); err != nil {
+			_ = rows.Close()
+			return sqldb.Internal("load order item variants", err)
+		}
+		v.PriceDelta = entity.MoneyCents(delta)
+		if i, ok := index[itemID]; ok {
+			o.Items[i].Variants = append(o.Items[i].Variants, v)
+		}
+	}
+	if err := closeRows(rows); err != nil {
+		return sqldb.Internal("load order item variants", err)
+	}
+
+	rows, err = r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT order_item_id, addon_group_id, addon_group, addon_option_id, option_name, qty, unit_price, line_total
+		FROM order_item_addons WHERE order_id = ?
+		ORDER BY order_item_id, position`), o.ID)
+	if err != nil {
+		return sqldb.Internal("load order item addons", err)
+	}
+	for rows.Next() {
+		var (
+			itemID          string
+			a               entity.OrderItemAddon
+			unit, lineTotal int64
+		)
+		if err := rows.Scan(&itemID, &a.AddonGroupID, &a.AddonGroup, &a.AddonOptionID, &a.OptionName, &a.Qty, &unit, &lineTotal); err != nil {
+			_ = rows.Close()
+			return sqldb.Internal("load order item addons", err)
+		}
+		a.UnitPrice = entity.MoneyCents(unit)
+		a.LineTotal = entity.MoneyCents(lineTotal)
+		if i, ok := index[itemID]; ok {
+			o.Items[i].Addons = append(o.Items[i].Addons, a)
+		}
+	}
+	if err := closeRows(rows); err != nil {
+		return sqldb.Internal("load order item addons", err)
+	}
+
+	return nil
+}
+
+func validate(o *entity.Order) error {
+	if o == nil {
+		return errx.New(errx.CodeInvalid, "missing order")
+	}
+	if o.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if o.StoreID == "" {
+		return errx.New(errx.CodeInvalid, "missing storeId")
+	}
+	if o.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing userId")
+	}
+	return nil
+}
+
+// cancellationColumns achata o Order.Cancellation nas colunas cancel_*.
+func cancellationColumns(o *entity.Order) (actor, actorID, reason string, at sql.NullTime) {
+	c := o.Cancellation
+	if c == nil {
+		return "", "", "", sql.NullTime{}
+	}
+	return string(c.Actor), c.ActorID, c.Reason, sqldb.NullTime(&c.At)
+}
+
+func scanOrder(s sqldb.Scanner) (*entity.Order, error) {
+	var (
+		o                     entity.Order
+		status                string
+		subtotal, fees, total int64
+		cancelActor           string
+		cancelActorID         string
+		cancelReason          string
+		canceledAt            sql.NullTime
+		scheduledFor          sql.NullTime
+	)
+	err := s.Scan(
+		&o.ID, &o.StoreID, &o.MenuID, &o.UserID, &status, &subtotal, &fees, &total, &o.Version, &o.CreatedAt, &o.UpdatedAt,
+		&cancelActor, &cancelActorID, &cancelReason, &canceledAt, &scheduledFor,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if canceledAt.Valid {
+		o.Cancellation = &entity.OrderCancellation{
+			Actor:   entity.OrderActor(cancelActor),
+			ActorID: cancelActorID,
+			Reason:  cancelReason,
+			At:      canceledAt.Time,
+		}
+	}
+	o.ScheduledFor = sqldb.TimePtr(scheduledFor)
+	o.Status = entity.OrderStatus(status)
+	o.Subtotal = entity.MoneyCents(subtotal)
+	o.Fees = entity.MoneyCents(fees)
+	o.Total = entity.MoneyCents(total)
+	return &o, nil
+}
+
+func closeRows(rows *sql.Rows) error {
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,190 @@
+package sqlorder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrder(id string) *entity.Order {
+	o := &entity.Order{
+		ID:      id,
+		StoreID: "store-1",
+		MenuID:  "menu-1",
+		UserID:  "user-1",
+		Items: []entity.OrderItem{
+			{
+				ID:        "line-1",
+				ItemID:    "item-1",
+				Name:      "Cheddar Burger",
+				Qty:       2,
+				BasePrice: 2500,
+				Note:      "sem cebola",
+				Variants: []entity.OrderItemVariant{
+					{VariantGroupID: "vg-1", VariantGroup: "Ponto", VariantOptionID: "vo-1", OptionName: "Ao ponto", PriceDelta: 0},
+				},
+				Addons: []entity.OrderItemAddon{
+					{AddonGroupID: "ag-1", AddonGroup: "Adicionais", AddonOptionID: "ao-1", OptionName: "Bacon", Qty: 1, UnitPrice: 500},
+					{AddonGroupID: "ag-1", AddonGroup: "Adicionais", AddonOptionID: "ao-2", OptionName: "Cheddar", Qty: 2, UnitPrice: 300},
+				},
+			},
+		},
+	}
+	o.RecalculateTotals()
+	return o
+}
+
+func TestOrderSQLRepository(t *testing.T) {
+	repo := New(testkit.NewTestDB(t), pkg.NewClock())
+
+	t.Run("test create and get an order with nested items", func(t *testing.T) {
+		o := newOrder("order-1")
+		require.NoError(t, repo.Create(t.Context(), o))
+
+		got, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderCreated, got.Status)
+		require.Equal(t, o.Total, got.Total)
+		require.Equal(t, o.Items, got.Items)
+	})
+
+	t.Run("test create a second draft for the same user and store", func(t *testing.T) {
+		err := repo.Create(t.Context(), newOrder("order-2"))
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+
+	t.Run("test get the active draft and update its items", func(t *testing.T) {
+		draft, err := repo.GetActiveDraftByUserIDAndStoreID(t.Context(), "user-1", "store-1")
+		require.NoError(t, err)
+		require.Equal(t, "order-1", draft.ID)
+
+		draft.Items = append(draft.Items, entity.OrderItem{ID: "line-2", ItemID: "item-2", Name: "Coca", Qty: 1, BasePrice: 700})
+		draft.RecalculateTotals()
+		require.NoError(t, repo.Update(t.Context(), draft))
+
+		got, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Len(t, got.Items, 2)
+		require.Equal(t, draft.Total, got.Total)
+		require.Len(t, got.Items[0].Addons, 2)
+	})
+
+	t.Run("test place the draft and open a new one", func(t *testing.T) {
+		draft, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+
+		scheduledFor := time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+		draft.Status = entity.OrderPlaced
+		draft.ScheduledFor = &scheduledFor
+		require.NoError(t, repo.Update(t.Context(), draft))
+
+		placed, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.NotNil(t, placed.ScheduledFor)
+		require.True(t, scheduledFor.Equal(*placed.ScheduledFor))
+
+		_, err = repo.GetActiveDraftByUserIDAndStoreID(t.Context(), "user-1", "store-1")
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(err))
+
+		require.NoError(t, repo.Create(t.Context(), newOrder("order-3")))
+	})
+
+	t.Run("test record status transitions across updates", func(t *testing.T) {
+		o, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+
+		now := time.Now()
+		require.NoError(t, o.TransitionTo(entity.OrderPaid, entity.OrderActorSystem, "", now))
+		require.NoError(t, repo.Update(t.Context(), o))
+		require.NoError(t, o.TransitionTo(entity.OrderAccepted, entity.OrderActorStore, "owner-1", now.Add(time.Minute)))
+		require.NoError(t, repo.Update(t.Context(), o))
+
+		got, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderAccepted, got.Status)
+		require.Len(t, got.Transitions, 2)
+		require.Equal(t, entity.OrderPlaced, got.Transitions[0].From)
+		require.Equal(t, entity.OrderActorStore, got.Transitions[1].Actor)
+		require.Equal(t, "owner-1", got.Transitions[1].ActorID)
+		require.WithinDuration(t, now.Add(time.Minute), got.Transitions[1].At, time.Millisecond)
+	})
+
+	t.Run("test list orders by store", func(t *testing.T) {
+		orders, err := repo.ListByStoreID(t.Context(), "store-1")
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		require.Equal(t, "order-1", orders[0].ID)
+		require.Len(t, orders[0].Transitions, 2)
+		require.Len(t, orders[1].Items, 1)
+
+		orders, err = repo.ListByStoreID(t.Context(), "store-2")
+		require.NoError(t, err)
+		require.Empty(t, orders)
+	})
+
+	t.Run("test list stale orders by status", func(t *testing.T) {
+		now := time.Now()
+
+		orders, err := repo.ListStale(t.Context(), entity.OrderCreated, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		require.Equal(t, "order-3", orders[0].ID)
+		require.Len(t, orders[0].Items, 1)
+
+		orders, err = repo.ListStale(t.Context(), entity.OrderCreated, now.Add(-time.Hour), 10)
+		require.NoError(t, err)
+		require.Empty(t, orders)
+
+		orders, err = repo.ListStale(t.Context(), entity.OrderPlaced, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Empty(t, orders)
+	})
+
+	t.Run("test persist who canceled the order and why", func(t *testing.T) {
+		o, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Nil(t, o.Cancellation)
+
+		at := time.Now()
+		require.NoError(t, o.Cancel(entity.OrderActorStore, "owner-1", "acabou o gás", at))
+		require.NoError(t, repo.Update(t.Context(), o))
+
+		got, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderCanceled, got.Status)
+		require.NotNil(t, got.Cancellation)
+		require.Equal(t, entity.OrderActorStore, got.Cancellation.Actor)
+		require.Equal(t, "owner-1", got.Cancellation.ActorID)
+		require.Equal(t, "acabou o gás", got.Cancellation.Reason)
+		require.WithinDuration(t, at, got.Cancellation.At, time.Millisecond)
+	})
+
+	t.Run("test update an order with a stale version", func(t *testing.T) {
+		first, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+		second, err := repo.GetByID(t.Context(), "order-1")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Update(t.Context(), first))
+		require.Equal(t, second.Version+1, first.Version)
+
+		err = repo.Update(t.Context(), second)
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+		require.ErrorIs(t, err, errx.ErrStaleVersion)
+	})
+
+	t.Run("test update an order that does not exist", func(t *testing.T) {
+		err := repo.Update(t.Context(), newOrder("missing"))
+
+		require.Error(t, err)
+		require.Equal(t, "not_found: order not found", err.Error())
+	})
+}
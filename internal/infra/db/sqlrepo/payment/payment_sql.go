@@ -0,0 +1,250 @@
+package sqlpayment
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, order_id, user_id, store_id, method, provider, status, provider_ref, amount, currency,
+	idempotency_key, version, created_at, updated_at, paid_at, pix_txid, pix_code, expires_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.PaymentRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, p *entity.Payment) error {
+	if p == nil {
+		return errx.New(errx.CodeInvalid, "missing payment")
+	}
+	if p.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if p.OrderID == "" {
+		return errx.New(errx.CodeInvalid, "missing orderId")
+	}
+	if p.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing userId")
+	}
+	if p.StoreID == "" {
+		return errx.New(errx.CodeInvalid, "missing storeId")
+	}
+	if p.Amount < 0 {
+		return errx.New(errx.CodeInvalid, "amount must be >= 0")
+	}
+	if p.Currency == "" {
+		p.Currency = "BRL"
+	}
+	if p.Status == "" {
+		p.Status = entity.PaymentStatusCreated
+	}
+
+	now := r.clock.Now()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now
+	}
+	p.UpdatedAt = now
+	p.Version = 1
+
+	// isolado num savepoint para a consulta do conflito funcionar dentro de uma tx externa
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO payments (`+columns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			p.ID, p.OrderID, p.UserID, p.StoreID,
+			string(p.Method), string(p.Provider), string(p.Status), p.ProviderRef,
+			p.Amount, p.Currency, p.IdempotencyKey, p.Version,
+			sqldb.Time(p.CreatedAt), sqldb.Time(p.UpdatedAt), sqldb.NullTime(p.PaidAt),
+			p.PixTxID, p.PixCode, sqldb.NullTime(p.ExpiresAt),
+		)
+		return err
+	})
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			if p.IdempotencyKey != "" {
+				if existing, getErr := r.GetByOrderAndKey(ctx, p.OrderID, p.IdempotencyKey); getErr == nil && existing.ID != p.ID {
+					return errx.New(errx.CodeConflict, "payment already exists for idempotency key")
+				}
+			}
+			return errx.New(errx.CodeConflict, "payment already exists")
+		}
+		return sqldb.Internal("create payment", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) Update(ctx context.Context, p *entity.Payment) error {
+	if p == nil {
+		return errx.New(errx.CodeInvalid, "missing payment")
+	}
+	if p.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	now := r.clock.Now()
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE payments
+		SET method = ?, provider = ?, status = ?, provider_ref = ?, amount = ?, currency = ?,
+		    updated_at = ?, paid_at = ?, pix_txid = ?, pix_code = ?, expires_at = ?, version = version + 1
+		WHERE id = ? AND version = ?`),
+		string(p.Method), string(p.Provider), string(p.Status), p.ProviderRef, p.Amount, p.Currency,
+		sqldb.Time(now), sqldb.NullTime(p.PaidAt), p.PixTxID, p.PixCode, sqldb.NullTime(p.ExpiresAt),
+		p.ID, p.Version,
+	)
+	if err != nil {
+		return sqldb.Internal("update payment", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("update payment", err)
+	}
+	if n == 0 {
+		// distingue "não existe" de "versão antiga"
+		if _, err := r.GetByID(ctx, p.ID); err != nil {
+			return err
+		}
+		return errx.Wrap(errx.CodeConflict, "payment was modified concurrently", errx.ErrStaleVersion)
+	}
+
+	p.UpdatedAt = now
+	p.Version++
+
+	if p.CreatedAt.IsZero() {
+		cur, err := r.GetByID(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+		p.CreatedAt = cur.CreatedAt
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM payments WHERE id = ?`), id)
+	return scanOne(row)
+}
+
+func (r *Repo) GetByOrderAndKey(ctx context.Context, orderID, key string) (*entity.Payment, error) {
+	if orderID == "" || key == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing orderId or key")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM payments
+		WHERE order_id = ? AND idempotency_key = ?`), orderID, key)
+	return scanOne(row)
+}
+
+func (r *Repo) GetByProviderRef(ctx context.Context, provider entity.PaymentProvider, ref string) (*entity.Payment, error) {
+	if provider == "" || ref == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing provider or ref")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM payments
+		WHERE provider = ? AND provider_ref = ?`), string(provider), ref)
+	return scanOne(row)
+}
+
+func (r *Repo) ListByOrderID(ctx context.Context, orderID string) ([]*entity.Payment, error) {
+	if orderID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing orderId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM payments
+		WHERE order_id = ?
+		ORDER BY created_at, id`), orderID)
+	if err != nil {
+		return nil, sqldb.Internal("list payments", err)
+	}
+	return scanAll(rows, "list payments")
+}
+
+func (r *Repo) ListExpired(ctx context.Context, now, createdBefore time.Time, limit int) ([]*entity.Payment, error) {
+	if limit <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "limit must be > 0")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM payments
+		WHERE status IN (?, ?)
+		  AND ((expires_at IS NOT NULL AND expires_at <= ?) OR (expires_at IS NULL AND created_at <= ?))
+		ORDER BY created_at, id
+		LIMIT ?`),
+		string(entity.PaymentStatusCreated), string(entity.PaymentStatusPending),
+		sqldb.Time(now), sqldb.Time(createdBefore), limit)
+	if err != nil {
+		return nil, sqldb.Internal("list expired payments", err)
+	}
+	return scanAll(rows, "list expired payments")
+}
+
+func scanAll(rows *sql.Rows, op string) ([]*entity.Payment, error) {
+	defer rows.Close()
+
+	out := make([]*entity.Payment, 0)
+	for rows.Next() {
+		p, err := scanPayment(rows)
+		if err != nil {
+			return nil, sqldb.Internal(op, err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal(op, err)
+	}
+
+	return out, nil
+}
+
+func scanOne(row sqldb.Scanner) (*entity.Payment, error) {
+	p, err := scanPayment(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "payment not found")
+		}
+		return nil, sqldb.Internal("get payment", err)
+	}
+	return p, nil
+}
+
+func scanPayment(s sqldb.Scanner) (*entity.Payment, error) {
+	var (
+		p                        entity.Payment
+		method, provider, status string
+		paidAt, expiresAt        sql.NullTime
+	)
+	err := s.Scan(
+		&p.ID, &p.OrderID, &p.UserID, &p.StoreID, &method, &provider, &status, &p.ProviderRef,
+		&p.Amount, &p.Currency, &p.IdempotencyKey, &p.Version, &p.CreatedAt, &p.UpdatedAt, &paidAt,
+		&p.PixTxID, &p.PixCode, &expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Method = entity.PaymentMethod(method)
+	p.Provider = entity.PaymentProvider(provider)
+	p.Status = entity.PaymentStatus(status)
+	p.PaidAt = sqldb.TimePtr(paidAt)
+	p.ExpiresAt = sqldb.TimePtr(expiresAt)
+	return &p, nil
+}
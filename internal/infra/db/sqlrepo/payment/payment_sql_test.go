@@ -0,0 +1,145 @@
+package sqlpayment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newPayment(id, key string) *entity.Payment {
+	return &entity.Payment{
+		ID:             id,
+		OrderID:        "order-1",
+		UserID:         "user-1",
+		StoreID:        "store-1",
+		Method:         entity.PaymentMethodPix,
+		Provider:       entity.PaymentProviderMock,
+		Status:         entity.PaymentStatusPending,
+		Amount:         4200,
+		IdempotencyKey: key,
+	}
+}
+
+func TestPaymentSQLRepository(t *testing.T) {
+	repo := New(testkit.NewTestDB(t), pkg.NewClock())
+
+	t.Run("test create and get a payment by idempotency key", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newPayment("pay-1", "key-1")))
+
+		got, err := repo.GetByOrderAndKey(t.Context(), "order-1", "key-1")
+		require.NoError(t, err)
+		require.Equal(t, "pay-1", got.ID)
+		require.Equal(t, "BRL", got.Currency)
+	})
+
+	t.Run("test create a payment with a duplicated idempotency key", func(t *testing.T) {
+		err := repo.Create(t.Context(), newPayment("pay-2", "key-1"))
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+
+	t.Run("test payments without idempotency key do not conflict", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newPayment("pay-3", "")))
+		require.NoError(t, repo.Create(t.Context(), newPayment("pay-4", "")))
+
+		payments, err := repo.ListByOrderID(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.Len(t, payments, 3)
+	})
+
+	t.Run("test update a payment", func(t *testing.T) {
+		p, err := repo.GetByID(t.Context(), "pay-1")
+		require.NoError(t, err)
+
+		paidAt := time.Now()
+		p.Status = entity.PaymentStatusPaid
+		p.ProviderRef = "mock_pay-1"
+		p.PaidAt = &paidAt
+		require.NoError(t, repo.Update(t.Context(), p))
+
+		got, err := repo.GetByID(t.Context(), "pay-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusPaid, got.Status)
+		require.Equal(t, "mock_pay-1", got.ProviderRef)
+		require.NotNil(t, got.PaidAt)
+
+		byRef, err := repo.GetByProviderRef(t.Context(), entity.PaymentProviderMock, "mock_pay-1")
+		require.NoError(t, err)
+		require.Equal(t, "pay-1", byRef.ID)
+	})
+
+	t.Run("test update a payment with a stale version", func(t *testing.T) {
+		first, err := repo.GetByID(t.Context(), "pay-3")
+		require.NoError(t, err)
+		second, err := repo.GetByID(t.Context(), "pay-3")
+		require.NoError(t, err)
+
+		first.Status = entity.PaymentStatusFailed
+		require.NoError(t, repo.Update(t.Context(), first))
+
+		second.Status = entity.PaymentStatusPaid
+		err = repo.Update(t.Context(), second)
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+		require.ErrorIs(t, err, errx.ErrStaleVersion)
+
+		got, err := repo.GetByID(t.Context(), "pay-3")
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusFailed, got.Status)
+	})
+
+	t.Run("test list expired pix payments", func(t *testing.T) {
+		now := time.Now()
+		expired := now.Add(-time.Minute)
+		valid := now.Add(time.Hour)
+
+		pix := newPayment("pay-pix-1", "")
+		pix.PixTxID = "paypix1"
+		pix.PixCode = "000201..."
+		pix.ExpiresAt = &expired
+		require.NoError(t, repo.Create(t.Context(), pix))
+
+		notYet := newPayment("pay-pix-2", "")
+		notYet.ExpiresAt = &valid
+		require.NoError(t, repo.Create(t.Context(), notYet))
+
+		paid := newPayment("pay-pix-3", "")
+		paid.Status = entity.PaymentStatusPaid
+		paid.ExpiresAt = &expired
+		require.NoError(t, repo.Create(t.Context(), paid))
+
+		// sem prazo próprio: só as criadas antes de createdBefore
+		got, err := repo.ListExpired(t.Context(), now, now.Add(-time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "pay-pix-1", got[0].ID)
+		require.Equal(t, "paypix1", got[0].PixTxID)
+		require.Equal(t, "000201...", got[0].PixCode)
+		require.NotNil(t, got[0].ExpiresAt)
+		require.WithinDuration(t, expired, *got[0].ExpiresAt, time.Second)
+
+		all, err := repo.ListExpired(t.Context(), now, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(all))
+		for _, p := range all {
+			ids = append(ids, p.ID)
+		}
+		require.Contains(t, ids, "pay-pix-1")
+		require.Contains(t, ids, "pay-4")
+		require.NotContains(t, ids, "pay-pix-2")
+		require.NotContains(t, ids, "pay-pix-3")
+	})
+
+	t.Run("test update a payment that does not exist", func(t *testing.T) {
+		err := repo.Update(t.Context(), newPayment("missing", ""))
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(err))
+	})
+}
@@ -0,0 +1,132 @@
+package sqlpaymentevent
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, provider, event_id, payment_ref, payment_id, status, amount, payload, result,
+	occurred_at, received_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.PaymentEventRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, e *entity.PaymentEvent) error {
+	if e == nil {
+		return errx.New(errx.CodeInvalid, "missing payment event")
+	}
+	if e.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if e.Provider == "" {
+		return errx.New(errx.CodeInvalid, "missing provider")
+	}
+	if e.EventID == "" {
+		return errx.New(errx.CodeInvalid, "missing eventId")
+	}
+
+	if e.ReceivedAt.IsZero() {
+		e.ReceivedAt = r.clock.Now()
+	}
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = e.ReceivedAt
+	}
+
+	// isolado num savepoint para a consulta do conflito funcionar dentro de uma tx externa
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO payment_events (`+columns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			e.ID, string(e.Provider), e.EventID, e.PaymentRef, e.PaymentID, string(e.Status), e.Amount,
+			e.Payload, e.Result, sqldb.Time(e.OccurredAt), sqldb.Time(e.ReceivedAt),
+		)
+		return err
+	})
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			if existing, getErr := r.GetByProviderEventID(ctx, e.Provider, e.EventID); getErr == nil && existing.ID != e.ID {
+				return errx.New(errx.CodeConflict, "payment event already received")
+			}
+			return errx.New(errx.CodeConflict, "payment event already exists")
+		}
+		return sqldb.Internal("create payment event", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByProviderEventID(ctx context.Context, provider entity.PaymentProvider, eventID string) (*entity.PaymentEvent, error) {
+	if provider == "" || eventID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing provider or eventId")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM payment_events
+		WHERE provider = ? AND event_id = ?`), string(provider), eventID)
+
+	e, err := scanEvent(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "payment event not found")
+		}
+		return nil, sqldb.Internal("get payment event", err)
+	}
+	return e, nil
+}
+
+func (r *Repo) ListByPaymentID(ctx context.Context, paymentID string) ([]*entity.PaymentEvent, error) {
+	if paymentID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing paymentId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM payment_events
+		WHERE payment_id = ?
+		ORDER BY received_at, id`), paymentID)
+	if err != nil {
+		return nil, sqldb.Internal("list payment events", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.PaymentEvent, 0)
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list payment events", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list payment events", err)
+	}
+
+	return out, nil
+}
+
+func scanEvent(s sqldb.Scanner) (*entity.PaymentEvent, error) {
+	var (
+		e                entity.PaymentEvent
+		provider, status string
+	)
+	err := s.Scan(
+		&e.ID, &provider, &e.EventID, &e.PaymentRef, &e.PaymentID, &status, &e.Amount, &e.Payload, &e.Result,
+		&e.OccurredAt, &e.ReceivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Provider = entity.PaymentProvider(provider)
+	e.Status = entity.PaymentStatus(status)
+	return &e, nil
+}
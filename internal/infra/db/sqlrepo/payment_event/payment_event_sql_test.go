@@ -0,0 +1,66 @@
+package sqlpaymentevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvent(id, eventID, paymentID string) *entity.PaymentEvent {
+	return &entity.PaymentEvent{
+		ID:         id,
+		Provider:   entity.PaymentProviderMock,
+		EventID:    eventID,
+		PaymentRef: "mock_" + paymentID,
+		PaymentID:  paymentID,
+		Status:     entity.PaymentStatusPaid,
+		Amount:     4200,
+		Payload:    `{"id":"` + eventID + `"}`,
+		Result:     "applied",
+		OccurredAt: time.Now().Add(-time.Second),
+	}
+}
+
+func TestPaymentEventSQLRepository(t *testing.T) {
+	repo := New(testkit.NewTestDB(t), pkg.NewClock())
+
+	t.Run("test create and get an event by provider event id", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newEvent("pe-1", "evt-1", "pay-1")))
+
+		got, err := repo.GetByProviderEventID(t.Context(), entity.PaymentProviderMock, "evt-1")
+		require.NoError(t, err)
+		require.Equal(t, "pe-1", got.ID)
+		require.Equal(t, "pay-1", got.PaymentID)
+		require.Equal(t, `{"id":"evt-1"}`, got.Payload)
+		require.Equal(t, "applied", got.Result)
+		require.False(t, got.ReceivedAt.IsZero())
+	})
+
+	t.Run("test the same provider event is stored once", func(t *testing.T) {
+		err := repo.Create(t.Context(), newEvent("pe-2", "evt-1", "pay-1"))
+
+		require.Error(t, err)
+		require.Equal(t, "conflict: payment event already received", err.Error())
+	})
+
+	t.Run("test list events of a payment", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newEvent("pe-3", "evt-2", "pay-1")))
+		require.NoError(t, repo.Create(t.Context(), newEvent("pe-4", "evt-3", "pay-2")))
+
+		events, err := repo.ListByPaymentID(t.Context(), "pay-1")
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+	})
+
+	t.Run("test get an event that does not exist", func(t *testing.T) {
+		_, err := repo.GetByProviderEventID(t.Context(), entity.PaymentProviderMock, "missing")
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(err))
+	})
+}
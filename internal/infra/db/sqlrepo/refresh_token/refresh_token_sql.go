@@ -0,0 +1,134 @@
+package sqlrefreshtoken
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, session_id, user_id, token_hash, expires_at, used_at, created_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.RefreshTokenRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, t *entity.RefreshToken) error {
+	if t == nil {
+		return errx.New(errx.CodeInvalid, "missing refresh token")
+	}
+	if t.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if t.SessionID == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+	if t.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if t.TokenHash == "" {
+		return errx.New(errx.CodeInvalid, "missing token hash")
+	}
+	if t.ExpiresAt.IsZero() {
+		return errx.New(errx.CodeInvalid, "missing expiresAt")
+	}
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = r.clock.Now()
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO refresh_tokens (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		t.ID, t.SessionID, t.UserID, t.TokenHash,
+		sqldb.Time(t.ExpiresAt), sqldb.NullTime(t.UsedAt), sqldb.Time(t.CreatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "refresh token already exists")
+		}
+		return sqldb.Internal("create refresh token", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	if tokenHash == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing token hash")
+	}
+
+	t, err := scanToken(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM refresh_tokens WHERE token_hash = ?`), tokenHash))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "refresh token not found")
+		}
+		return nil, sqldb.Internal("get refresh token", err)
+	}
+	return t, nil
+}
+
+func (r *Repo) MarkUsed(ctx context.Context, id string, usedAt time.Time) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	// used_at IS NULL no WHERE: só uma rotação concorrente vence
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE refresh_tokens SET used_at = ?
+		WHERE id = ? AND used_at IS NULL`), sqldb.Time(usedAt), id)
+	if err != nil {
+		return sqldb.Internal("mark refresh token used", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("mark refresh token used", err)
+	}
+	if n == 1 {
+		return nil
+	}
+
+	var exists int
+	err = r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT 1 FROM refresh_tokens WHERE id = ?`), id).Scan(&exists)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return errx.New(errx.CodeNotFound, "refresh token not found")
+		}
+		return sqldb.Internal("mark refresh token used", err)
+	}
+	return errx.New(errx.CodeConflict, "refresh token already used")
+}
+
+func (r *Repo) DeleteBySessionID(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM refresh_tokens WHERE session_id = ?`), sessionID)
+	if err != nil {
+		return sqldb.Internal("delete refresh tokens", err)
+	}
+	return nil
+}
+
+func scanToken(s sqldb.Scanner) (*entity.RefreshToken, error) {
+	var (
+		t      entity.RefreshToken
+		usedAt sql.NullTime
+	)
+	if err := s.Scan(&t.ID, &t.SessionID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &usedAt, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	t.UsedAt = sqldb.TimePtr(usedAt)
+	return &t, nil
+}
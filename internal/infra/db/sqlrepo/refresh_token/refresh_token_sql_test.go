@@ -0,0 +1,62 @@
+package sqlrefreshtoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenSQLRepository(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	repo := New(testkit.NewTestDB(t), clock)
+
+	newToken := func(id, sessionID string) *entity.RefreshToken {
+		return &entity.RefreshToken{
+			ID:        id,
+			SessionID: sessionID,
+			UserID:    "user-1",
+			TokenHash: "hash-" + id,
+			ExpiresAt: clock.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("test create and get a token by hash", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newToken("rt-1", "s-1")))
+
+		got, err := repo.GetByHash(t.Context(), "hash-rt-1")
+		require.NoError(t, err)
+		require.Equal(t, "s-1", got.SessionID)
+		require.False(t, got.IsUsed())
+	})
+
+	t.Run("test a token is marked used only once", func(t *testing.T) {
+		require.NoError(t, repo.MarkUsed(t.Context(), "rt-1", clock.Now()))
+
+		err := repo.MarkUsed(t.Context(), "rt-1", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeConflict))
+
+		got, err := repo.GetByHash(t.Context(), "hash-rt-1")
+		require.NoError(t, err)
+		require.True(t, got.IsUsed())
+
+		err = repo.MarkUsed(t.Context(), "missing", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test delete the whole family of a session", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newToken("rt-2", "s-1")))
+		require.NoError(t, repo.Create(t.Context(), newToken("rt-3", "s-2")))
+
+		require.NoError(t, repo.DeleteBySessionID(t.Context(), "s-1"))
+
+		_, err := repo.GetByHash(t.Context(), "hash-rt-2")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+		_, err = repo.GetByHash(t.Context(), "hash-rt-3")
+		require.NoError(t, err)
+	})
+}
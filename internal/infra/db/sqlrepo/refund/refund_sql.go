@@ -0,0 +1,215 @@
+package sqlrefund
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, payment_id, order_id, store_id, amount, currency, reason, status,
+	idempotency_key, provider_ref, requested_by, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.RefundRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, rf *entity.Refund) error {
+	if rf == nil {
+		return errx.New(errx.CodeInvalid, "missing refund")
+	}
+	if rf.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if rf.PaymentID == "" {
+		return errx.New(errx.CodeInvalid, "missing paymentId")
+	}
+	if rf.OrderID == "" {
+		return errx.New(errx.CodeInvalid, "missing orderId")
+	}
+	if rf.Amount <= 0 {
+		return errx.New(errx.CodeInvalid, "amount must be > 0")
+	}
+	if rf.Currency == "" {
+		rf.Currency = "BRL"
+	}
+	if rf.Status == "" {
+		rf.Status = entity.RefundStatusPending
+	}
+
+	now := r.clock.Now()
+	if rf.CreatedAt.IsZero() {
+		rf.CreatedAt = now
+	}
+	rf.UpdatedAt = now
+
+	// isolado num savepoint para a consulta do conflito funcionar dentro de uma tx externa
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO refunds (`+columns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			rf.ID, rf.PaymentID, rf.OrderID, rf.StoreID, rf.Amount, rf.Currency, rf.Reason, string(rf.Status),
+			rf.IdempotencyKey, rf.ProviderRef, rf.RequestedBy, sqldb.Time(rf.CreatedAt), sqldb.Time(rf.UpdatedAt),
+		)
+		return err
+	})
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			if rf.IdempotencyKey != "" {
+				if existing, getErr := r.GetByPaymentAndKey(ctx, rf.PaymentID, rf.IdempotencyKey); getErr == nil && existing.ID != rf.ID {
+					return errx.New(errx.CodeConflict, "refund already exists for idempotency key")
+				}
+			}
+			return errx.New(errx.CodeConflict, "refund already exists")
+		}
+		return sqldb.Internal("create refund", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) Update(ctx context.Context, rf *entity.Refund) error {
+	if rf == nil {
+		return errx.New(errx.CodeInvalid, "missing refund")
+	}
+	if rf.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	now := r.clock.Now()
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE refunds
+		SET status = ?, provider_ref = ?, updated_at = ?
+		WHERE id = ?`),
+		string(rf.Status), rf.ProviderRef, sqldb.Time(now), rf.ID,
+	)
+	if err != nil {
+		return sqldb.Internal("update refund", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("update refund", err)
+	}
+	if n == 0 {
+		return errx.New(errx.CodeNotFound, "refund not found")
+	}
+
+	rf.UpdatedAt = now
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Refund, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM refunds WHERE id = ?`), id)
+	return scanOne(row)
+}
+
+func (r *Repo) GetByPaymentAndKey(ctx context.Context, paymentID, key string) (*entity.Refund, error) {
+	if paymentID == "" || key == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing paymentId or key")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM refunds
+		WHERE payment_id = ? AND idempotency_key = ?`), paymentID, key)
+	return scanOne(row)
+}
+
+func (r *Repo) ListByPaymentID(ctx context.Context, paymentID string) ([]*entity.Refund, error) {
+	if paymentID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing paymentId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM refunds
+		WHERE payment_id = ?
+		ORDER BY created_at, id`), paymentID)
+	if err != nil {
+		return nil, sqldb.Internal("list refunds", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.Refund, 0)
+	for rows.Next() {
+		rf, err := scanRefund(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list refunds", err)
+		}
+		out = append(out, rf)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list refunds", err)
+	}
+
+	return out, nil
+}
+
+func (r *Repo) ListPending(ctx context.Context, updatedBefore time.Time, limit int) ([]*entity.Refund, error) {
+	if limit <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "limit must be > 0")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM refunds
+		WHERE status = ? AND updated_at <= ?
+		ORDER BY updated_at, id
+		LIMIT ?`), string(entity.RefundStatusPending), sqldb.Time(updatedBefore), limit)
+	if err != nil {
+		return nil, sqldb.Internal("list pending refunds", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.Refund, 0)
+	for rows.Next() {
+		rf, err := scanRefund(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list pending refunds", err)
+		}
+		out = append(out, rf)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list pending refunds", err)
+	}
+
+	return out, nil
+}
+
+func scanOne(row sqldb.Scanner) (*entity.Refund, error) {
+	rf, err := scanRefund(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "refund not found")
+		}
+		return nil, sqldb.Internal("get refund", err)
+	}
+	return rf, nil
+}
+
+func scanRefund(s sqldb.Scanner) (*entity.Refund, error) {
+	var (
+		rf     entity.Refund
+		status string
+	)
+	err := s.Scan(
+		&rf.ID, &rf.PaymentID, &rf.OrderID, &rf.StoreID, &rf.Amount, &rf.Currency, &rf.Reason, &status,
+		&rf.IdempotencyKey, &rf.ProviderRef, &rf.RequestedBy, &rf.CreatedAt, &rf.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rf.Status = entity.RefundStatus(status)
+	return &rf, nil
+}
@@ -0,0 +1,104 @@
+package sqlrefund
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	sqlpayment "github.com/FabioRocha231/saas-core/internal/infra/db/sqlrepo/payment"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newRefund(id, key string, amount int64) *entity.Refund {
+	return &entity.Refund{
+		ID:             id,
+		PaymentID:      "pay-1",
+		OrderID:        "order-1",
+		StoreID:        "store-1",
+		Amount:         amount,
+		Status:         entity.RefundStatusSucceeded,
+		IdempotencyKey: key,
+		RequestedBy:    "owner-1",
+	}
+}
+
+func TestRefundSQLRepository(t *testing.T) {
+	db := testkit.NewTestDB(t)
+	repo := New(db, pkg.NewClock())
+
+	require.NoError(t, sqlpayment.New(db, pkg.NewClock()).Create(t.Context(), &entity.Payment{
+		ID: "pay-1", OrderID: "order-1", UserID: "user-1", StoreID: "store-1",
+		Method: entity.PaymentMethodPix, Provider: entity.PaymentProviderMock, Status: entity.PaymentStatusPaid, Amount: 4200,
+	}))
+
+	t.Run("test create and get a refund by idempotency key", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newRefund("ref-1", "key-1", 1000)))
+
+		got, err := repo.GetByPaymentAndKey(t.Context(), "pay-1", "key-1")
+		require.NoError(t, err)
+		require.Equal(t, "ref-1", got.ID)
+		require.Equal(t, "BRL", got.Currency)
+		require.Equal(t, entity.RefundStatusSucceeded, got.Status)
+		require.Equal(t, "owner-1", got.RequestedBy)
+	})
+
+	t.Run("test create a refund with a duplicated idempotency key", func(t *testing.T) {
+		err := repo.Create(t.Context(), newRefund("ref-2", "key-1", 500))
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+
+	t.Run("test list refunds of a payment", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newRefund("ref-3", "", 200)))
+		require.NoError(t, repo.Create(t.Context(), newRefund("ref-4", "", 300)))
+
+		refunds, err := repo.ListByPaymentID(t.Context(), "pay-1")
+		require.NoError(t, err)
+		require.Len(t, refunds, 3)
+		require.Equal(t, "ref-1", refunds[0].ID)
+	})
+
+	t.Run("test update a pending refund and list the pending ones", func(t *testing.T) {
+		rf := newRefund("ref-6", "key-6", 100)
+		rf.Status = entity.RefundStatusPending
+		require.NoError(t, repo.Create(t.Context(), rf))
+
+		pending, err := repo.ListPending(t.Context(), time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, "ref-6", pending[0].ID)
+
+		rf.Status = entity.RefundStatusSucceeded
+		rf.ProviderRef = "mock_rf_ref-6"
+		require.NoError(t, repo.Update(t.Context(), rf))
+
+		got, err := repo.GetByID(t.Context(), "ref-6")
+		require.NoError(t, err)
+		require.Equal(t, entity.RefundStatusSucceeded, got.Status)
+		require.Equal(t, "mock_rf_ref-6", got.ProviderRef)
+
+		pending, err = repo.ListPending(t.Context(), time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Empty(t, pending)
+
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(repo.Update(t.Context(), newRefund("missing", "", 100))))
+	})
+
+	t.Run("test create a refund for a payment that does not exist", func(t *testing.T) {
+		rf := newRefund("ref-5", "", 100)
+		rf.PaymentID = "missing"
+
+		require.Error(t, repo.Create(t.Context(), rf))
+	})
+
+	t.Run("test get a refund that does not exist", func(t *testing.T) {
+		_, err := repo.GetByID(t.Context(), "missing")
+
+		require.Error(t, err)
+		require.Equal(t, "not_found: refund not found", err.Error())
+	})
+}
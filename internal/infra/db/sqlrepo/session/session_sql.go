@@ -0,0 +1,140 @@
+package sqlsession
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, user_id, role, user_agent, ip, expires_at, created_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.SessionRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, s *entity.Session) error {
+	if s == nil {
+		return errx.New(errx.CodeInvalid, "missing session")
+	}
+	if s.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+	if s.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if s.ExpiresAt.IsZero() {
+		return errx.New(errx.CodeInvalid, "missing expiresAt")
+	}
+
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = r.clock.Now()
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO sessions (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		s.ID, s.UserID, s.Role, s.UserAgent, s.IP, sqldb.Time(s.ExpiresAt), sqldb.Time(s.CreatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "session already exists")
+		}
+		return sqldb.Internal("create session", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Session, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing session id")
+	}
+
+	s, err := scanSession(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM sessions WHERE id = ?`), id))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "session not found")
+		}
+		return nil, sqldb.Internal("get session", err)
+	}
+
+	return s, nil
+}
+
+func (r *Repo) ListByUserID(ctx context.Context, userID string) ([]*entity.Session, error) {
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM sessions
+		WHERE user_id = ?
+		ORDER BY created_at, id`), userID)
+	if err != nil {
+		return nil, sqldb.Internal("list sessions", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.Session, 0)
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list sessions", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list sessions", err)
+	}
+
+	return out, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM sessions WHERE id = ?`), id)
+	if err != nil {
+		return sqldb.Internal("delete session", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("delete session", err)
+	}
+	if n == 0 {
+		return errx.New(errx.CodeNotFound, "session not found")
+	}
+	return nil
+}
+
+func (r *Repo) DeleteExpired(ctx context.Context, now time.Time) error {
+	if now.IsZero() {
+		now = r.clock.Now()
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM sessions WHERE expires_at <= ?`), sqldb.Time(now))
+	if err != nil {
+		return sqldb.Internal("delete expired sessions", err)
+	}
+	return nil
+}
+
+func scanSession(s sqldb.Scanner) (*entity.Session, error) {
+	var out entity.Session
+	if err := s.Scan(&out.ID, &out.UserID, &out.Role, &out.UserAgent, &out.IP, &out.ExpiresAt, &out.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
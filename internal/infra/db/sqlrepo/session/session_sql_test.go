@@ -0,0 +1,61 @@
+package sqlsession
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionSQLRepository(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	repo := New(testkit.NewTestDB(t), clock)
+
+	newSession := func(id, userID string) *entity.Session {
+		return &entity.Session{
+			ID:        id,
+			UserID:    userID,
+			Role:      "costumer",
+			UserAgent: "curl/8.0",
+			IP:        "10.0.0.1",
+			ExpiresAt: clock.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("test create and get a session with device metadata", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newSession("s-1", "user-1")))
+
+		got, err := repo.GetByID(t.Context(), "s-1")
+		require.NoError(t, err)
+		require.Equal(t, "user-1", got.UserID)
+		require.Equal(t, "curl/8.0", got.UserAgent)
+		require.Equal(t, "10.0.0.1", got.IP)
+		require.True(t, got.CreatedAt.Equal(clock.Now()))
+	})
+
+	t.Run("test list sessions of a user", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		require.NoError(t, repo.Create(t.Context(), newSession("s-2", "user-1")))
+		require.NoError(t, repo.Create(t.Context(), newSession("s-3", "user-2")))
+
+		sessions, err := repo.ListByUserID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		require.Equal(t, "s-1", sessions[0].ID)
+		require.Equal(t, "s-2", sessions[1].ID)
+	})
+
+	t.Run("test delete a session", func(t *testing.T) {
+		require.NoError(t, repo.Delete(t.Context(), "s-2"))
+
+		_, err := repo.GetByID(t.Context(), "s-2")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		err = repo.Delete(t.Context(), "s-2")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+}
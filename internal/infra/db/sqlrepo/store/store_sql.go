@@ -0,0 +1,248 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, name, slug, is_open, cnpj, owner_id, phone, logo_url,
+	address_street, address_number, address_complement, address_district,
+	address_city, address_state, address_zip_code, timezone, paused_until`
+
+type Repo struct {
+	db *sqldb.DB
+}
+
+func New(db *sqldb.DB) repository.StoreRepository {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Create(ctx context.Context, s *entity.Store) error {
+	// isolado num savepoint para a consulta do conflito funcionar dentro de uma tx externa
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		a := s.Address
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO stores (`+columns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			s.ID, s.Name, s.Slug, s.IsOpen, s.Cnpj, s.OwnerID, s.Phone, s.LogoURL,
+			a.Street, a.Number, a.Complement, a.District, a.City, a.State, a.ZipCode, s.Timezone,
+			sqldb.NullTime(s.PausedUntil),
+		)
+		if err != nil {
+			return err
+		}
+		return r.insertHours(ctx, s.ID, s.Hours)
+	})
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			if _, getErr := r.GetByID(ctx, s.ID); getErr == nil {
+				return errx.F(errx.CodeConflict, "id %s already exists", s.ID)
+			}
+			return errx.F(errx.CodeConflict, "Slug %s already exists", s.Slug)
+		}
+		return sqldb.Internal("create store", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) Update(ctx context.Context, s *entity.Store) error {
+	var found bool
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		a := s.Address
+		res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			UPDATE stores SET
+				name = ?, slug = ?, is_open = ?, phone = ?, logo_url = ?,
+				address_street = ?, address_number = ?, address_complement = ?, address_district = ?,
+				address_city = ?, address_state = ?, address_zip_code = ?, timezone = ?, paused_until = ?
+			WHERE id = ?`),
+			s.Name, s.Slug, s.IsOpen, s.Phone, s.LogoURL,
+			a.Street, a.Number, a.Complement, a.District, a.City, a.State, a.ZipCode, s.Timezone,
+			sqldb.NullTime(s.PausedUntil),
+			s.ID,
+		)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if found = n > 0; !found {
+			return nil
+		}
+
+		// o horário é sempre substituído por inteiro
+		for _, table := range []string{"store_weekly_hours", "store_hour_exceptions"} {
+			if _, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM `+table+` WHERE store_id = ?`), s.ID); err != nil {
+				return err
+			}
+		}
+		return r.insertHours(ctx, s.ID, s.Hours)
+	})
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.F(errx.CodeConflict, "Slug %s already exists", s.Slug)
+		}
+		return sqldb.Internal("update store", err)
+	}
+	if !found {
+		return errx.New(errx.CodeNotFound, "store not found")
+	}
+
+	return nil
+}
+
+func (r *Repo) insertHours(ctx context.Context, storeID string, h entity.OpeningHours) error {
+	for i, w := range h.Weekly {
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO store_weekly_hours (store_id, position, weekday, opens, closes)
+			VALUES (?, ?, ?, ?, ?)`),
+			storeID, i, int(w.Weekday), w.Opens, w.Closes,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	for i, e := range h.Exceptions {
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+			INSERT INTO store_hour_exceptions (store_id, position, date, closed, opens, closes, note)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`),
+			storeID, i, e.Date, e.Closed, e.Opens, e.Closes, e.Note,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.Store, error) {
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM stores WHERE id = ?`), id)
+	return r.scanOne(ctx, row)
+}
+
+func (r *Repo) GetBySlug(ctx context.Context, slug string) (*entity.Store, error) {
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM stores WHERE slug = ?`), slug)
+	return r.scanOne(ctx, row)
+}
+
+func (r *Repo) CountByOwnerID(ctx context.Context, ownerID string) (int, error) {
+	var count int
+	err := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) FROM stores WHERE owner_id = ?`), ownerID).Scan(&count)
+	if err != nil {
+		return 0, sqldb.Internal("count stores", err)
+	}
+	return count, nil
+}
+
+func (r *Repo) ListByOwnerID(ctx context.Context, ownerID string) ([]*entity.Store, error) {
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM stores
+		WHERE owner_id = ?
+		ORDER BY created_at, id`), ownerID)
+	if err != nil {
+		return nil, sqldb.Internal("list stores", err)
+	}
+	defer rows.Close()
+
+	var stores []*entity.Store
+	for rows.Next() {
+		s, err := scanStore(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list stores", err)
+		}
+		stores = append(stores, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list stores", err)
+	}
+	rows.Close()
+
+	for _, s := range stores {
+		if s.Hours, err = r.loadHours(ctx, s.ID); err != nil {
+			return nil, sqldb.Internal("list stores", err)
+		}
+	}
+
+	return stores, nil
+}
+
+func (r *Repo) scanOne(ctx context.Context, row sqldb.Scanner) (*entity.Store, error) {
+	s, err := scanStore(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "store not found")
+		}
+		return nil, sqldb.Internal("get store", err)
+	}
+	if s.Hours, err = r.loadHours(ctx, s.ID); err != nil {
+		return nil, sqldb.Internal("get store", err)
+	}
+	return s, nil
+}
+
+func (r *Repo) loadHours(ctx context.Context, storeID string) (entity.OpeningHours, error) {
+	var h entity.OpeningHours
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT weekday, opens, closes FROM store_weekly_hours
+		WHERE store_id = ?
+		ORDER BY position`), storeID)
+	if err != nil {
+		return h, err
+	}
+	for rows.Next() {
+		var w entity.WeeklyWindow
+		var weekday int
+		if err := rows.Scan(&weekday, &w.Opens, &w.Closes); err != nil {
+			rows.Close()
+			return h, err
+		}
+		w.Weekday = time.Weekday(weekday)
+		h.Weekly = append(h.Weekly, w)
+	}
+	if err := rows.Close(); err != nil {
+		return h, err
+	}
+
+	rows, err = r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT date, closed, opens, closes, note FROM store_hour_exceptions
+		WHERE store_id = ?
+		ORDER BY position`), storeID)
+	if err != nil {
+		return h, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e entity.HoursException
+		if err := rows.Scan(&e.Date, &e.Closed, &e.Opens, &e.Closes, &e.Note); err != nil {
+			return h, err
+		}
+		h.Exceptions = append(h.Exceptions, e)
+	}
+	return h, rows.Err()
+}
+
+func scanStore(s sqldb.Scanner) (*entity.Store, error) {
+	var (
+		st          entity.Store
+		pausedUntil sql.NullTime
+	)
+	a := &st.Address
+	if err := s.Scan(
+		&st.ID, &st.Name, &st.Slug, &st.IsOpen, &st.Cnpj, &st.OwnerID, &st.Phone, &st.LogoURL,
+		&a.Street, &a.Number, &a.Complement, &a.District, &a.City, &a.State, &a.ZipCode, &st.Timezone,
+		&pausedUntil,
+	); err != nil {
+		return nil, err
+	}
+	st.PausedUntil = sqldb.TimePtr(pausedUntil)
+	return &st, nil
+}
@@ -0,0 +1,127 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreSQLRepository(t *testing.T) {
+	repo := New(testkit.NewTestDB(t))
+
+	store := &entity.Store{ID: "store-1", Name: "Loja", Slug: "loja", IsOpen: true, Cnpj: "11222333000181", OwnerID: "owner-1"}
+
+	t.Run("test create and get a store", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), store))
+
+		byID, err := repo.GetByID(t.Context(), "store-1")
+		require.NoError(t, err)
+		require.Equal(t, store, byID)
+
+		bySlug, err := repo.GetBySlug(t.Context(), "loja")
+		require.NoError(t, err)
+		require.Equal(t, "store-1", bySlug.ID)
+	})
+
+	t.Run("test create a store with a duplicated slug", func(t *testing.T) {
+		err := repo.Create(t.Context(), &entity.Store{ID: "store-2", Name: "Outra", Slug: "loja", OwnerID: "owner-1"})
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+
+	t.Run("test list and count stores by owner", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), &entity.Store{ID: "store-3", Name: "Outra", Slug: "outra", OwnerID: "owner-1"}))
+
+		count, err := repo.CountByOwnerID(t.Context(), "owner-1")
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		stores, err := repo.ListByOwnerID(t.Context(), "owner-1")
+		require.NoError(t, err)
+		require.Len(t, stores, 2)
+	})
+
+	t.Run("test update the profile and opening hours", func(t *testing.T) {
+		updated := *store
+		updated.Name = "Loja Nova"
+		updated.Slug = "loja-nova"
+		updated.IsOpen = false
+		updated.Phone = "11999990000"
+		updated.Address = entity.StoreAddress{Street: "Rua A", Number: "10", City: "São Paulo", State: "SP", ZipCode: "01001000"}
+		updated.Timezone = "America/Manaus"
+		updated.Hours = entity.OpeningHours{
+			Weekly: []entity.WeeklyWindow{
+				{Weekday: time.Friday, Opens: 18 * 60, Closes: 2 * 60},
+				{Weekday: time.Monday, Opens: 11 * 60, Closes: 14 * 60},
+			},
+			Exceptions: []entity.HoursException{{Date: "2026-12-25", Closed: true, Note: "Natal"}},
+		}
+
+		require.NoError(t, repo.Update(t.Context(), &updated))
+
+		got, err := repo.GetBySlug(t.Context(), "loja-nova")
+		require.NoError(t, err)
+		require.Equal(t, &updated, got)
+
+		_, err = repo.GetBySlug(t.Context(), "loja")
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(err))
+
+		// o horário é substituído, não acumulado
+		updated.Hours = entity.OpeningHours{Weekly: updated.Hours.Weekly[:1]}
+		require.NoError(t, repo.Update(t.Context(), &updated))
+
+		got, err = repo.GetByID(t.Context(), "store-1")
+		require.NoError(t, err)
+		require.Equal(t, updated.Hours, got.Hours)
+	})
+
+	t.Run("test pause and resume a store", func(t *testing.T) {
+		s, err := repo.GetByID(t.Context(), "store-1")
+		require.NoError(t, err)
+
+		until := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+		s.PausedUntil = &until
+		require.NoError(t, repo.Update(t.Context(), s))
+
+		got, err := repo.GetByID(t.Context(), "store-1")
+		require.NoError(t, err)
+		require.NotNil(t, got.PausedUntil)
+		require.True(t, until.Equal(*got.PausedUntil))
+
+		got.PausedUntil = nil
+		require.NoError(t, repo.Update(t.Context(), got))
+
+		got, err = repo.GetByID(t.Context(), "store-1")
+		require.NoError(t, err)
+		require.Nil(t, got.PausedUntil)
+	})
+
+	t.Run("test update a store to a slug already taken", func(t *testing.T) {
+		taken := *store
+		taken.Slug = "outra"
+
+		err := repo.Update(t.Context(), &taken)
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+
+	t.Run("test update a store that does not exist", func(t *testing.T) {
+		err := repo.Update(t.Context(), &entity.Store{ID: "missing", Slug: "missing"})
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(err))
+	})
+
+	t.Run("test get a store that does not exist", func(t *testing.T) {
+		_, err := repo.GetByID(t.Context(), "missing")
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(err))
+	})
+}
@@ -0,0 +1,179 @@
+package sqlstoreinvitation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, store_id, email, role, token_hash, invited_by, expires_at, accepted_at, accepted_by, revoked_at, created_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.StoreInvitationRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, i *entity.StoreInvitation) error {
+	if i == nil {
+		return errx.New(errx.CodeInvalid, "missing store invitation")
+	}
+	if i.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if i.StoreID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if i.Email == "" {
+		return errx.New(errx.CodeInvalid, "missing email")
+	}
+	if i.TokenHash == "" {
+		return errx.New(errx.CodeInvalid, "missing token hash")
+	}
+	if i.ExpiresAt.IsZero() {
+		return errx.New(errx.CodeInvalid, "missing expiresAt")
+	}
+
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = r.clock.Now()
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO store_invitations (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		i.ID, i.StoreID, i.Email, i.Role.String(), i.TokenHash, i.InvitedBy,
+		sqldb.Time(i.ExpiresAt), sqldb.NullTime(i.AcceptedAt), i.AcceptedBy, sqldb.NullTime(i.RevokedAt),
+		sqldb.Time(i.CreatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "store invitation already exists")
+		}
+		return sqldb.Internal("create store invitation", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.StoreInvitation, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+	return r.getBy(ctx, "id", id)
+}
+
+func (r *Repo) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.StoreInvitation, error) {
+	if tokenHash == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing token hash")
+	}
+	return r.getBy(ctx, "token_hash", tokenHash)
+}
+
+func (r *Repo) getBy(ctx context.Context, column, value string) (*entity.StoreInvitation, error) {
+	i, err := scanInvitation(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM store_invitations WHERE `+column+` = ?`), value))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "store invitation not found")
+		}
+		return nil, sqldb.Internal("get store invitation", err)
+	}
+	return i, nil
+}
+
+func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.StoreInvitation, error) {
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing store id")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM store_invitations
+		WHERE store_id = ?
+		ORDER BY created_at, id`), storeID)
+	if err != nil {
+		return nil, sqldb.Internal("list store invitations", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.StoreInvitation, 0)
+	for rows.Next() {
+		i, err := scanInvitation(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list store invitations", err)
+		}
+		out = append(out, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list store invitations", err)
+	}
+
+	return out, nil
+}
+
+func (r *Repo) MarkAccepted(ctx context.Context, id, userID string, at time.Time) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	return r.close(ctx, id, `accepted_at = ?, accepted_by = ?`, sqldb.Time(at), userID)
+}
+
+func (r *Repo) MarkRevoked(ctx context.Context, id string, at time.Time) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	return r.close(ctx, id, `revoked_at = ?`, sqldb.Time(at))
+}
+
+// close só altera convite pendente; o WHERE garante que aceitar e revogar ao
+// mesmo tempo não vencem os dois.
+func (r *Repo) close(ctx context.Context, id, set string, args ...any) error {
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE store_invitations SET `+set+`
+		WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL`), append(args, id)...)
+	if err != nil {
+		return sqldb.Internal("update store invitation", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("update store invitation", err)
+	}
+	if n == 1 {
+		return nil
+	}
+
+	current, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current.IsAccepted() {
+		return errx.New(errx.CodeConflict, "store invitation already accepted")
+	}
+	return errx.New(errx.CodeConflict, "store invitation revoked")
+}
+
+func scanInvitation(s sqldb.Scanner) (*entity.StoreInvitation, error) {
+	var (
+		i          entity.StoreInvitation
+		role       string
+		acceptedAt sql.NullTime
+		revokedAt  sql.NullTime
+	)
+	if err := s.Scan(
+		&i.ID, &i.StoreID, &i.Email, &role, &i.TokenHash, &i.InvitedBy,
+		&i.ExpiresAt, &acceptedAt, &i.AcceptedBy, &revokedAt, &i.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	i.Role = entity.StoreMemberRole(role)
+	i.AcceptedAt = sqldb.TimePtr(acceptedAt)
+	i.RevokedAt = sqldb.TimePtr(revokedAt)
+	return &i, nil
+}
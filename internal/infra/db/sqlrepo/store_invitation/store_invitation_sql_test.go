@@ -0,0 +1,75 @@
+package sqlstoreinvitation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreInvitationSQLRepository(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	repo := New(testkit.NewTestDB(t), clock)
+
+	newInvitation := func(id string) *entity.StoreInvitation {
+		return &entity.StoreInvitation{
+			ID:        id,
+			StoreID:   "store-1",
+			Email:     id + "@example.com",
+			Role:      entity.StoreMemberRoleKitchen,
+			TokenHash: "hash-" + id,
+			InvitedBy: "owner-1",
+			ExpiresAt: clock.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("test create and get by id and token hash", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newInvitation("inv-1")))
+
+		got, err := repo.GetByTokenHash(t.Context(), "hash-inv-1")
+		require.NoError(t, err)
+		require.Equal(t, "inv-1", got.ID)
+		require.Equal(t, entity.StoreMemberRoleKitchen, got.Role)
+		require.False(t, got.IsAccepted())
+		require.False(t, got.IsRevoked())
+
+		_, err = repo.GetByID(t.Context(), "missing")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		err = repo.Create(t.Context(), newInvitation("inv-1"))
+		require.True(t, errx.Is(err, errx.CodeConflict))
+	})
+
+	t.Run("test an invitation is closed only once", func(t *testing.T) {
+		require.NoError(t, repo.MarkAccepted(t.Context(), "inv-1", "user-1", clock.Now()))
+
+		err := repo.MarkRevoked(t.Context(), "inv-1", clock.Now())
+		require.Error(t, err)
+		require.Equal(t, "conflict: store invitation already accepted", err.Error())
+
+		got, err := repo.GetByID(t.Context(), "inv-1")
+		require.NoError(t, err)
+		require.True(t, got.IsAccepted())
+		require.Equal(t, "user-1", got.AcceptedBy)
+
+		require.NoError(t, repo.Create(t.Context(), newInvitation("inv-2")))
+		require.NoError(t, repo.MarkRevoked(t.Context(), "inv-2", clock.Now()))
+		err = repo.MarkAccepted(t.Context(), "inv-2", "user-2", clock.Now())
+		require.Error(t, err)
+		require.Equal(t, "conflict: store invitation revoked", err.Error())
+
+		err = repo.MarkRevoked(t.Context(), "missing", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test list by store", func(t *testing.T) {
+		invitations, err := repo.ListByStoreID(t.Context(), "store-1")
+		require.NoError(t, err)
+		require.Len(t, invitations, 2)
+		require.Equal(t, "inv-1", invitations[0].ID)
+	})
+}
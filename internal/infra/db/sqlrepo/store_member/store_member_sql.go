@@ -0,0 +1,154 @@
+package sqlstoremember
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, store_id, user_id, role, invited_by, created_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.StoreMemberRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, m *entity.StoreMember) error {
+	if m == nil {
+		return errx.New(errx.CodeInvalid, "missing store member")
+	}
+	if m.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if m.StoreID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if m.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if _, ok := entity.StoreMemberRoleMap[m.Role.String()]; !ok {
+		return errx.New(errx.CodeInvalid, "invalid store member role")
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = r.clock.Now()
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO store_members (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		m.ID, m.StoreID, m.UserID, m.Role.String(), m.InvitedBy, sqldb.Time(m.CreatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "user is already a member of this store")
+		}
+		return sqldb.Internal("create store member", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetByStoreAndUser(ctx context.Context, storeID, userID string) (*entity.StoreMember, error) {
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	m, err := scanMember(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM store_members WHERE store_id = ? AND user_id = ?`), storeID, userID))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "store member not found")
+		}
+		return nil, sqldb.Internal("get store member", err)
+	}
+	return m, nil
+}
+
+func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.StoreMember, error) {
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing store id")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM store_members
+		WHERE store_id = ?
+		ORDER BY created_at, id`), storeID)
+	if err != nil {
+		return nil, sqldb.Internal("list store members", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.StoreMember, 0)
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list store members", err)
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list store members", err)
+	}
+
+	return out, nil
+}
+
+func (r *Repo) CountByUserID(ctx context.Context, userID string) (int, error) {
+	if userID == "" {
+		return 0, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	var count int
+	err := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT COUNT(*) FROM store_members WHERE user_id = ?`), userID).Scan(&count)
+	if err != nil {
+		return 0, sqldb.Internal("count store members", err)
+	}
+	return count, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, storeID, userID string) error {
+	if storeID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		DELETE FROM store_members WHERE store_id = ? AND user_id = ?`), storeID, userID)
+	if err != nil {
+		return sqldb.Internal("delete store member", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("delete store member", err)
+	}
+	if n == 0 {
+		return errx.New(errx.CodeNotFound, "store member not found")
+	}
+	return nil
+}
+
+func scanMember(s sqldb.Scanner) (*entity.StoreMember, error) {
+	var (
+		m    entity.StoreMember
+		role string
+	)
+	if err := s.Scan(&m.ID, &m.StoreID, &m.UserID, &role, &m.InvitedBy, &m.CreatedAt); err != nil {
+		return nil, err
+	}
+	m.Role = entity.StoreMemberRole(role)
+	return &m, nil
+}
@@ -0,0 +1,66 @@
+package sqlstoremember
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreMemberSQLRepository(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	repo := New(testkit.NewTestDB(t), clock)
+
+	newMember := func(id, storeID, userID string) *entity.StoreMember {
+		return &entity.StoreMember{ID: id, StoreID: storeID, UserID: userID, Role: entity.StoreMemberRoleCashier, InvitedBy: "owner-1"}
+	}
+
+	t.Run("test create and get a member", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newMember("m-1", "store-1", "user-1")))
+
+		got, err := repo.GetByStoreAndUser(t.Context(), "store-1", "user-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.StoreMemberRoleCashier, got.Role)
+		require.Equal(t, "owner-1", got.InvitedBy)
+		require.True(t, got.CreatedAt.Equal(clock.Now()))
+
+		_, err = repo.GetByStoreAndUser(t.Context(), "store-2", "user-1")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test a user joins a store only once", func(t *testing.T) {
+		err := repo.Create(t.Context(), newMember("m-2", "store-1", "user-1"))
+		require.True(t, errx.Is(err, errx.CodeConflict))
+
+		err = repo.Create(t.Context(), &entity.StoreMember{ID: "m-3", StoreID: "store-1", UserID: "user-9", Role: "owner"})
+		require.True(t, errx.Is(err, errx.CodeInvalid))
+	})
+
+	t.Run("test list, count and delete", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		require.NoError(t, repo.Create(t.Context(), newMember("m-4", "store-1", "user-2")))
+		require.NoError(t, repo.Create(t.Context(), newMember("m-5", "store-2", "user-1")))
+
+		members, err := repo.ListByStoreID(t.Context(), "store-1")
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		require.Equal(t, "user-1", members[0].UserID)
+		require.Equal(t, "user-2", members[1].UserID)
+
+		count, err := repo.CountByUserID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		require.NoError(t, repo.Delete(t.Context(), "store-1", "user-1"))
+		err = repo.Delete(t.Context(), "store-1", "user-1")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		count, err = repo.CountByUserID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+}
@@ -0,0 +1,119 @@
+package sqlstoremenu
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, store_id, name, is_active, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.StoreMenuRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, m *entity.StoreMenu) error {
+	if m == nil {
+		return errx.New(errx.CodeInvalid, "missing menu")
+	}
+	if m.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if m.StoreID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	if m.Name == "" {
+		return errx.New(errx.CodeInvalid, "missing name")
+	}
+
+	now := r.clock.Now()
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	m.UpdatedAt = now
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO store_menus (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		m.ID, m.StoreID, m.Name, m.IsActive, sqldb.Time(m.CreatedAt), sqldb.Time(m.UpdatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "menu already exists")
+		}
+		return sqldb.Internal("create menu", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.StoreMenu, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM store_menus WHERE id = ?`), id)
+	m, err := scanStoreMenu(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "menu not found")
+		}
+		return nil, sqldb.Internal("get menu", err)
+	}
+	return m, nil
+}
+
+func (r *Repo) ListByStoreID(ctx context.Context, storeID string) ([]*entity.StoreMenu, error) {
+	if storeID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing store id")
+	}
+
+	var exists int
+	err := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) FROM stores WHERE id = ?`), storeID).Scan(&exists)
+	if err != nil {
+		return nil, sqldb.Internal("list menus", err)
+	}
+	if exists == 0 {
+		return nil, errx.New(errx.CodeNotFound, "store not found")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM store_menus
+		WHERE store_id = ?
+		ORDER BY created_at, id`), storeID)
+	if err != nil {
+		return nil, sqldb.Internal("list menus", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.StoreMenu, 0)
+	for rows.Next() {
+		m, err := scanStoreMenu(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list menus", err)
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list menus", err)
+	}
+
+	return out, nil
+}
+
+func scanStoreMenu(s sqldb.Scanner) (*entity.StoreMenu, error) {
+	var m entity.StoreMenu
+	if err := s.Scan(&m.ID, &m.StoreID, &m.Name, &m.IsActive, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
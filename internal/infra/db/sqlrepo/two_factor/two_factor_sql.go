@@ -0,0 +1,251 @@
+package sqltwofactor
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `user_id, secret, enabled_at, last_used_step, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.TwoFactorRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) SavePending(ctx context.Context, tf *entity.TwoFactor) error {
+	if tf == nil {
+		return errx.New(errx.CodeInvalid, "missing two factor")
+	}
+	if tf.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if tf.Secret == "" {
+		return errx.New(errx.CodeInvalid, "missing secret")
+	}
+
+	now := r.clock.Now()
+	if tf.CreatedAt.IsZero() {
+		tf.CreatedAt = now
+	}
+	tf.UpdatedAt = now
+	tf.EnabledAt = nil
+	tf.LastUsedStep = 0
+
+	// só substitui cadastro pendente; o ativo fica intacto
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO user_two_factor (`+columns+`)
+		VALUES (?, ?, NULL, 0, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = excluded.secret,
+			last_used_step = 0,
+			created_at = excluded.created_at,
+			updated_at = excluded.updated_at
+		WHERE user_two_factor.enabled_at IS NULL`),
+		tf.UserID, tf.Secret, sqldb.Time(tf.CreatedAt), sqldb.Time(tf.UpdatedAt),
+	)
+	if err != nil {
+		return sqldb.Internal("save two factor", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("save two factor", err)
+	}
+	if n == 0 {
+		return errx.New(errx.CodeConflict, "two-factor authentication already enabled")
+	}
+	return nil
+}
+
+func (r *Repo) GetByUserID(ctx context.Context, userID string) (*entity.TwoFactor, error) {
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	tf, err := scanTwoFactor(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM user_two_factor WHERE user_id = ?`), userID))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "two factor not found")
+		}
+		return nil, sqldb.Internal("get two factor", err)
+	}
+	return tf, nil
+}
+
+func (r *Repo) Enable(ctx context.Context, userID string, at time.Time) error {
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE user_two_factor SET enabled_at = ?, updated_at = ?
+		WHERE user_id = ? AND enabled_at IS NULL`),
+		sqldb.Time(at), sqldb.Time(r.clock.Now()), userID)
+	if err != nil {
+		return sqldb.Internal("enable two factor", err)
+	}
+	return r.checkUpdated(ctx, res, userID, "enable two factor", "two-factor authentication already enabled")
+}
+
+func (r *Repo) UseStep(ctx context.Context, userID string, step int64) error {
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	// a comparação no WHERE impede que duas requisições usem o mesmo código
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE user_two_factor SET last_used_step = ?, updated_at = ?
+		WHERE user_id = ? AND last_used_step < ?`),
+		step, sqldb.Time(r.clock.Now()), userID, step)
+	if err != nil {
+		return sqldb.Internal("use two factor step", err)
+	}
+	return r.checkUpdated(ctx, res, userID, "use two factor step", "code already used")
+}
+
+func (r *Repo) Delete(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM user_two_factor WHERE user_id = ?`), userID)
+		if err != nil {
+			return sqldb.Internal("delete two factor", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return sqldb.Internal("delete two factor", err)
+		}
+		if n == 0 {
+			return errx.New(errx.CodeNotFound, "two factor not found")
+		}
+
+		_, err = r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM two_factor_recovery_codes WHERE user_id = ?`), userID)
+		if err != nil {
+			return sqldb.Internal("delete recovery codes", err)
+		}
+		return nil
+	})
+}
+
+func (r *Repo) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*entity.RecoveryCode) error {
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	for _, c := range codes {
+		if c == nil || c.ID == "" || c.CodeHash == "" {
+			return errx.New(errx.CodeInvalid, "invalid recovery code")
+		}
+	}
+
+	now := r.clock.Now()
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM two_factor_recovery_codes WHERE user_id = ?`), userID)
+		if err != nil {
+			return sqldb.Internal("replace recovery codes", err)
+		}
+
+		for _, c := range codes {
+			c.UserID = userID
+			if c.CreatedAt.IsZero() {
+				c.CreatedAt = now
+			}
+			_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+				INSERT INTO two_factor_recovery_codes (id, user_id, code_hash, used_at, created_at)
+				VALUES (?, ?, ?, ?, ?)`),
+				c.ID, c.UserID, c.CodeHash, sqldb.NullTime(c.UsedAt), sqldb.Time(c.CreatedAt),
+			)
+			if err != nil {
+				if sqldb.IsUniqueViolation(err) {
+					return errx.New(errx.CodeConflict, "recovery code already exists")
+				}
+				return sqldb.Internal("replace recovery codes", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repo) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) error {
+	if userID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	// marca um único código ainda livre; o WHERE resolve a corrida entre dois usos
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE two_factor_recovery_codes SET used_at = ?
+		WHERE id = (
+			SELECT id FROM two_factor_recovery_codes
+			WHERE user_id = ? AND code_hash = ? AND used_at IS NULL
+			LIMIT 1
+		) AND used_at IS NULL`),
+		sqldb.Time(at), userID, codeHash)
+	if err != nil {
+		return sqldb.Internal("use recovery code", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("use recovery code", err)
+	}
+	if n == 0 {
+		return errx.New(errx.CodeNotFound, "recovery code not found")
+	}
+	return nil
+}
+
+func (r *Repo) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT COUNT(*) FROM two_factor_recovery_codes
+		WHERE user_id = ? AND used_at IS NULL`), userID).Scan(&n)
+	if err != nil {
+		return 0, sqldb.Internal("count recovery codes", err)
+	}
+	return n, nil
+}
+
+// checkUpdated diferencia "não existe" de "condição não atendida" quando o
+// UPDATE não afeta nenhuma linha.
+func (r *Repo) checkUpdated(ctx context.Context, res sql.Result, userID, op, conflict string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal(op, err)
+	}
+	if n == 1 {
+		return nil
+	}
+
+	var exists int
+	err = r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT 1 FROM user_two_factor WHERE user_id = ?`), userID).Scan(&exists)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return errx.New(errx.CodeNotFound, "two factor not found")
+		}
+		return sqldb.Internal(op, err)
+	}
+	return errx.New(errx.CodeConflict, conflict)
+}
+
+func scanTwoFactor(s sqldb.Scanner) (*entity.TwoFactor, error) {
+	var (
+		tf        entity.TwoFactor
+		enabledAt sql.NullTime
+	)
+	if err := s.Scan(&tf.UserID, &tf.Secret, &enabledAt, &tf.LastUsedStep, &tf.CreatedAt, &tf.UpdatedAt); err != nil {
+		return nil, err
+	}
+	tf.EnabledAt = sqldb.TimePtr(enabledAt)
+	return &tf, nil
+}
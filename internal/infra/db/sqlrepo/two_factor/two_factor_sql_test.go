@@ -0,0 +1,95 @@
+package sqltwofactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorSQLRepository(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	repo := New(testkit.NewTestDB(t), clock)
+
+	t.Run("test pending setup can be replaced until enabled", func(t *testing.T) {
+		require.NoError(t, repo.SavePending(t.Context(), &entity.TwoFactor{UserID: "user-1", Secret: "SECRET1"}))
+		require.NoError(t, repo.SavePending(t.Context(), &entity.TwoFactor{UserID: "user-1", Secret: "SECRET2"}))
+
+		got, err := repo.GetByUserID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, "SECRET2", got.Secret)
+		require.False(t, got.IsEnabled())
+
+		require.NoError(t, repo.Enable(t.Context(), "user-1", clock.Now()))
+		err = repo.Enable(t.Context(), "user-1", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeConflict))
+
+		err = repo.SavePending(t.Context(), &entity.TwoFactor{UserID: "user-1", Secret: "SECRET3"})
+		require.True(t, errx.Is(err, errx.CodeConflict))
+
+		got, err = repo.GetByUserID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, "SECRET2", got.Secret)
+		require.True(t, got.IsEnabled())
+
+		_, err = repo.GetByUserID(t.Context(), "user-2")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test a step is accepted only once", func(t *testing.T) {
+		require.NoError(t, repo.UseStep(t.Context(), "user-1", 100))
+
+		err := repo.UseStep(t.Context(), "user-1", 100)
+		require.True(t, errx.Is(err, errx.CodeConflict))
+		err = repo.UseStep(t.Context(), "user-1", 99)
+		require.True(t, errx.Is(err, errx.CodeConflict))
+
+		require.NoError(t, repo.UseStep(t.Context(), "user-1", 101))
+
+		err = repo.UseStep(t.Context(), "user-2", 1)
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test recovery codes are single use and replaceable", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceRecoveryCodes(t.Context(), "user-1", []*entity.RecoveryCode{
+			{ID: "rc-1", CodeHash: "hash-1"},
+			{ID: "rc-2", CodeHash: "hash-2"},
+		}))
+
+		n, err := repo.CountRecoveryCodes(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		require.NoError(t, repo.UseRecoveryCode(t.Context(), "user-1", "hash-1", clock.Now()))
+		err = repo.UseRecoveryCode(t.Context(), "user-1", "hash-1", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		n, err = repo.CountRecoveryCodes(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		require.NoError(t, repo.ReplaceRecoveryCodes(t.Context(), "user-1", []*entity.RecoveryCode{
+			{ID: "rc-3", CodeHash: "hash-3"},
+		}))
+		err = repo.UseRecoveryCode(t.Context(), "user-1", "hash-2", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test delete removes setup and recovery codes", func(t *testing.T) {
+		require.NoError(t, repo.Delete(t.Context(), "user-1"))
+
+		_, err := repo.GetByUserID(t.Context(), "user-1")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		n, err := repo.CountRecoveryCodes(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, 0, n)
+
+		err = repo.Delete(t.Context(), "user-1")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+}
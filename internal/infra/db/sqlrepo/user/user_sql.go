@@ -0,0 +1,163 @@
+package sqluser
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, name, cpf, email, phone, status, role, password,
+	email_verified_at, phone_verified_at, last_login_at, created_at, updated_at, deleted_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.UserRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, u *entity.User) error {
+	if u == nil {
+		return errx.New(errx.CodeInvalid, "missing user")
+	}
+	if u.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if u.Cpf == "" {
+		return errx.New(errx.CodeInvalid, "missing cpf")
+	}
+	if u.Email == "" {
+		return errx.New(errx.CodeInvalid, "missing email")
+	}
+
+	now := r.clock.Now()
+
+	if u.Status == "" {
+		u.Status = entity.UserStatusActive
+	}
+	if u.Role == "" {
+		u.Role = entity.UserRoleCostumer
+	}
+	if u.CreatedAt.IsZero() {
+		u.CreatedAt = now
+	}
+	u.UpdatedAt = now
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO users (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		u.ID, u.Name, u.Cpf, u.Email, u.Phone, string(u.Status), string(u.Role), u.Password,
+		sqldb.NullTime(u.EmailVerifiedAt), sqldb.NullTime(u.PhoneVerifiedAt), sqldb.NullTime(u.LastLoginAt),
+		sqldb.Time(u.CreatedAt), sqldb.Time(u.UpdatedAt), sqldb.NullTime(u.DeletedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "user already exists")
+		}
+		return sqldb.Internal("create user", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.User, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+	return r.getOne(ctx, "id", id)
+}
+
+func (r *Repo) GetByCpf(ctx context.Context, cpf string) (*entity.User, error) {
+	if cpf == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing cpf")
+	}
+	return r.getOne(ctx, "cpf", cpf)
+}
+
+func (r *Repo) GetByMail(ctx context.Context, mail string) (*entity.User, error) {
+	if mail == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing email")
+	}
+	return r.getOne(ctx, "email", mail)
+}
+
+func (r *Repo) Update(ctx context.Context, u *entity.User) error {
+	if u == nil {
+		return errx.New(errx.CodeInvalid, "missing user")
+	}
+	if u.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	u.UpdatedAt = r.clock.Now()
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE users SET
+			name = ?, phone = ?, status = ?, role = ?, password = ?,
+			email_verified_at = ?, phone_verified_at = ?, last_login_at = ?,
+			updated_at = ?, deleted_at = ?
+		WHERE id = ?`),
+		u.Name, u.Phone, string(u.Status), string(u.Role), u.Password,
+		sqldb.NullTime(u.EmailVerifiedAt), sqldb.NullTime(u.PhoneVerifiedAt), sqldb.NullTime(u.LastLoginAt),
+		sqldb.Time(u.UpdatedAt), sqldb.NullTime(u.DeletedAt),
+		u.ID,
+	)
+	if err != nil {
+		return sqldb.Internal("update user", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("update user", err)
+	}
+	if n == 0 {
+		return errx.New(errx.CodeNotFound, "user not found")
+	}
+	return nil
+}
+
+// column vem sempre de constantes internas, nunca de input
+func (r *Repo) getOne(ctx context.Context, column, value string) (*entity.User, error) {
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM users WHERE `+column+` = ?`), value)
+
+	u, err := scanUser(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "user not found")
+		}
+		return nil, sqldb.Internal("get user", err)
+	}
+	return u, nil
+}
+
+func scanUser(s sqldb.Scanner) (*entity.User, error) {
+	var (
+		u                                    entity.User
+		status, role                         string
+		emailVerified, phoneVerified, lastAt sql.NullTime
+		deletedAt                            sql.NullTime
+	)
+
+	err := s.Scan(
+		&u.ID, &u.Name, &u.Cpf, &u.Email, &u.Phone, &status, &role, &u.Password,
+		&emailVerified, &phoneVerified, &lastAt, &u.CreatedAt, &u.UpdatedAt, &deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Status = entity.UserStatus(status)
+	u.Role = entity.UserRole(role)
+	u.EmailVerifiedAt = sqldb.TimePtr(emailVerified)
+	u.PhoneVerifiedAt = sqldb.TimePtr(phoneVerified)
+	u.LastLoginAt = sqldb.TimePtr(lastAt)
+	u.DeletedAt = sqldb.TimePtr(deletedAt)
+
+	return &u, nil
+}
@@ -0,0 +1,93 @@
+package sqluser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newUser(id, cpf, email string) *entity.User {
+	return &entity.User{
+		ID:       id,
+		Name:     "usuario teste",
+		Cpf:      cpf,
+		Email:    email,
+		Phone:    "12345678910",
+		Password: "hash",
+	}
+}
+
+func TestUserSQLRepository(t *testing.T) {
+	repo := New(testkit.NewTestDB(t), pkg.NewClock())
+
+	t.Run("test create and get a user by id, cpf and email", func(t *testing.T) {
+		now := time.Now()
+		u := newUser("user-1", "74444217065", "user1@example.com")
+		u.EmailVerifiedAt = &now
+		require.NoError(t, repo.Create(t.Context(), u))
+
+		byID, err := repo.GetByID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, "user1@example.com", byID.Email)
+		require.Equal(t, entity.UserStatusActive, byID.Status)
+		require.Equal(t, entity.UserRoleCostumer, byID.Role)
+		require.NotNil(t, byID.EmailVerifiedAt)
+		require.Nil(t, byID.PhoneVerifiedAt)
+
+		byCpf, err := repo.GetByCpf(t.Context(), "74444217065")
+		require.NoError(t, err)
+		require.Equal(t, "user-1", byCpf.ID)
+
+		byMail, err := repo.GetByMail(t.Context(), "user1@example.com")
+		require.NoError(t, err)
+		require.Equal(t, "user-1", byMail.ID)
+	})
+
+	t.Run("test create a user with a duplicated email", func(t *testing.T) {
+		err := repo.Create(t.Context(), newUser("user-2", "85608186001", "user1@example.com"))
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+
+	t.Run("test create a user with a duplicated cpf", func(t *testing.T) {
+		err := repo.Create(t.Context(), newUser("user-3", "74444217065", "user3@example.com"))
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+
+	t.Run("test update keeps email and cpf", func(t *testing.T) {
+		u, err := repo.GetByID(t.Context(), "user-1")
+		require.NoError(t, err)
+
+		now := time.Now()
+		u.Email = "outro@example.com"
+		u.Status = entity.UserStatusBlocked
+		u.PhoneVerifiedAt = &now
+		u.Password = "new-hash"
+		require.NoError(t, repo.Update(t.Context(), u))
+
+		got, err := repo.GetByID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, "user1@example.com", got.Email)
+		require.Equal(t, entity.UserStatusBlocked, got.Status)
+		require.Equal(t, "new-hash", got.Password)
+		require.NotNil(t, got.PhoneVerifiedAt)
+
+		err = repo.Update(t.Context(), newUser("missing", "23756676030", "missing@example.com"))
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test get a user that does not exist", func(t *testing.T) {
+		_, err := repo.GetByID(t.Context(), "missing")
+
+		require.Error(t, err)
+		require.Equal(t, "not_found: user not found", err.Error())
+	})
+}
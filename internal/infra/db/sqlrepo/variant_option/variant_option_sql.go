@@ -0,0 +1,112 @@
+package sqlvariantoption
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, variant_group_id, name, price_delta, is_default, sort_order, is_active, created_at, updated_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.VariantOptionRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, o *entity.VariantOption) error {
+	if o == nil {
+		return errx.New(errx.CodeInvalid, "missing variant option")
+	}
+	if o.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if o.VariantGroupID == "" {
+		return errx.New(errx.CodeInvalid, "missing groupId")
+	}
+	if o.Name == "" {
+		return errx.New(errx.CodeInvalid, "missing name")
+	}
+
+	now := r.clock.Now()
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = now
+	}
+	o.UpdatedAt = now
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO variant_options (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		o.ID, o.VariantGroupID, o.Name, o.PriceDelta, o.IsDefault, o.Order, o.IsActive,
+		sqldb.Time(o.CreatedAt), sqldb.Time(o.UpdatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "variant option already exists")
+		}
+		return sqldb.Internal("create variant option", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.VariantOption, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	row := r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT `+columns+` FROM variant_options WHERE id = ?`), id)
+	o, err := scanVariantOption(row)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "variant option not found")
+		}
+		return nil, sqldb.Internal("get variant option", err)
+	}
+	return o, nil
+}
+
+func (r *Repo) ListByVariantGroupID(ctx context.Context, groupID string) ([]*entity.VariantOption, error) {
+	if groupID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing groupId")
+	}
+
+	rows, err := r.db.Q(ctx).QueryContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM variant_options
+		WHERE variant_group_id = ?
+		ORDER BY created_at, id`), groupID)
+	if err != nil {
+		return nil, sqldb.Internal("list variant options", err)
+	}
+	defer rows.Close()
+
+	out := make([]*entity.VariantOption, 0)
+	for rows.Next() {
+		o, err := scanVariantOption(rows)
+		if err != nil {
+			return nil, sqldb.Internal("list variant options", err)
+		}
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqldb.Internal("list variant options", err)
+	}
+
+	return out, nil
+}
+
+func scanVariantOption(s sqldb.Scanner) (*entity.VariantOption, error) {
+	var o entity.VariantOption
+	err := s.Scan(&o.ID, &o.VariantGroupID, &o.Name, &o.PriceDelta, &o.IsDefault, &o.Order, &o.IsActive, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
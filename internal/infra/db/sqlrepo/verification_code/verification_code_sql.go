@@ -0,0 +1,170 @@
+package sqlverificationcode
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const columns = `id, user_id, purpose, target, code_hash, attempts, expires_at, consumed_at, created_at`
+
+type Repo struct {
+	db    *sqldb.DB
+	clock ports.Clock
+}
+
+func New(db *sqldb.DB, clock ports.Clock) repository.VerificationCodeRepository {
+	return &Repo{db: db, clock: clock}
+}
+
+func (r *Repo) Create(ctx context.Context, c *entity.VerificationCode) error {
+	if c == nil {
+		return errx.New(errx.CodeInvalid, "missing verification code")
+	}
+	if c.ID == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+	if c.UserID == "" {
+		return errx.New(errx.CodeInvalid, "missing user id")
+	}
+	if c.Purpose == "" {
+		return errx.New(errx.CodeInvalid, "missing purpose")
+	}
+	if c.CodeHash == "" {
+		return errx.New(errx.CodeInvalid, "missing code hash")
+	}
+	if c.ExpiresAt.IsZero() {
+		return errx.New(errx.CodeInvalid, "missing expiresAt")
+	}
+
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = r.clock.Now()
+	}
+
+	_, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		INSERT INTO verification_codes (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		c.ID, c.UserID, string(c.Purpose), c.Target, c.CodeHash, c.Attempts,
+		sqldb.Time(c.ExpiresAt), sqldb.NullTime(c.ConsumedAt), sqldb.Time(c.CreatedAt),
+	)
+	if err != nil {
+		if sqldb.IsUniqueViolation(err) {
+			return errx.New(errx.CodeConflict, "verification code already exists")
+		}
+		return sqldb.Internal("create verification code", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*entity.VerificationCode, error) {
+	if id == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	c, err := scanCode(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM verification_codes WHERE id = ?`), id))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "verification code not found")
+		}
+		return nil, sqldb.Internal("get verification code", err)
+	}
+	return c, nil
+}
+
+func (r *Repo) GetLatest(ctx context.Context, userID string, purpose entity.VerificationPurpose) (*entity.VerificationCode, error) {
+	if userID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing user id")
+	}
+
+	c, err := scanCode(r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT `+columns+` FROM verification_codes
+		WHERE user_id = ? AND purpose = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1`), userID, string(purpose)))
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return nil, errx.New(errx.CodeNotFound, "verification code not found")
+		}
+		return nil, sqldb.Internal("get verification code", err)
+	}
+	return c, nil
+}
+
+func (r *Repo) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	if id == "" {
+		return 0, errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	// incremento no próprio UPDATE: palpites concorrentes não se perdem
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE verification_codes SET attempts = attempts + 1 WHERE id = ?`), id)
+	if err != nil {
+		return 0, sqldb.Internal("increment verification attempts", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, sqldb.Internal("increment verification attempts", err)
+	}
+	if n == 0 {
+		return 0, errx.New(errx.CodeNotFound, "verification code not found")
+	}
+
+	var attempts int
+	err = r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`
+		SELECT attempts FROM verification_codes WHERE id = ?`), id).Scan(&attempts)
+	if err != nil {
+		return 0, sqldb.Internal("increment verification attempts", err)
+	}
+	return attempts, nil
+}
+
+func (r *Repo) MarkConsumed(ctx context.Context, id string, at time.Time) error {
+	if id == "" {
+		return errx.New(errx.CodeInvalid, "missing id")
+	}
+
+	res, err := r.db.Q(ctx).ExecContext(ctx, r.db.Rebind(`
+		UPDATE verification_codes SET consumed_at = ?
+		WHERE id = ? AND consumed_at IS NULL`), sqldb.Time(at), id)
+	if err != nil {
+		return sqldb.Internal("consume verification code", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return sqldb.Internal("consume verification code", err)
+	}
+	if n == 1 {
+		return nil
+	}
+
+	var exists int
+	err = r.db.Q(ctx).QueryRowContext(ctx, r.db.Rebind(`SELECT 1 FROM verification_codes WHERE id = ?`), id).Scan(&exists)
+	if err != nil {
+		if sqldb.IsNoRows(err) {
+			return errx.New(errx.CodeNotFound, "verification code not found")
+		}
+		return sqldb.Internal("consume verification code", err)
+	}
+	return errx.New(errx.CodeConflict, "verification code already used")
+}
+
+func scanCode(s sqldb.Scanner) (*entity.VerificationCode, error) {
+	var (
+		c          entity.VerificationCode
+		purpose    string
+		consumedAt sql.NullTime
+	)
+	if err := s.Scan(&c.ID, &c.UserID, &purpose, &c.Target, &c.CodeHash, &c.Attempts, &c.ExpiresAt, &consumedAt, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+	c.Purpose = entity.VerificationPurpose(purpose)
+	c.ConsumedAt = sqldb.TimePtr(consumedAt)
+	return &c, nil
+}
@@ -0,0 +1,80 @@
+package sqlverificationcode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationCodeSQLRepository(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	repo := New(testkit.NewTestDB(t), clock)
+
+	newCode := func(id string, purpose entity.VerificationPurpose) *entity.VerificationCode {
+		return &entity.VerificationCode{
+			ID:        id,
+			UserID:    "user-1",
+			Purpose:   purpose,
+			Target:    "user@example.com",
+			CodeHash:  "hash-" + id,
+			ExpiresAt: clock.Now().Add(10 * time.Minute),
+		}
+	}
+
+	t.Run("test get latest returns the newest code of the purpose", func(t *testing.T) {
+		require.NoError(t, repo.Create(t.Context(), newCode("vc-1", entity.VerificationPurposeEmail)))
+		clock.Advance(time.Minute)
+		require.NoError(t, repo.Create(t.Context(), newCode("vc-2", entity.VerificationPurposeEmail)))
+		require.NoError(t, repo.Create(t.Context(), newCode("vc-3", entity.VerificationPurposePhone)))
+
+		got, err := repo.GetLatest(t.Context(), "user-1", entity.VerificationPurposeEmail)
+		require.NoError(t, err)
+		require.Equal(t, "vc-2", got.ID)
+		require.False(t, got.IsConsumed())
+
+		_, err = repo.GetLatest(t.Context(), "user-2", entity.VerificationPurposeEmail)
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test get by id", func(t *testing.T) {
+		got, err := repo.GetByID(t.Context(), "vc-3")
+		require.NoError(t, err)
+		require.Equal(t, entity.VerificationPurposePhone, got.Purpose)
+		require.Equal(t, "hash-vc-3", got.CodeHash)
+
+		_, err = repo.GetByID(t.Context(), "missing")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test increment attempts", func(t *testing.T) {
+		n, err := repo.IncrementAttempts(t.Context(), "vc-2")
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		n, err = repo.IncrementAttempts(t.Context(), "vc-2")
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		_, err = repo.IncrementAttempts(t.Context(), "missing")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+
+	t.Run("test a code is consumed only once", func(t *testing.T) {
+		require.NoError(t, repo.MarkConsumed(t.Context(), "vc-2", clock.Now()))
+
+		err := repo.MarkConsumed(t.Context(), "vc-2", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeConflict))
+
+		got, err := repo.GetLatest(t.Context(), "user-1", entity.VerificationPurposeEmail)
+		require.NoError(t, err)
+		require.True(t, got.IsConsumed())
+
+		err = repo.MarkConsumed(t.Context(), "missing", clock.Now())
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+}
@@ -26,6 +26,8 @@ func ToHTTP(err error) (int, response.Envelope) {
 		return http.StatusUnauthorized, response.Fail(string(code), msg)
 	case errx.CodeForbidden:
 		return http.StatusForbidden, response.Fail(string(code), msg)
+	case errx.CodeRateLimited:
+		return http.StatusTooManyRequests, response.Fail(string(code), msg)
 	default:
 		return http.StatusInternalServerError, response.Fail(string(errx.CodeInternal), "internal error")
 	}
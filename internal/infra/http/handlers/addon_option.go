@@ -47,7 +47,7 @@ func (aoh *AddonOptionHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewCreateAddonOptionUseCase(aoh.addonOptionRepo, aoh.itemAddonGroupRepo, aoh.uuid, ctx)
+	uc := usecase.NewCreateAddonOptionUseCase(aoh.addonOptionRepo, aoh.itemAddonGroupRepo, aoh.uuid, ctx.Request.Context())
 	output, err := uc.Execute(usecase.CreateAddonOptionInput{
 		ItemAddonGroupID: itemAddonGroupID,
 		Name:             req.Name,
@@ -69,7 +69,7 @@ func (aoh *AddonOptionHandler) GetByID(ctx *gin.Context) {
 		RespondErr(ctx, errx.New(errx.CodeInvalid, "addon option id is required"))
 		return
 	}
-	uc := usecase.NewGetAddonOptionByIDUsecase(aoh.addonOptionRepo, aoh.uuid, ctx)
+	uc := usecase.NewGetAddonOptionByIDUsecase(aoh.addonOptionRepo, aoh.uuid, ctx.Request.Context())
 	output, err := uc.Execute(usecase.GetAddonOptionByIDInput{
 		ID: addonOptionId,
 	})
@@ -88,7 +88,7 @@ func (aoh *AddonOptionHandler) GetByItemAddonGroupID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewListByItemAddonGroupIDUsecase(aoh.addonOptionRepo, aoh.itemAddonGroupRepo, aoh.uuid, ctx)
+	uc := usecase.NewListByItemAddonGroupIDUsecase(aoh.addonOptionRepo, aoh.itemAddonGroupRepo, aoh.uuid, ctx.Request.Context())
 	output, err := uc.Execute(usecase.ListByItemAddonGroupIDInput{
 		ItemAddonGroupID: itemAddonGroupID,
 	})
@@ -4,8 +4,10 @@ package handlers
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
 	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 	usecase "github.com/FabioRocha231/saas-core/internal/usecase/auth"
@@ -17,27 +19,78 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 type AuthHandler struct {
-	passwordHash ports.PasswordHashInterface
-	jwtService   ports.JwtInterface
-	userRepo     repository.UserRepository
-	sessionRepo  repository.SessionRepository
-	storeRepo    repository.StoreRepository
+	passwordHash  ports.PasswordHashInterface
+	jwtService    ports.JwtInterface
+	token         ports.TokenInterface
+	totp          ports.TOTPInterface
+	userRepo      repository.UserRepository
+	sessionRepo   repository.SessionRepository
+	refreshRepo   repository.RefreshTokenRepository
+	storeRepo     repository.StoreRepository
+	memberRepo    repository.StoreMemberRepository
+	throttleRepo  repository.LoginThrottleRepository
+	auditRepo     repository.AuditRepository
+	codeRepo      repository.VerificationCodeRepository
+	twoFactorRepo repository.TwoFactorRepository
+	uuid          ports.UUIDInterface
+	tx            ports.TxManager
+	clock         ports.Clock
+	refreshTTL    time.Duration
+	twoFactor     usecase.TwoFactorSettings
+	lockout       usecase.LockoutSettings
 }
 
 func NewAuthHandler(
 	passwordHash ports.PasswordHashInterface,
 	jwtService ports.JwtInterface,
+	token ports.TokenInterface,
+	totp ports.TOTPInterface,
 	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
 	storeRepo repository.StoreRepository,
+	memberRepo repository.StoreMemberRepository,
+	throttleRepo repository.LoginThrottleRepository,
+	auditRepo repository.AuditRepository,
+	codeRepo repository.VerificationCodeRepository,
+	twoFactorRepo repository.TwoFactorRepository,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	refreshTTL time.Duration,
+	twoFactor usecase.TwoFactorSettings,
+	lockout usecase.LockoutSettings,
 ) *AuthHandler {
 	return &AuthHandler{
-		passwordHash: passwordHash,
-		jwtService:   jwtService,
-		userRepo:     userRepo,
-		sessionRepo:  sessionRepo,
-		storeRepo:    storeRepo,
+		passwordHash:  passwordHash,
+		jwtService:    jwtService,
+		token:         token,
+		totp:          totp,
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		refreshRepo:   refreshRepo,
+		storeRepo:     storeRepo,
+		memberRepo:    memberRepo,
+		throttleRepo:  throttleRepo,
+		auditRepo:     auditRepo,
+		codeRepo:      codeRepo,
+		twoFactorRepo: twoFactorRepo,
+		uuid:          uuid,
+		tx:            tx,
+		clock:         clock,
+		refreshTTL:    refreshTTL,
+		twoFactor:     twoFactor,
+		lockout:       lockout,
 	}
 }
 
@@ -60,15 +113,113 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 	}
 
 	uc := usecase.NewLoginUsecase(
-		ctx,
+		ctx.Request.Context(),
 		h.userRepo,
 		h.sessionRepo,
 		h.storeRepo,
+		h.memberRepo,
+		h.refreshRepo,
+		h.throttleRepo,
+		h.auditRepo,
+		h.codeRepo,
+		h.twoFactorRepo,
 		h.jwtService,
 		h.passwordHash,
+		h.token,
+		h.uuid,
+		h.clock,
+		h.refreshTTL,
+		h.twoFactor,
+		h.lockout,
 	)
-	output, err := uc.Execute(usecase.LoginInput{Email: req.Email, Password: req.Password})
+	output, err := uc.Execute(usecase.LoginInput{
+		Email:     req.Email,
+		Password:  req.Password,
+		UserAgent: ctx.Request.UserAgent(),
+		IP:        ctx.ClientIP(),
+	})
+
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	// conta com 2FA: o cliente segue para POST /login/2fa
+	if output.Challenge != nil {
+		RespondOK(ctx, http.StatusOK, output.Challenge)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (h *AuthHandler) LoginTwoFactor(ctx *gin.Context) {
+	var req TwoFactorLoginRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "invalid body"))
+		return
+	}
+
+	if strings.TrimSpace(req.ChallengeToken) == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "challenge_token is required"))
+		return
+	}
+
+	if strings.TrimSpace(req.Code) == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "code is required"))
+		return
+	}
+
+	uc := usecase.NewCompleteTwoFactorLoginUsecase(
+		h.userRepo,
+		h.sessionRepo,
+		h.storeRepo,
+		h.memberRepo,
+		h.refreshRepo,
+		h.throttleRepo,
+		h.auditRepo,
+		h.codeRepo,
+		h.twoFactorRepo,
+		h.jwtService,
+		h.token,
+		h.uuid,
+		h.totp,
+		h.tx,
+		h.clock,
+		h.refreshTTL,
+		h.twoFactor,
+		h.lockout,
+	)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.CompleteTwoFactorLoginInput{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		UserAgent:      ctx.Request.UserAgent(),
+		IP:             ctx.ClientIP(),
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (h *AuthHandler) Refresh(ctx *gin.Context) {
+	var req RefreshRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "invalid body"))
+		return
+	}
+
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "refresh_token is required"))
+		return
+	}
 
+	uc := usecase.NewRefreshUsecase(h.sessionRepo, h.refreshRepo, h.jwtService, h.token, h.uuid, h.tx, h.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.RefreshInput{RefreshToken: strings.TrimSpace(req.RefreshToken)})
 	if err != nil {
 		RespondErr(ctx, err)
 		return
@@ -76,3 +227,70 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 
 	RespondOK(ctx, http.StatusOK, output)
 }
+
+func (h *AuthHandler) Logout(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+	sessionID, err := helper.GetSessionIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	uc := usecase.NewLogoutUsecase(h.sessionRepo, h.refreshRepo)
+	if err := uc.Execute(ctx.Request.Context(), usecase.LogoutInput{SessionID: sessionID, UserID: userID}); err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *AuthHandler) ListSessions(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+	sessionID, err := helper.GetSessionIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	uc := usecase.NewListSessionsUsecase(h.sessionRepo, h.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.ListSessionsInput{UserID: userID, CurrentSessionID: sessionID})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (h *AuthHandler) RevokeAllSessions(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	uc := usecase.NewRevokeAllSessionsUsecase(h.sessionRepo, h.refreshRepo)
+	output, err := uc.Execute(ctx.Request.Context(), userID)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+// JWKS responde no formato da RFC 7517, sem o envelope da API, porque é o que
+// as bibliotecas de validação esperam.
+func (h *AuthHandler) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, h.jwtService.JWKS())
+}
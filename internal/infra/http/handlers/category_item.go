@@ -15,6 +15,7 @@ type CategoryItemHandler struct {
 	categoryItemRepo repository.CategoryItemRepository
 	menuCategoryRepo repository.MenuCategoryRepository
 	uuid             ports.UUIDInterface
+	clock            ports.Clock
 }
 
 type CreateCategoryItemRequest struct {
@@ -25,11 +26,12 @@ type CreateCategoryItemRequest struct {
 	IsActive    bool   `json:"is_active" binding:"required"`
 }
 
-func NewCategoryItemHandler(categoryItemRepo repository.CategoryItemRepository, menuCategoryRepo repository.MenuCategoryRepository, uuid ports.UUIDInterface) *CategoryItemHandler {
+func NewCategoryItemHandler(categoryItemRepo repository.CategoryItemRepository, menuCategoryRepo repository.MenuCategoryRepository, uuid ports.UUIDInterface, clock ports.Clock) *CategoryItemHandler {
 	return &CategoryItemHandler{
 		categoryItemRepo: categoryItemRepo,
 		menuCategoryRepo: menuCategoryRepo,
 		uuid:             uuid,
+		clock:            clock,
 	}
 }
 
@@ -56,7 +58,7 @@ func (cih *CategoryItemHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewCreateCategoryItemUsecase(cih.categoryItemRepo, cih.menuCategoryRepo, cih.uuid, ctx)
+	uc := usecase.NewCreateCategoryItemUsecase(cih.categoryItemRepo, cih.menuCategoryRepo, cih.uuid, ctx.Request.Context(), cih.clock)
 	output, err := uc.Execute(usecase.CreateCategoryItemInput{
 		Name:        req.Name,
 		Description: req.Description,
@@ -80,7 +82,7 @@ func (cih *CategoryItemHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewGetCategoryItemByIDUsecase(cih.categoryItemRepo, cih.uuid, ctx)
+	uc := usecase.NewGetCategoryItemByIDUsecase(cih.categoryItemRepo, cih.uuid, ctx.Request.Context())
 	output, err := uc.Execute(usecase.GetCategoryItemByIDInput{ID: id})
 	if err != nil {
 		RespondErr(ctx, err)
@@ -97,7 +99,7 @@ func (cih *CategoryItemHandler) ListByCategoryID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewListCategoryItemsByCategoryIDUsecase(cih.categoryItemRepo, cih.menuCategoryRepo, cih.uuid, ctx)
+	uc := usecase.NewListCategoryItemsByCategoryIDUsecase(cih.categoryItemRepo, cih.menuCategoryRepo, cih.uuid, ctx.Request.Context())
 	output, err := uc.Execute(usecase.ListCategoryItemsByCategoryIDInput{CategoryID: categoryID})
 	if err != nil {
 		RespondErr(ctx, err)
@@ -1,6 +1,15 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	"github.com/FabioRocha231/saas-core/internal/infra/http/apperr"
 	"github.com/FabioRocha231/saas-core/internal/infra/http/response"
 	"github.com/gin-gonic/gin"
@@ -14,3 +23,35 @@ func RespondErr(c *gin.Context, err error) {
 	status, body := apperr.ToHTTP(err)
 	c.JSON(status, body)
 }
+
+// RespondCached responde 200 com ETag (hash do corpo) e Cache-Control público.
+// Se o cliente já tem essa versão (If-None-Match), devolve 304 sem corpo.
+func RespondCached(c *gin.Context, data any, maxAge time.Duration) {
+	body, err := json.Marshal(response.Ok(data))
+	if err != nil {
+		RespondErr(c, errx.Wrap(errx.CodeInternal, "encode response", err))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		// comparação fraca (RFC 9110): W/"x" vale o mesmo que "x"
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
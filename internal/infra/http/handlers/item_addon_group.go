@@ -50,7 +50,7 @@ func (iah *ItemAddonGroupHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewCreateItemAddonGroupUseCase(ctx, iah.itemAddonGroupRepo, iah.categoryItemRepo, iah.uuid)
+	uc := usecase.NewCreateItemAddonGroupUseCase(ctx.Request.Context(), iah.itemAddonGroupRepo, iah.categoryItemRepo, iah.uuid)
 	input := usecase.CreateItemAddonGroupInput{
 		CategoryItemID: itemID,
 		Name:           req.Name,
@@ -77,7 +77,7 @@ func (iah *ItemAddonGroupHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewGetItemAddonGroupByIDUseCase(ctx, iah.itemAddonGroupRepo, iah.uuid)
+	uc := usecase.NewGetItemAddonGroupByIDUseCase(ctx.Request.Context(), iah.itemAddonGroupRepo, iah.uuid)
 	input := usecase.GetItemAddonGroupByIDInput{
 		ID: itemID,
 	}
@@ -98,7 +98,7 @@ func (iah *ItemAddonGroupHandler) ListByCategoryItemID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewListItemAddonGroupByCategoryItemIDUseCase(ctx, iah.itemAddonGroupRepo, iah.categoryItemRepo, iah.uuid)
+	uc := usecase.NewListItemAddonGroupByCategoryItemIDUseCase(ctx.Request.Context(), iah.itemAddonGroupRepo, iah.categoryItemRepo, iah.uuid)
 	input := usecase.ListItemAddonGroupByCategoryItemIDInput{
 		CategoryItemID: itemID,
 	}
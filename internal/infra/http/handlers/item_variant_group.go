@@ -14,6 +14,7 @@ type ItemVariantGroupHandler struct {
 	itemVariantGroupRepo repository.ItemVariantGroupRepository
 	categoryItemRepo     repository.CategoryItemRepository
 	uuid                 ports.UUIDInterface
+	clock                ports.Clock
 }
 
 type CreateItemVariantGroupRequest struct {
@@ -29,11 +30,13 @@ func NewItemVariantGroupHandler(
 	itemVariantGroupRepo repository.ItemVariantGroupRepository,
 	categoryItemRepo repository.CategoryItemRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *ItemVariantGroupHandler {
 	return &ItemVariantGroupHandler{
 		itemVariantGroupRepo: itemVariantGroupRepo,
 		categoryItemRepo:     categoryItemRepo,
 		uuid:                 uuid,
+		clock:                clock,
 	}
 }
 
@@ -50,7 +53,7 @@ func (handler *ItemVariantGroupHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewCreateItemVariantGroupUseCase(ctx, handler.itemVariantGroupRepo, handler.categoryItemRepo, handler.uuid)
+	uc := usecase.NewCreateItemVariantGroupUseCase(ctx.Request.Context(), handler.itemVariantGroupRepo, handler.categoryItemRepo, handler.uuid, handler.clock)
 	input := usecase.CreateItemVariantGroupInput{
 		CategoryItemID: categoryItemID,
 		Name:           req.Name,
@@ -77,7 +80,7 @@ func (handler *ItemVariantGroupHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewGetItemVariantGroupByIDUseCase(handler.itemVariantGroupRepo, handler.uuid, ctx)
+	uc := usecase.NewGetItemVariantGroupByIDUseCase(handler.itemVariantGroupRepo, handler.uuid, ctx.Request.Context())
 	output, err := uc.Execute(usecase.GetItemVariantGroupByIDInput{
 		ID: id,
 	})
@@ -96,7 +99,7 @@ func (handler *ItemVariantGroupHandler) ListByCategoryItemID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewListItemVariantGroupByCategoryItemIDUsecase(ctx, handler.itemVariantGroupRepo, handler.categoryItemRepo, handler.uuid)
+	uc := usecase.NewListItemVariantGroupByCategoryItemIDUsecase(ctx.Request.Context(), handler.itemVariantGroupRepo, handler.categoryItemRepo, handler.uuid)
 	output, err := uc.Execute(usecase.ListItemVariantGroupByCategoryItemIDInput{
 		CategoryItemID: categoryItemID,
 	})
@@ -15,6 +15,7 @@ type MenuCategoryHandler struct {
 	menuCategoryRepository repository.MenuCategoryRepository
 	storeMenuRepo          repository.StoreMenuRepository
 	uuid                   ports.UUIDInterface
+	clock                  ports.Clock
 }
 
 type CreateMenuCategoryRequest struct {
@@ -22,11 +23,12 @@ type CreateMenuCategoryRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
-func NewMenuCategoryHandler(menuCategoryRepository repository.MenuCategoryRepository, storeMenuRepo repository.StoreMenuRepository, uuid ports.UUIDInterface) *MenuCategoryHandler {
+func NewMenuCategoryHandler(menuCategoryRepository repository.MenuCategoryRepository, storeMenuRepo repository.StoreMenuRepository, uuid ports.UUIDInterface, clock ports.Clock) *MenuCategoryHandler {
 	return &MenuCategoryHandler{
 		menuCategoryRepository: menuCategoryRepository,
 		storeMenuRepo:          storeMenuRepo,
 		uuid:                   uuid,
+		clock:                  clock,
 	}
 }
 
@@ -48,8 +50,8 @@ func (mch *MenuCategoryHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewCreateMenuCategoryUsecase(mch.menuCategoryRepository, mch.storeMenuRepo, mch.uuid)
-	output, err := uc.Execute(ctx, usecase.CreateMenuCategoryInput{Name: req.Name, IsActive: req.IsActive, MenuID: menuId})
+	uc := usecase.NewCreateMenuCategoryUsecase(mch.menuCategoryRepository, mch.storeMenuRepo, mch.uuid, mch.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.CreateMenuCategoryInput{Name: req.Name, IsActive: req.IsActive, MenuID: menuId})
 	if err != nil {
 		RespondErr(ctx, err)
 		return
@@ -65,7 +67,7 @@ func (mch *MenuCategoryHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewGetMenuCategoryByIDUseCase(mch.menuCategoryRepository, mch.uuid, ctx)
+	uc := usecase.NewGetMenuCategoryByIDUseCase(mch.menuCategoryRepository, mch.uuid, ctx.Request.Context())
 	output, err := uc.Execute(usecase.GetMenuCategoryByIDInput{ID: id})
 	if err != nil {
 		RespondErr(ctx, err)
@@ -3,19 +3,28 @@ package handlers
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
 	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 	usecase "github.com/FabioRocha231/saas-core/internal/usecase/order"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
 	"github.com/gin-gonic/gin"
 )
 
 type OrderHandler struct {
 	orderRepo    repository.OrderRepository
+	storeRepo    repository.StoreRepository
+	policy       *policy.Policy
+	paymentRepo  repository.PaymentRepository
+	refundRepo   repository.RefundRepository
 	menuReadRepo repository.MenuReadRepository
+	gateways     ports.PaymentGateways
+	tx           ports.TxManager
 	uuid         ports.UUIDInterface
+	clock        ports.Clock
 }
 
 type AddItemRequest struct {
@@ -33,15 +42,42 @@ type UpdateItemQtyRequest struct {
 	Qty int64 `json:"qty"`
 }
 
+type AdvanceOrderStatusRequest struct {
+	Status string `json:"status"`
+}
+
+type PlaceOrderRequest struct {
+	// RFC 3339 com fuso, ex.: 2026-10-16T20:00:00-03:00
+	ScheduledFor *time.Time `json:"scheduled_for"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
 func NewOrderHandler(
 	orderRepo repository.OrderRepository,
+	storeRepo repository.StoreRepository,
+	authz *policy.Policy,
+	paymentRepo repository.PaymentRepository,
+	refundRepo repository.RefundRepository,
 	menuReadRepo repository.MenuReadRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *OrderHandler {
 	return &OrderHandler{
 		orderRepo:    orderRepo,
+		storeRepo:    storeRepo,
+		policy:       authz,
+		paymentRepo:  paymentRepo,
+		refundRepo:   refundRepo,
 		menuReadRepo: menuReadRepo,
+		gateways:     gateways,
+		tx:           tx,
 		uuid:         uuid,
+		clock:        clock,
 	}
 }
 
@@ -58,7 +94,7 @@ func (h *OrderHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewGetOrCreateDraftUsecase(h.orderRepo, h.uuid, ctx.Request.Context())
+	uc := usecase.NewGetOrCreateDraftUsecase(h.orderRepo, h.storeRepo, h.uuid, ctx.Request.Context(), h.clock)
 
 	out, err := uc.Execute(usecase.GetOrCreateDraftInput{
 		UserID:  userID,
@@ -79,6 +115,12 @@ func (h *OrderHandler) Create(ctx *gin.Context) {
 }
 
 func (h *OrderHandler) AddItem(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
 	orderID := strings.TrimSpace(ctx.Param("orderId"))
 	if orderID == "" {
 		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing orderId"))
@@ -100,7 +142,7 @@ func (h *OrderHandler) AddItem(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewAddItem(h.orderRepo, h.menuReadRepo, h.uuid)
+	uc := usecase.NewAddItem(h.orderRepo, h.storeRepo, h.menuReadRepo, h.uuid, h.clock)
 
 	addons := make([]usecase.AddonSelection, 0, len(req.Addons))
 	for _, a := range req.Addons {
@@ -110,8 +152,9 @@ func (h *OrderHandler) AddItem(ctx *gin.Context) {
 		})
 	}
 
-	out, err := uc.Execute(ctx, usecase.AddItemInput{
+	out, err := uc.Execute(ctx.Request.Context(), usecase.AddItemInput{
 		OrderID:          orderID,
+		UserID:           userID,
 		ItemID:           req.ItemID,
 		Qty:              req.Qty,
 		VariantOptionIDs: req.VariantOptionIDs,
@@ -141,7 +184,7 @@ func (h *OrderHandler) GetByID(ctx *gin.Context) {
 
 	uc := usecase.NewGetOrderUsecase(h.orderRepo, h.uuid)
 
-	out, err := uc.Execute(ctx, usecase.GetOrderInput{OrderID: orderID, UserID: userID})
+	out, err := uc.Execute(ctx.Request.Context(), usecase.GetOrderInput{OrderID: orderID, UserID: userID})
 	if err != nil {
 		RespondErr(ctx, err)
 		return
@@ -179,8 +222,8 @@ func (h *OrderHandler) UpdateItemQty(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewUpdateItemQtyUsecase(h.orderRepo, h.uuid)
-	out, err := uc.Execute(ctx, usecase.UpdateItemQtyInput{
+	uc := usecase.NewUpdateItemQtyUsecase(h.orderRepo, h.uuid, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.UpdateItemQtyInput{
 		UserID:  userID,
 		OrderID: orderID,
 		ItemID:  itemID,
@@ -212,8 +255,8 @@ func (h *OrderHandler) RemoveItem(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewRemoveItemUsecase(h.orderRepo, h.uuid)
-	out, err := uc.Execute(ctx, usecase.RemoveItemInput{
+	uc := usecase.NewRemoveItemUsecase(h.orderRepo, h.uuid, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.RemoveItemInput{
 		UserID:  userID,
 		OrderID: orderID,
 		ItemID:  itemID,
@@ -239,10 +282,165 @@ func (h *OrderHandler) PlaceOrder(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewPlaceOrderUsecase(h.orderRepo, h.uuid)
-	out, err := uc.Execute(ctx, usecase.PlaceOrderInput{
+	// body vazio é pedido para agora
+	var req PlaceOrderRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+			return
+		}
+	}
+
+	uc := usecase.NewPlaceOrderUsecase(h.orderRepo, h.storeRepo, h.tx, h.uuid, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.PlaceOrderInput{
+		OrderID:      orderID,
+		UserID:       userID,
+		ScheduledFor: req.ScheduledFor,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
+
+func (h *OrderHandler) ListStoreOrders(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	storeID := strings.TrimSpace(ctx.Param("storeId"))
+	if storeID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing storeId"))
+		return
+	}
+
+	uc := usecase.NewListStoreOrdersUsecase(h.orderRepo, h.policy, h.uuid)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.ListStoreOrdersInput{
+		StoreID: storeID,
+		UserID:  userID,
+		Status:  strings.ToUpper(strings.TrimSpace(ctx.Query("status"))),
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
+
+func (h *OrderHandler) AdvanceStatus(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	storeID := strings.TrimSpace(ctx.Param("storeId"))
+	orderID := strings.TrimSpace(ctx.Param("orderId"))
+	if storeID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing storeId"))
+		return
+	}
+	if orderID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing orderId"))
+		return
+	}
+
+	var req AdvanceOrderStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+	if strings.TrimSpace(req.Status) == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "status is required"))
+		return
+	}
+
+	uc := usecase.NewAdvanceOrderStatusUsecase(h.orderRepo, h.policy, h.uuid, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.AdvanceOrderStatusInput{
+		StoreID: storeID,
+		OrderID: orderID,
+		UserID:  userID,
+		Status:  strings.ToUpper(strings.TrimSpace(req.Status)),
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
+
+func (h *OrderHandler) Cancel(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	orderID := strings.TrimSpace(ctx.Param("orderId"))
+	if orderID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing orderId"))
+		return
+	}
+
+	// motivo é opcional para o cliente: body vazio é aceito
+	var req CancelOrderRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+			return
+		}
+	}
+
+	uc := usecase.NewCancelOrderUsecase(h.orderRepo, h.paymentRepo, h.refundRepo, h.gateways, h.tx, h.uuid, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.CancelOrderInput{
+		OrderID: orderID,
+		UserID:  userID,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
+
+func (h *OrderHandler) Reject(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	storeID := strings.TrimSpace(ctx.Param("storeId"))
+	orderID := strings.TrimSpace(ctx.Param("orderId"))
+	if storeID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing storeId"))
+		return
+	}
+	if orderID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing orderId"))
+		return
+	}
+
+	var req CancelOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewRejectOrderUsecase(h.orderRepo, h.policy, h.paymentRepo, h.refundRepo, h.gateways, h.tx, h.uuid, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.RejectOrderInput{
+		StoreID: storeID,
 		OrderID: orderID,
 		UserID:  userID,
+		Reason:  req.Reason,
 	})
 	if err != nil {
 		RespondErr(ctx, err)
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	usecase "github.com/FabioRocha231/saas-core/internal/usecase/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Email       string `json:"email"`
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type PasswordHandler struct {
+	userRepo     repository.UserRepository
+	codeRepo     repository.VerificationCodeRepository
+	sessionRepo  repository.SessionRepository
+	refreshRepo  repository.RefreshTokenRepository
+	passwordHash ports.PasswordHashInterface
+	sender       ports.MessageSender
+	token        ports.TokenInterface
+	uuid         ports.UUIDInterface
+	tx           ports.TxManager
+	clock        ports.Clock
+	settings     usecase.PasswordResetSettings
+}
+
+func NewPasswordHandler(
+	userRepo repository.UserRepository,
+	codeRepo repository.VerificationCodeRepository,
+	sessionRepo repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	passwordHash ports.PasswordHashInterface,
+	sender ports.MessageSender,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	settings usecase.PasswordResetSettings,
+) *PasswordHandler {
+	return &PasswordHandler{
+		userRepo:     userRepo,
+		codeRepo:     codeRepo,
+		sessionRepo:  sessionRepo,
+		refreshRepo:  refreshRepo,
+		passwordHash: passwordHash,
+		sender:       sender,
+		token:        token,
+		uuid:         uuid,
+		tx:           tx,
+		clock:        clock,
+		settings:     settings,
+	}
+}
+
+// Forgot responde 202 mesmo para email sem conta.
+func (h *PasswordHandler) Forgot(ctx *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewForgotPasswordUsecase(h.userRepo, h.codeRepo, h.sender, h.token, h.uuid, h.clock, h.settings)
+	if err := uc.Execute(ctx.Request.Context(), usecase.ForgotPasswordInput{Email: req.Email}); err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+func (h *PasswordHandler) Reset(ctx *gin.Context) {
+	var req ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewResetPasswordUsecase(h.userRepo, h.codeRepo, h.sessionRepo, h.refreshRepo, h.passwordHash, h.token, h.tx, h.clock, h.settings)
+	err := uc.Execute(ctx.Request.Context(), usecase.ResetPasswordInput{
+		Email:       req.Email,
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Change troca a senha do usuário logado; o token atual deixa de valer junto
+// com as outras sessões.
+func (h *PasswordHandler) Change(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewChangePasswordUsecase(h.userRepo, h.sessionRepo, h.refreshRepo, h.passwordHash, h.tx)
+	err = uc.Execute(ctx.Request.Context(), usecase.ChangePasswordInput{
+		UserID:          userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
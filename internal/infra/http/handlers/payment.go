@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 	"strings"
 
@@ -10,29 +11,51 @@ import (
 	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
 	usecase "github.com/FabioRocha231/saas-core/internal/usecase/payment"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
 	"github.com/gin-gonic/gin"
 )
 
 type PaymentHandler struct {
 	orderRepo   repository.OrderRepository
 	paymentRepo repository.PaymentRepository
+	refundRepo  repository.RefundRepository
+	eventRepo   repository.PaymentEventRepository
+	policy      *policy.Policy
+	gateways    ports.PaymentGateways
+	qr          ports.QRCodeInterface
+	tx          ports.TxManager
 	uuid        ports.UUIDInterface
+	clock       ports.Clock
 }
 
 func NewPaymentHandler(
 	orderRepo repository.OrderRepository,
 	paymentRepo repository.PaymentRepository,
+	refundRepo repository.RefundRepository,
+	eventRepo repository.PaymentEventRepository,
+	authz *policy.Policy,
+	gateways ports.PaymentGateways,
+	qr ports.QRCodeInterface,
+	tx ports.TxManager,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *PaymentHandler {
 	return &PaymentHandler{
 		orderRepo:   orderRepo,
 		paymentRepo: paymentRepo,
+		refundRepo:  refundRepo,
+		eventRepo:   eventRepo,
+		policy:      authz,
+		gateways:    gateways,
+		qr:          qr,
+		tx:          tx,
 		uuid:        uuid,
+		clock:       clock,
 	}
 }
 
 type CreatePaymentRequest struct {
-	// por enquanto só MOCK, mas já deixamos a estrutura
+	// MOCK, PIX, CREDIT_CARD ou DEBIT_CARD (MOCK quando vazio)
 	Method string `json:"method"`
 	// para idempotência (opcional)
 	IdempotencyKey string `json:"idempotency_key"`
@@ -57,21 +80,22 @@ func (h *PaymentHandler) CreateForOrder(ctx *gin.Context) {
 		return
 	}
 
-	method := strings.TrimSpace(req.Method)
-	if method == "" {
-		method = string(entity.PaymentMethodMock)
-	}
-	if method != string(entity.PaymentMethodMock) {
-		RespondErr(ctx, errx.New(errx.CodeInvalid, "unsupported payment method (use MOCK)"))
-		return
+	method := entity.PaymentMethodMock
+	if raw := strings.ToUpper(strings.TrimSpace(req.Method)); raw != "" {
+		m, ok := entity.PaymentMethodMap[raw]
+		if !ok {
+			RespondErr(ctx, errx.New(errx.CodeInvalid, "invalid payment method"))
+			return
+		}
+		method = m
 	}
 
-	uc := usecase.NewCreatePaymentUsecase(h.orderRepo, h.paymentRepo, h.uuid)
+	uc := usecase.NewCreatePaymentUsecase(h.orderRepo, h.paymentRepo, h.refundRepo, h.gateways, h.qr, h.tx, h.uuid, h.clock)
 
-	out, err := uc.Execute(ctx, usecase.CreatePaymentInput{
+	out, err := uc.Execute(ctx.Request.Context(), usecase.CreatePaymentInput{
 		OrderID:        orderID,
 		UserID:         userID,
-		Method:         entity.PaymentMethod(method),
+		Method:         method,
 		IdempotencyKey: strings.TrimSpace(req.IdempotencyKey),
 	})
 	if err != nil {
@@ -95,7 +119,7 @@ func (h *PaymentHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	p, err := h.paymentRepo.GetByID(ctx, paymentID)
+	p, err := h.paymentRepo.GetByID(ctx.Request.Context(), paymentID)
 	if err != nil {
 		RespondErr(ctx, err)
 		return
@@ -125,9 +149,9 @@ func (h *PaymentHandler) Confirm(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewConfirmPaymentUsecase(h.orderRepo, h.paymentRepo, h.uuid)
+	uc := usecase.NewConfirmPaymentUsecase(h.orderRepo, h.paymentRepo, h.refundRepo, h.gateways, h.tx, h.uuid, h.clock)
 
-	out, err := uc.Execute(ctx, usecase.ConfirmPaymentInput{
+	out, err := uc.Execute(ctx.Request.Context(), usecase.ConfirmPaymentInput{
 		PaymentID: paymentID,
 		UserID:    userID,
 	})
@@ -158,9 +182,9 @@ func (h *PaymentHandler) Fail(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewFailPaymentUsecase(h.paymentRepo, h.uuid)
+	uc := usecase.NewFailPaymentUsecase(h.paymentRepo, h.uuid, h.clock)
 
-	out, err := uc.Execute(ctx, usecase.FailPaymentInput{
+	out, err := uc.Execute(ctx.Request.Context(), usecase.FailPaymentInput{
 		PaymentID: paymentID,
 		UserID:    userID,
 		Reason:    strings.TrimSpace(req.Reason),
@@ -172,3 +196,79 @@ func (h *PaymentHandler) Fail(ctx *gin.Context) {
 
 	RespondOK(ctx, http.StatusOK, out)
 }
+
+type RefundPaymentRequest struct {
+	Amount int64  `json:"amount"` // centavos
+	Reason string `json:"reason"`
+	// para idempotência (opcional)
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+func (h *PaymentHandler) Refund(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	paymentID := strings.TrimSpace(ctx.Param("paymentId"))
+	if paymentID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing paymentId"))
+		return
+	}
+
+	var req RefundPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewRefundPaymentUsecase(h.orderRepo, h.paymentRepo, h.refundRepo, h.policy, h.gateways, h.tx, h.uuid, h.clock)
+
+	out, err := uc.Execute(ctx.Request.Context(), usecase.RefundPaymentInput{
+		PaymentID:      paymentID,
+		UserID:         userID,
+		Amount:         req.Amount,
+		Reason:         req.Reason,
+		IdempotencyKey: strings.TrimSpace(req.IdempotencyKey),
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusCreated, out)
+}
+
+// maxWebhookBody limita o corpo aceito dos providers.
+const maxWebhookBody = 1 << 20
+
+// Webhook recebe notificações do provider. A rota é pública: a autenticação é
+// a assinatura do corpo, conferida pelo gateway.
+func (h *PaymentHandler) Webhook(ctx *gin.Context) {
+	provider := strings.TrimSpace(ctx.Param("provider"))
+	if provider == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing provider"))
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxWebhookBody))
+	if err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "invalid webhook body"))
+		return
+	}
+
+	uc := usecase.NewHandlePaymentWebhookUsecase(h.orderRepo, h.paymentRepo, h.refundRepo, h.eventRepo, h.gateways, h.tx, h.uuid, h.clock)
+
+	out, err := uc.Execute(ctx.Request.Context(), usecase.HandlePaymentWebhookInput{
+		Provider: provider,
+		Payload:  payload,
+		Header:   ctx.Request.Header,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
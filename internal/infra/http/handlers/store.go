@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
@@ -18,21 +19,42 @@ type CreateStoreRequest struct {
 	Cnpj string `json:"cnpj"`
 }
 
+// UpdateStoreRequest é um PATCH: só os campos enviados são alterados.
+type UpdateStoreRequest struct {
+	Name     *string                  `json:"name"`
+	Slug     *string                  `json:"slug"`
+	IsOpen   *bool                    `json:"is_open"`
+	Phone    *string                  `json:"phone"`
+	LogoURL  *string                  `json:"logo_url"`
+	Address  *usecase.AddressDTO      `json:"address"`
+	Timezone *string                  `json:"timezone"`
+	Hours    *usecase.OpeningHoursDTO `json:"hours"`
+}
+
+// PauseStoreRequest: minutes ou until (RFC 3339).
+type PauseStoreRequest struct {
+	Minutes int        `json:"minutes"`
+	Until   *time.Time `json:"until"`
+}
+
 type StoreHandler struct {
 	storeRepo repository.StoreRepository
 	userRepo  repository.UserRepository
 	uuid      ports.UUIDInterface
+	clock     ports.Clock
 }
 
 func NewStoreHandler(
 	storeRepo repository.StoreRepository,
 	userRepo repository.UserRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *StoreHandler {
 	return &StoreHandler{
 		storeRepo: storeRepo,
 		userRepo:  userRepo,
 		uuid:      uuid,
+		clock:     clock,
 	}
 }
 
@@ -61,7 +83,7 @@ func (sh *StoreHandler) Create(ctx *gin.Context) {
 	}
 
 	uc := usecase.NewCreateStoreUsecase(sh.storeRepo, sh.userRepo, sh.uuid)
-	output, err := uc.Execute(ctx, usecase.CreateStoreInput{
+	output, err := uc.Execute(ctx.Request.Context(), usecase.CreateStoreInput{
 		Name:    req.Name,
 		Cnpj:    req.Cnpj,
 		OwnerID: userID,
@@ -83,8 +105,106 @@ func (sh *StoreHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewGetStoreByIDUsecase(sh.storeRepo, sh.uuid)
-	output, err := uc.Execute(ctx, usecase.GetStoreByIDInput{StoreID: storeID})
+	uc := usecase.NewGetStoreByIDUsecase(sh.storeRepo, sh.uuid, sh.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetStoreByIDInput{StoreID: storeID})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (sh *StoreHandler) GetBySlug(ctx *gin.Context) {
+	slug := strings.TrimSpace(ctx.Param("slug"))
+
+	if slug == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing store slug"))
+		return
+	}
+
+	uc := usecase.NewGetStoreBySlugUsecase(sh.storeRepo, sh.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetStoreBySlugInput{Slug: slug})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (sh *StoreHandler) Update(ctx *gin.Context) {
+	storeID := strings.TrimSpace(ctx.Param("storeId"))
+
+	if storeID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing store id"))
+		return
+	}
+
+	var req UpdateStoreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	uc := usecase.NewUpdateStoreUsecase(sh.storeRepo, sh.uuid, sh.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.UpdateStoreInput{
+		StoreID:  storeID,
+		Name:     req.Name,
+		Slug:     req.Slug,
+		IsOpen:   req.IsOpen,
+		Phone:    req.Phone,
+		LogoURL:  req.LogoURL,
+		Address:  req.Address,
+		Timezone: req.Timezone,
+		Hours:    req.Hours,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (sh *StoreHandler) Pause(ctx *gin.Context) {
+	storeID := strings.TrimSpace(ctx.Param("storeId"))
+
+	if storeID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing store id"))
+		return
+	}
+
+	var req PauseStoreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewPauseStoreUsecase(sh.storeRepo, sh.uuid, sh.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.PauseStoreInput{
+		StoreID: storeID,
+		Minutes: req.Minutes,
+		Until:   req.Until,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (sh *StoreHandler) Resume(ctx *gin.Context) {
+	storeID := strings.TrimSpace(ctx.Param("storeId"))
+
+	if storeID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing store id"))
+		return
+	}
+
+	uc := usecase.NewResumeStoreUsecase(sh.storeRepo, sh.uuid, sh.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetStoreByIDInput{StoreID: storeID})
 	if err != nil {
 		RespondErr(ctx, err)
 		return
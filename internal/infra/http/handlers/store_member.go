@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	usecase "github.com/FabioRocha231/saas-core/internal/usecase/store_member"
+	"github.com/gin-gonic/gin"
+)
+
+type StoreMemberHandler struct {
+	memberRepo     repository.StoreMemberRepository
+	invitationRepo repository.StoreInvitationRepository
+	userRepo       repository.UserRepository
+	policy         *policy.Policy
+	sender         ports.MessageSender
+	token          ports.TokenInterface
+	uuid           ports.UUIDInterface
+	tx             ports.TxManager
+	clock          ports.Clock
+	invitationTTL  time.Duration
+}
+
+type InviteStoreMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+func NewStoreMemberHandler(
+	memberRepo repository.StoreMemberRepository,
+	invitationRepo repository.StoreInvitationRepository,
+	userRepo repository.UserRepository,
+	authz *policy.Policy,
+	sender ports.MessageSender,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	invitationTTL time.Duration,
+) *StoreMemberHandler {
+	return &StoreMemberHandler{
+		memberRepo:     memberRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		policy:         authz,
+		sender:         sender,
+		token:          token,
+		uuid:           uuid,
+		tx:             tx,
+		clock:          clock,
+		invitationTTL:  invitationTTL,
+	}
+}
+
+// staffInput lê o usuário logado e o storeId da rota.
+func staffInput(ctx *gin.Context) (usecase.StoreStaffInput, error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return usecase.StoreStaffInput{}, err
+	}
+	storeID := strings.TrimSpace(ctx.Param("storeId"))
+	if storeID == "" {
+		return usecase.StoreStaffInput{}, errx.New(errx.CodeInvalid, "missing storeId")
+	}
+	return usecase.StoreStaffInput{StoreID: storeID, UserID: userID}, nil
+}
+
+func (h *StoreMemberHandler) Invite(ctx *gin.Context) {
+	in, err := staffInput(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	var req InviteStoreMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewInviteStoreMemberUsecase(h.invitationRepo, h.policy, h.sender, h.token, h.uuid, h.clock, h.invitationTTL)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.InviteStoreMemberInput{
+		StoreID: in.StoreID,
+		UserID:  in.UserID,
+		Email:   req.Email,
+		Role:    req.Role,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusCreated, out)
+}
+
+func (h *StoreMemberHandler) List(ctx *gin.Context) {
+	in, err := staffInput(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	uc := usecase.NewListStoreStaffUsecase(h.memberRepo, h.invitationRepo, h.userRepo, h.policy, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), in)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
+
+func (h *StoreMemberHandler) RevokeInvitation(ctx *gin.Context) {
+	in, err := staffInput(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+	invitationID := strings.TrimSpace(ctx.Param("invitationId"))
+	if invitationID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing invitationId"))
+		return
+	}
+
+	uc := usecase.NewRevokeInvitationUsecase(h.invitationRepo, h.policy, h.clock)
+	if err := uc.Execute(ctx.Request.Context(), usecase.RevokeInvitationInput{StoreStaffInput: in, InvitationID: invitationID}); err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *StoreMemberHandler) RemoveMember(ctx *gin.Context) {
+	in, err := staffInput(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+	memberUserID := strings.TrimSpace(ctx.Param("userId"))
+	if memberUserID == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing userId"))
+		return
+	}
+
+	uc := usecase.NewRemoveMemberUsecase(h.memberRepo, h.policy)
+	if err := uc.Execute(ctx.Request.Context(), usecase.RemoveMemberInput{StoreStaffInput: in, MemberUserID: memberUserID}); err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *StoreMemberHandler) Accept(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	var req AcceptInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "token are required"))
+		return
+	}
+
+	uc := usecase.NewAcceptInvitationUsecase(h.invitationRepo, h.memberRepo, h.userRepo, h.token, h.uuid, h.tx, h.clock)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.AcceptInvitationInput{
+		Token:  strings.TrimSpace(req.Token),
+		UserID: userID,
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
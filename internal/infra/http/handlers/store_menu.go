@@ -15,6 +15,7 @@ type StoreMenuHandler struct {
 	storeRepository     repository.StoreRepository
 	storeMenuRepository repository.StoreMenuRepository
 	uuid                ports.UUIDInterface
+	clock               ports.Clock
 }
 
 type CreateStoreMenuRequest struct {
@@ -26,11 +27,13 @@ func NewStoreMenuHandler(
 	storeRepo repository.StoreRepository,
 	storeMenuRepo repository.StoreMenuRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *StoreMenuHandler {
 	return &StoreMenuHandler{
 		storeRepository:     storeRepo,
 		storeMenuRepository: storeMenuRepo,
 		uuid:                uuid,
+		clock:               clock,
 	}
 }
 
@@ -53,8 +56,8 @@ func (smh *StoreMenuHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	uc := usecase.NewCreateStoreMenuUsecase(smh.storeRepository, smh.storeMenuRepository, smh.uuid)
-	output, err := uc.Execute(ctx, usecase.CreateStoreMenuInput{Name: req.Name, StoreID: storeId})
+	uc := usecase.NewCreateStoreMenuUsecase(smh.storeRepository, smh.storeMenuRepository, smh.uuid, smh.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.CreateStoreMenuInput{Name: req.Name, StoreID: storeId})
 
 	if err != nil {
 		RespondErr(ctx, err)
@@ -73,7 +76,7 @@ func (smh *StoreMenuHandler) GetByID(ctx *gin.Context) {
 	}
 
 	uc := usecase.NewGetStoreMenuByIDUsecase(smh.storeMenuRepository, smh.uuid)
-	output, err := uc.Execute(ctx, usecase.GetStoreMenuByIDInput{StoreMenuID: id})
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetStoreMenuByIDInput{StoreMenuID: id})
 
 	if err != nil {
 		RespondErr(ctx, err)
@@ -92,7 +95,7 @@ func (smh *StoreMenuHandler) ListByStoreID(ctx *gin.Context) {
 	}
 
 	uc := usecase.NewListStoreMenuByStoreIDUsecase(smh.storeMenuRepository, smh.uuid)
-	output, err := uc.Execute(ctx, usecase.ListStoreMenuByStoreIDInput{StoreID: storeId})
+	output, err := uc.Execute(ctx.Request.Context(), usecase.ListStoreMenuByStoreIDInput{StoreID: storeId})
 
 	if err != nil {
 		RespondErr(ctx, err)
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	usecase "github.com/FabioRocha231/saas-core/internal/usecase/storefront"
+	"github.com/gin-gonic/gin"
+)
+
+// curto de propósito: is_open muda com o horário e a pausa da loja
+const storefrontMaxAge = time.Minute
+
+// StorefrontHandler serve a vitrine pública (sem login), só leitura.
+type StorefrontHandler struct {
+	storeRepo         repository.StoreRepository
+	menuRepo          repository.StoreMenuRepository
+	categoryRepo      repository.MenuCategoryRepository
+	itemRepo          repository.CategoryItemRepository
+	addonGroupRepo    repository.ItemAddonGroupRepository
+	addonOptionRepo   repository.AddonOptionRepository
+	variantGroupRepo  repository.ItemVariantGroupRepository
+	variantOptionRepo repository.VariantOptionRepository
+	clock             ports.Clock
+}
+
+func NewStorefrontHandler(
+	storeRepo repository.StoreRepository,
+	menuRepo repository.StoreMenuRepository,
+	categoryRepo repository.MenuCategoryRepository,
+	itemRepo repository.CategoryItemRepository,
+	addonGroupRepo repository.ItemAddonGroupRepository,
+	addonOptionRepo repository.AddonOptionRepository,
+	variantGroupRepo repository.ItemVariantGroupRepository,
+	variantOptionRepo repository.VariantOptionRepository,
+	clock ports.Clock,
+) *StorefrontHandler {
+	return &StorefrontHandler{
+		storeRepo:         storeRepo,
+		menuRepo:          menuRepo,
+		categoryRepo:      categoryRepo,
+		itemRepo:          itemRepo,
+		addonGroupRepo:    addonGroupRepo,
+		addonOptionRepo:   addonOptionRepo,
+		variantGroupRepo:  variantGroupRepo,
+		variantOptionRepo: variantOptionRepo,
+		clock:             clock,
+	}
+}
+
+func (h *StorefrontHandler) GetStore(ctx *gin.Context) {
+	slug := strings.TrimSpace(ctx.Param("slug"))
+	if slug == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing store slug"))
+		return
+	}
+
+	uc := usecase.NewGetStoreUsecase(h.storeRepo, h.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetStoreInput{Slug: slug})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondCached(ctx, output, storefrontMaxAge)
+}
+
+func (h *StorefrontHandler) GetMenu(ctx *gin.Context) {
+	slug := strings.TrimSpace(ctx.Param("slug"))
+	if slug == "" {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, "missing store slug"))
+		return
+	}
+
+	uc := usecase.NewGetMenuUsecase(
+		h.storeRepo,
+		h.menuRepo,
+		h.categoryRepo,
+		h.itemRepo,
+		h.addonGroupRepo,
+		h.addonOptionRepo,
+		h.variantGroupRepo,
+		h.variantOptionRepo,
+		h.clock,
+	)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetStoreInput{Slug: slug})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondCached(ctx, output, storefrontMaxAge)
+}
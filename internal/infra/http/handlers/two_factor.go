@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	usecase "github.com/FabioRocha231/saas-core/internal/usecase/two_factor"
+	"github.com/gin-gonic/gin"
+)
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type TwoFactorHandler struct {
+	twoFactorRepo repository.TwoFactorRepository
+	userRepo      repository.UserRepository
+	passwordHash  ports.PasswordHashInterface
+	totp          ports.TOTPInterface
+	token         ports.TokenInterface
+	uuid          ports.UUIDInterface
+	tx            ports.TxManager
+	clock         ports.Clock
+	issuer        string
+	requiredRoles []entity.UserRole
+}
+
+func NewTwoFactorHandler(
+	twoFactorRepo repository.TwoFactorRepository,
+	userRepo repository.UserRepository,
+	passwordHash ports.PasswordHashInterface,
+	totp ports.TOTPInterface,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	issuer string,
+	requiredRoles []entity.UserRole,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorRepo: twoFactorRepo,
+		userRepo:      userRepo,
+		passwordHash:  passwordHash,
+		totp:          totp,
+		token:         token,
+		uuid:          uuid,
+		tx:            tx,
+		clock:         clock,
+		issuer:        issuer,
+		requiredRoles: requiredRoles,
+	}
+}
+
+func (h *TwoFactorHandler) checker() *usecase.Checker {
+	return usecase.NewChecker(h.twoFactorRepo, h.totp, h.token, h.clock)
+}
+
+func (h *TwoFactorHandler) Status(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	output, err := usecase.NewStatusUsecase(h.twoFactorRepo).Execute(ctx.Request.Context(), userID)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+// Setup devolve o segredo e o otpauth:// para o QR code; o 2FA só vale depois
+// do Enable.
+func (h *TwoFactorHandler) Setup(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	uc := usecase.NewSetupUsecase(h.twoFactorRepo, h.userRepo, h.totp, h.issuer)
+	output, err := uc.Execute(ctx.Request.Context(), userID)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (h *TwoFactorHandler) Enable(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	var req VerifyCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewEnableUsecase(h.twoFactorRepo, h.checker(), h.token, h.uuid, h.tx, h.clock)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.CodeInput{UserID: userID, Code: req.Code})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	var req VerifyCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewRegenerateRecoveryCodesUsecase(h.twoFactorRepo, h.checker(), h.token, h.uuid, h.tx)
+	output, err := uc.Execute(ctx.Request.Context(), usecase.CodeInput{UserID: userID, Code: req.Code})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, output)
+}
+
+func (h *TwoFactorHandler) Disable(ctx *gin.Context) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	var req DisableTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewDisableUsecase(h.twoFactorRepo, h.userRepo, h.checker(), h.passwordHash, h.tx, h.requiredRoles)
+	err = uc.Execute(ctx.Request.Context(), usecase.DisableInput{UserID: userID, Password: req.Password, Code: req.Code})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
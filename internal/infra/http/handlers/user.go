@@ -26,6 +26,7 @@ type UserHandler struct {
 	storeRepo    portsRepository.StoreRepository
 	uuid         ports.UUIDInterface
 	passwordHash ports.PasswordHashInterface
+	clock        ports.Clock
 }
 
 func NewUserHandler(
@@ -33,12 +34,14 @@ func NewUserHandler(
 	storeRepo portsRepository.StoreRepository,
 	uuid ports.UUIDInterface,
 	passwordHash ports.PasswordHashInterface,
+	clock ports.Clock,
 ) *UserHandler {
 	return &UserHandler{
 		userRepo:     userRepo,
 		storeRepo:    storeRepo,
 		uuid:         uuid,
 		passwordHash: passwordHash,
+		clock:        clock,
 	}
 }
 
@@ -89,8 +92,8 @@ func (h *UserHandler) Create(ctx *gin.Context) {
 		UserType: strings.TrimSpace(req.UserType),
 	}
 
-	uc := usecase.NewCreateUserUsecase(h.userRepo, h.storeRepo, h.uuid, h.passwordHash)
-	usecaseOutput, err := uc.Execute(ctx, createUserInput)
+	uc := usecase.NewCreateUserUsecase(h.userRepo, h.storeRepo, h.uuid, h.passwordHash, h.clock)
+	usecaseOutput, err := uc.Execute(ctx.Request.Context(), createUserInput)
 	if err != nil {
 		RespondErr(ctx, err)
 		return
@@ -108,7 +111,7 @@ func (h *UserHandler) GetByID(ctx *gin.Context) {
 	}
 	uc := usecase.NewGetUserByIdUsecase(h.userRepo, h.uuid)
 
-	output, err := uc.Execute(ctx, usecase.GetUserByIdInput{ID: id})
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetUserByIdInput{ID: id})
 	if err != nil {
 		RespondErr(ctx, err)
 		return
@@ -127,7 +130,7 @@ func (h *UserHandler) GetByEmail(ctx *gin.Context) {
 
 	uc := usecase.NewGetUserByEmailUsecase(h.userRepo, h.uuid)
 
-	output, err := uc.Execute(ctx, usecase.GetUserByEmailInput{Email: email})
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetUserByEmailInput{Email: email})
 	if err != nil {
 		RespondErr(ctx, err)
 		return
@@ -146,7 +149,7 @@ func (h *UserHandler) GetByCpf(ctx *gin.Context) {
 
 	uc := usecase.NewGetUserByCpfUsecase(h.userRepo, h.uuid)
 
-	output, err := uc.Execute(ctx, usecase.GetUserByCpfInput{Cpf: cpf})
+	output, err := uc.Execute(ctx.Request.Context(), usecase.GetUserByCpfInput{Cpf: cpf})
 
 	if err != nil {
 		RespondErr(ctx, err)
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	usecase "github.com/FabioRocha231/saas-core/internal/usecase/verification"
+	"github.com/gin-gonic/gin"
+)
+
+type VerificationHandler struct {
+	codeRepo repository.VerificationCodeRepository
+	userRepo repository.UserRepository
+	sender   ports.MessageSender
+	token    ports.TokenInterface
+	uuid     ports.UUIDInterface
+	tx       ports.TxManager
+	clock    ports.Clock
+	settings usecase.Settings
+}
+
+type VerifyCodeRequest struct {
+	Code string `json:"code"`
+}
+
+func NewVerificationHandler(
+	codeRepo repository.VerificationCodeRepository,
+	userRepo repository.UserRepository,
+	sender ports.MessageSender,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	settings usecase.Settings,
+) *VerificationHandler {
+	return &VerificationHandler{
+		codeRepo: codeRepo,
+		userRepo: userRepo,
+		sender:   sender,
+		token:    token,
+		uuid:     uuid,
+		tx:       tx,
+		clock:    clock,
+		settings: settings,
+	}
+}
+
+func (h *VerificationHandler) SendEmailCode(ctx *gin.Context) {
+	h.send(ctx, entity.VerificationPurposeEmail)
+}
+
+func (h *VerificationHandler) SendPhoneCode(ctx *gin.Context) {
+	h.send(ctx, entity.VerificationPurposePhone)
+}
+
+func (h *VerificationHandler) VerifyEmail(ctx *gin.Context) {
+	h.verify(ctx, entity.VerificationPurposeEmail)
+}
+
+func (h *VerificationHandler) VerifyPhone(ctx *gin.Context) {
+	h.verify(ctx, entity.VerificationPurposePhone)
+}
+
+func (h *VerificationHandler) send(ctx *gin.Context, purpose entity.VerificationPurpose) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	uc := usecase.NewSendCodeUsecase(h.codeRepo, h.userRepo, h.sender, h.token, h.uuid, h.clock, h.settings)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.SendCodeInput{UserID: userID, Purpose: purpose})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusAccepted, out)
+}
+
+func (h *VerificationHandler) verify(ctx *gin.Context, purpose entity.VerificationPurpose) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	var req VerifyCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		RespondErr(ctx, errx.New(errx.CodeInvalid, err.Error()))
+		return
+	}
+
+	uc := usecase.NewVerifyCodeUsecase(h.codeRepo, h.userRepo, h.token, h.tx, h.clock, h.settings)
+	out, err := uc.Execute(ctx.Request.Context(), usecase.VerifyCodeInput{
+		UserID:  userID,
+		Purpose: purpose,
+		Code:    strings.TrimSpace(req.Code),
+	})
+	if err != nil {
+		RespondErr(ctx, err)
+		return
+	}
+
+	RespondOK(ctx, http.StatusOK, out)
+}
@@ -5,7 +5,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-const CtxUserIDKey = "user_id"
+const (
+	CtxUserIDKey    = "user_id"
+	CtxSessionIDKey = "session_id"
+)
 
 func GetUserIDFromContext(c *gin.Context) (string, error) {
 	v, ok := c.Get(CtxUserIDKey)
@@ -18,3 +21,15 @@ func GetUserIDFromContext(c *gin.Context) (string, error) {
 	}
 	return s, nil
 }
+
+func GetSessionIDFromContext(c *gin.Context) (string, error) {
+	v, ok := c.Get(CtxSessionIDKey)
+	if !ok {
+		return "", errx.New(errx.CodeUnauthorized, "missing session")
+	}
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", errx.New(errx.CodeUnauthorized, "invalid session")
+	}
+	return s, nil
+}
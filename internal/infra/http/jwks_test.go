@@ -0,0 +1,54 @@
+package http
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKS(t *testing.T) {
+	dir := t.TempDir()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2026-01.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	t.Setenv("JWT_KEYS_DIR", dir)
+
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	t.Run("test jwks lists the public signing key", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var jwks ports.JWKS
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jwks))
+		require.Len(t, jwks.Keys, 1)
+		require.Equal(t, "2026-01", jwks.Keys[0].Kid)
+		require.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	})
+
+	t.Run("test login issues EdDSA tokens with the kid", func(t *testing.T) {
+		token := loginSeedUser(t, engine)
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+		require.NoError(t, err)
+		require.Equal(t, "EdDSA", parsed.Header["alg"])
+		require.Equal(t, "2026-01", parsed.Header["kid"])
+
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/sessions", token, nil, nil))
+	})
+}
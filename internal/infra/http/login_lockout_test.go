@@ -0,0 +1,56 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginLockout(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "2")
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	login := func(email, password string) int {
+		return doJSON(t, engine, http.MethodPost, "/login", "", map[string]string{"email": email, "password": password}, nil)
+	}
+
+	t.Run("test unknown email and wrong password answer the same", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, login("ninguem@example.com", "123456"))
+	})
+
+	t.Run("test account is locked after repeated failures", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, login("teste@gmail.com", "errada"))
+		require.Equal(t, http.StatusUnauthorized, login("teste@gmail.com", "errada"))
+		require.Equal(t, http.StatusTooManyRequests, login("teste@gmail.com", "123456"))
+	})
+
+	t.Run("test forged X-Forwarded-For does not reset the ip counter", func(t *testing.T) {
+		t.Setenv("LOGIN_MAX_FAILURES", "100")
+		t.Setenv("LOGIN_IP_MAX_FAILURES", "3")
+		engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+		loginFrom := func(forwardedFor, email, password string) int {
+			body, err := json.Marshal(map[string]string{"email": email, "password": password})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		for i := 0; i < 3; i++ {
+			code := loginFrom(fmt.Sprintf("203.0.113.%d", i+1), fmt.Sprintf("alvo%d@example.com", i), "errada")
+			require.Equal(t, http.StatusUnauthorized, code)
+		}
+		// IP novo no cabeçalho, mesma conexão: continua travado
+		require.Equal(t, http.StatusTooManyRequests, loginFrom("198.51.100.7", "teste@gmail.com", "123456"))
+	})
+}
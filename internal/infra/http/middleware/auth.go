@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"strings"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	"github.com/FabioRocha231/saas-core/internal/infra/http/handlers"
@@ -14,15 +13,17 @@ import (
 type AuthMiddleware struct {
 	jwtService  ports.JwtInterface
 	sessionRepo repository.SessionRepository
+	clock       ports.Clock
 }
 
-func NewAuthMiddleware(jwtService ports.JwtInterface, sessionRepo repository.SessionRepository) *AuthMiddleware {
-	return &AuthMiddleware{jwtService: jwtService, sessionRepo: sessionRepo}
+func NewAuthMiddleware(jwtService ports.JwtInterface, sessionRepo repository.SessionRepository, clock ports.Clock) *AuthMiddleware {
+	return &AuthMiddleware{jwtService: jwtService, sessionRepo: sessionRepo, clock: clock}
 }
 
 const (
-	CtxUserIDKey = "user_id"
-	CtxRoleKey   = "user_role"
+	CtxUserIDKey    = "user_id"
+	CtxRoleKey      = "user_role"
+	CtxSessionIDKey = "session_id"
 )
 
 func (m *AuthMiddleware) Middleware(c *gin.Context) {
@@ -54,8 +55,7 @@ func (m *AuthMiddleware) Middleware(c *gin.Context) {
 		return
 	}
 
-	now := time.Now()
-	if !session.ExpiresAt.After(now) {
+	if !session.ExpiresAt.After(m.clock.Now()) {
 		handlers.RespondErr(c, errx.New(errx.CodeUnauthorized, "session expired"))
 		c.Abort()
 		return
@@ -63,5 +63,6 @@ func (m *AuthMiddleware) Middleware(c *gin.Context) {
 
 	c.Set(CtxUserIDKey, claims.UserID)
 	c.Set(CtxRoleKey, claims.Role)
+	c.Set(CtxSessionIDKey, session.ID)
 	c.Next()
 }
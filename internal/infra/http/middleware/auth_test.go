@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/response"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	sessionRepo := memorysession.New(clock)
+	jwtService := pkg.NewJwtService(testkit.NewJwtKeyset(t), 24*time.Hour, "saas-core", pkg.NewUUID(), clock)
+
+	engine := gin.New()
+	engine.GET("/me", NewAuthMiddleware(jwtService, sessionRepo, clock).Middleware, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetString(CtxUserIDKey)})
+	})
+
+	type body struct {
+		UserID string              `json:"user_id"`
+		Error  *response.ErrorBody `json:"error"`
+	}
+	do := func(t *testing.T, authorization string) (int, body) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		var b body
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &b))
+		return rec.Code, b
+	}
+
+	// a sessão tem validade própria, independente do exp do token
+	login := func(t *testing.T, sessionTTL time.Duration) string {
+		t.Helper()
+		token, err := jwtService.Sign("user-1", "customer")
+		require.NoError(t, err)
+		claims, err := jwtService.Parse(token)
+		require.NoError(t, err)
+		require.NoError(t, sessionRepo.Create(t.Context(), &entity.Session{
+			ID:        claims.ID,
+			UserID:    claims.UserID,
+			Role:      claims.Role,
+			ExpiresAt: clock.Now().Add(sessionTTL),
+		}))
+		return "Bearer " + token
+	}
+
+	t.Run("test missing authorization header", func(t *testing.T) {
+		code, body := do(t, "")
+		require.Equal(t, http.StatusUnauthorized, code)
+		require.Equal(t, "unauthorized", body.Error.Message)
+	})
+
+	t.Run("test malformed authorization header", func(t *testing.T) {
+		code, body := do(t, "Basic abc")
+		require.Equal(t, http.StatusUnauthorized, code)
+		require.Equal(t, "invalid auth header", body.Error.Message)
+	})
+
+	t.Run("test token without session", func(t *testing.T) {
+		token, err := jwtService.Sign("user-1", "customer")
+		require.NoError(t, err)
+
+		code, body := do(t, "Bearer "+token)
+		require.Equal(t, http.StatusUnauthorized, code)
+		require.Equal(t, "invalid session", body.Error.Message)
+	})
+
+	t.Run("test valid session passes", func(t *testing.T) {
+		code, body := do(t, login(t, time.Hour))
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "user-1", body.UserID)
+	})
+
+	t.Run("test session expires when the clock passes expires_at", func(t *testing.T) {
+		auth := login(t, time.Hour)
+
+		clock.Advance(59 * time.Minute)
+		code, _ := do(t, auth)
+		require.Equal(t, http.StatusOK, code)
+
+		clock.Advance(time.Minute)
+		code, body := do(t, auth)
+		require.Equal(t, http.StatusUnauthorized, code)
+		require.Equal(t, "session expired", body.Error.Message)
+	})
+
+	t.Run("test token expires with the same clock", func(t *testing.T) {
+		auth := login(t, 48*time.Hour)
+
+		clock.Advance(24*time.Hour + time.Second)
+		code, body := do(t, auth)
+		require.Equal(t, http.StatusUnauthorized, code)
+		require.Equal(t, "invalid token", body.Error.Message)
+	})
+}
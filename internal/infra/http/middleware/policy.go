@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/handlers"
+	"github.com/FabioRocha231/saas-core/internal/infra/http/helper"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	"github.com/gin-gonic/gin"
+)
+
+// PolicyMiddleware barra a request antes do handler quando o usuário autenticado
+// não pode executar a ação. Precisa rodar depois do AuthMiddleware.
+type PolicyMiddleware struct {
+	policy *policy.Policy
+}
+
+func NewPolicyMiddleware(p *policy.Policy) *PolicyMiddleware {
+	return &PolicyMiddleware{policy: p}
+}
+
+// Require checa ações que não dependem de loja.
+func (m *PolicyMiddleware) Require(action policy.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m.authorize(c, action, "")
+	}
+}
+
+// RequireStore resolve a loja a partir do parâmetro da rota (ex.: menuId → loja)
+// e checa a ação nela.
+func (m *PolicyMiddleware) RequireStore(action policy.Action, param string, lookup policy.StoreLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := strings.TrimSpace(c.Param(param))
+		if id == "" {
+			handlers.RespondErr(c, errx.F(errx.CodeInvalid, "missing %s", param))
+			c.Abort()
+			return
+		}
+
+		storeID, err := lookup(c.Request.Context(), id)
+		if err != nil {
+			handlers.RespondErr(c, err)
+			c.Abort()
+			return
+		}
+		m.authorize(c, action, storeID)
+	}
+}
+
+func (m *PolicyMiddleware) authorize(c *gin.Context, action policy.Action, storeID string) {
+	userID, err := helper.GetUserIDFromContext(c)
+	if err != nil {
+		handlers.RespondErr(c, err)
+		c.Abort()
+		return
+	}
+
+	if err := m.policy.Authorize(c.Request.Context(), userID, action, storeID); err != nil {
+		handlers.RespondErr(c, err)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
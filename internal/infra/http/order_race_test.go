@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type testOrder struct {
+	ID      string `json:"id"`
+	Version int64  `json:"version"`
+	Items   []struct {
+		ID   string `json:"id"`
+		Note string `json:"note"`
+	} `json:"items"`
+}
+
+func newTestServer(t *testing.T, cfg db.Config) *gin.Engine {
+	t.Helper()
+	t.Setenv("APP_ENV", "dev")
+	t.Setenv("JWT_SECRET", "test-secret")
+	// só os testes de 2FA ligam a exigência; os demais logam com senha
+	if _, ok := os.LookupEnv("TWO_FACTOR_REQUIRED_ROLES"); !ok {
+		t.Setenv("TWO_FACTOR_REQUIRED_ROLES", "none")
+	}
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	app, err := RegisterRoutes(engine, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = app.Repos.Close() })
+	return engine
+}
+
+func doJSON(t *testing.T, engine *gin.Engine, method, path, token string, body any, out any) int {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	if out != nil && rec.Code < 300 {
+		envelope := struct {
+			Data json.RawMessage `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+		require.NoError(t, json.Unmarshal(envelope.Data, out))
+	}
+	return rec.Code
+}
+
+func loginSeedUser(t *testing.T, engine *gin.Engine) string {
+	t.Helper()
+
+	var out struct {
+		Token string `json:"token"`
+	}
+	code := doJSON(t, engine, http.MethodPost, "/login", "", map[string]string{
+		"email":    "teste@gmail.com",
+		"password": "123456",
+	}, &out)
+	require.Equal(t, http.StatusOK, code)
+	require.NotEmpty(t, out.Token)
+	return out.Token
+}
+
+func TestConcurrentAddItem(t *testing.T) {
+	drivers := map[string]func(t *testing.T) db.Config{
+		"memory": func(t *testing.T) db.Config { return db.Config{Driver: db.DriverMemory} },
+		"sqlite": func(t *testing.T) db.Config {
+			return db.Config{Driver: db.DriverSQLite, SQLitePath: filepath.Join(t.TempDir(), "race.db")}
+		},
+	}
+
+	for name, cfg := range drivers {
+		t.Run("test parallel add item does not drop lines with "+name, func(t *testing.T) {
+			engine := newTestServer(t, cfg(t))
+			token := loginSeedUser(t, engine)
+
+			var draft testOrder
+			code := doJSON(t, engine, http.MethodPost, "/store/"+seed.SeedStoreID+"/order", token, nil, &draft)
+			require.Equal(t, http.StatusCreated, code)
+
+			const workers = 20
+			var (
+				wg       sync.WaitGroup
+				mu       sync.Mutex
+				statuses = map[int]int{}
+			)
+			for i := range workers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					// nota distinta: cada request deve virar uma linha nova
+					code := doJSON(t, engine, http.MethodPost, "/order/"+draft.ID+"/item", token, map[string]any{
+						"item_id": seed.SeedItemCoke,
+						"qty":     1,
+						"note":    "request " + strconv.Itoa(i),
+					}, nil)
+					mu.Lock()
+					statuses[code]++
+					mu.Unlock()
+				}()
+			}
+			wg.Wait()
+
+			for code := range statuses {
+				require.Contains(t, []int{http.StatusOK, http.StatusConflict}, code)
+			}
+
+			var got testOrder
+			code = doJSON(t, engine, http.MethodGet, "/order/"+draft.ID, token, nil, &got)
+			require.Equal(t, http.StatusOK, code)
+
+			// toda resposta 200 tem que estar no pedido; nenhuma escrita se perde
+			require.Len(t, got.Items, statuses[http.StatusOK])
+			require.Equal(t, int64(1+statuses[http.StatusOK]), got.Version)
+		})
+	}
+}
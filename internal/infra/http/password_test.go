@@ -0,0 +1,90 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+var outboxToken = regexp.MustCompile(`token (\S+) `)
+
+// lastEmailedToken lê do outbox o último token (redefinição de senha, convite)
+// enviado para to.
+func lastEmailedToken(t *testing.T, dir, to string) string {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join(dir, "outbox.jsonl"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var token string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg struct {
+			To   string `json:"to"`
+			Body string `json:"body"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		if m := outboxToken.FindStringSubmatch(msg.Body); msg.To == to && m != nil {
+			token = m[1]
+		}
+	}
+	require.NoError(t, scanner.Err())
+	require.NotEmpty(t, token, "no token sent to %s", to)
+	return token
+}
+
+func TestPasswordRoutes(t *testing.T) {
+	outbox := t.TempDir()
+	t.Setenv("MESSAGE_SENDER", "file")
+	t.Setenv("MESSAGE_OUTBOX_DIR", outbox)
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	email := "senha@example.com"
+	token := signUp(t, engine, email, "23756676030", "customer")
+
+	login := func(password string) int {
+		return doJSON(t, engine, http.MethodPost, "/login", "", map[string]string{"email": email, "password": password}, nil)
+	}
+
+	t.Run("test forgot password answers the same for unknown emails", func(t *testing.T) {
+		require.Equal(t, http.StatusAccepted, doJSON(t, engine, http.MethodPost, "/auth/forgot-password", "", map[string]string{"email": "ninguem@example.com"}, nil))
+	})
+
+	t.Run("test reset password with the emailed token", func(t *testing.T) {
+		require.Equal(t, http.StatusAccepted, doJSON(t, engine, http.MethodPost, "/auth/forgot-password", "", map[string]string{"email": email}, nil))
+
+		reset := map[string]string{"email": email, "token": lastEmailedToken(t, outbox, email), "new_password": "nova-senha"}
+		require.Equal(t, http.StatusNoContent, doJSON(t, engine, http.MethodPost, "/auth/reset-password", "", reset, nil))
+		require.Equal(t, http.StatusBadRequest, doJSON(t, engine, http.MethodPost, "/auth/reset-password", "", reset, nil))
+
+		// sessões antigas caem
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodGet, "/sessions", token, nil, nil))
+		require.Equal(t, http.StatusUnauthorized, login("123456"))
+		require.Equal(t, http.StatusOK, login("nova-senha"))
+	})
+
+	t.Run("test change password of the logged user", func(t *testing.T) {
+		var out struct {
+			Token string `json:"token"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login", "", map[string]string{"email": email, "password": "nova-senha"}, &out))
+
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPatch, "/user/me/password", out.Token, map[string]string{
+			"current_password": "errada", "new_password": "outra-senha",
+		}, nil))
+		require.Equal(t, http.StatusNoContent, doJSON(t, engine, http.MethodPatch, "/user/me/password", out.Token, map[string]string{
+			"current_password": "nova-senha", "new_password": "outra-senha",
+		}, nil))
+
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodGet, "/sessions", out.Token, nil, nil))
+		require.Equal(t, http.StatusOK, login("outra-senha"))
+	})
+}
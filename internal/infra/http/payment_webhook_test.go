@@ -0,0 +1,83 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	mockgateway "github.com/FabioRocha231/saas-core/internal/infra/payment/mock"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "whsec-test"
+
+// stubProvider faz o papel do PSP: assina o evento e chama a rota pública.
+func stubProvider(t *testing.T, engine *gin.Engine, payload string, secret string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/payments/mock", bytes.NewBufferString(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(mockgateway.SignatureHeader, mockgateway.SignWebhook(secret, []byte(payload), time.Now()))
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	t.Setenv("PAYMENT_MOCK_WEBHOOK_SECRET", testWebhookSecret)
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+	token := loginSeedUser(t, engine)
+
+	var draft testOrder
+	require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, "/store/"+seed.SeedStoreID+"/order", token, nil, &draft))
+	require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/order/"+draft.ID+"/item", token, map[string]any{
+		"item_id": seed.SeedItemCoke,
+		"qty":     1,
+	}, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPatch, "/order/"+draft.ID+"/place", token, nil, nil))
+
+	var created struct {
+		Payment struct {
+			ID          string `json:"id"`
+			Status      string `json:"status"`
+			Amount      int64  `json:"amount"`
+			ProviderRef string `json:"provider_ref"`
+		} `json:"payment"`
+	}
+	require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, "/order/"+draft.ID+"/payments", token, map[string]string{
+		"method": "PIX",
+	}, &created))
+	require.Equal(t, "PENDING", created.Payment.Status)
+
+	payload := `{"id":"evt-1","payment_ref":"` + created.Payment.ProviderRef + `","status":"PAID"}`
+
+	t.Run("test webhook signed with another secret is rejected", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, stubProvider(t, engine, payload, "wrong-secret"))
+	})
+
+	t.Run("test signed webhook marks the order as paid", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, stubProvider(t, engine, payload, testWebhookSecret))
+
+		var order struct {
+			Status string `json:"status"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/order/"+draft.ID, token, nil, &order))
+		require.Equal(t, "PAID", order.Status)
+
+		// reenvio do mesmo evento continua 200
+		require.Equal(t, http.StatusOK, stubProvider(t, engine, payload, testWebhookSecret))
+	})
+
+	t.Run("test webhook for an unknown provider", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/payments/acme", bytes.NewBufferString(payload))
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
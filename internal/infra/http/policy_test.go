@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func signUp(t *testing.T, engine *gin.Engine, email, cpf, userType string) string {
+	t.Helper()
+
+	require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, "/user", "", map[string]string{
+		"name":      "usuario " + userType,
+		"email":     email,
+		"password":  "123456",
+		"cpf":       cpf,
+		"phone":     "11999999999",
+		"user_type": userType,
+	}, nil))
+
+	var out struct {
+		Token string `json:"token"`
+	}
+	require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login", "", map[string]string{
+		"email":    email,
+		"password": "123456",
+	}, &out))
+	return out.Token
+}
+
+func TestRoutePolicy(t *testing.T) {
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	owner := loginSeedUser(t, engine)
+	customer := signUp(t, engine, "cliente@example.com", "23756676030", "customer")
+	otherOwner := signUp(t, engine, "outra-loja@example.com", "74444217065", "store")
+
+	menuPath := "/store/" + seed.SeedStoreID + "/menu"
+	menu := map[string]any{"name": "Almoço"}
+
+	t.Run("test customer cannot manage the catalog", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, menuPath, customer, menu, nil))
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, "/menu/"+seed.SeedMenuID+"/category", customer, map[string]any{"name": "Bebidas"}, nil))
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, "/store", customer, map[string]any{"name": "Loja"}, nil))
+	})
+
+	t.Run("test owner of another store cannot manage this one", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, menuPath, otherOwner, menu, nil))
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodGet, "/store/"+seed.SeedStoreID+"/orders", otherOwner, nil, nil))
+	})
+
+	t.Run("test store owner manages its own store", func(t *testing.T) {
+		require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, menuPath, owner, menu, nil))
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/store/"+seed.SeedStoreID+"/orders", owner, nil, nil))
+	})
+
+	t.Run("test resource of an unknown menu", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, doJSON(t, engine, http.MethodPost, "/menu/nao-existe/category", owner, map[string]any{"name": "Bebidas"}, nil))
+	})
+}
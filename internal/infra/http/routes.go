@@ -3,50 +3,84 @@ package http
 import (
 	"context"
 	"os"
-	"time"
-
-	memoryaddonoption "github.com/FabioRocha231/saas-core/internal/infra/db/repository/addon_option"
-	memorycategoryitem "github.com/FabioRocha231/saas-core/internal/infra/db/repository/category_item"
-	memoryitemaddongroup "github.com/FabioRocha231/saas-core/internal/infra/db/repository/item_addon_group"
-	memoryitemvariantgroup "github.com/FabioRocha231/saas-core/internal/infra/db/repository/item_variant_group"
-	memorymenucategory "github.com/FabioRocha231/saas-core/internal/infra/db/repository/menu_category"
-	memorymenuread "github.com/FabioRocha231/saas-core/internal/infra/db/repository/menu_read"
-	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
-	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
-	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
-	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
-	memorystoremenu "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_menu"
-	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
-	memoryvariantoption "github.com/FabioRocha231/saas-core/internal/infra/db/repository/variant_option"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/auth"
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
 	"github.com/FabioRocha231/saas-core/internal/infra/http/handlers"
 	"github.com/FabioRocha231/saas-core/internal/infra/http/middleware"
+	"github.com/FabioRocha231/saas-core/internal/infra/message"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
 	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	authusecase "github.com/FabioRocha231/saas-core/internal/usecase/auth"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	verification "github.com/FabioRocha231/saas-core/internal/usecase/verification"
 	"github.com/FabioRocha231/saas-core/pkg"
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterRoutes(engine *gin.Engine) {
+// App é o que RegisterRoutes montou e o resto do processo (jobs em
+// background) reaproveita: um só relógio e um só conjunto de gateways.
+type App struct {
+	Repos    *db.Repositories
+	Gateways ports.PaymentGateways
+	UUID     ports.UUIDInterface
+	Clock    ports.Clock
+}
+
+func RegisterRoutes(engine *gin.Engine, dbConfig db.Config) (*App, error) {
 	uuid := pkg.NewUUID()
 	passwordHash := pkg.NewPasswordHash()
-	storeRepo := memorystore.New()
-	userRepo := memoryuser.New()
-	sessionRepo := memorysession.New()
-	storeMenuRepo := memorystoremenu.New()
-	menuCategoryRepo := memorymenucategory.New()
-	itemCategoryRepo := memorycategoryitem.New()
-	itemAddonGroupRepo := memoryitemaddongroup.New()
-	addonOptionRepo := memoryaddonoption.New()
-	itemVariantGroupRepo := memoryitemvariantgroup.New()
-	variantOptionRepo := memoryvariantoption.New()
-	orderRepo := memoryorder.New()
-	paymentRepo := memorypayment.New()
-	menuReadRepo := memorymenuread.New(
-		itemCategoryRepo,
-		itemAddonGroupRepo,
-		addonOptionRepo,
-		itemVariantGroupRepo,
-		variantOptionRepo,
-	)
+	totp := pkg.NewTOTP()
+	qrCode := pkg.NewQRCode()
+	clock := pkg.NewClock()
+
+	authConfig, err := auth.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	jwtKeys, err := authConfig.Keyset()
+	if err != nil {
+		return nil, err
+	}
+	// sem proxies confiáveis o gin ignora X-Forwarded-For: senão qualquer
+	// cliente trocaria de IP a cada tentativa e furaria a trava de login
+	if err := engine.SetTrustedProxies(authConfig.TrustedProxies); err != nil {
+		return nil, err
+	}
+
+	paymentConfig, err := payment.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	gateways := payment.NewGateways(paymentConfig, clock)
+
+	messageConfig, err := message.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	sender := message.NewSender(messageConfig, clock)
+
+	repos, err := db.NewRepositories(context.Background(), dbConfig, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	storeRepo := repos.Store
+	userRepo := repos.User
+	sessionRepo := repos.Session
+	storeMenuRepo := repos.StoreMenu
+	menuCategoryRepo := repos.MenuCategory
+	itemCategoryRepo := repos.CategoryItem
+	itemAddonGroupRepo := repos.ItemAddonGroup
+	addonOptionRepo := repos.AddonOption
+	itemVariantGroupRepo := repos.ItemVariantGroup
+	variantOptionRepo := repos.VariantOption
+	orderRepo := repos.Order
+	paymentRepo := repos.Payment
+	refundRepo := repos.Refund
+	paymentEventRepo := repos.PaymentEvent
+	menuReadRepo := repos.MenuRead
 
 	seed.Seed(
 		context.Background(),
@@ -60,87 +94,173 @@ func RegisterRoutes(engine *gin.Engine) {
 		itemVariantGroupRepo,
 		variantOptionRepo,
 		passwordHash,
+		clock,
 	)
 
-	jwtService := pkg.NewJwtService(os.Getenv("JWT_SECRET"), 24*time.Hour, "saas-core", uuid)
+	pol := policy.New(userRepo, storeRepo, repos.StoreMember).
+		RequireVerified(authConfig.CheckoutRequires).
+		RequireTwoFactor(repos.TwoFactor, authConfig.TwoFactorRequiredRoles)
+	locator := policy.NewLocator(storeMenuRepo, menuCategoryRepo, itemCategoryRepo, itemAddonGroupRepo, itemVariantGroupRepo, paymentRepo)
 
-	storeHandler := handlers.NewStoreHandler(storeRepo, userRepo, uuid)
-	userHandler := handlers.NewUserHandler(userRepo, storeRepo, uuid, passwordHash)
-	authHandler := handlers.NewAuthHandler(passwordHash, jwtService, userRepo, sessionRepo, storeRepo)
-	storeMenuHandler := handlers.NewStoreMenuHandler(storeRepo, storeMenuRepo, uuid)
-	menuCategoryHandler := handlers.NewMenuCategoryHandler(menuCategoryRepo, storeMenuRepo, uuid)
-	categoryItemHandler := handlers.NewCategoryItemHandler(itemCategoryRepo, menuCategoryRepo, uuid)
+	jwtService := pkg.NewJwtService(jwtKeys, authConfig.AccessTokenTTL, "saas-core", uuid, clock)
+
+	storeHandler := handlers.NewStoreHandler(storeRepo, userRepo, uuid, clock)
+	userHandler := handlers.NewUserHandler(userRepo, storeRepo, uuid, passwordHash, clock)
+	storeMemberHandler := handlers.NewStoreMemberHandler(repos.StoreMember, repos.StoreInvitation, userRepo, pol, sender, pkg.NewToken(), uuid, repos.Tx, clock, authConfig.InvitationTTL)
+	verificationHandler := handlers.NewVerificationHandler(repos.VerificationCode, userRepo, sender, pkg.NewToken(), uuid, repos.Tx, clock, verification.Settings{
+		TTL:         authConfig.VerificationCodeTTL,
+		MaxAttempts: authConfig.VerificationMaxAttempts,
+		ResendAfter: authConfig.VerificationResendAfter,
+	})
+	passwordHandler := handlers.NewPasswordHandler(userRepo, repos.VerificationCode, sessionRepo, repos.RefreshToken, passwordHash, sender, pkg.NewToken(), uuid, repos.Tx, clock, authusecase.PasswordResetSettings{
+		TTL:         authConfig.PasswordResetTTL,
+		MaxAttempts: authConfig.VerificationMaxAttempts,
+		ResendAfter: authConfig.VerificationResendAfter,
+	})
+	authHandler := handlers.NewAuthHandler(passwordHash, jwtService, pkg.NewToken(), totp, userRepo, sessionRepo, repos.RefreshToken, storeRepo, repos.StoreMember, repos.LoginThrottle, repos.Audit, repos.VerificationCode, repos.TwoFactor, uuid, repos.Tx, clock, authConfig.RefreshTokenTTL, authusecase.TwoFactorSettings{
+		ChallengeTTL: authConfig.TwoFactorChallengeTTL,
+		MaxAttempts:  authConfig.VerificationMaxAttempts,
+	}, authusecase.LockoutSettings{
+		MaxFailures:   authConfig.LoginMaxFailures,
+		IPMaxFailures: authConfig.LoginIPMaxFailures,
+		Window:        authConfig.LoginFailureWindow,
+		Lockout:       authConfig.LoginLockout,
+		MaxLockout:    authConfig.LoginMaxLockout,
+	})
+	twoFactorHandler := handlers.NewTwoFactorHandler(repos.TwoFactor, userRepo, passwordHash, totp, pkg.NewToken(), uuid, repos.Tx, clock, authConfig.TwoFactorIssuer, authConfig.TwoFactorRequiredRoles)
+	storeMenuHandler := handlers.NewStoreMenuHandler(storeRepo, storeMenuRepo, uuid, clock)
+	menuCategoryHandler := handlers.NewMenuCategoryHandler(menuCategoryRepo, storeMenuRepo, uuid, clock)
+	categoryItemHandler := handlers.NewCategoryItemHandler(itemCategoryRepo, menuCategoryRepo, uuid, clock)
 	itemAddonGroupHandler := handlers.NewItemAddonGroupHandler(itemAddonGroupRepo, itemCategoryRepo, uuid)
 	addonOptionHandler := handlers.NewAddonOptionHandler(addonOptionRepo, itemAddonGroupRepo, uuid)
-	itemVariantGroupHandler := handlers.NewItemVariantGroupHandler(itemVariantGroupRepo, itemCategoryRepo, uuid)
+	itemVariantGroupHandler := handlers.NewItemVariantGroupHandler(itemVariantGroupRepo, itemCategoryRepo, uuid, clock)
 	variantOptionHandler := handlers.NewVariantOptionHandler(variantOptionRepo, itemVariantGroupRepo, uuid)
-	orderHandler := handlers.NewOrderHandler(orderRepo, menuReadRepo, uuid)
-	paymentHandler := handlers.NewPaymentHandler(orderRepo, paymentRepo, uuid)
+	orderHandler := handlers.NewOrderHandler(orderRepo, storeRepo, pol, paymentRepo, refundRepo, menuReadRepo, gateways, repos.Tx, uuid, clock)
+	storefrontHandler := handlers.NewStorefrontHandler(storeRepo, storeMenuRepo, menuCategoryRepo, itemCategoryRepo, itemAddonGroupRepo, addonOptionRepo, itemVariantGroupRepo, variantOptionRepo, clock)
+	paymentHandler := handlers.NewPaymentHandler(orderRepo, paymentRepo, refundRepo, paymentEventRepo, pol, gateways, qrCode, repos.Tx, uuid, clock)
 
-	authMiddleware := middleware.NewAuthMiddleware(jwtService, sessionRepo)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, sessionRepo, clock)
+	authz := middleware.NewPolicyMiddleware(pol)
 
 	engine.POST("/user", userHandler.Create)
 
 	engine.POST("/login", authHandler.Login)
+	engine.POST("/login/2fa", authHandler.LoginTwoFactor)
+	engine.POST("/auth/refresh", authHandler.Refresh)
+	engine.POST("/auth/forgot-password", passwordHandler.Forgot)
+	engine.POST("/auth/reset-password", passwordHandler.Reset)
+	engine.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	// vitrine pública: navegar pelas lojas não exige login
+	public := engine.Group("/public")
+	public.GET("/stores/:slug", storefrontHandler.GetStore)
+	public.GET("/stores/:slug/menu", storefrontHandler.GetMenu)
+
+	// webhooks dos providers (autenticados pela assinatura, não por JWT)
+	engine.POST("/webhooks/payments/:provider", paymentHandler.Webhook)
 
 	protected := engine.Group("/")
 	protected.Use(authMiddleware.Middleware)
 
+	// Session routes
+	protected.POST("/logout", authHandler.Logout)
+	protected.GET("/sessions", authHandler.ListSessions)
+	protected.POST("/sessions/revoke-all", authHandler.RevokeAllSessions)
+
 	// Store routes
-	protected.POST("/store", storeHandler.Create)
+	protected.POST("/store", authz.Require(policy.ActionStoreCreate), storeHandler.Create)
 	protected.GET("/store/id/:id", storeHandler.GetByID)
-	protected.POST("/store/:storeId/menu", storeMenuHandler.Create)
+	protected.GET("/store/slug/:slug", storeHandler.GetBySlug)
+	protected.PATCH("/store/:storeId", authz.RequireStore(policy.ActionStoreManage, "storeId", policy.SameID), storeHandler.Update)
+	// pausar é operação do dia a dia: quem toca a fila de pedidos pode
+	pauseStore := authz.RequireStore(policy.ActionOrdersManage, "storeId", policy.SameID)
+	protected.POST("/store/:storeId/pause", pauseStore, storeHandler.Pause)
+	protected.DELETE("/store/:storeId/pause", pauseStore, storeHandler.Resume)
+	protected.POST("/store/:storeId/menu", authz.RequireStore(policy.ActionCatalogManage, "storeId", policy.SameID), storeMenuHandler.Create)
 	protected.GET("/store/:storeId/menus", storeMenuHandler.ListByStoreID)
 
+	// Store staff routes
+	manageStaff := authz.RequireStore(policy.ActionStaffManage, "storeId", policy.SameID)
+	protected.GET("/store/:storeId/members", manageStaff, storeMemberHandler.List)
+	protected.POST("/store/:storeId/invitations", manageStaff, storeMemberHandler.Invite)
+	protected.DELETE("/store/:storeId/invitations/:invitationId", manageStaff, storeMemberHandler.RevokeInvitation)
+	protected.DELETE("/store/:storeId/members/:userId", manageStaff, storeMemberHandler.RemoveMember)
+	protected.POST("/invitations/accept", storeMemberHandler.Accept)
+
 	// User routes
 	protected.GET("/user/:id", userHandler.GetByID)
 	protected.GET("/user/email/:email", userHandler.GetByEmail)
 	protected.GET("/user/cpf/:cpf", userHandler.GetByCpf)
+	protected.PATCH("/user/me/password", passwordHandler.Change)
+	protected.POST("/user/verify-email/send", verificationHandler.SendEmailCode)
+	protected.POST("/user/verify-email", verificationHandler.VerifyEmail)
+	protected.POST("/user/verify-phone/send", verificationHandler.SendPhoneCode)
+	protected.POST("/user/verify-phone", verificationHandler.VerifyPhone)
+	protected.GET("/user/me/2fa", twoFactorHandler.Status)
+	protected.POST("/user/me/2fa/setup", twoFactorHandler.Setup)
+	protected.POST("/user/me/2fa/enable", twoFactorHandler.Enable)
+	protected.POST("/user/me/2fa/disable", twoFactorHandler.Disable)
+	protected.POST("/user/me/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
 
 	// Menu Store routes
 	protected.GET("/menu/:id", storeMenuHandler.GetByID)
 
 	// Menu category routes
-	protected.POST("/menu/:menuId/category", menuCategoryHandler.Create)
+	protected.POST("/menu/:menuId/category", authz.RequireStore(policy.ActionCatalogManage, "menuId", locator.StoreOfMenu), menuCategoryHandler.Create)
 	protected.GET("/menu/categories/:menuId", menuCategoryHandler.ListByMenuID)
 	protected.GET("/menu/category/:id", menuCategoryHandler.GetByID)
 
 	// Category item routes
-	protected.POST("/menu/category/:categoryId/item", categoryItemHandler.Create)
+	protected.POST("/menu/category/:categoryId/item", authz.RequireStore(policy.ActionCatalogManage, "categoryId", locator.StoreOfCategory), categoryItemHandler.Create)
 	protected.GET("/menu/category/item/:id", categoryItemHandler.GetByID)
 	protected.GET("/menu/category/items/:categoryId", categoryItemHandler.ListByCategoryID)
 
 	// item addon group routes
-	protected.POST("/item/:categoryItemId/addon-group", itemAddonGroupHandler.Create)
+	protected.POST("/item/:categoryItemId/addon-group", authz.RequireStore(policy.ActionCatalogManage, "categoryItemId", locator.StoreOfItem), itemAddonGroupHandler.Create)
 	protected.GET("/item/addon-group/:id", itemAddonGroupHandler.GetByID)
 	protected.GET("/item/:categoryItemId/addon-groups", itemAddonGroupHandler.ListByCategoryItemID)
 
 	// addon option routes
-	protected.POST("/addon-group/:itemAddonGroupId/addon-option", addonOptionHandler.Create)
+	protected.POST("/addon-group/:itemAddonGroupId/addon-option", authz.RequireStore(policy.ActionCatalogManage, "itemAddonGroupId", locator.StoreOfAddonGroup), addonOptionHandler.Create)
 	protected.GET("/addon-option/:id", addonOptionHandler.GetByID)
 	protected.GET("/addon-group/:itemAddonGroupId/addon-options", addonOptionHandler.GetByItemAddonGroupID)
 
 	// Item variant group routes
-	protected.POST("/item/:categoryItemId/variant-group", itemVariantGroupHandler.Create)
+	protected.POST("/item/:categoryItemId/variant-group", authz.RequireStore(policy.ActionCatalogManage, "categoryItemId", locator.StoreOfItem), itemVariantGroupHandler.Create)
 	protected.GET("/item/variant-group/:id", itemVariantGroupHandler.GetByID)
 	protected.GET("/item/:categoryItemId/variant-groups", itemVariantGroupHandler.ListByCategoryItemID)
 
 	// Variant option routes
-	protected.POST("/variant-group/:itemVariantGroupId/variant-option", variantOptionHandler.Create)
+	protected.POST("/variant-group/:itemVariantGroupId/variant-option", authz.RequireStore(policy.ActionCatalogManage, "itemVariantGroupId", locator.StoreOfVariantGroup), variantOptionHandler.Create)
 	protected.GET("/variant-option/:id", variantOptionHandler.GetByID)
 	protected.GET("/variant-group/:itemVariantGroupId/variant-options", variantOptionHandler.ListByItemVariantGroupID)
 
 	// order routes
-	protected.POST("/store/:storeId/order", orderHandler.Create)
-	protected.POST("/order/:orderId/item", orderHandler.AddItem)
+	placeOrder := authz.Require(policy.ActionOrderPlace)
+	checkout := authz.Require(policy.ActionOrderCheckout)
+	protected.POST("/store/:storeId/order", placeOrder, orderHandler.Create)
+	protected.POST("/order/:orderId/item", placeOrder, orderHandler.AddItem)
 	protected.GET("/order/:orderId", orderHandler.GetByID)
-	protected.PATCH("/order/:orderId/item/:itemId", orderHandler.UpdateItemQty)
-	protected.DELETE("/order/:orderId/item/:itemId", orderHandler.RemoveItem)
-	protected.PATCH("/order/:orderId/place", orderHandler.PlaceOrder)
+	protected.PATCH("/order/:orderId/item/:itemId", placeOrder, orderHandler.UpdateItemQty)
+	protected.DELETE("/order/:orderId/item/:itemId", placeOrder, orderHandler.RemoveItem)
+	protected.PATCH("/order/:orderId/place", checkout, orderHandler.PlaceOrder)
+	protected.POST("/order/:orderId/cancel", placeOrder, orderHandler.Cancel)
+
+	// store staff order routes
+	manageOrders := authz.RequireStore(policy.ActionOrdersManage, "storeId", policy.SameID)
+	protected.GET("/store/:storeId/orders", manageOrders, orderHandler.ListStoreOrders)
+	protected.PATCH("/store/:storeId/order/:orderId/status", manageOrders, orderHandler.AdvanceStatus)
+	protected.POST("/store/:storeId/order/:orderId/reject", manageOrders, orderHandler.Reject)
 
 	//payment routes
-	protected.POST("/order/:orderId/payments", paymentHandler.CreateForOrder)
+	protected.POST("/order/:orderId/payments", checkout, paymentHandler.CreateForOrder)
 	protected.GET("/payments/:paymentId", paymentHandler.GetByID)
-	protected.POST("/payments/:paymentId/confirm", paymentHandler.Confirm)
-	protected.POST("/payments/:paymentId/fail", paymentHandler.Fail)
+	// simulação do provider para cobranças MOCK; fora de dev só o webhook confirma
+	if os.Getenv("APP_ENV") == "dev" {
+		protected.POST("/payments/:paymentId/confirm", placeOrder, paymentHandler.Confirm)
+		protected.POST("/payments/:paymentId/fail", placeOrder, paymentHandler.Fail)
+	}
+	protected.POST("/payments/:paymentId/refunds", authz.RequireStore(policy.ActionPaymentsRefund, "paymentId", locator.StoreOfPayment), paymentHandler.Refund)
+
+	return &App{Repos: repos, Gateways: gateways, UUID: uuid, Clock: clock}, nil
 }
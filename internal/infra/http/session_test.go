@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRevocation(t *testing.T) {
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	t.Run("test logout invalidates the token immediately", func(t *testing.T) {
+		token := loginSeedUser(t, engine)
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/sessions", token, nil, nil))
+
+		require.Equal(t, http.StatusNoContent, doJSON(t, engine, http.MethodPost, "/logout", token, nil, nil))
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodGet, "/sessions", token, nil, nil))
+	})
+
+	t.Run("test list and revoke all sessions", func(t *testing.T) {
+		first := loginSeedUser(t, engine)
+		second := loginSeedUser(t, engine)
+
+		var sessions []struct {
+			ID      string `json:"id"`
+			IP      string `json:"ip"`
+			Current bool   `json:"current"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/sessions", second, nil, &sessions))
+		require.Len(t, sessions, 2)
+		currents := 0
+		for _, s := range sessions {
+			require.NotEmpty(t, s.IP)
+			if s.Current {
+				currents++
+			}
+		}
+		require.Equal(t, 1, currents)
+
+		var out struct {
+			Revoked int `json:"revoked"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/sessions/revoke-all", first, nil, &out))
+		require.Equal(t, 2, out.Revoked)
+
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodGet, "/sessions", first, nil, nil))
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodGet, "/sessions", second, nil, nil))
+	})
+
+	t.Run("test refresh rotates tokens and rejects a replay", func(t *testing.T) {
+		var login struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login", "", map[string]string{
+			"email":    "teste@gmail.com",
+			"password": "123456",
+		}, &login))
+		require.NotEmpty(t, login.RefreshToken)
+
+		var refreshed struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/auth/refresh", "", map[string]string{
+			"refresh_token": login.RefreshToken,
+		}, &refreshed))
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/sessions", refreshed.Token, nil, nil))
+
+		// token antigo reapresentado: a sessão cai junto
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodPost, "/auth/refresh", "", map[string]string{
+			"refresh_token": login.RefreshToken,
+		}, nil))
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodGet, "/sessions", refreshed.Token, nil, nil))
+	})
+}
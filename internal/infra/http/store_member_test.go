@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreInvitationFlow(t *testing.T) {
+	outbox := t.TempDir()
+	t.Setenv("MESSAGE_SENDER", "file")
+	t.Setenv("MESSAGE_OUTBOX_DIR", outbox)
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	owner := loginSeedUser(t, engine)
+	cashier := signUp(t, engine, "caixa@example.com", "23756676030", "customer")
+	storePath := "/store/" + seed.SeedStoreID
+
+	var invitation map[string]any
+	require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, storePath+"/invitations", cashier, map[string]string{
+		"email": "caixa@example.com", "role": "manager",
+	}, nil))
+	require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, storePath+"/invitations", owner, map[string]string{
+		"email": "caixa@example.com", "role": "cashier",
+	}, &invitation))
+	// o token só vai no email do convidado
+	require.NotContains(t, invitation, "token")
+
+	require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodGet, storePath+"/orders", cashier, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/invitations/accept", cashier, map[string]string{
+		"token": lastEmailedToken(t, outbox, "caixa@example.com"),
+	}, nil))
+
+	t.Run("test cashier works the order queue but not the catalog", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, storePath+"/orders", cashier, nil, nil))
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, storePath+"/menu", cashier, map[string]any{"name": "Jantar"}, nil))
+	})
+
+	t.Run("test login sends the member to the store dashboard", func(t *testing.T) {
+		var login struct {
+			StoresCount int    `json:"stores_count"`
+			NextStep    string `json:"next_step"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login", "", map[string]string{
+			"email":    "caixa@example.com",
+			"password": "123456",
+		}, &login))
+		require.Equal(t, 1, login.StoresCount)
+		require.Equal(t, "STORE_DASHBOARD", login.NextStep)
+	})
+
+	t.Run("test removed member loses access", func(t *testing.T) {
+		var staff struct {
+			Members []struct {
+				UserID string `json:"user_id"`
+			} `json:"members"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, storePath+"/members", owner, nil, &staff))
+		require.Len(t, staff.Members, 1)
+
+		require.Equal(t, http.StatusNoContent, doJSON(t, engine, http.MethodDelete, storePath+"/members/"+staff.Members[0].UserID, owner, nil, nil))
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodGet, storePath+"/orders", cashier, nil, nil))
+	})
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	usecase "github.com/FabioRocha231/saas-core/internal/usecase/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreProfile(t *testing.T) {
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	owner := loginSeedUser(t, engine)
+	otherOwner := signUp(t, engine, "outra-loja@example.com", "74444217065", "store")
+	storePath := "/store/" + seed.SeedStoreID
+
+	t.Run("test a new store gets a unique slug from its name", func(t *testing.T) {
+		var created usecase.CreateStoreOutput
+		require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, "/store", otherOwner, map[string]string{
+			"name": "Loja Teste", "cnpj": "65.921.814/0001-04",
+		}, &created))
+
+		require.Equal(t, "loja-teste-2", created.Slug)
+	})
+
+	t.Run("test owner of another store cannot update this one", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPatch, storePath, otherOwner, map[string]any{"name": "Invasor"}, nil))
+	})
+
+	t.Run("test owner updates the profile and opening hours", func(t *testing.T) {
+		var out usecase.GetStoreByIDOutput
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPatch, storePath, owner, map[string]any{
+			"name":     "Pizzaria São João",
+			"slug":     "Pizzaria São João",
+			"phone":    "(11) 3333-4444",
+			"address":  map[string]string{"street": "Rua A", "number": "10", "city": "São Paulo", "state": "sp", "zip_code": "01001-000"},
+			"timezone": "America/Sao_Paulo",
+			"hours": map[string]any{
+				"weekly":     []map[string]string{{"weekday": "friday", "opens": "18:00", "closes": "02:00"}},
+				"exceptions": []map[string]any{{"date": "2026-12-25", "closed": true, "note": "Natal"}},
+			},
+		}, &out))
+
+		require.Equal(t, "pizzaria-sao-joao", out.Store.Slug)
+		require.Equal(t, "1133334444", out.Store.Phone)
+		require.Equal(t, "SP", out.Store.Address.State)
+		require.Equal(t, "01001000", out.Store.Address.ZipCode)
+		require.Equal(t, []usecase.WeeklyWindowDTO{{Weekday: "friday", Opens: "18:00", Closes: "02:00"}}, out.Store.Hours.Weekly)
+		require.True(t, out.Store.OpenEnabled)
+
+		var bySlug usecase.GetStoreByIDOutput
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/store/slug/pizzaria-sao-joao", owner, nil, &bySlug))
+		require.Equal(t, seed.SeedStoreID, bySlug.Store.ID)
+		require.Equal(t, "Pizzaria São João", bySlug.Store.Name)
+	})
+
+	t.Run("test the manual toggle closes the store", func(t *testing.T) {
+		var out usecase.GetStoreByIDOutput
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPatch, storePath, owner, map[string]any{"is_open": false}, &out))
+
+		require.False(t, out.Store.IsOpen)
+		require.False(t, out.Store.OpenEnabled)
+	})
+
+	t.Run("test invalid profile changes are rejected", func(t *testing.T) {
+		for _, body := range []map[string]any{
+			{"slug": "loja-teste-2"},
+			{"timezone": "Mars/Olympus"},
+			{"hours": map[string]any{"weekly": []map[string]string{{"weekday": "someday", "opens": "10:00", "closes": "12:00"}}}},
+			{"hours": map[string]any{"weekly": []map[string]string{{"weekday": "monday", "opens": "10:00", "closes": "10:00"}}}},
+			{"logo_url": "ftp://logo"},
+		} {
+			code := doJSON(t, engine, http.MethodPatch, storePath, owner, body, nil)
+			require.Contains(t, []int{http.StatusBadRequest, http.StatusConflict}, code, body)
+		}
+	})
+}
+
+func TestStorePause(t *testing.T) {
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	owner := loginSeedUser(t, engine)
+	customer := signUp(t, engine, "cliente@example.com", "23756676030", "customer")
+	storePath := "/store/" + seed.SeedStoreID
+
+	var draft testOrder
+	require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, storePath+"/order", customer, nil, &draft))
+	require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/order/"+draft.ID+"/item", customer, map[string]any{
+		"item_id": seed.SeedItemCoke,
+		"qty":     1,
+	}, nil))
+
+	t.Run("test customer cannot pause the store", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, storePath+"/pause", customer, map[string]any{"minutes": 30}, nil))
+	})
+
+	t.Run("test a paused store refuses orders for now", func(t *testing.T) {
+		var out usecase.GetStoreByIDOutput
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, storePath+"/pause", owner, map[string]any{"minutes": 30}, &out))
+		require.False(t, out.Store.IsOpen)
+		require.NotNil(t, out.Store.PausedUntil)
+
+		require.Equal(t, http.StatusConflict, doJSON(t, engine, http.MethodPatch, "/order/"+draft.ID+"/place", customer, nil, nil))
+	})
+
+	t.Run("test resuming the store accepts orders again", func(t *testing.T) {
+		var out usecase.GetStoreByIDOutput
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodDelete, storePath+"/pause", owner, nil, &out))
+		require.True(t, out.Store.IsOpen)
+		require.Nil(t, out.Store.PausedUntil)
+
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPatch, "/order/"+draft.ID+"/place", customer, nil, nil))
+	})
+
+	t.Run("test a pause longer than a day is rejected", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, doJSON(t, engine, http.MethodPost, storePath+"/pause", owner, map[string]any{"minutes": 25 * 60}, nil))
+	})
+}
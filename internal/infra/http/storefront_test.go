@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	usecase "github.com/FabioRocha231/saas-core/internal/usecase/storefront"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicStorefront(t *testing.T) {
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	t.Run("test browse the store and its menu without logging in", func(t *testing.T) {
+		var store usecase.GetStoreOutput
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/public/stores/loja-teste", "", nil, &store))
+		require.Equal(t, seed.SeedStoreID, store.Store.ID)
+		require.True(t, store.Store.IsOpen)
+
+		var menu usecase.GetMenuOutput
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/public/stores/loja-teste/menu", "", nil, &menu))
+		require.Len(t, menu.Menus, 1)
+		require.Equal(t, seed.SeedMenuID, menu.Menus[0].ID)
+		require.NotEmpty(t, menu.Menus[0].Categories)
+
+		var coke *usecase.Item
+		for _, c := range menu.Menus[0].Categories {
+			for i := range c.Items {
+				if c.Items[i].ID == seed.SeedItemCoke {
+					coke = &c.Items[i]
+				}
+			}
+		}
+		require.NotNil(t, coke)
+	})
+
+	t.Run("test an unknown store", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, doJSON(t, engine, http.MethodGet, "/public/stores/nao-existe/menu", "", nil, nil))
+	})
+
+	t.Run("test conditional requests with the etag", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/public/stores/loja-teste/menu", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "public, max-age=60", rec.Header().Get("Cache-Control"))
+		etag := rec.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		req := httptest.NewRequest(http.MethodGet, "/public/stores/loja-teste/menu", nil)
+		req.Header.Set("If-None-Match", `W/"outra", `+etag)
+		rec = httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNotModified, rec.Code)
+		require.Empty(t, rec.Body.Bytes())
+		require.Equal(t, etag, rec.Header().Get("ETag"))
+
+		// a loja pausou: a resposta mudou e o etag antigo não serve mais
+		owner := loginSeedUser(t, engine)
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/store/"+seed.SeedStoreID+"/pause", owner, map[string]any{"minutes": 10}, nil))
+
+		req = httptest.NewRequest(http.MethodGet, "/public/stores/loja-teste/menu", nil)
+		req.Header.Set("If-None-Match", etag)
+		rec = httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NotEqual(t, etag, rec.Header().Get("ETag"))
+	})
+}
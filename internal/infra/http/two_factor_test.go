@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorRoutes(t *testing.T) {
+	t.Setenv("TWO_FACTOR_REQUIRED_ROLES", "store_owner")
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+	totp := &pkg.TOTP{}
+
+	credentials := map[string]string{"email": "teste@gmail.com", "password": "123456"}
+	ordersPath := "/store/" + seed.SeedStoreID + "/orders"
+
+	token := loginSeedUser(t, engine)
+	var secret string
+	var recovery []string
+
+	t.Run("test owner without 2FA cannot manage the store", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodGet, ordersPath, token, nil, nil))
+	})
+
+	t.Run("test enrollment", func(t *testing.T) {
+		var setup struct {
+			Secret     string `json:"secret"`
+			OTPAuthURI string `json:"otpauth_uri"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/user/me/2fa/setup", token, nil, &setup))
+		require.Contains(t, setup.OTPAuthURI, "otpauth://totp/saas-core:teste@gmail.com")
+		secret = setup.Secret
+
+		require.Equal(t, http.StatusBadRequest, doJSON(t, engine, http.MethodPost, "/user/me/2fa/enable", token, map[string]string{"code": "000000"}, nil))
+
+		code, err := totp.Code(secret, time.Now())
+		require.NoError(t, err)
+		var enabled struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/user/me/2fa/enable", token, map[string]string{"code": code}, &enabled))
+		require.Len(t, enabled.RecoveryCodes, 10)
+		recovery = enabled.RecoveryCodes
+
+		var status struct {
+			Enabled           bool `json:"enabled"`
+			RecoveryCodesLeft int  `json:"recovery_codes_left"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, "/user/me/2fa", token, nil, &status))
+		require.True(t, status.Enabled)
+		require.Equal(t, 10, status.RecoveryCodesLeft)
+
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, ordersPath, token, nil, nil))
+	})
+
+	t.Run("test login asks for the second factor", func(t *testing.T) {
+		var challenge struct {
+			Required       bool   `json:"two_factor_required"`
+			ChallengeToken string `json:"challenge_token"`
+			Token          string `json:"token"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login", "", credentials, &challenge))
+		require.True(t, challenge.Required)
+		require.NotEmpty(t, challenge.ChallengeToken)
+		require.Empty(t, challenge.Token)
+
+		body := map[string]string{"challenge_token": challenge.ChallengeToken, "code": "000000"}
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodPost, "/login/2fa", "", body, nil))
+
+		// o código do cadastro já foi usado; o do próximo passo ainda está na tolerância
+		code, err := totp.Code(secret, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		body["code"] = code
+		var session struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login/2fa", "", body, &session))
+		require.NotEmpty(t, session.Token)
+		require.NotEmpty(t, session.RefreshToken)
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodGet, ordersPath, session.Token, nil, nil))
+
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, engine, http.MethodPost, "/login/2fa", "", body, nil))
+	})
+
+	t.Run("test recovery code completes the login", func(t *testing.T) {
+		var challenge struct {
+			ChallengeToken string `json:"challenge_token"`
+		}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login", "", credentials, &challenge))
+
+		body := map[string]string{"challenge_token": challenge.ChallengeToken, "code": recovery[0]}
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/login/2fa", "", body, nil))
+	})
+
+	t.Run("test required role cannot disable", func(t *testing.T) {
+		body := map[string]string{"password": "123456", "code": recovery[1]}
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, "/user/me/2fa/disable", token, body, nil))
+	})
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/seed"
+	"github.com/stretchr/testify/require"
+)
+
+var outboxCode = regexp.MustCompile(`\b\d{6}\b`)
+
+// lastOutboxCode lê o outbox do FileSender e devolve o último código enviado
+// para to.
+func lastOutboxCode(t *testing.T, dir, to string) string {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join(dir, "outbox.jsonl"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var code string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg struct {
+			To   string `json:"to"`
+			Body string `json:"body"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		if msg.To == to {
+			code = outboxCode.FindString(msg.Body)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	require.NotEmpty(t, code, "no code sent to %s", to)
+	return code
+}
+
+func TestVerificationRoutes(t *testing.T) {
+	outbox := t.TempDir()
+	t.Setenv("MESSAGE_SENDER", "file")
+	t.Setenv("MESSAGE_OUTBOX_DIR", outbox)
+	t.Setenv("ORDER_REQUIRE_VERIFIED", "email")
+	engine := newTestServer(t, db.Config{Driver: db.DriverMemory})
+
+	email := "verifica@example.com"
+	token := signUp(t, engine, email, "23756676030", "customer")
+
+	// montar o carrinho não depende de verificação
+	var draft testOrder
+	require.Equal(t, http.StatusCreated, doJSON(t, engine, http.MethodPost, "/store/"+seed.SeedStoreID+"/order", token, nil, &draft))
+
+	t.Run("test checkout is blocked until the email is verified", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPatch, "/order/"+draft.ID+"/place", token, nil, nil))
+		require.Equal(t, http.StatusForbidden, doJSON(t, engine, http.MethodPost, "/order/"+draft.ID+"/payments", token, map[string]any{}, nil))
+	})
+
+	t.Run("test verify email with the code from the outbox", func(t *testing.T) {
+		require.Equal(t, http.StatusAccepted, doJSON(t, engine, http.MethodPost, "/user/verify-email/send", token, nil, nil))
+		// reenvio imediato cai no intervalo mínimo
+		require.Equal(t, http.StatusConflict, doJSON(t, engine, http.MethodPost, "/user/verify-email/send", token, nil, nil))
+
+		require.Equal(t, http.StatusBadRequest, doJSON(t, engine, http.MethodPost, "/user/verify-email", token, map[string]string{"code": "000000x"}, nil))
+
+		var out struct {
+			EmailVerified bool   `json:"email_verified"`
+			Status        string `json:"status"`
+		}
+		code := lastOutboxCode(t, outbox, email)
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/user/verify-email", token, map[string]string{"code": code}, &out))
+		require.True(t, out.EmailVerified)
+		require.Equal(t, "active", out.Status)
+	})
+
+	t.Run("test checkout passes the policy after verification", func(t *testing.T) {
+		// pedido vazio: agora quem recusa é o usecase, não a policy
+		code := doJSON(t, engine, http.MethodPatch, "/order/"+draft.ID+"/place", token, nil, nil)
+		require.NotEqual(t, http.StatusForbidden, code)
+	})
+
+	t.Run("test verify phone by sms", func(t *testing.T) {
+		require.Equal(t, http.StatusAccepted, doJSON(t, engine, http.MethodPost, "/user/verify-phone/send", token, nil, nil))
+
+		code := lastOutboxCode(t, outbox, "11999999999")
+		require.Equal(t, http.StatusOK, doJSON(t, engine, http.MethodPost, "/user/verify-phone", token, map[string]string{"code": code}, nil))
+		require.Equal(t, http.StatusConflict, doJSON(t, engine, http.MethodPost, "/user/verify-phone/send", token, nil, nil))
+	})
+}
@@ -0,0 +1,64 @@
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+// FileSender acrescenta cada mensagem como uma linha JSON em path.
+type FileSender struct {
+	mu    sync.Mutex
+	path  string
+	clock ports.Clock
+}
+
+type fileRecord struct {
+	Channel ports.MessageChannel `json:"channel"`
+	To      string               `json:"to"`
+	Subject string               `json:"subject,omitempty"`
+	Body    string               `json:"body"`
+	SentAt  time.Time            `json:"sent_at"`
+}
+
+func NewFileSender(path string, clock ports.Clock) *FileSender {
+	return &FileSender{path: path, clock: clock}
+}
+
+func (s *FileSender) Send(ctx context.Context, msg ports.Message) error {
+	_ = ctx
+
+	line, err := json.Marshal(fileRecord{
+		Channel: msg.Channel,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		SentAt:  s.clock.Now().UTC(),
+	})
+	if err != nil {
+		return errx.Wrap(errx.CodeInternal, "encode message", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return errx.Wrap(errx.CodeInternal, "create outbox dir", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errx.Wrap(errx.CodeInternal, "open outbox", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return errx.Wrap(errx.CodeInternal, "write outbox", err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package message
+
+import (
+	"context"
+	"log"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+// LogSender escreve a mensagem inteira no log. Só serve para desenvolvimento:
+// o corpo carrega códigos de verificação.
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg ports.Message) error {
+	_ = ctx
+	log.Printf("[message] %s to=%s subject=%q body=%q\n", msg.Channel, msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,53 @@
+// Package message monta o MessageSender a partir da configuração. Enquanto não
+// há provider de email/SMS, as mensagens vão para o log ou para um arquivo
+// local (outbox) de onde o desenvolvedor copia os códigos.
+package message
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+type Driver string
+
+const (
+	DriverLog  Driver = "log"
+	DriverFile Driver = "file"
+)
+
+const DefaultOutboxDir = "data/outbox"
+
+type Config struct {
+	Driver Driver
+	// diretório do outbox.jsonl quando Driver é file
+	OutboxDir string
+}
+
+// ConfigFromEnv lê MESSAGE_SENDER (log | file) e MESSAGE_OUTBOX_DIR.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{Driver: DriverLog, OutboxDir: DefaultOutboxDir}
+
+	if raw := strings.ToLower(strings.TrimSpace(os.Getenv("MESSAGE_SENDER"))); raw != "" {
+		switch Driver(raw) {
+		case DriverLog, DriverFile:
+			cfg.Driver = Driver(raw)
+		default:
+			return Config{}, errx.F(errx.CodeInvalid, "invalid MESSAGE_SENDER %q", raw)
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv("MESSAGE_OUTBOX_DIR")); raw != "" {
+		cfg.OutboxDir = raw
+	}
+	return cfg, nil
+}
+
+func NewSender(cfg Config, clock ports.Clock) ports.MessageSender {
+	if cfg.Driver == DriverFile {
+		return NewFileSender(filepath.Join(cfg.OutboxDir, "outbox.jsonl"), clock)
+	}
+	return NewLogSender()
+}
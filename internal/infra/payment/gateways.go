@@ -0,0 +1,112 @@
+// Package payment monta os gateways de pagamento a partir da configuração e
+// decide qual provider atende cada método.
+package payment
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	mockgateway "github.com/FabioRocha231/saas-core/internal/infra/payment/mock"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+type Config struct {
+	Mock mockgateway.Config
+}
+
+// ConfigFromEnv lê PAYMENT_MOCK_OUTCOME (pending | approve | decline | error),
+// PAYMENT_MOCK_LATENCY (duração Go, ex.: 300ms), PAYMENT_MOCK_WEBHOOK_SECRET e
+// o recebedor PIX (PIX_KEY, PIX_MERCHANT_NAME, PIX_MERCHANT_CITY, PIX_EXPIRATION).
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Mock: mockgateway.Config{
+			WebhookSecret:   os.Getenv("PAYMENT_MOCK_WEBHOOK_SECRET"),
+			PixKey:          envOr("PIX_KEY", "pix@saas-core.dev"),
+			PixMerchantName: envOr("PIX_MERCHANT_NAME", "SAAS CORE"),
+			PixMerchantCity: envOr("PIX_MERCHANT_CITY", "SAO PAULO"),
+		},
+	}
+
+	if raw := strings.ToLower(strings.TrimSpace(os.Getenv("PAYMENT_MOCK_OUTCOME"))); raw != "" {
+		outcome, ok := mockgateway.OutcomeMap[raw]
+		if !ok {
+			return Config{}, errx.F(errx.CodeInvalid, "invalid PAYMENT_MOCK_OUTCOME %q", raw)
+		}
+		cfg.Mock.Outcome = outcome
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("PAYMENT_MOCK_LATENCY")); raw != "" {
+		latency, err := time.ParseDuration(raw)
+		if err != nil || latency < 0 {
+			return Config{}, errx.F(errx.CodeInvalid, "invalid PAYMENT_MOCK_LATENCY %q", raw)
+		}
+		cfg.Mock.Latency = latency
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("PIX_EXPIRATION")); raw != "" {
+		ttl, err := time.ParseDuration(raw)
+		if err != nil || ttl <= 0 {
+			return Config{}, errx.F(errx.CodeInvalid, "invalid PIX_EXPIRATION %q", raw)
+		}
+		cfg.Mock.PixExpiration = ttl
+	}
+
+	return cfg, nil
+}
+
+func envOr(key, fallback string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return fallback
+}
+
+type Gateways struct {
+	byMethod   map[entity.PaymentMethod]ports.PaymentGateway
+	byProvider map[entity.PaymentProvider]ports.PaymentGateway
+}
+
+// NewGateways registra os providers disponíveis. Por enquanto só existe o
+// mock, que atende todos os métodos; um provider real entra aqui assumindo
+// os métodos que suporta.
+func NewGateways(cfg Config, clock ports.Clock) ports.PaymentGateways {
+	mock := mockgateway.New(cfg.Mock, clock)
+
+	g := &Gateways{
+		byMethod:   make(map[entity.PaymentMethod]ports.PaymentGateway),
+		byProvider: make(map[entity.PaymentProvider]ports.PaymentGateway),
+	}
+	g.register(mock,
+		entity.PaymentMethodMock,
+		entity.PaymentMethodPix,
+		entity.PaymentMethodCreditCard,
+		entity.PaymentMethodDebitCard,
+	)
+	return g
+}
+
+func (g *Gateways) register(gw ports.PaymentGateway, methods ...entity.PaymentMethod) {
+	g.byProvider[gw.Provider()] = gw
+	for _, m := range methods {
+		g.byMethod[m] = gw
+	}
+}
+
+func (g *Gateways) ForMethod(method entity.PaymentMethod) (ports.PaymentGateway, error) {
+	gw, ok := g.byMethod[method]
+	if !ok {
+		return nil, errx.F(errx.CodeInvalid, "unsupported payment method %s", method)
+	}
+	return gw, nil
+}
+
+func (g *Gateways) ForProvider(provider entity.PaymentProvider) (ports.PaymentGateway, error) {
+	gw, ok := g.byProvider[provider]
+	if !ok {
+		return nil, errx.F(errx.CodeInvalid, "unsupported payment provider %s", provider)
+	}
+	return gw, nil
+}
@@ -0,0 +1,269 @@
+package mockgateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/pkg/pix"
+)
+
+// Outcome decide como o mock responde ao Authorize.
+type Outcome string
+
+const (
+	// cobrança fica PENDING até alguém confirmar (endpoint de simulação ou webhook)
+	OutcomePending Outcome = "pending"
+	OutcomeApprove Outcome = "approve"
+	OutcomeDecline Outcome = "decline"
+	// provider fora do ar: toda chamada devolve erro
+	OutcomeError Outcome = "error"
+)
+
+var OutcomeMap = map[string]Outcome{
+	"pending": OutcomePending,
+	"approve": OutcomeApprove,
+	"decline": OutcomeDecline,
+	"error":   OutcomeError,
+}
+
+// SignatureHeader carrega a assinatura do webhook: "t=<unix>,v1=<hex>", onde
+// v1 = HMAC-SHA256(secret, "<t>.<corpo>").
+const SignatureHeader = "X-Mock-Signature"
+
+// signatureTolerance limita a idade de um evento assinado (proteção contra replay).
+const signatureTolerance = 5 * time.Minute
+
+type Config struct {
+	Outcome Outcome
+	// atraso artificial em cada chamada, para simular a rede do provider
+	Latency time.Duration
+	// segredo compartilhado com o "provider" para assinar webhooks; vazio recusa todos
+	WebhookSecret string
+
+	// recebedor das cobranças PIX
+	PixKey          string
+	PixMerchantName string
+	PixMerchantCity string
+	// validade da cobrança PIX (padrão DefaultPixExpiration)
+	PixExpiration time.Duration
+}
+
+const DefaultPixExpiration = 30 * time.Minute
+
+type Gateway struct {
+	cfg   Config
+	clock ports.Clock
+}
+
+func New(cfg Config, clock ports.Clock) ports.PaymentGateway {
+	if cfg.Outcome == "" {
+		cfg.Outcome = OutcomePending
+	}
+	if cfg.PixExpiration <= 0 {
+		cfg.PixExpiration = DefaultPixExpiration
+	}
+	return &Gateway{cfg: cfg, clock: clock}
+}
+
+func (g *Gateway) Provider() entity.PaymentProvider {
+	return entity.PaymentProviderMock
+}
+
+func (g *Gateway) Authorize(ctx context.Context, req ports.GatewayAuthorizeRequest) (*ports.GatewayPaymentResult, error) {
+	if err := g.call(ctx); err != nil {
+		return nil, err
+	}
+	if req.PaymentID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing payment id")
+	}
+	if req.Amount <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "amount must be > 0")
+	}
+
+	// a referência sai do id do pagamento, então repetir o Authorize é idempotente
+	res := &ports.GatewayPaymentResult{Ref: "mock_" + req.PaymentID}
+	switch g.cfg.Outcome {
+	case OutcomeApprove:
+		res.Status = entity.PaymentStatusPaid
+	case OutcomeDecline:
+		res.Status = entity.PaymentStatusFailed
+	default:
+		res.Status = entity.PaymentStatusPending
+	}
+
+	if req.Method == entity.PaymentMethodPix {
+		// txid amarrado ao pagamento: o mesmo Authorize gera o mesmo BR Code
+		txid := pix.TxID(req.PaymentID)
+		code, err := pix.Charge{
+			Key:          g.cfg.PixKey,
+			MerchantName: g.cfg.PixMerchantName,
+			MerchantCity: g.cfg.PixMerchantCity,
+			TxID:         txid,
+			Amount:       req.Amount,
+		}.BRCode()
+		if err != nil {
+			return nil, err
+		}
+		expiresAt := g.clock.Now().Add(g.cfg.PixExpiration)
+		res.Pix = &ports.GatewayPix{TxID: txid, Code: code}
+		res.ExpiresAt = &expiresAt
+	}
+	return res, nil
+}
+
+func (g *Gateway) Capture(ctx context.Context, ref string, amount int64) (*ports.GatewayPaymentResult, error) {
+	if err := g.call(ctx); err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing payment reference")
+	}
+	return &ports.GatewayPaymentResult{Ref: ref, Status: entity.PaymentStatusPaid}, nil
+}
+
+func (g *Gateway) Cancel(ctx context.Context, ref string) (*ports.GatewayPaymentResult, error) {
+	if err := g.call(ctx); err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing payment reference")
+	}
+	return &ports.GatewayPaymentResult{Ref: ref, Status: entity.PaymentStatusCanceled}, nil
+}
+
+func (g *Gateway) Refund(ctx context.Context, req ports.GatewayRefundRequest) (*ports.GatewayRefundResult, error) {
+	if err := g.call(ctx); err != nil {
+		return nil, err
+	}
+	if req.PaymentRef == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing payment reference")
+	}
+	if req.Amount <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "amount must be > 0")
+	}
+	return &ports.GatewayRefundResult{Ref: "mock_rf_" + req.RefundID, Status: entity.RefundStatusSucceeded}, nil
+}
+
+// webhookPayload é o formato aceito pelo mock, no estilo dos PSPs reais.
+type webhookPayload struct {
+	ID         string    `json:"id"`
+	PaymentRef string    `json:"payment_ref"`
+	Status     string    `json:"status"`
+	Amount     int64     `json:"amount"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (g *Gateway) ParseWebhook(ctx context.Context, payload []byte, header http.Header) (*ports.GatewayEvent, error) {
+	_ = ctx
+
+	if err := g.verifySignature(payload, header.Get(SignatureHeader), g.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	var body webhookPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, errx.New(errx.CodeInvalid, "invalid webhook payload")
+	}
+	if body.ID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing event id")
+	}
+	if body.PaymentRef == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing payment reference")
+	}
+
+	status := entity.PaymentStatus(strings.ToUpper(strings.TrimSpace(body.Status)))
+	switch status {
+	case entity.PaymentStatusPaid, entity.PaymentStatusFailed, entity.PaymentStatusCanceled:
+	default:
+		return nil, errx.F(errx.CodeInvalid, "unsupported webhook status %q", body.Status)
+	}
+
+	if body.OccurredAt.IsZero() {
+		body.OccurredAt = g.clock.Now()
+	}
+
+	return &ports.GatewayEvent{
+		ID:         body.ID,
+		Provider:   entity.PaymentProviderMock,
+		PaymentRef: body.PaymentRef,
+		Status:     status,
+		Amount:     body.Amount,
+		OccurredAt: body.OccurredAt,
+	}, nil
+}
+
+// SignWebhook gera o valor de SignatureHeader como o provider faria. Usado
+// pelos testes e por quem quiser simular o provider localmente.
+func SignWebhook(secret string, payload []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(signature(secret, ts, payload))
+}
+
+func signature(secret, ts string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (g *Gateway) verifySignature(payload []byte, value string, now time.Time) error {
+	if g.cfg.WebhookSecret == "" {
+		return errx.New(errx.CodeUnauthorized, "webhook secret not configured")
+	}
+
+	var ts, v1 string
+	for _, part := range strings.Split(value, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			v1 = v
+		}
+	}
+	if ts == "" || v1 == "" {
+		return errx.New(errx.CodeUnauthorized, "missing webhook signature")
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errx.New(errx.CodeUnauthorized, "invalid webhook signature")
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > signatureTolerance || age < -signatureTolerance {
+		return errx.New(errx.CodeUnauthorized, "webhook signature expired")
+	}
+
+	got, err := hex.DecodeString(v1)
+	if err != nil || !hmac.Equal(got, signature(g.cfg.WebhookSecret, ts, payload)) {
+		return errx.New(errx.CodeUnauthorized, "invalid webhook signature")
+	}
+	return nil
+}
+
+// call aplica a latência configurada e a falha simulada.
+func (g *Gateway) call(ctx context.Context) error {
+	if g.cfg.Latency > 0 {
+		timer := time.NewTimer(g.cfg.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if g.cfg.Outcome == OutcomeError {
+		return errx.New(errx.CodeInternal, "payment provider unavailable")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package mockgateway
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockGateway(t *testing.T) {
+	authorize := ports.GatewayAuthorizeRequest{PaymentID: "pay-1", OrderID: "order-1", Amount: 1000, Currency: "BRL"}
+
+	t.Run("test authorize follows the configured outcome", func(t *testing.T) {
+		cases := map[Outcome]entity.PaymentStatus{
+			OutcomePending: entity.PaymentStatusPending,
+			OutcomeApprove: entity.PaymentStatusPaid,
+			OutcomeDecline: entity.PaymentStatusFailed,
+		}
+		for outcome, status := range cases {
+			res, err := New(Config{Outcome: outcome}, pkg.NewClock()).Authorize(t.Context(), authorize)
+			require.NoError(t, err)
+			require.Equal(t, status, res.Status)
+			require.Equal(t, "mock_pay-1", res.Ref)
+		}
+	})
+
+	t.Run("test authorize a pix charge returns the br code", func(t *testing.T) {
+		gw := New(Config{PixKey: "pix@loja.com", PixMerchantName: "Loja", PixMerchantCity: "Recife", PixExpiration: time.Hour}, pkg.NewClock())
+		req := authorize
+		req.Method = entity.PaymentMethodPix
+
+		res, err := gw.Authorize(t.Context(), req)
+		require.NoError(t, err)
+		require.NotNil(t, res.Pix)
+		require.Equal(t, "pay1", res.Pix.TxID)
+		require.Contains(t, res.Pix.Code, "0504pay1")
+		require.NotNil(t, res.ExpiresAt)
+		require.WithinDuration(t, time.Now().Add(time.Hour), *res.ExpiresAt, 5*time.Second)
+
+		// cartão não tem BR Code nem prazo
+		card, err := gw.Authorize(t.Context(), authorize)
+		require.NoError(t, err)
+		require.Nil(t, card.Pix)
+		require.Nil(t, card.ExpiresAt)
+	})
+
+	t.Run("test authorize a pix charge without a pix key", func(t *testing.T) {
+		req := authorize
+		req.Method = entity.PaymentMethodPix
+
+		_, err := New(Config{}, pkg.NewClock()).Authorize(t.Context(), req)
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: missing pix key", err.Error())
+	})
+
+	t.Run("test every call fails when the provider is down", func(t *testing.T) {
+		gw := New(Config{Outcome: OutcomeError}, pkg.NewClock())
+
+		_, err := gw.Authorize(t.Context(), authorize)
+		require.Equal(t, "internal: payment provider unavailable", err.Error())
+		_, err = gw.Refund(t.Context(), ports.GatewayRefundRequest{PaymentRef: "mock_pay-1", RefundID: "rf-1", Amount: 100})
+		require.Error(t, err)
+	})
+
+	t.Run("test latency respects the context deadline", func(t *testing.T) {
+		gw := New(Config{Latency: time.Second}, pkg.NewClock())
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := gw.Authorize(ctx, authorize)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	const secret = "whsec-test"
+	gw := New(Config{WebhookSecret: secret}, pkg.NewClock())
+	signed := func(payload string, at time.Time) http.Header {
+		h := http.Header{}
+		h.Set(SignatureHeader, SignWebhook(secret, []byte(payload), at))
+		return h
+	}
+
+	t.Run("test parse a signed webhook", func(t *testing.T) {
+		payload := `{"id":"evt-1","payment_ref":"mock_pay-1","status":"paid","amount":1000}`
+
+		ev, err := gw.ParseWebhook(t.Context(), []byte(payload), signed(payload, time.Now()))
+		require.NoError(t, err)
+		require.Equal(t, "evt-1", ev.ID)
+		require.Equal(t, entity.PaymentStatusPaid, ev.Status)
+		require.Equal(t, entity.PaymentProviderMock, ev.Provider)
+		require.False(t, ev.OccurredAt.IsZero())
+
+		payload = `{"id":"evt-2","payment_ref":"mock_pay-1","status":"REFUNDED"}`
+		_, err = gw.ParseWebhook(t.Context(), []byte(payload), signed(payload, time.Now()))
+		require.Equal(t, `invalid_argument: unsupported webhook status "REFUNDED"`, err.Error())
+	})
+
+	t.Run("test reject webhooks with a bad signature", func(t *testing.T) {
+		payload := `{"id":"evt-3","payment_ref":"mock_pay-1","status":"PAID"}`
+
+		_, err := gw.ParseWebhook(t.Context(), []byte(payload), http.Header{})
+		require.Equal(t, "unauthorized: missing webhook signature", err.Error())
+
+		// corpo alterado depois de assinado
+		_, err = gw.ParseWebhook(t.Context(), []byte(`{"id":"evt-3","payment_ref":"mock_pay-2","status":"PAID"}`), signed(payload, time.Now()))
+		require.Equal(t, "unauthorized: invalid webhook signature", err.Error())
+
+		_, err = gw.ParseWebhook(t.Context(), []byte(payload), signed(payload, time.Now().Add(-time.Hour)))
+		require.Equal(t, "unauthorized: webhook signature expired", err.Error())
+
+		h := http.Header{}
+		h.Set(SignatureHeader, SignWebhook("other-secret", []byte(payload), time.Now()))
+		_, err = gw.ParseWebhook(t.Context(), []byte(payload), h)
+		require.Equal(t, "unauthorized: invalid webhook signature", err.Error())
+
+		_, err = New(Config{}, pkg.NewClock()).ParseWebhook(t.Context(), []byte(payload), signed(payload, time.Now()))
+		require.Equal(t, "unauthorized: webhook secret not configured", err.Error())
+	})
+}
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	orderuc "github.com/FabioRocha231/saas-core/internal/usecase/order"
+	paymentuc "github.com/FabioRocha231/saas-core/internal/usecase/payment"
+)
+
+const (
+	DefaultInterval   = time.Minute
+	DefaultPaymentTTL = 30 * time.Minute
+	DefaultOrderTTL   = time.Hour
+)
+
+type Config struct {
+	// de quanto em quanto tempo os jobs rodam; 0 desliga
+	Interval time.Duration
+	// cobrança em aberto sem prazo próprio (cartão) é cancelada depois disso
+	PaymentTTL time.Duration
+	// pedido PLACED sem pagamento é cancelado depois disso
+	OrderTTL time.Duration
+}
+
+// ConfigFromEnv lê SCHEDULER_INTERVAL, PAYMENT_PENDING_TTL e ORDER_PLACED_TTL
+// (durações Go, ex.: 30s, 15m).
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Interval:   DefaultInterval,
+		PaymentTTL: DefaultPaymentTTL,
+		OrderTTL:   DefaultOrderTTL,
+	}
+
+	for _, v := range []struct {
+		env      string
+		dst      *time.Duration
+		zeroOkay bool
+	}{
+		{"SCHEDULER_INTERVAL", &cfg.Interval, true},
+		{"PAYMENT_PENDING_TTL", &cfg.PaymentTTL, false},
+		{"ORDER_PLACED_TTL", &cfg.OrderTTL, false},
+	} {
+		raw := strings.TrimSpace(os.Getenv(v.env))
+		if raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 || (d == 0 && !v.zeroOkay) {
+			return Config{}, errx.F(errx.CodeInvalid, "invalid %s %q", v.env, raw)
+		}
+		*v.dst = d
+	}
+
+	return cfg, nil
+}
+
+// ExpirationJobs monta os jobs que tiram cobranças, pedidos e estornos do
+// limbo: cobranças vencidas viram CANCELED, pedidos PLACED sem pagamento são
+// cancelados pelo sistema e estornos PENDING são reenviados ao provider. Cada
+// mudança sai no log.
+func ExpirationJobs(
+	cfg Config,
+	repos *db.Repositories,
+	gateways ports.PaymentGateways,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+) []Job {
+	expirePayments := paymentuc.NewExpirePaymentsUsecase(repos.Payment, gateways, repos.Tx, clock, cfg.PaymentTTL)
+	expireOrders := orderuc.NewExpireOrdersUsecase(repos.Order, repos.Payment, repos.Refund, gateways, repos.Tx, uuid, clock, cfg.OrderTTL)
+	retryRefunds := paymentuc.NewRetryRefundsUsecase(repos.Order, repos.Payment, repos.Refund, gateways, repos.Tx, clock)
+
+	return []Job{
+		{
+			Name:     "expire-payments",
+			Interval: cfg.Interval,
+			Run: func(ctx context.Context) error {
+				out, err := expirePayments.Execute(ctx)
+				if out != nil {
+					for _, p := range out.Expired {
+						log.Printf("[scheduler] payment %s (order %s) -> CANCELED: expired\n", p.ID, p.OrderID)
+					}
+				}
+				return err
+			},
+		},
+		{
+			Name:     "expire-orders",
+			Interval: cfg.Interval,
+			Run: func(ctx context.Context) error {
+				out, err := expireOrders.Execute(ctx)
+				if out != nil {
+					for _, o := range out.Canceled {
+						log.Printf("[scheduler] order %s PLACED -> %s: %s\n", o.ID, o.Status, orderuc.ExpiredOrderReason)
+					}
+				}
+				return err
+			},
+		},
+		{
+			Name:     "retry-refunds",
+			Interval: cfg.Interval,
+			Run: func(ctx context.Context) error {
+				out, err := retryRefunds.Execute(ctx)
+				if out != nil {
+					for _, rf := range out.Settled {
+						log.Printf("[scheduler] refund %s (payment %s) PENDING -> %s\n", rf.ID, rf.PaymentID, rf.Status)
+					}
+				}
+				return err
+			},
+		},
+	}
+}
@@ -0,0 +1,96 @@
+// Package scheduler roda tarefas periódicas em background (expiração de
+// cobranças e pedidos) ao lado do servidor HTTP.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	jobs []Job
+
+	stop chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+func New(jobs ...Job) *Scheduler {
+	return &Scheduler{jobs: jobs, stop: make(chan struct{})}
+}
+
+// Start dispara cada job no seu intervalo, cada um na sua goroutine. Jobs
+// sem intervalo ficam desligados.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			log.Printf("[scheduler] %s disabled\n", job.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Shutdown para de agendar e espera a execução em andamento de cada job
+// terminar, até o prazo de ctx.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errx.Wrap(errx.CodeInternal, "scheduler shutdown timed out", ctx.Err())
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// a execução não é interrompida no meio pelo Shutdown: cada
+			// item roda numa tx e um corte deixaria o lote pela metade
+			s.run(context.WithoutCancel(ctx), job)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[scheduler] %s panic: %v\n", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil {
+		log.Printf("[scheduler] %s error: %v\n", job.Name, err)
+	}
+}
@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Run("test run jobs on their interval until shutdown", func(t *testing.T) {
+		var runs atomic.Int64
+		s := New(
+			Job{Name: "tick", Interval: 5 * time.Millisecond, Run: func(ctx context.Context) error {
+				runs.Add(1)
+				return nil
+			}},
+			Job{Name: "disabled", Run: func(ctx context.Context) error {
+				t.Error("disabled job must not run")
+				return nil
+			}},
+		)
+		s.Start(t.Context())
+
+		require.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+		require.NoError(t, s.Shutdown(t.Context()))
+
+		stopped := runs.Load()
+		time.Sleep(20 * time.Millisecond)
+		require.Equal(t, stopped, runs.Load())
+	})
+
+	t.Run("test shutdown waits for the running job", func(t *testing.T) {
+		started := make(chan struct{}, 1)
+		release := make(chan struct{})
+		var finished atomic.Bool
+		s := New(Job{Name: "slow", Interval: time.Millisecond, Run: func(ctx context.Context) error {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-release
+			finished.Store(true)
+			return nil
+		}})
+		s.Start(t.Context())
+		<-started
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		require.Error(t, s.Shutdown(ctx))
+
+		close(release)
+		require.NoError(t, s.Shutdown(t.Context()))
+		require.True(t, finished.Load())
+	})
+
+	t.Run("test a panicking job does not stop the scheduler", func(t *testing.T) {
+		var runs atomic.Int64
+		s := New(Job{Name: "panics", Interval: time.Millisecond, Run: func(ctx context.Context) error {
+			runs.Add(1)
+			panic("boom")
+		}})
+		s.Start(t.Context())
+
+		require.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+		require.NoError(t, s.Shutdown(t.Context()))
+	})
+}
+
+func TestExpirationJobs(t *testing.T) {
+	repos, err := db.NewRepositories(t.Context(), db.Config{Driver: db.DriverMemory}, pkg.NewClock())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repos.Close() })
+
+	uuid := pkg.NewUUID()
+	clock := pkg.NewFakeClock(time.Now())
+	jobs := ExpirationJobs(
+		Config{Interval: time.Minute, PaymentTTL: 30 * time.Minute, OrderTTL: time.Hour},
+		repos,
+		payment.NewGateways(payment.Config{}, pkg.NewClock()),
+		uuid,
+		clock,
+	)
+	require.Len(t, jobs, 3)
+	runAll := func(t *testing.T) {
+		for _, job := range jobs {
+			require.NoError(t, job.Run(t.Context()), job.Name)
+		}
+	}
+
+	orderID := uuid.Generate()
+	paymentID := uuid.Generate()
+	require.NoError(t, repos.Order.Create(t.Context(), &entity.Order{
+		ID: orderID, StoreID: uuid.Generate(), UserID: uuid.Generate(), Status: entity.OrderPlaced,
+	}))
+	require.NoError(t, repos.Payment.Create(t.Context(), &entity.Payment{
+		ID: paymentID, OrderID: orderID, UserID: uuid.Generate(), StoreID: "store", Status: entity.PaymentStatusPending, Amount: 1000,
+		Method: entity.PaymentMethodCreditCard, Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + paymentID,
+	}))
+
+	t.Run("test nothing expires before the ttl", func(t *testing.T) {
+		clock.Advance(29 * time.Minute)
+		runAll(t)
+
+		p, err := repos.Payment.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusPending, p.Status)
+	})
+
+	t.Run("test the pending payment expires after the payment ttl", func(t *testing.T) {
+		clock.Advance(2 * time.Minute)
+		runAll(t)
+
+		p, err := repos.Payment.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusCanceled, p.Status)
+
+		o, err := repos.Order.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPlaced, o.Status)
+	})
+
+	t.Run("test the unpaid order is canceled after the order ttl", func(t *testing.T) {
+		clock.Advance(30 * time.Minute)
+		runAll(t)
+
+		o, err := repos.Order.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderCanceled, o.Status)
+		require.Equal(t, entity.OrderActorSystem, o.Cancellation.Actor)
+	})
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Run("test defaults", func(t *testing.T) {
+		t.Setenv("SCHEDULER_INTERVAL", "")
+		t.Setenv("PAYMENT_PENDING_TTL", "")
+		t.Setenv("ORDER_PLACED_TTL", "")
+
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		require.Equal(t, Config{Interval: DefaultInterval, PaymentTTL: DefaultPaymentTTL, OrderTTL: DefaultOrderTTL}, cfg)
+	})
+
+	t.Run("test overrides and disabled scheduler", func(t *testing.T) {
+		t.Setenv("SCHEDULER_INTERVAL", "0")
+		t.Setenv("PAYMENT_PENDING_TTL", "10m")
+		t.Setenv("ORDER_PLACED_TTL", "2h")
+
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		require.Equal(t, Config{Interval: 0, PaymentTTL: 10 * time.Minute, OrderTTL: 2 * time.Hour}, cfg)
+	})
+
+	t.Run("test invalid ttl", func(t *testing.T) {
+		t.Setenv("ORDER_PLACED_TTL", "0")
+
+		_, err := ConfigFromEnv()
+		require.Error(t, err)
+		require.Equal(t, `invalid_argument: invalid ORDER_PLACED_TTL "0"`, err.Error())
+	})
+}
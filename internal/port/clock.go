@@ -0,0 +1,9 @@
+package ports
+
+import "time"
+
+// Clock é a fonte de "agora". Quem depende de prazo (expiração, sessão)
+// recebe um Clock para os testes poderem controlar o tempo.
+type Clock interface {
+	Now() time.Time
+}
@@ -14,7 +14,27 @@ type Claims struct {
 
 type JwtInterface interface {
 	Sign(userID string, role string) (string, error)
+	// SignWithID reaproveita o jti de uma sessão existente (refresh).
+	SignWithID(jti string, userID string, role string) (string, error)
 	Parse(tokenStr string) (*Claims, error)
 	GetJTI(token string) string
 	GetExpiresAt(token string) time.Time
+	// JWKS expõe as chaves públicas para quem valida os tokens fora da API.
+	JWKS() JWKS
+}
+
+// JWK segue a RFC 7517; só os campos de RSA e OKP (Ed25519) são usados.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
 }
@@ -0,0 +1,24 @@
+package ports
+
+import "context"
+
+type MessageChannel string
+
+const (
+	MessageChannelEmail MessageChannel = "email"
+	MessageChannelSMS   MessageChannel = "sms"
+)
+
+// Message é uma mensagem de saída para o usuário (email ou SMS).
+type Message struct {
+	Channel MessageChannel
+	To      string
+	Subject string
+	Body    string
+}
+
+// MessageSender entrega mensagens de saída. Hoje só existem os substitutos
+// locais (log e arquivo); um provider real entra como nova implementação.
+type MessageSender interface {
+	Send(ctx context.Context, msg Message) error
+}
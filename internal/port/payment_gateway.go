@@ -0,0 +1,77 @@
+package ports
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+// PaymentGateway é o adaptador de um provider de pagamento (PSP). Os usecases
+// só falam com esta interface; cada provider real vira uma implementação nova.
+type PaymentGateway interface {
+	Provider() entity.PaymentProvider
+
+	// Authorize abre a cobrança no provider. O Status devolvido pode ser
+	// PENDING (aguardando o cliente/webhook), PAID ou FAILED.
+	Authorize(ctx context.Context, req GatewayAuthorizeRequest) (*GatewayPaymentResult, error)
+	Capture(ctx context.Context, ref string, amount int64) (*GatewayPaymentResult, error)
+	Cancel(ctx context.Context, ref string) (*GatewayPaymentResult, error)
+	Refund(ctx context.Context, req GatewayRefundRequest) (*GatewayRefundResult, error)
+
+	// ParseWebhook traduz a notificação crua do provider num evento.
+	ParseWebhook(ctx context.Context, payload []byte, header http.Header) (*GatewayEvent, error)
+}
+
+// PaymentGateways escolhe o gateway: pelo método na criação e pelo provider
+// gravado no pagamento nas operações seguintes.
+type PaymentGateways interface {
+	ForMethod(method entity.PaymentMethod) (PaymentGateway, error)
+	ForProvider(provider entity.PaymentProvider) (PaymentGateway, error)
+}
+
+type GatewayAuthorizeRequest struct {
+	PaymentID string
+	OrderID   string
+	Method    entity.PaymentMethod
+	Amount    int64 // centavos
+	Currency  string
+	// repassado ao provider para que uma nova tentativa não cobre duas vezes
+	IdempotencyKey string
+}
+
+type GatewayPaymentResult struct {
+	Ref    string
+	Status entity.PaymentStatus
+	// preenchido quando o método é PIX: o cliente paga pelo QR/"copia e cola"
+	Pix *GatewayPix
+	// prazo para o pagamento; depois disso a cobrança é cancelada
+	ExpiresAt *time.Time
+}
+
+type GatewayPix struct {
+	TxID string
+	Code string // BR Code ("copia e cola")
+}
+
+type GatewayRefundRequest struct {
+	PaymentRef     string
+	RefundID       string
+	Amount         int64
+	IdempotencyKey string
+}
+
+type GatewayRefundResult struct {
+	Ref    string
+	Status entity.RefundStatus
+}
+
+type GatewayEvent struct {
+	ID         string // id do evento no provider (dedup)
+	Provider   entity.PaymentProvider
+	PaymentRef string
+	Status     entity.PaymentStatus
+	Amount     int64
+	OccurredAt time.Time
+}
@@ -0,0 +1,6 @@
+package ports
+
+type QRCodeInterface interface {
+	// PNG desenha content num QR code quadrado de size pixels.
+	PNG(content string, size int) ([]byte, error)
+}
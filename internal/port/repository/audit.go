@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, e *entity.AuditEntry) error
+	// ListByAction devolve as entradas mais recentes primeiro.
+	ListByAction(ctx context.Context, action entity.AuditAction, limit int) ([]*entity.AuditEntry, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type LoginThrottleRepository interface {
+	// Get devolve not_found quando a chave não tem falhas registradas.
+	Get(ctx context.Context, key string) (*entity.LoginThrottle, error)
+	// RecordFailure soma uma falha de forma atômica e devolve o estado novo. Se
+	// a última falha é anterior a at-window, a contagem recomeça do 1.
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entity.LoginThrottle, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset esquece as falhas da chave (login certo).
+	Reset(ctx context.Context, key string) error
+}
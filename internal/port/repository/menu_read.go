@@ -8,6 +8,7 @@ import (
 
 type MenuReadRepository interface {
 	GetCategoryItemByID(ctx context.Context, id string) (*entity.CategoryItem, error)
+	GetStoreIDByItemID(ctx context.Context, itemID string) (string, error)
 
 	ListItemAddonGroupsByItemID(ctx context.Context, itemID string) ([]*entity.ItemAddonGroup, error)
 	GetItemAddonGroupByID(ctx context.Context, id string) (*entity.ItemAddonGroup, error)
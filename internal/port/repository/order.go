@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 )
@@ -10,6 +11,10 @@ type OrderRepository interface {
 	Create(ctx context.Context, o *entity.Order) error
 	Update(ctx context.Context, o *entity.Order) error
 	GetByID(ctx context.Context, id string) (*entity.Order, error)
+	ListByStoreID(ctx context.Context, storeID string) ([]*entity.Order, error)
+
+	// pedidos parados num status desde antes de updatedBefore, os mais antigos primeiro
+	ListStale(ctx context.Context, status entity.OrderStatus, updatedBefore time.Time, limit int) ([]*entity.Order, error)
 
 	// carrinho único
 	GetActiveDraftByUserIDAndStoreID(ctx context.Context, userID, storeID string) (*entity.Order, error)
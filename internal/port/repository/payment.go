@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 )
@@ -14,5 +15,12 @@ type PaymentRepository interface {
 	// idempotência: 1 cobrança por (order + key)
 	GetByOrderAndKey(ctx context.Context, orderID, key string) (*entity.Payment, error)
 
+	// webhooks chegam com a referência do provider, não com o nosso id
+	GetByProviderRef(ctx context.Context, provider entity.PaymentProvider, ref string) (*entity.Payment, error)
+
 	ListByOrderID(ctx context.Context, orderID string) ([]*entity.Payment, error)
+
+	// cobranças em aberto (CREATED/PENDING) vencidas: expires_at <= now ou, sem
+	// prazo próprio, criadas antes de createdBefore. As mais antigas primeiro.
+	ListExpired(ctx context.Context, now, createdBefore time.Time, limit int) ([]*entity.Payment, error)
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type PaymentEventRepository interface {
+	// conflito quando (provider + eventID) já foi recebido
+	Create(ctx context.Context, e *entity.PaymentEvent) error
+	GetByProviderEventID(ctx context.Context, provider entity.PaymentProvider, eventID string) (*entity.PaymentEvent, error)
+	ListByPaymentID(ctx context.Context, paymentID string) ([]*entity.PaymentEvent, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, t *entity.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// MarkUsed falha com conflict se o token já foi usado (rotação concorrente).
+	MarkUsed(ctx context.Context, id string, usedAt time.Time) error
+	// DeleteBySessionID revoga a família inteira de um login.
+	DeleteBySessionID(ctx context.Context, sessionID string) error
+}
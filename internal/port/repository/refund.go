@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type RefundRepository interface {
+	Create(ctx context.Context, r *entity.Refund) error
+	// Update grava o resultado do provider (status e referência)
+	Update(ctx context.Context, r *entity.Refund) error
+	GetByID(ctx context.Context, id string) (*entity.Refund, error)
+
+	// idempotência: 1 estorno por (payment + key)
+	GetByPaymentAndKey(ctx context.Context, paymentID, key string) (*entity.Refund, error)
+
+	ListByPaymentID(ctx context.Context, paymentID string) ([]*entity.Refund, error)
+
+	// ListPending devolve até `limit` estornos PENDING sem mudança desde
+	// updatedBefore, os mais antigos primeiro (reenvio ao provider).
+	ListPending(ctx context.Context, updatedBefore time.Time, limit int) ([]*entity.Refund, error)
+}
@@ -10,5 +10,7 @@ import (
 type SessionRepository interface {
 	Create(ctx context.Context, s *entity.Session) error
 	GetByID(ctx context.Context, id string) (*entity.Session, error)
+	ListByUserID(ctx context.Context, userID string) ([]*entity.Session, error)
+	Delete(ctx context.Context, id string) error
 	DeleteExpired(ctx context.Context, now time.Time) error
 }
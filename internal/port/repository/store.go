@@ -8,6 +8,8 @@ import (
 
 type StoreRepository interface {
 	Create(ctx context.Context, s *entity.Store) error
+	// Update grava o perfil e substitui o horário de funcionamento por inteiro.
+	Update(ctx context.Context, s *entity.Store) error
 	GetByID(ctx context.Context, id string) (*entity.Store, error)
 	GetBySlug(ctx context.Context, slug string) (*entity.Store, error)
 	CountByOwnerID(ctx context.Context, ownerID string) (int, error)
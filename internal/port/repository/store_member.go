@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type StoreMemberRepository interface {
+	// Create falha com conflict se o usuário já é da equipe da loja.
+	Create(ctx context.Context, m *entity.StoreMember) error
+	GetByStoreAndUser(ctx context.Context, storeID, userID string) (*entity.StoreMember, error)
+	ListByStoreID(ctx context.Context, storeID string) ([]*entity.StoreMember, error)
+	CountByUserID(ctx context.Context, userID string) (int, error)
+	Delete(ctx context.Context, storeID, userID string) error
+}
+
+type StoreInvitationRepository interface {
+	Create(ctx context.Context, i *entity.StoreInvitation) error
+	GetByID(ctx context.Context, id string) (*entity.StoreInvitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.StoreInvitation, error)
+	ListByStoreID(ctx context.Context, storeID string) ([]*entity.StoreInvitation, error)
+	// MarkAccepted e MarkRevoked falham com conflict se o convite já foi
+	// aceito ou revogado (só uma das duas vence).
+	MarkAccepted(ctx context.Context, id, userID string, at time.Time) error
+	MarkRevoked(ctx context.Context, id string, at time.Time) error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type TwoFactorRepository interface {
+	// SavePending grava um cadastro ainda não ativado, substituindo outro
+	// pendente. Falha com conflict se o 2FA do usuário já está ativo.
+	SavePending(ctx context.Context, tf *entity.TwoFactor) error
+	GetByUserID(ctx context.Context, userID string) (*entity.TwoFactor, error)
+	Enable(ctx context.Context, userID string, at time.Time) error
+	// UseStep registra o passo TOTP aceito; conflict se não for maior que o
+	// último (código reaproveitado).
+	UseStep(ctx context.Context, userID string, step int64) error
+	// Delete desliga o 2FA e apaga os códigos de recuperação.
+	Delete(ctx context.Context, userID string) error
+
+	// ReplaceRecoveryCodes troca todos os códigos de recuperação do usuário.
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*entity.RecoveryCode) error
+	// UseRecoveryCode marca o código como usado; not_found se não existe ou já
+	// foi usado.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) error
+	// CountRecoveryCodes conta os códigos ainda não usados.
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+}
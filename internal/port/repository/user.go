@@ -11,4 +11,7 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*entity.User, error)
 	GetByCpf(ctx context.Context, cpf string) (*entity.User, error)
 	GetByMail(ctx context.Context, mail string) (*entity.User, error)
+	// Update grava os campos mutáveis (nome, telefone, status, papel, senha e
+	// datas de verificação/login). Email e cpf são a identidade e não mudam aqui.
+	Update(ctx context.Context, u *entity.User) error
 }
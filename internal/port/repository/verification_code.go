@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+)
+
+type VerificationCodeRepository interface {
+	Create(ctx context.Context, c *entity.VerificationCode) error
+	GetByID(ctx context.Context, id string) (*entity.VerificationCode, error)
+	// GetLatest devolve o código mais recente do usuário para o propósito; os
+	// anteriores deixam de valer quando um novo é emitido.
+	GetLatest(ctx context.Context, userID string, purpose entity.VerificationPurpose) (*entity.VerificationCode, error)
+	// IncrementAttempts soma um palpite errado e devolve o total.
+	IncrementAttempts(ctx context.Context, id string) (int, error)
+	// MarkConsumed falha com conflict se o código já foi usado.
+	MarkConsumed(ctx context.Context, id string, at time.Time) error
+}
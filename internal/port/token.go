@@ -0,0 +1,11 @@
+package ports
+
+// TokenInterface gera segredos opacos (ex.: refresh token) e o hash que é
+// guardado no banco no lugar do valor original.
+type TokenInterface interface {
+	Generate() (string, error)
+	// GenerateCode devolve um código numérico curto (ex.: 6 dígitos) para o
+	// usuário digitar; só é seguro junto com TTL e limite de tentativas.
+	GenerateCode(digits int) (string, error)
+	Hash(token string) string
+}
@@ -0,0 +1,14 @@
+package ports
+
+import "time"
+
+// TOTPInterface implementa os códigos de app autenticador (RFC 6238).
+type TOTPInterface interface {
+	// GenerateSecret devolve um segredo novo em base32, como os apps esperam.
+	GenerateSecret() (string, error)
+	// URI monta o otpauth:// que vira QR code no cadastro.
+	URI(issuer, account, secret string) string
+	// Validate confere o código com tolerância de um passo para cada lado e
+	// devolve o passo que bateu, para o chamador recusar reuso.
+	Validate(secret, code string, at time.Time) (step int64, ok bool)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// TxManager delimita uma unidade de trabalho: as escritas dos repositórios
+// feitas com o ctx recebido por fn são confirmadas juntas ou desfeitas juntas.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
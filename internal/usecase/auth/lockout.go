@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type LockoutSettings struct {
+	// falhas seguidas de uma conta (email) antes de travar
+	MaxFailures int
+	// falhas de um IP, somando todas as contas, antes de travar
+	IPMaxFailures int
+	// falhas mais antigas que isso deixam de contar
+	Window time.Duration
+	// primeira trava; dobra a cada falha extra até MaxLockout
+	Lockout    time.Duration
+	MaxLockout time.Duration
+}
+
+// errInvalidCredentials é a única resposta de senha errada ou email
+// desconhecido, para não revelar quem tem conta.
+var errInvalidCredentials = errx.New(errx.CodeUnauthorized, "invalid credentials")
+
+var errLoginLocked = errx.New(errx.CodeRateLimited, "too many failed login attempts, try again later")
+
+// loginGuard conta as falhas por email e por IP e trava quem passa do limite.
+type loginGuard struct {
+	throttleRepo repository.LoginThrottleRepository
+	auditRepo    repository.AuditRepository
+	uuid         ports.UUIDInterface
+	clock        ports.Clock
+	settings     LockoutSettings
+}
+
+func accountKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	return "ip:" + ip
+}
+
+// check falha se a conta ou o IP estiverem travados.
+func (g *loginGuard) check(ctx context.Context, email, ip string) error {
+	now := g.clock.Now()
+	for _, key := range []string{accountKey(email), ipKey(ip)} {
+		if key == "" {
+			continue
+		}
+		t, err := g.throttleRepo.Get(ctx, key)
+		if err != nil {
+			if errx.Is(err, errx.CodeNotFound) {
+				continue
+			}
+			return err
+		}
+		if t.IsLocked(now) {
+			return errLoginLocked
+		}
+	}
+	return nil
+}
+
+// fail registra a falha e devolve sempre errInvalidCredentials (ou um erro de
+// infraestrutura). userID vai para a auditoria quando a conta existe.
+func (g *loginGuard) fail(ctx context.Context, email, ip, userID string) error {
+	now := g.clock.Now()
+	for _, k := range []struct {
+		key string
+		max int
+	}{
+		{accountKey(email), g.settings.MaxFailures},
+		{ipKey(ip), g.settings.IPMaxFailures},
+	} {
+		if k.key == "" || k.max <= 0 {
+			continue
+		}
+
+		t, err := g.throttleRepo.RecordFailure(ctx, k.key, now, g.settings.Window)
+		if err != nil {
+			return err
+		}
+		if t.Failures < k.max {
+			continue
+		}
+
+		lockout := g.backoff(t.Failures - k.max)
+		if err := g.throttleRepo.Lock(ctx, k.key, now.Add(lockout)); err != nil {
+			return err
+		}
+		if err := g.auditRepo.Create(ctx, &entity.AuditEntry{
+			ID:        g.uuid.Generate(),
+			Action:    entity.AuditActionLoginLockout,
+			UserID:    userID,
+			Subject:   k.key,
+			IP:        ip,
+			Detail:    fmt.Sprintf("%d failed attempts, locked for %s", t.Failures, lockout),
+			CreatedAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+	return errInvalidCredentials
+}
+
+// succeed zera as falhas da conta. As do IP continuam: um login certo não
+// apaga as tentativas contra outras contas.
+func (g *loginGuard) succeed(ctx context.Context, email string) error {
+	return g.throttleRepo.Reset(ctx, accountKey(email))
+}
+
+// backoff: Lockout, 2×Lockout, 4×Lockout... limitado a MaxLockout.
+func (g *loginGuard) backoff(extra int) time.Duration {
+	d := g.settings.Lockout
+	for i := 0; i < extra && d < g.settings.MaxLockout; i++ {
+		d *= 2
+	}
+	if g.settings.MaxLockout > 0 && d > g.settings.MaxLockout {
+		d = g.settings.MaxLockout
+	}
+	return d
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// verifyUnknownUser gasta o mesmo bcrypt de um login real para que o tempo de
+// resposta não denuncie email sem conta.
+func verifyUnknownUser(passwordHash ports.PasswordHashInterface, password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = passwordHash.Hash("saas-core-dummy-password")
+	})
+	_ = passwordHash.Verify(dummyHash, password)
+}
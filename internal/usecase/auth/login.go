@@ -11,17 +11,30 @@ import (
 )
 
 type LoginUsecase struct {
-	userRepo     repository.UserRepository
-	storeRepo    repository.StoreRepository
-	sessionRepo  repository.SessionRepository
-	jwtService   ports.JwtInterface
-	passwordHash ports.PasswordHashInterface
-	context      context.Context
+	userRepo      repository.UserRepository
+	codeRepo      repository.VerificationCodeRepository
+	twoFactorRepo repository.TwoFactorRepository
+	passwordHash  ports.PasswordHashInterface
+	token         ports.TokenInterface
+	uuid          ports.UUIDInterface
+	context       context.Context
+	clock         ports.Clock
+	twoFactor     TwoFactorSettings
+	// abre a sessão depois que as credenciais passam
+	starter *sessionStarter
+	// falhas por email/IP e trava temporária
+	guard *loginGuard
 }
 
+const maxUserAgentLen = 255
+
 type LoginInput struct {
 	Email    string
 	Password string
+
+	// dispositivo de origem, guardado na sessão
+	UserAgent string
+	IP        string
 }
 
 type UserLoginOutput struct {
@@ -32,10 +45,15 @@ type UserLoginOutput struct {
 }
 
 type LoginOutput struct {
-	Token       string          `json:"token"`
-	User        UserLoginOutput `json:"user"`
-	StoresCount int             `json:"stores_count"`
-	NextStep    entity.NextStep `json:"next_step"`
+	Token        string          `json:"token"`
+	RefreshToken string          `json:"refresh_token"`
+	ExpiresAt    time.Time       `json:"expires_at"`
+	User         UserLoginOutput `json:"user"`
+	StoresCount  int             `json:"stores_count"`
+	NextStep     entity.NextStep `json:"next_step"`
+
+	// preenchido no lugar da sessão quando a conta tem 2FA ativo
+	Challenge *TwoFactorChallenge `json:"-"`
 }
 
 func NewLoginUsecase(
@@ -43,56 +61,163 @@ func NewLoginUsecase(
 	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
 	storeRepo repository.StoreRepository,
+	memberRepo repository.StoreMemberRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	throttleRepo repository.LoginThrottleRepository,
+	auditRepo repository.AuditRepository,
+	codeRepo repository.VerificationCodeRepository,
+	twoFactorRepo repository.TwoFactorRepository,
 	jwtService ports.JwtInterface,
 	passwordHash ports.PasswordHashInterface,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+	refreshTTL time.Duration,
+	twoFactor TwoFactorSettings,
+	lockout LockoutSettings,
 ) *LoginUsecase {
 	return &LoginUsecase{
-		context:      context,
-		userRepo:     userRepo,
-		sessionRepo:  sessionRepo,
-		storeRepo:    storeRepo,
-		jwtService:   jwtService,
-		passwordHash: passwordHash,
+		context:       context,
+		userRepo:      userRepo,
+		codeRepo:      codeRepo,
+		twoFactorRepo: twoFactorRepo,
+		passwordHash:  passwordHash,
+		token:         token,
+		uuid:          uuid,
+		clock:         clock,
+		twoFactor:     twoFactor,
+		starter: &sessionStarter{
+			sessionRepo: sessionRepo,
+			storeRepo:   storeRepo,
+			memberRepo:  memberRepo,
+			refreshRepo: refreshRepo,
+			jwtService:  jwtService,
+			token:       token,
+			uuid:        uuid,
+			clock:       clock,
+			refreshTTL:  refreshTTL,
+		},
+		guard: &loginGuard{
+			throttleRepo: throttleRepo,
+			auditRepo:    auditRepo,
+			uuid:         uuid,
+			clock:        clock,
+			settings:     lockout,
+		},
 	}
 }
 
 func (l *LoginUsecase) Execute(input LoginInput) (*LoginOutput, error) {
-	user, err := l.userRepo.GetByMail(l.context, input.Email)
+	if err := l.guard.check(l.context, input.Email, input.IP); err != nil {
+		return nil, err
+	}
 
+	user, err := l.userRepo.GetByMail(l.context, input.Email)
 	if err != nil {
-		return nil, err
+		if !errx.Is(err, errx.CodeNotFound) {
+			return nil, err
+		}
+		verifyUnknownUser(l.passwordHash, input.Password)
+		return nil, l.guard.fail(l.context, input.Email, input.IP, "")
 	}
 
 	isEqual := l.passwordHash.Verify(user.Password, input.Password)
 	if !isEqual {
-		return nil, errx.New(errx.CodeNotFound, "user not found")
+		return nil, l.guard.fail(l.context, input.Email, input.IP, user.ID)
+	}
+
+	// com 2FA as falhas só zeram depois do código: senha certa seguida de
+	// códigos errados continua contando para a trava
+	enabled, err := l.twoFactorEnabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challenge, err := issueTwoFactorChallenge(l.context, l.codeRepo, l.token, l.uuid, l.clock, l.twoFactor, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginOutput{Challenge: challenge}, nil
+	}
+
+	if err := l.guard.succeed(l.context, input.Email); err != nil {
+		return nil, err
 	}
+	return l.starter.start(l.context, user, input.UserAgent, input.IP)
+}
 
-	token, err := l.jwtService.Sign(user.ID, user.Role.String())
+func (l *LoginUsecase) twoFactorEnabled(userID string) (bool, error) {
+	tf, err := l.twoFactorRepo.GetByUserID(l.context, userID)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return tf.IsEnabled(), nil
+}
+
+// sessionStarter cria a sessão e os tokens de um login já autenticado; é o
+// passo final tanto do login simples quanto do desafio 2FA.
+type sessionStarter struct {
+	sessionRepo repository.SessionRepository
+	storeRepo   repository.StoreRepository
+	memberRepo  repository.StoreMemberRepository
+	refreshRepo repository.RefreshTokenRepository
+	jwtService  ports.JwtInterface
+	token       ports.TokenInterface
+	uuid        ports.UUIDInterface
+	clock       ports.Clock
+	// validade do login (sessão + família de refresh tokens)
+	refreshTTL time.Duration
+}
+
+func (s *sessionStarter) start(ctx context.Context, user *entity.User, userAgent, ip string) (*LoginOutput, error) {
+	token, err := s.jwtService.Sign(user.ID, user.Role.String())
 	if err != nil {
 		return nil, err
 	}
 
-	err = l.sessionRepo.Create(l.context, &entity.Session{
-		ID:        l.jwtService.GetJTI(token),
+	// a sessão vive o login inteiro; o jti se repete nos access tokens do refresh
+	now := s.clock.Now()
+	session := &entity.Session{
+		ID:        s.jwtService.GetJTI(token),
 		UserID:    user.ID,
-		ExpiresAt: l.jwtService.GetExpiresAt(token),
+		ExpiresAt: now.Add(s.refreshTTL),
 		Role:      user.Role.String(),
-		CreatedAt: time.Now(),
-	})
+		UserAgent: truncate(userAgent, maxUserAgentLen),
+		IP:        ip,
+		CreatedAt: now,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := issueRefreshToken(ctx, s.refreshRepo, s.token, s.uuid, session)
 	if err != nil {
 		return nil, err
 	}
 
-	storesQuantity, err := l.storeRepo.CountByOwnerID(l.context, user.ID)
+	ownedStores, err := s.storeRepo.CountByOwnerID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	memberStores, err := s.memberRepo.CountByUserID(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	storesQuantity := ownedStores + memberStores
 
 	userRole := mapRoleToKind(user.Role)
+	// cliente convidado para a equipe de uma loja também vai para o painel
+	if userRole == entity.UserKindCustomer && memberStores > 0 {
+		userRole = entity.UserKindStore
+	}
 
 	return &LoginOutput{
-		Token: token,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    s.jwtService.GetExpiresAt(token),
 		User: UserLoginOutput{
 			ID:    user.ID,
 			Email: user.Email,
@@ -130,3 +255,10 @@ func decideNextStep(kind entity.UserKind, storesCount int) entity.NextStep {
 	}
 	return entity.NextStepStoreDashboard
 }
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
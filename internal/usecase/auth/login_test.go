@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryaudit "github.com/FabioRocha231/saas-core/internal/infra/db/repository/audit"
+	memoryloginthrottle "github.com/FabioRocha231/saas-core/internal/infra/db/repository/login_throttle"
+	memoryrefreshtoken "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refresh_token"
+	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memorytwofactor "github.com/FabioRocha231/saas-core/internal/infra/db/repository/two_factor"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	memoryverificationcode "github.com/FabioRocha231/saas-core/internal/infra/db/repository/verification_code"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginLockout(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	uuid := pkg.NewUUID()
+	passwordHash := pkg.NewPasswordHash()
+	userRepo := memoryuser.New(clock)
+	auditRepo := memoryaudit.New(clock)
+	throttleRepo := memoryloginthrottle.New()
+	jwtService := pkg.NewJwtService(testkit.NewJwtKeyset(t), 15*time.Minute, "saas-core", uuid, clock)
+	settings := LockoutSettings{MaxFailures: 3, IPMaxFailures: 5, Window: time.Hour, Lockout: time.Minute, MaxLockout: 4 * time.Minute}
+
+	uc := NewLoginUsecase(
+		t.Context(), userRepo, memorysession.New(clock), memorystore.New(), memorystoremember.New(clock),
+		memoryrefreshtoken.New(clock), throttleRepo, auditRepo, memoryverificationcode.New(clock), memorytwofactor.New(clock),
+		jwtService, passwordHash, pkg.NewToken(), uuid, clock, 24*time.Hour, TwoFactorSettings{ChallengeTTL: 5 * time.Minute, MaxAttempts: 5},
+		settings,
+	)
+
+	newUser := func(t *testing.T, email string) string {
+		hash, err := passwordHash.Hash("123456")
+		require.NoError(t, err)
+		id := uuid.Generate()
+		require.NoError(t, userRepo.Create(t.Context(), &entity.User{
+			ID: id, Name: "usuario", Email: email, Cpf: id, Password: hash, Role: entity.UserRoleCostumer,
+		}))
+		return id
+	}
+	login := func(email, password, ip string) error {
+		_, err := uc.Execute(LoginInput{Email: email, Password: password, IP: ip})
+		return err
+	}
+
+	t.Run("test unknown email and wrong password get the same error", func(t *testing.T) {
+		newUser(t, "existe@example.com")
+
+		require.EqualError(t, login("nao-existe@example.com", "123456", "10.0.0.1"), "unauthorized: invalid credentials")
+		require.EqualError(t, login("existe@example.com", "errada", "10.0.0.2"), "unauthorized: invalid credentials")
+	})
+
+	t.Run("test account is locked after max failures with backoff", func(t *testing.T) {
+		userID := newUser(t, "alvo@example.com")
+
+		for i := 0; i < settings.MaxFailures; i++ {
+			require.EqualError(t, login("alvo@example.com", "errada", "10.0.1.1"), "unauthorized: invalid credentials")
+		}
+
+		// nem a senha certa entra durante a trava
+		require.EqualError(t, login("alvo@example.com", "123456", "10.0.1.2"), "rate_limited: too many failed login attempts, try again later")
+
+		entries, err := auditRepo.ListByAction(t.Context(), entity.AuditActionLoginLockout, 0)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, userID, entries[0].UserID)
+		require.Equal(t, "email:alvo@example.com", entries[0].Subject)
+		require.Equal(t, "3 failed attempts, locked for 1m0s", entries[0].Detail)
+
+		// passada a trava, mais uma falha dobra o tempo
+		clock.Advance(time.Minute)
+		require.EqualError(t, login("alvo@example.com", "errada", "10.0.1.3"), "unauthorized: invalid credentials")
+		got, err := throttleRepo.Get(t.Context(), "email:alvo@example.com")
+		require.NoError(t, err)
+		require.Equal(t, clock.Now().Add(2*time.Minute), *got.LockedUntil)
+
+		clock.Advance(2 * time.Minute)
+		require.NoError(t, login("alvo@example.com", "123456", "10.0.1.4"))
+
+		// login certo zera a conta
+		_, err = throttleRepo.Get(t.Context(), "email:alvo@example.com")
+		require.Error(t, err)
+	})
+
+	t.Run("test ip is locked across accounts", func(t *testing.T) {
+		newUser(t, "vitima@example.com")
+
+		for i := 0; i < settings.IPMaxFailures; i++ {
+			err := login("conta-"+uuid.Generate()+"@example.com", "errada", "10.0.2.1")
+			require.EqualError(t, err, "unauthorized: invalid credentials")
+		}
+
+		require.EqualError(t, login("vitima@example.com", "123456", "10.0.2.1"), "rate_limited: too many failed login attempts, try again later")
+		require.NoError(t, login("vitima@example.com", "123456", "10.0.2.2"))
+	})
+}
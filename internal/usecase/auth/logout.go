@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type LogoutInput struct {
+	SessionID string
+	UserID    string
+}
+
+// LogoutUsecase encerra a sessão do token atual e os refresh tokens dela.
+// Como o middleware busca a sessão a cada request, o token deixa de valer na hora.
+type LogoutUsecase struct {
+	sessionRepo repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+}
+
+func NewLogoutUsecase(sessionRepo repository.SessionRepository, refreshRepo repository.RefreshTokenRepository) *LogoutUsecase {
+	return &LogoutUsecase{sessionRepo: sessionRepo, refreshRepo: refreshRepo}
+}
+
+func (uc *LogoutUsecase) Execute(ctx context.Context, in LogoutInput) error {
+	if in.SessionID == "" {
+		return errx.New(errx.CodeInvalid, "missing session id")
+	}
+	if in.UserID == "" {
+		return errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	s, err := uc.sessionRepo.GetByID(ctx, in.SessionID)
+	if err != nil {
+		return err
+	}
+	if s.UserID != in.UserID {
+		return errx.New(errx.CodeNotFound, "session not found")
+	}
+
+	return revokeSession(ctx, uc.sessionRepo, uc.refreshRepo, s.ID)
+}
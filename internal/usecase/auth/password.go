@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type PasswordResetSettings struct {
+	// validade do token enviado por email
+	TTL time.Duration
+	// palpites errados aceitos antes de exigir um token novo
+	MaxAttempts int
+	// intervalo mínimo entre dois envios para o mesmo usuário
+	ResendAfter time.Duration
+}
+
+type ForgotPasswordInput struct {
+	Email string
+}
+
+// ForgotPasswordUsecase envia um token de redefinição para o email. Responde
+// igual para email desconhecido, bloqueado ou em intervalo de reenvio, para
+// não revelar quem tem conta.
+type ForgotPasswordUsecase struct {
+	userRepo repository.UserRepository
+	codeRepo repository.VerificationCodeRepository
+	sender   ports.MessageSender
+	token    ports.TokenInterface
+	uuid     ports.UUIDInterface
+	clock    ports.Clock
+	settings PasswordResetSettings
+}
+
+func NewForgotPasswordUsecase(
+	userRepo repository.UserRepository,
+	codeRepo repository.VerificationCodeRepository,
+	sender ports.MessageSender,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+	settings PasswordResetSettings,
+) *ForgotPasswordUsecase {
+	return &ForgotPasswordUsecase{
+		userRepo: userRepo,
+		codeRepo: codeRepo,
+		sender:   sender,
+		token:    token,
+		uuid:     uuid,
+		clock:    clock,
+		settings: settings,
+	}
+}
+
+func (uc *ForgotPasswordUsecase) Execute(ctx context.Context, in ForgotPasswordInput) error {
+	email := strings.TrimSpace(in.Email)
+	if email == "" {
+		return errx.New(errx.CodeInvalid, "missing email")
+	}
+
+	user, err := uc.userRepo.GetByMail(ctx, email)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.Status == entity.UserStatusBlocked {
+		return nil
+	}
+
+	now := uc.clock.Now()
+
+	last, err := uc.codeRepo.GetLatest(ctx, user.ID, entity.VerificationPurposePasswordReset)
+	switch {
+	case err == nil:
+		if !last.IsConsumed() && now.Before(last.CreatedAt.Add(uc.settings.ResendAfter)) {
+			return nil
+		}
+	case !errx.Is(err, errx.CodeNotFound):
+		return err
+	}
+
+	raw, err := uc.token.Generate()
+	if err != nil {
+		return err
+	}
+
+	c := &entity.VerificationCode{
+		ID:        uc.uuid.Generate(),
+		UserID:    user.ID,
+		Purpose:   entity.VerificationPurposePasswordReset,
+		Target:    user.Email,
+		ExpiresAt: now.Add(uc.settings.TTL),
+		CreatedAt: now,
+	}
+	c.CodeHash = uc.token.Hash(c.ID + ":" + raw)
+
+	if err := uc.codeRepo.Create(ctx, c); err != nil {
+		return err
+	}
+
+	return uc.sender.Send(ctx, ports.Message{
+		Channel: ports.MessageChannelEmail,
+		To:      user.Email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf(
+			"Use o token %s para redefinir sua senha. Ele expira em %d minutos. Se não foi você, ignore esta mensagem.",
+			raw, int(uc.settings.TTL.Minutes()),
+		),
+	})
+}
+
+type ResetPasswordInput struct {
+	Email       string
+	Token       string
+	NewPassword string
+}
+
+// ResetPasswordUsecase troca a senha com o token do email e derruba todas as
+// sessões do usuário.
+type ResetPasswordUsecase struct {
+	userRepo     repository.UserRepository
+	codeRepo     repository.VerificationCodeRepository
+	sessionRepo  repository.SessionRepository
+	refreshRepo  repository.RefreshTokenRepository
+	passwordHash ports.PasswordHashInterface
+	token        ports.TokenInterface
+	tx           ports.TxManager
+	clock        ports.Clock
+	settings     PasswordResetSettings
+}
+
+func NewResetPasswordUsecase(
+	userRepo repository.UserRepository,
+	codeRepo repository.VerificationCodeRepository,
+	sessionRepo repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	passwordHash ports.PasswordHashInterface,
+	token ports.TokenInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	settings PasswordResetSettings,
+) *ResetPasswordUsecase {
+	return &ResetPasswordUsecase{
+		userRepo:     userRepo,
+		codeRepo:     codeRepo,
+		sessionRepo:  sessionRepo,
+		refreshRepo:  refreshRepo,
+		passwordHash: passwordHash,
+		token:        token,
+		tx:           tx,
+		clock:        clock,
+		settings:     settings,
+	}
+}
+
+func (uc *ResetPasswordUsecase) Execute(ctx context.Context, in ResetPasswordInput) error {
+	email := strings.TrimSpace(in.Email)
+	if email == "" {
+		return errx.New(errx.CodeInvalid, "missing email")
+	}
+	if in.Token == "" {
+		return errx.New(errx.CodeInvalid, "missing token")
+	}
+	if strings.TrimSpace(in.NewPassword) == "" {
+		return errx.New(errx.CodeInvalid, "invalid password")
+	}
+
+	// mesmo erro para email desconhecido, conta bloqueada e token errado até o
+	// token ser conferido
+	invalid := errx.New(errx.CodeInvalid, "invalid or expired reset token")
+
+	user, err := uc.userRepo.GetByMail(ctx, email)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return invalid
+		}
+		return err
+	}
+
+	c, err := uc.codeRepo.GetLatest(ctx, user.ID, entity.VerificationPurposePasswordReset)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return invalid
+		}
+		return err
+	}
+
+	now := uc.clock.Now()
+	if c.IsConsumed() || c.IsExpired(now) || c.Target != user.Email {
+		return invalid
+	}
+	// conta a tentativa antes de comparar: palpites em paralelo não passam de
+	// MaxAttempts
+	attempts, err := uc.codeRepo.IncrementAttempts(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if attempts > uc.settings.MaxAttempts {
+		return invalid
+	}
+	if subtle.ConstantTimeCompare([]byte(uc.token.Hash(c.ID+":"+in.Token)), []byte(c.CodeHash)) != 1 {
+		return invalid
+	}
+	if user.Status == entity.UserStatusBlocked {
+		return errx.New(errx.CodeForbidden, "user is blocked")
+	}
+
+	hash, err := uc.passwordHash.Hash(in.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.codeRepo.MarkConsumed(ctx, c.ID, now); err != nil {
+			if errx.Is(err, errx.CodeConflict) {
+				return invalid
+			}
+			return err
+		}
+
+		user.Password = hash
+		// o token chegou pelo email, então o email está confirmado
+		if user.EmailVerifiedAt == nil {
+			user.EmailVerifiedAt = &now
+		}
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		_, err := revokeUserSessions(ctx, uc.sessionRepo, uc.refreshRepo, user.ID)
+		return err
+	})
+}
+
+type ChangePasswordInput struct {
+	UserID          string
+	CurrentPassword string
+	NewPassword     string
+}
+
+// ChangePasswordUsecase troca a senha do usuário logado. Todas as sessões,
+// inclusive a atual, caem: o cliente precisa logar de novo.
+type ChangePasswordUsecase struct {
+	userRepo     repository.UserRepository
+	sessionRepo  repository.SessionRepository
+	refreshRepo  repository.RefreshTokenRepository
+	passwordHash ports.PasswordHashInterface
+	tx           ports.TxManager
+}
+
+func NewChangePasswordUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	passwordHash ports.PasswordHashInterface,
+	tx ports.TxManager,
+) *ChangePasswordUsecase {
+	return &ChangePasswordUsecase{
+		userRepo:     userRepo,
+		sessionRepo:  sessionRepo,
+		refreshRepo:  refreshRepo,
+		passwordHash: passwordHash,
+		tx:           tx,
+	}
+}
+
+func (uc *ChangePasswordUsecase) Execute(ctx context.Context, in ChangePasswordInput) error {
+	if in.UserID == "" {
+		return errx.New(errx.CodeUnauthorized, "missing user")
+	}
+	if in.CurrentPassword == "" {
+		return errx.New(errx.CodeInvalid, "missing current password")
+	}
+	if strings.TrimSpace(in.NewPassword) == "" {
+		return errx.New(errx.CodeInvalid, "invalid password")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, in.UserID)
+	if err != nil {
+		return err
+	}
+	if !uc.passwordHash.Verify(user.Password, in.CurrentPassword) {
+		return errx.New(errx.CodeForbidden, "current password is incorrect")
+	}
+	if uc.passwordHash.Verify(user.Password, in.NewPassword) {
+		return errx.New(errx.CodeInvalid, "new password must differ from the current one")
+	}
+
+	hash, err := uc.passwordHash.Hash(in.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		user.Password = hash
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		_, err := revokeUserSessions(ctx, uc.sessionRepo, uc.refreshRepo, user.ID)
+		return err
+	})
+}
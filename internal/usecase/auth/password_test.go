@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryrefreshtoken "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refresh_token"
+	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	memoryverificationcode "github.com/FabioRocha231/saas-core/internal/infra/db/repository/verification_code"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+var resetTokenPattern = regexp.MustCompile(`token (\S+) `)
+
+func TestPassword(t *testing.T) {
+	uuid := pkg.NewUUID()
+	token := pkg.NewToken()
+	passwordHash := pkg.NewPasswordHash()
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	settings := PasswordResetSettings{TTL: 30 * time.Minute, MaxAttempts: 3, ResendAfter: time.Minute}
+
+	userRepo := memoryuser.New(clock)
+	codeRepo := memoryverificationcode.New(clock)
+	sessionRepo := memorysession.New(clock)
+	refreshRepo := memoryrefreshtoken.New(clock)
+	tx := memorytx.New()
+	outbox := testkit.NewOutbox()
+
+	forgot := NewForgotPasswordUsecase(userRepo, codeRepo, outbox, token, uuid, clock, settings)
+	reset := NewResetPasswordUsecase(userRepo, codeRepo, sessionRepo, refreshRepo, passwordHash, token, tx, clock, settings)
+	change := NewChangePasswordUsecase(userRepo, sessionRepo, refreshRepo, passwordHash, tx)
+
+	newUser := func(t *testing.T) *entity.User {
+		hash, err := passwordHash.Hash("senha-antiga")
+		require.NoError(t, err)
+		u := &entity.User{ID: uuid.Generate(), Name: "usuario", Role: entity.UserRoleCostumer, Password: hash}
+		u.Email = u.ID + "@example.com"
+		u.Cpf = u.ID
+		require.NoError(t, userRepo.Create(t.Context(), u))
+		for _, id := range []string{"celular", "notebook"} {
+			require.NoError(t, sessionRepo.Create(t.Context(), &entity.Session{
+				ID: u.ID + "-" + id, UserID: u.ID, Role: "costumer", ExpiresAt: clock.Now().Add(time.Hour),
+			}))
+		}
+		return u
+	}
+	resetToken := func(t *testing.T, email string) string {
+		m := resetTokenPattern.FindStringSubmatch(outbox.LastMessage(t, email).Body)
+		require.Len(t, m, 2)
+		return m[1]
+	}
+	passwordIs := func(t *testing.T, userID, plain string) bool {
+		u, err := userRepo.GetByID(t.Context(), userID)
+		require.NoError(t, err)
+		return passwordHash.Verify(u.Password, plain)
+	}
+	sessionsOf := func(t *testing.T, userID string) int {
+		s, err := sessionRepo.ListByUserID(t.Context(), userID)
+		require.NoError(t, err)
+		return len(s)
+	}
+
+	t.Run("test forgot and reset password", func(t *testing.T) {
+		u := newUser(t)
+
+		require.NoError(t, forgot.Execute(t.Context(), ForgotPasswordInput{Email: u.Email}))
+		tok := resetToken(t, u.Email)
+
+		require.NoError(t, reset.Execute(t.Context(), ResetPasswordInput{Email: u.Email, Token: tok, NewPassword: "senha-nova"}))
+		require.True(t, passwordIs(t, u.ID, "senha-nova"))
+		require.Zero(t, sessionsOf(t, u.ID))
+
+		got, err := userRepo.GetByID(t.Context(), u.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.EmailVerifiedAt)
+
+		// uso único
+		err = reset.Execute(t.Context(), ResetPasswordInput{Email: u.Email, Token: tok, NewPassword: "outra"})
+		require.EqualError(t, err, "invalid_argument: invalid or expired reset token")
+	})
+
+	t.Run("test forgot password does not reveal unknown emails", func(t *testing.T) {
+		before := len(outbox.Messages())
+		require.NoError(t, forgot.Execute(t.Context(), ForgotPasswordInput{Email: "ninguem@example.com"}))
+		require.Len(t, outbox.Messages(), before)
+	})
+
+	t.Run("test expired reset token", func(t *testing.T) {
+		u := newUser(t)
+
+		require.NoError(t, forgot.Execute(t.Context(), ForgotPasswordInput{Email: u.Email}))
+		tok := resetToken(t, u.Email)
+		clock.Advance(31 * time.Minute)
+
+		err := reset.Execute(t.Context(), ResetPasswordInput{Email: u.Email, Token: tok, NewPassword: "senha-nova"})
+		require.EqualError(t, err, "invalid_argument: invalid or expired reset token")
+		require.True(t, passwordIs(t, u.ID, "senha-antiga"))
+		require.Equal(t, 2, sessionsOf(t, u.ID))
+	})
+
+	t.Run("test wrong reset tokens burn the token", func(t *testing.T) {
+		u := newUser(t)
+
+		require.NoError(t, forgot.Execute(t.Context(), ForgotPasswordInput{Email: u.Email}))
+		tok := resetToken(t, u.Email)
+
+		for range settings.MaxAttempts {
+			err := reset.Execute(t.Context(), ResetPasswordInput{Email: u.Email, Token: "errado", NewPassword: "senha-nova"})
+			require.True(t, errx.Is(err, errx.CodeInvalid))
+		}
+		err := reset.Execute(t.Context(), ResetPasswordInput{Email: u.Email, Token: tok, NewPassword: "senha-nova"})
+		require.EqualError(t, err, "invalid_argument: invalid or expired reset token")
+	})
+
+	t.Run("test reset password does not reveal a blocked account before the token", func(t *testing.T) {
+		u := newUser(t)
+
+		require.NoError(t, forgot.Execute(t.Context(), ForgotPasswordInput{Email: u.Email}))
+		tok := resetToken(t, u.Email)
+
+		// bloqueado depois de pedir o token
+		got, err := userRepo.GetByID(t.Context(), u.ID)
+		require.NoError(t, err)
+		got.Status = entity.UserStatusBlocked
+		require.NoError(t, userRepo.Update(t.Context(), got))
+
+		err = reset.Execute(t.Context(), ResetPasswordInput{Email: u.Email, Token: "errado", NewPassword: "senha-nova"})
+		require.EqualError(t, err, "invalid_argument: invalid or expired reset token")
+
+		err = reset.Execute(t.Context(), ResetPasswordInput{Email: u.Email, Token: tok, NewPassword: "senha-nova"})
+		require.EqualError(t, err, "forbidden: user is blocked")
+		require.True(t, passwordIs(t, u.ID, "senha-antiga"))
+	})
+
+	t.Run("test change password checks the current one and revokes sessions", func(t *testing.T) {
+		u := newUser(t)
+
+		err := change.Execute(t.Context(), ChangePasswordInput{UserID: u.ID, CurrentPassword: "errada", NewPassword: "senha-nova"})
+		require.EqualError(t, err, "forbidden: current password is incorrect")
+		require.Equal(t, 2, sessionsOf(t, u.ID))
+
+		err = change.Execute(t.Context(), ChangePasswordInput{UserID: u.ID, CurrentPassword: "senha-antiga", NewPassword: "senha-antiga"})
+		require.EqualError(t, err, "invalid_argument: new password must differ from the current one")
+
+		require.NoError(t, change.Execute(t.Context(), ChangePasswordInput{UserID: u.ID, CurrentPassword: "senha-antiga", NewPassword: "senha-nova"}))
+		require.True(t, passwordIs(t, u.ID, "senha-nova"))
+		require.Zero(t, sessionsOf(t, u.ID))
+	})
+}
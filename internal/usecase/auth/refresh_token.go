@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+// issueRefreshToken grava o hash de um novo refresh token da família da
+// sessão e devolve o valor em claro, que só o cliente conhece.
+func issueRefreshToken(
+	ctx context.Context,
+	refreshRepo repository.RefreshTokenRepository,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	session *entity.Session,
+) (string, error) {
+	raw, err := token.Generate()
+	if err != nil {
+		return "", errx.Wrap(errx.CodeInternal, "generate refresh token", err)
+	}
+
+	err = refreshRepo.Create(ctx, &entity.RefreshToken{
+		ID:        uuid.Generate(),
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		TokenHash: token.Hash(raw),
+		// o login tem validade fixa, a rotação não estende
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// revokeSession derruba o login inteiro: a sessão (access token) e todos os
+// refresh tokens da família.
+func revokeSession(
+	ctx context.Context,
+	sessionRepo repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	sessionID string,
+) error {
+	if err := refreshRepo.DeleteBySessionID(ctx, sessionID); err != nil {
+		return err
+	}
+	if err := sessionRepo.Delete(ctx, sessionID); err != nil && !errx.Is(err, errx.CodeNotFound) {
+		return err
+	}
+	return nil
+}
+
+type RefreshInput struct {
+	RefreshToken string
+}
+
+type RefreshOutput struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// RefreshUsecase troca um refresh token por um novo par (rotação). O token
+// apresentado é marcado como usado; se ele voltar a aparecer alguém tem uma
+// cópia, então a família toda é revogada.
+type RefreshUsecase struct {
+	sessionRepo repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+	jwtService  ports.JwtInterface
+	token       ports.TokenInterface
+	uuid        ports.UUIDInterface
+	tx          ports.TxManager
+	clock       ports.Clock
+}
+
+func NewRefreshUsecase(
+	sessionRepo repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	jwtService ports.JwtInterface,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+) *RefreshUsecase {
+	return &RefreshUsecase{
+		sessionRepo: sessionRepo,
+		refreshRepo: refreshRepo,
+		jwtService:  jwtService,
+		token:       token,
+		uuid:        uuid,
+		tx:          tx,
+		clock:       clock,
+	}
+}
+
+func (uc *RefreshUsecase) Execute(ctx context.Context, in RefreshInput) (*RefreshOutput, error) {
+	if in.RefreshToken == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing refresh token")
+	}
+
+	current, err := uc.refreshRepo.GetByHash(ctx, uc.token.Hash(in.RefreshToken))
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errx.New(errx.CodeUnauthorized, "invalid refresh token")
+		}
+		return nil, err
+	}
+
+	if current.IsUsed() {
+		return nil, uc.reused(ctx, current.SessionID)
+	}
+
+	now := uc.clock.Now()
+	if current.IsExpired(now) {
+		return nil, errx.New(errx.CodeUnauthorized, "refresh token expired")
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, current.SessionID)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			// logout já derrubou a sessão, limpa o que sobrou da família
+			if err := uc.refreshRepo.DeleteBySessionID(ctx, current.SessionID); err != nil {
+				return nil, err
+			}
+			return nil, errx.New(errx.CodeUnauthorized, "invalid session")
+		}
+		return nil, err
+	}
+	if !session.ExpiresAt.After(now) {
+		return nil, errx.New(errx.CodeUnauthorized, "session expired")
+	}
+
+	var out RefreshOutput
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.refreshRepo.MarkUsed(ctx, current.ID, now); err != nil {
+			return err
+		}
+
+		raw, err := issueRefreshToken(ctx, uc.refreshRepo, uc.token, uc.uuid, session)
+		if err != nil {
+			return err
+		}
+
+		access, err := uc.jwtService.SignWithID(session.ID, session.UserID, session.Role)
+		if err != nil {
+			return errx.Wrap(errx.CodeInternal, "sign token", err)
+		}
+
+		out = RefreshOutput{Token: access, RefreshToken: raw, ExpiresAt: uc.jwtService.GetExpiresAt(access)}
+		return nil
+	})
+	if err != nil {
+		// outra request rotacionou o mesmo token antes: também é reuso
+		if errx.Is(err, errx.CodeConflict) {
+			return nil, uc.reused(ctx, current.SessionID)
+		}
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (uc *RefreshUsecase) reused(ctx context.Context, sessionID string) error {
+	if err := revokeSession(ctx, uc.sessionRepo, uc.refreshRepo, sessionID); err != nil {
+		return err
+	}
+	return errx.New(errx.CodeUnauthorized, "refresh token reused")
+}
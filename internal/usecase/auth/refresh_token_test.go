@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryrefreshtoken "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refresh_token"
+	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefresh(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	uuid := pkg.NewUUID()
+	token := pkg.NewToken()
+	sessionRepo := memorysession.New(clock)
+	refreshRepo := memoryrefreshtoken.New(clock)
+	tx := memorytx.New()
+	jwtService := pkg.NewJwtService(testkit.NewJwtKeyset(t), 15*time.Minute, "saas-core", uuid, clock)
+	uc := NewRefreshUsecase(sessionRepo, refreshRepo, jwtService, token, uuid, tx, clock)
+
+	login := func(t *testing.T) (*entity.Session, string) {
+		t.Helper()
+		s := &entity.Session{ID: uuid.Generate(), UserID: "user-1", Role: "costumer", ExpiresAt: clock.Now().Add(24 * time.Hour)}
+		require.NoError(t, sessionRepo.Create(t.Context(), s))
+		raw, err := issueRefreshToken(t.Context(), refreshRepo, token, uuid, s)
+		require.NoError(t, err)
+		return s, raw
+	}
+	sessionExists := func(t *testing.T, id string) bool {
+		_, err := sessionRepo.GetByID(t.Context(), id)
+		return err == nil
+	}
+
+	t.Run("test only the hash is stored", func(t *testing.T) {
+		_, raw := login(t)
+
+		_, err := refreshRepo.GetByHash(t.Context(), raw)
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+		_, err = refreshRepo.GetByHash(t.Context(), token.Hash(raw))
+		require.NoError(t, err)
+	})
+
+	t.Run("test refresh rotates the token and keeps the session jti", func(t *testing.T) {
+		s, raw := login(t)
+
+		out, err := uc.Execute(t.Context(), RefreshInput{RefreshToken: raw})
+		require.NoError(t, err)
+		require.NotEqual(t, raw, out.RefreshToken)
+		require.Equal(t, clock.Now().Add(15*time.Minute).Unix(), out.ExpiresAt.Unix())
+
+		claims, err := jwtService.Parse(out.Token)
+		require.NoError(t, err)
+		require.Equal(t, s.ID, claims.ID)
+		require.Equal(t, "user-1", claims.UserID)
+
+		// o novo token também gira
+		_, err = uc.Execute(t.Context(), RefreshInput{RefreshToken: out.RefreshToken})
+		require.NoError(t, err)
+	})
+
+	t.Run("test replaying a rotated token revokes the whole family", func(t *testing.T) {
+		s, raw := login(t)
+
+		out, err := uc.Execute(t.Context(), RefreshInput{RefreshToken: raw})
+		require.NoError(t, err)
+
+		_, err = uc.Execute(t.Context(), RefreshInput{RefreshToken: raw})
+		require.Error(t, err)
+		require.Equal(t, "unauthorized: refresh token reused", err.Error())
+
+		// quem tinha o token legítimo também perde o acesso
+		require.False(t, sessionExists(t, s.ID))
+		_, err = uc.Execute(t.Context(), RefreshInput{RefreshToken: out.RefreshToken})
+		require.Equal(t, "unauthorized: invalid refresh token", err.Error())
+	})
+
+	t.Run("test unknown refresh token", func(t *testing.T) {
+		_, err := uc.Execute(t.Context(), RefreshInput{RefreshToken: "nope"})
+		require.Equal(t, "unauthorized: invalid refresh token", err.Error())
+	})
+
+	t.Run("test refresh after logout", func(t *testing.T) {
+		s, raw := login(t)
+		require.NoError(t, NewLogoutUsecase(sessionRepo, refreshRepo).Execute(t.Context(), LogoutInput{SessionID: s.ID, UserID: "user-1"}))
+
+		_, err := uc.Execute(t.Context(), RefreshInput{RefreshToken: raw})
+		require.Equal(t, "unauthorized: invalid refresh token", err.Error())
+	})
+
+	t.Run("test refresh token expires with the login", func(t *testing.T) {
+		_, raw := login(t)
+		clock.Advance(24 * time.Hour)
+
+		_, err := uc.Execute(t.Context(), RefreshInput{RefreshToken: raw})
+		require.Equal(t, "unauthorized: refresh token expired", err.Error())
+	})
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type SessionOutput struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ListSessionsInput struct {
+	UserID           string
+	CurrentSessionID string
+}
+
+type ListSessionsUsecase struct {
+	sessionRepo repository.SessionRepository
+	clock       ports.Clock
+}
+
+func NewListSessionsUsecase(sessionRepo repository.SessionRepository, clock ports.Clock) *ListSessionsUsecase {
+	return &ListSessionsUsecase{sessionRepo: sessionRepo, clock: clock}
+}
+
+// Execute lista só as sessões ainda válidas, as vencidas esperam o DeleteExpired.
+func (uc *ListSessionsUsecase) Execute(ctx context.Context, in ListSessionsInput) ([]SessionOutput, error) {
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	sessions, err := uc.sessionRepo.ListByUserID(ctx, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+	out := make([]SessionOutput, 0, len(sessions))
+	for _, s := range sessions {
+		if !s.ExpiresAt.After(now) {
+			continue
+		}
+		out = append(out, SessionOutput{
+			ID:        s.ID,
+			UserAgent: s.UserAgent,
+			IP:        s.IP,
+			Current:   s.ID == in.CurrentSessionID,
+			CreatedAt: s.CreatedAt,
+			ExpiresAt: s.ExpiresAt,
+		})
+	}
+	return out, nil
+}
+
+type RevokeAllSessionsOutput struct {
+	Revoked int `json:"revoked"`
+}
+
+// RevokeAllSessionsUsecase é o "sair de todos os dispositivos", incluindo o atual.
+type RevokeAllSessionsUsecase struct {
+	sessionRepo repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+}
+
+func NewRevokeAllSessionsUsecase(sessionRepo repository.SessionRepository, refreshRepo repository.RefreshTokenRepository) *RevokeAllSessionsUsecase {
+	return &RevokeAllSessionsUsecase{sessionRepo: sessionRepo, refreshRepo: refreshRepo}
+}
+
+func (uc *RevokeAllSessionsUsecase) Execute(ctx context.Context, userID string) (*RevokeAllSessionsOutput, error) {
+	if userID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	revoked, err := revokeUserSessions(ctx, uc.sessionRepo, uc.refreshRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &RevokeAllSessionsOutput{Revoked: revoked}, nil
+}
+
+// revokeUserSessions derruba todas as sessões do usuário e devolve quantas eram.
+func revokeUserSessions(
+	ctx context.Context,
+	sessionRepo repository.SessionRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	userID string,
+) (int, error) {
+	sessions, err := sessionRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range sessions {
+		if err := revokeSession(ctx, sessionRepo, refreshRepo, s.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(sessions), nil
+}
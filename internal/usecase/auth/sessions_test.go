@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryrefreshtoken "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refresh_token"
+	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessions(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	sessionRepo := memorysession.New(clock)
+	refreshRepo := memoryrefreshtoken.New(clock)
+
+	seed := func(t *testing.T, id, userID string, ttl time.Duration) {
+		t.Helper()
+		require.NoError(t, sessionRepo.Create(t.Context(), &entity.Session{
+			ID: id, UserID: userID, Role: "costumer", UserAgent: "Firefox", IP: "10.0.0.1",
+			ExpiresAt: clock.Now().Add(ttl),
+		}))
+	}
+	seed(t, "phone", "user-1", time.Hour)
+	seed(t, "laptop", "user-1", 2*time.Hour)
+	seed(t, "old", "user-1", time.Minute)
+	seed(t, "other", "user-2", time.Hour)
+	clock.Advance(2 * time.Minute)
+
+	t.Run("test list only active sessions and flag the current one", func(t *testing.T) {
+		out, err := NewListSessionsUsecase(sessionRepo, clock).Execute(t.Context(), ListSessionsInput{
+			UserID:           "user-1",
+			CurrentSessionID: "laptop",
+		})
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+
+		current := map[string]bool{}
+		for _, s := range out {
+			current[s.ID] = s.Current
+			require.Equal(t, "Firefox", s.UserAgent)
+			require.Equal(t, "10.0.0.1", s.IP)
+		}
+		require.Equal(t, map[string]bool{"phone": false, "laptop": true}, current)
+	})
+
+	t.Run("test logout of a session owned by another user", func(t *testing.T) {
+		err := NewLogoutUsecase(sessionRepo, refreshRepo).Execute(t.Context(), LogoutInput{SessionID: "other", UserID: "user-1"})
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		_, err = sessionRepo.GetByID(t.Context(), "other")
+		require.NoError(t, err)
+	})
+
+	t.Run("test logout revokes only the current session", func(t *testing.T) {
+		require.NoError(t, NewLogoutUsecase(sessionRepo, refreshRepo).Execute(t.Context(), LogoutInput{SessionID: "phone", UserID: "user-1"}))
+
+		_, err := sessionRepo.GetByID(t.Context(), "phone")
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+		_, err = sessionRepo.GetByID(t.Context(), "laptop")
+		require.NoError(t, err)
+	})
+
+	t.Run("test revoke all sessions of the user", func(t *testing.T) {
+		out, err := NewRevokeAllSessionsUsecase(sessionRepo, refreshRepo).Execute(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Equal(t, 2, out.Revoked) // laptop + a vencida ainda não limpa
+
+		sessions, err := sessionRepo.ListByUserID(t.Context(), "user-1")
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+
+		_, err = sessionRepo.GetByID(t.Context(), "other")
+		require.NoError(t, err)
+	})
+}
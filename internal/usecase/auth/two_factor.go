@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	twofactoruc "github.com/FabioRocha231/saas-core/internal/usecase/two_factor"
+)
+
+type TwoFactorSettings struct {
+	// validade do desafio entre a senha e o código
+	ChallengeTTL time.Duration
+	// códigos errados aceitos por desafio
+	MaxAttempts int
+}
+
+// TwoFactorChallenge é a resposta do login quando falta o segundo fator.
+type TwoFactorChallenge struct {
+	Required       bool      `json:"two_factor_required"`
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// errInvalidChallenge cobre desafio inexistente, vencido, usado ou esgotado.
+var errInvalidChallenge = errx.New(errx.CodeUnauthorized, "invalid or expired two-factor challenge")
+
+var errInvalidTwoFactorCode = errx.New(errx.CodeUnauthorized, "invalid two-factor code")
+
+// issueTwoFactorChallenge guarda o desafio como código de verificação. O token
+// é "<id>.<segredo>": o id localiza o registro, o segredo só existe como hash.
+func issueTwoFactorChallenge(
+	ctx context.Context,
+	codeRepo repository.VerificationCodeRepository,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+	settings TwoFactorSettings,
+	userID string,
+) (*TwoFactorChallenge, error) {
+	secret, err := token.Generate()
+	if err != nil {
+		return nil, errx.Wrap(errx.CodeInternal, "generate two-factor challenge", err)
+	}
+
+	now := clock.Now()
+	c := &entity.VerificationCode{
+		ID:        uuid.Generate(),
+		UserID:    userID,
+		Purpose:   entity.VerificationPurposeLogin2FA,
+		ExpiresAt: now.Add(settings.ChallengeTTL),
+		CreatedAt: now,
+	}
+	c.CodeHash = challengeHash(token, c.ID, secret)
+	if err := codeRepo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorChallenge{
+		Required:       true,
+		ChallengeToken: c.ID + "." + secret,
+		ExpiresAt:      c.ExpiresAt,
+	}, nil
+}
+
+func challengeHash(token ports.TokenInterface, id, secret string) string {
+	return token.Hash(id + ":" + secret)
+}
+
+type CompleteTwoFactorLoginInput struct {
+	ChallengeToken string
+	// código do app ou de recuperação
+	Code string
+
+	UserAgent string
+	IP        string
+}
+
+// CompleteTwoFactorLoginUsecase é o segundo passo do login: troca o desafio e
+// o código por uma sessão.
+type CompleteTwoFactorLoginUsecase struct {
+	userRepo      repository.UserRepository
+	codeRepo      repository.VerificationCodeRepository
+	twoFactorRepo repository.TwoFactorRepository
+	checker       *twofactoruc.Checker
+	token         ports.TokenInterface
+	tx            ports.TxManager
+	clock         ports.Clock
+	settings      TwoFactorSettings
+	starter       *sessionStarter
+	guard         *loginGuard
+}
+
+func NewCompleteTwoFactorLoginUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	storeRepo repository.StoreRepository,
+	memberRepo repository.StoreMemberRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	throttleRepo repository.LoginThrottleRepository,
+	auditRepo repository.AuditRepository,
+	codeRepo repository.VerificationCodeRepository,
+	twoFactorRepo repository.TwoFactorRepository,
+	jwtService ports.JwtInterface,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	totp ports.TOTPInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	refreshTTL time.Duration,
+	settings TwoFactorSettings,
+	lockout LockoutSettings,
+) *CompleteTwoFactorLoginUsecase {
+	return &CompleteTwoFactorLoginUsecase{
+		userRepo:      userRepo,
+		codeRepo:      codeRepo,
+		twoFactorRepo: twoFactorRepo,
+		checker:       twofactoruc.NewChecker(twoFactorRepo, totp, token, clock),
+		token:         token,
+		tx:            tx,
+		clock:         clock,
+		settings:      settings,
+		starter: &sessionStarter{
+			sessionRepo: sessionRepo,
+			storeRepo:   storeRepo,
+			memberRepo:  memberRepo,
+			refreshRepo: refreshRepo,
+			jwtService:  jwtService,
+			token:       token,
+			uuid:        uuid,
+			clock:       clock,
+			refreshTTL:  refreshTTL,
+		},
+		guard: &loginGuard{
+			throttleRepo: throttleRepo,
+			auditRepo:    auditRepo,
+			uuid:         uuid,
+			clock:        clock,
+			settings:     lockout,
+		},
+	}
+}
+
+func (uc *CompleteTwoFactorLoginUsecase) Execute(ctx context.Context, in CompleteTwoFactorLoginInput) (*LoginOutput, error) {
+	if strings.TrimSpace(in.Code) == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing code")
+	}
+
+	challenge, err := uc.getChallenge(ctx, in.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, err
+	}
+	if err := uc.guard.check(ctx, user.Email, in.IP); err != nil {
+		return nil, err
+	}
+
+	tf, err := uc.twoFactorRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, err
+	}
+	if !tf.IsEnabled() {
+		return nil, errInvalidChallenge
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.codeRepo.MarkConsumed(ctx, challenge.ID, uc.clock.Now()); err != nil {
+			if errx.Is(err, errx.CodeConflict) {
+				return errInvalidChallenge
+			}
+			return err
+		}
+		ok, err := uc.checker.Verify(ctx, tf, in.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errInvalidTwoFactorCode
+		}
+		return nil
+	})
+	if err == errInvalidTwoFactorCode {
+		// fora da transação: o palpite errado conta no desafio e na trava da conta
+		if _, err := uc.codeRepo.IncrementAttempts(ctx, challenge.ID); err != nil {
+			return nil, err
+		}
+		if err := uc.guard.fail(ctx, user.Email, in.IP, user.ID); err != errInvalidCredentials {
+			return nil, err
+		}
+		return nil, errInvalidTwoFactorCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.guard.succeed(ctx, user.Email); err != nil {
+		return nil, err
+	}
+	return uc.starter.start(ctx, user, in.UserAgent, in.IP)
+}
+
+func (uc *CompleteTwoFactorLoginUsecase) getChallenge(ctx context.Context, raw string) (*entity.VerificationCode, error) {
+	id, secret, ok := strings.Cut(strings.TrimSpace(raw), ".")
+	if !ok || id == "" || secret == "" {
+		return nil, errInvalidChallenge
+	}
+
+	c, err := uc.codeRepo.GetByID(ctx, id)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errInvalidChallenge
+		}
+		return nil, err
+	}
+	if c.Purpose != entity.VerificationPurposeLogin2FA ||
+		subtle.ConstantTimeCompare([]byte(challengeHash(uc.token, c.ID, secret)), []byte(c.CodeHash)) != 1 {
+		return nil, errInvalidChallenge
+	}
+
+	now := uc.clock.Now()
+	if c.IsConsumed() || c.IsExpired(now) || c.Attempts >= uc.settings.MaxAttempts {
+		return nil, errInvalidChallenge
+	}
+	return c, nil
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryaudit "github.com/FabioRocha231/saas-core/internal/infra/db/repository/audit"
+	memoryloginthrottle "github.com/FabioRocha231/saas-core/internal/infra/db/repository/login_throttle"
+	memoryrefreshtoken "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refresh_token"
+	memorysession "github.com/FabioRocha231/saas-core/internal/infra/db/repository/session"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memorytwofactor "github.com/FabioRocha231/saas-core/internal/infra/db/repository/two_factor"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	memoryverificationcode "github.com/FabioRocha231/saas-core/internal/infra/db/repository/verification_code"
+	twofactoruc "github.com/FabioRocha231/saas-core/internal/usecase/two_factor"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorLogin(t *testing.T) {
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	uuid := pkg.NewUUID()
+	token := pkg.NewToken()
+	totp := &pkg.TOTP{}
+	passwordHash := pkg.NewPasswordHash()
+	jwtService := pkg.NewJwtService(testkit.NewJwtKeyset(t), 15*time.Minute, "saas-core", uuid, clock)
+
+	userRepo := memoryuser.New(clock)
+	sessionRepo := memorysession.New(clock)
+	storeRepo := memorystore.New()
+	memberRepo := memorystoremember.New(clock)
+	refreshRepo := memoryrefreshtoken.New(clock)
+	throttleRepo := memoryloginthrottle.New()
+	auditRepo := memoryaudit.New(clock)
+	codeRepo := memoryverificationcode.New(clock)
+	twoFactorRepo := memorytwofactor.New(clock)
+	tx := memorytx.New()
+
+	settings := TwoFactorSettings{ChallengeTTL: 5 * time.Minute, MaxAttempts: 2}
+	lockout := LockoutSettings{MaxFailures: 3, IPMaxFailures: 50, Window: time.Hour, Lockout: time.Minute, MaxLockout: time.Hour}
+
+	login := func(email string) (*LoginOutput, error) {
+		return NewLoginUsecase(
+			t.Context(), userRepo, sessionRepo, storeRepo, memberRepo, refreshRepo, throttleRepo, auditRepo, codeRepo,
+			twoFactorRepo, jwtService, passwordHash, token, uuid, clock, 24*time.Hour, settings, lockout,
+		).Execute(LoginInput{Email: email, Password: "123456", IP: "10.0.0.1"})
+	}
+	complete := NewCompleteTwoFactorLoginUsecase(
+		userRepo, sessionRepo, storeRepo, memberRepo, refreshRepo, throttleRepo, auditRepo, codeRepo, twoFactorRepo,
+		jwtService, token, uuid, totp, tx, clock, 24*time.Hour, settings, lockout,
+	)
+	completeWith := func(challenge, code string) (*LoginOutput, error) {
+		return complete.Execute(t.Context(), CompleteTwoFactorLoginInput{ChallengeToken: challenge, Code: code, IP: "10.0.0.1"})
+	}
+
+	// usuário com 2FA ativo; devolve o segredo e os códigos de recuperação
+	newUser := func(t *testing.T) (*entity.User, string, []string) {
+		hash, err := passwordHash.Hash("123456")
+		require.NoError(t, err)
+		u := &entity.User{ID: uuid.Generate(), Name: "dono", Role: entity.UserRoleStoreOwner, Password: hash}
+		u.Email = u.ID + "@example.com"
+		u.Cpf = u.ID
+		require.NoError(t, userRepo.Create(t.Context(), u))
+
+		checker := twofactoruc.NewChecker(twoFactorRepo, totp, token, clock)
+		out, err := twofactoruc.NewSetupUsecase(twoFactorRepo, userRepo, totp, "saas-core").Execute(t.Context(), u.ID)
+		require.NoError(t, err)
+		code, err := totp.Code(out.Secret, clock.Now())
+		require.NoError(t, err)
+		codes, err := twofactoruc.NewEnableUsecase(twoFactorRepo, checker, token, uuid, tx, clock).
+			Execute(t.Context(), twofactoruc.CodeInput{UserID: u.ID, Code: code})
+		require.NoError(t, err)
+
+		// o código do cadastro já foi gasto
+		clock.Advance(30 * time.Second)
+		return u, out.Secret, codes.RecoveryCodes
+	}
+	currentCode := func(t *testing.T, secret string) string {
+		code, err := totp.Code(secret, clock.Now())
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("test login returns a challenge instead of a session", func(t *testing.T) {
+		u, secret, _ := newUser(t)
+
+		out, err := login(u.Email)
+		require.NoError(t, err)
+		require.NotNil(t, out.Challenge)
+		require.Empty(t, out.Token)
+		require.Equal(t, clock.Now().Add(5*time.Minute), out.Challenge.ExpiresAt)
+
+		sessions, err := sessionRepo.ListByUserID(t.Context(), u.ID)
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+
+		got, err := completeWith(out.Challenge.ChallengeToken, currentCode(t, secret))
+		require.NoError(t, err)
+		require.NotEmpty(t, got.Token)
+		require.NotEmpty(t, got.RefreshToken)
+		require.Equal(t, u.ID, got.User.ID)
+
+		// o desafio é de uso único
+		clock.Advance(30 * time.Second)
+		_, err = completeWith(out.Challenge.ChallengeToken, currentCode(t, secret))
+		require.EqualError(t, err, "unauthorized: invalid or expired two-factor challenge")
+	})
+
+	t.Run("test wrong codes exhaust the challenge", func(t *testing.T) {
+		u, secret, _ := newUser(t)
+
+		out, err := login(u.Email)
+		require.NoError(t, err)
+
+		for i := 0; i < settings.MaxAttempts; i++ {
+			_, err = completeWith(out.Challenge.ChallengeToken, "000000")
+			require.EqualError(t, err, "unauthorized: invalid two-factor code")
+		}
+		_, err = completeWith(out.Challenge.ChallengeToken, currentCode(t, secret))
+		require.EqualError(t, err, "unauthorized: invalid or expired two-factor challenge")
+
+		// mais uma rodada de códigos errados trava a conta, mesmo com a senha certa
+		out, err = login(u.Email)
+		require.NoError(t, err)
+		_, err = completeWith(out.Challenge.ChallengeToken, "000000")
+		require.EqualError(t, err, "unauthorized: invalid two-factor code")
+		_, err = login(u.Email)
+		require.EqualError(t, err, "rate_limited: too many failed login attempts, try again later")
+	})
+
+	t.Run("test recovery code completes the login once", func(t *testing.T) {
+		u, _, recovery := newUser(t)
+
+		out, err := login(u.Email)
+		require.NoError(t, err)
+		_, err = completeWith(out.Challenge.ChallengeToken, recovery[0])
+		require.NoError(t, err)
+
+		out, err = login(u.Email)
+		require.NoError(t, err)
+		_, err = completeWith(out.Challenge.ChallengeToken, recovery[0])
+		require.EqualError(t, err, "unauthorized: invalid two-factor code")
+	})
+
+	t.Run("test expired or forged challenge is rejected", func(t *testing.T) {
+		u, secret, _ := newUser(t)
+
+		out, err := login(u.Email)
+		require.NoError(t, err)
+
+		_, err = completeWith("forjado", currentCode(t, secret))
+		require.EqualError(t, err, "unauthorized: invalid or expired two-factor challenge")
+		id, _, _ := strings.Cut(out.Challenge.ChallengeToken, ".")
+		_, err = completeWith(id+".outro-segredo", currentCode(t, secret))
+		require.EqualError(t, err, "unauthorized: invalid or expired two-factor challenge")
+
+		clock.Advance(6 * time.Minute)
+		_, err = completeWith(out.Challenge.ChallengeToken, currentCode(t, secret))
+		require.EqualError(t, err, "unauthorized: invalid or expired two-factor challenge")
+	})
+}
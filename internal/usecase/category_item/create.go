@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
@@ -28,6 +27,7 @@ type CreateCategoryItemUsecase struct {
 	menuCategoryRepo repository.MenuCategoryRepository
 	uuid             ports.UUIDInterface
 	context          context.Context
+	clock            ports.Clock
 }
 
 func NewCreateCategoryItemUsecase(
@@ -35,12 +35,14 @@ func NewCreateCategoryItemUsecase(
 	menuCategoryRepo repository.MenuCategoryRepository,
 	uuid ports.UUIDInterface,
 	context context.Context,
+	clock ports.Clock,
 ) *CreateCategoryItemUsecase {
 	return &CreateCategoryItemUsecase{
 		categoryItemRepo: categoryItemRepo,
 		menuCategoryRepo: menuCategoryRepo,
 		uuid:             uuid,
 		context:          context,
+		clock:            clock,
 	}
 }
 
@@ -63,8 +65,8 @@ func (uc *CreateCategoryItemUsecase) Execute(input CreateCategoryItemInput) (*Cr
 		BasePrice:   input.BasePrice,
 		ImageURL:    input.ImageURL,
 		IsActive:    input.IsActive,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   uc.clock.Now(),
+		UpdatedAt:   uc.clock.Now(),
 	}
 
 	err = uc.categoryItemRepo.Create(uc.context, itemCategory)
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
@@ -30,6 +29,7 @@ type CreateItemVariantGroupUseCase struct {
 	itemVariantGroupRepo repository.ItemVariantGroupRepository
 	uuid                 ports.UUIDInterface
 	context              context.Context
+	clock                ports.Clock
 }
 
 func NewCreateItemVariantGroupUseCase(
@@ -37,12 +37,14 @@ func NewCreateItemVariantGroupUseCase(
 	itemVariantGroupRepo repository.ItemVariantGroupRepository,
 	categoryItemRepo repository.CategoryItemRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *CreateItemVariantGroupUseCase {
 	return &CreateItemVariantGroupUseCase{
 		context:              ctx,
 		itemVariantGroupRepo: itemVariantGroupRepo,
 		categoryItemRepo:     categoryItemRepo,
 		uuid:                 uuid,
+		clock:                clock,
 	}
 }
 
@@ -57,7 +59,7 @@ func (uc *CreateItemVariantGroupUseCase) Execute(input CreateItemVariantGroupInp
 	}
 
 	id := uc.uuid.Generate()
-	now := time.Now()
+	now := uc.clock.Now()
 
 	itemVariantGroup := &entity.ItemVariantGroup{
 		ID:             id,
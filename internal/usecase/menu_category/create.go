@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
@@ -24,17 +23,20 @@ type CreateMenuCategoryUseCase struct {
 	menuCategoryRepo repository.MenuCategoryRepository
 	storeMenuRepo    repository.StoreMenuRepository
 	uuid             ports.UUIDInterface
+	clock            ports.Clock
 }
 
 func NewCreateMenuCategoryUsecase(
 	menuCategoryRepo repository.MenuCategoryRepository,
 	storeMenuRepo repository.StoreMenuRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *CreateMenuCategoryUseCase {
 	return &CreateMenuCategoryUseCase{
 		menuCategoryRepo: menuCategoryRepo,
 		storeMenuRepo:    storeMenuRepo,
 		uuid:             uuid,
+		clock:            clock,
 	}
 }
 
@@ -54,8 +56,8 @@ func (uc *CreateMenuCategoryUseCase) Execute(context context.Context, input Crea
 		MenuID:    storeMenu.ID,
 		Name:      input.Name,
 		IsActive:  input.IsActive,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: uc.clock.Now(),
+		UpdatedAt: uc.clock.Now(),
 	}
 
 	createErr := uc.menuCategoryRepo.Create(context, menuCategory)
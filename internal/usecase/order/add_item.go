@@ -5,7 +5,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
@@ -15,6 +14,7 @@ import (
 
 type AddItemInput struct {
 	OrderID string
+	UserID  string
 	ItemID  string
 	Qty     int64
 
@@ -31,22 +31,29 @@ type AddonSelection struct {
 
 type AddItem struct {
 	OrdersRepo repository.OrderRepository
+	StoreRepo  repository.StoreRepository
 	MenuRepo   repository.MenuReadRepository
 	UUID       ports.UUIDInterface
+	Clock      ports.Clock
 }
 
 func NewAddItem(
 	ordersRepo repository.OrderRepository,
+	storeRepo repository.StoreRepository,
 	menuRepo repository.MenuReadRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *AddItem {
-	return &AddItem{OrdersRepo: ordersRepo, MenuRepo: menuRepo, UUID: uuid}
+	return &AddItem{OrdersRepo: ordersRepo, StoreRepo: storeRepo, MenuRepo: menuRepo, UUID: uuid, Clock: clock}
 }
 
 func (uc *AddItem) Execute(ctx context.Context, in AddItemInput) (*Order, error) {
 	if in.OrderID == "" {
 		return nil, errx.New(errx.CodeInvalid, "missing orderId")
 	}
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
 	if in.ItemID == "" {
 		return nil, errx.New(errx.CodeInvalid, "missing itemId")
 	}
@@ -59,6 +66,10 @@ func (uc *AddItem) Execute(ctx context.Context, in AddItemInput) (*Order, error)
 		return nil, errx.New(errx.CodeInvalid, "invalid order id")
 	}
 
+	if isValidUUID := uc.UUID.Validate(in.UserID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid user id")
+	}
+
 	if in.Qty <= 0 {
 		return nil, errx.New(errx.CodeInvalid, "qty must be > 0")
 	}
@@ -67,14 +78,33 @@ func (uc *AddItem) Execute(ctx context.Context, in AddItemInput) (*Order, error)
 	if err != nil {
 		return nil, err
 	}
-	if o.Status != entity.OrderCreated {
+	if o.UserID != in.UserID {
+		return nil, errx.New(errx.CodeForbidden, "order does not belong to user")
+	}
+	if !o.IsEditable() {
 		return nil, errx.New(errx.CodeConflict, "order is not editable")
 	}
 
+	store, err := uc.StoreRepo.GetByID(ctx, o.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureAcceptingOrders(store); err != nil {
+		return nil, err
+	}
+
 	item, err := uc.MenuRepo.GetCategoryItemByID(ctx, in.ItemID)
 	if err != nil {
 		return nil, err
 	}
+	itemStoreID, err := uc.MenuRepo.GetStoreIDByItemID(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	// item de outra loja não entra no pedido, mesmo com o id certo
+	if itemStoreID != o.StoreID {
+		return nil, errx.New(errx.CodeInvalid, "item does not belong to the order store")
+	}
 	if !item.IsActive {
 		return nil, errx.New(errx.CodeConflict, "item is inactive")
 	}
@@ -189,38 +219,49 @@ func (uc *AddItem) Execute(ctx context.Context, in AddItemInput) (*Order, error)
 	// --- Merge automático: se mesma combinação (item + variants + addons + note), soma qty
 	newSig := signature(item.ID, variants, addons, in.Note)
 
-	for i := range o.Items {
-		if signatureFromExisting(o.Items[i]) == newSig {
-			o.Items[i].Qty += in.Qty
-			o.UpdatedAt = time.Now()
-			o.RecalculateTotals()
-			if err := uc.OrdersRepo.Update(ctx, o); err != nil {
+	attempt := 0
+	return retryOnStale(func() (*Order, error) {
+		// na primeira tentativa usa o pedido já lido; nas seguintes relê
+		if attempt++; attempt > 1 {
+			if o, err = uc.OrdersRepo.GetByID(ctx, in.OrderID); err != nil {
 				return nil, err
 			}
-			return toOrderDTO(o), nil
+			if !o.IsEditable() {
+				return nil, errx.New(errx.CodeConflict, "order is not editable")
+			}
 		}
-	}
 
-	// --- Se não existe igual, cria linha nova
-	newItem := entity.OrderItem{
-		ID:        uc.UUID.Generate(),
-		ItemID:    item.ID,
-		Name:      item.Name,
-		Qty:       in.Qty,
-		BasePrice: entity.MoneyCents(item.BasePrice),
-		Variants:  variants,
-		Addons:    addons,
-		Note:      in.Note,
-	}
+		merged := false
+		for i := range o.Items {
+			if signatureFromExisting(o.Items[i]) == newSig {
+				o.Items[i].Qty += in.Qty
+				merged = true
+				break
+			}
+		}
 
-	o.Items = append(o.Items, newItem)
-	o.UpdatedAt = time.Now()
-	o.RecalculateTotals()
+		// --- Se não existe igual, cria linha nova
+		if !merged {
+			o.Items = append(o.Items, entity.OrderItem{
+				ID:        uc.UUID.Generate(),
+				ItemID:    item.ID,
+				Name:      item.Name,
+				Qty:       in.Qty,
+				BasePrice: entity.MoneyCents(item.BasePrice),
+				Variants:  variants,
+				Addons:    addons,
+				Note:      in.Note,
+			})
+		}
 
-	if err := uc.OrdersRepo.Update(ctx, o); err != nil {
-		return nil, err
-	}
-	return toOrderDTO(o), nil
+		o.UpdatedAt = uc.Clock.Now()
+		o.RecalculateTotals()
+
+		if err := uc.OrdersRepo.Update(ctx, o); err != nil {
+			return nil, err
+		}
+		return toOrderDTO(o), nil
+	})
 }
 
 func validateVariantGroups(groups map[string]*entity.ItemVariantGroup, count map[string]int) error {
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/infra/db"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddItem(t *testing.T) {
+	uuid := pkg.NewUUID()
+	clock := pkg.NewClock()
+	repos := db.NewMemoryRepositories(clock)
+
+	// loja com um cardápio de um item só
+	seedStore := func(t *testing.T, slug string) (storeID, itemID string) {
+		store := &entity.Store{ID: uuid.Generate(), Name: slug, Slug: slug, IsOpen: true}
+		menu := &entity.StoreMenu{ID: uuid.Generate(), StoreID: store.ID, Name: "Cardápio", IsActive: true}
+		category := &entity.MenuCategory{ID: uuid.Generate(), MenuID: menu.ID, Name: "Bebidas", IsActive: true}
+		item := &entity.CategoryItem{ID: uuid.Generate(), CategoryID: category.ID, Name: "Coca", BasePrice: 500, IsActive: true}
+		require.NoError(t, repos.Store.Create(t.Context(), store))
+		require.NoError(t, repos.StoreMenu.Create(t.Context(), menu))
+		require.NoError(t, repos.MenuCategory.Create(t.Context(), category))
+		require.NoError(t, repos.CategoryItem.Create(t.Context(), item))
+		return store.ID, item.ID
+	}
+
+	storeID, itemID := seedStore(t, "loja")
+	_, otherItemID := seedStore(t, "outra-loja")
+
+	userID := uuid.Generate()
+	orderID := uuid.Generate()
+	require.NoError(t, repos.Order.Create(t.Context(), &entity.Order{
+		ID: orderID, StoreID: storeID, UserID: userID, Status: entity.OrderCreated,
+	}))
+
+	uc := NewAddItem(repos.Order, repos.Store, repos.MenuRead, uuid, clock)
+
+	t.Run("test add an item of the order store", func(t *testing.T) {
+		out, err := uc.Execute(t.Context(), AddItemInput{OrderID: orderID, UserID: userID, ItemID: itemID, Qty: 2})
+		require.NoError(t, err)
+		require.Len(t, out.Items, 1)
+		require.Equal(t, int64(2), out.Items[0].Qty)
+	})
+
+	t.Run("test add an item to another user's order", func(t *testing.T) {
+		_, err := uc.Execute(t.Context(), AddItemInput{OrderID: orderID, UserID: uuid.Generate(), ItemID: itemID, Qty: 1})
+		require.Error(t, err)
+		require.Equal(t, errx.CodeForbidden, errx.CodeOf(err))
+	})
+
+	t.Run("test add an item from another store's menu", func(t *testing.T) {
+		_, err := uc.Execute(t.Context(), AddItemInput{OrderID: orderID, UserID: userID, ItemID: otherItemID, Qty: 1})
+		require.Error(t, err)
+		require.Equal(t, errx.CodeInvalid, errx.CodeOf(err))
+
+		o, err := repos.Order.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Len(t, o.Items, 1)
+	})
+
+	t.Run("test failed tx keeps an item added outside it", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		added := make(chan error, 1)
+		before, err := repos.Order.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+
+		err = repos.Tx.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := repos.Order.Create(ctx, &entity.Order{ID: uuid.Generate(), StoreID: storeID, UserID: uuid.Generate()}); err != nil {
+				return err
+			}
+			// AddItem não usa tx: roda e responde enquanto esta ainda está aberta
+			go func() {
+				_, err := uc.Execute(context.Background(), AddItemInput{OrderID: orderID, UserID: userID, ItemID: itemID, Qty: 1})
+				added <- err
+			}()
+			if err := <-added; err != nil {
+				return err
+			}
+			return errBoom
+		})
+		require.ErrorIs(t, err, errBoom)
+
+		o, err := repos.Order.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, before.Items[0].Qty+1, o.Items[0].Qty)
+	})
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+)
+
+type AdvanceOrderStatusInput struct {
+	StoreID string
+	OrderID string
+	UserID  string
+	Status  string
+}
+
+// AdvanceOrderStatusUsecase é usado pela equipe da loja para mover o pedido
+// pela máquina de estados (aceitar, preparar, despachar, entregar...).
+type AdvanceOrderStatusUsecase struct {
+	OrderRepo repository.OrderRepository
+	Policy    *policy.Policy
+	UUID      ports.UUIDInterface
+	Clock     ports.Clock
+}
+
+func NewAdvanceOrderStatusUsecase(
+	orderRepo repository.OrderRepository,
+	authz *policy.Policy,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+) *AdvanceOrderStatusUsecase {
+	return &AdvanceOrderStatusUsecase{OrderRepo: orderRepo, Policy: authz, UUID: uuid, Clock: clock}
+}
+
+func (uc *AdvanceOrderStatusUsecase) Execute(ctx context.Context, in AdvanceOrderStatusInput) (*Order, error) {
+	if in.StoreID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing storeId")
+	}
+	if in.OrderID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing orderId")
+	}
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	if isValidUUID := uc.UUID.Validate(in.StoreID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid store id")
+	}
+	if isValidUUID := uc.UUID.Validate(in.OrderID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid order id")
+	}
+	if isValidUUID := uc.UUID.Validate(in.UserID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid user id")
+	}
+
+	to, ok := entity.OrderStatusMap[in.Status]
+	if !ok {
+		return nil, errx.New(errx.CodeInvalid, "invalid status")
+	}
+	// cancelamento e estorno têm fluxo próprio porque mexem nos pagamentos
+	if to.IsCanceled() || to == entity.OrderRefunded {
+		return nil, errx.F(errx.CodeInvalid, "status %s cannot be set directly", to)
+	}
+
+	if err := ensureStoreStaff(ctx, uc.Policy, in.StoreID, in.UserID); err != nil {
+		return nil, err
+	}
+
+	return retryOnStale(func() (*Order, error) {
+		o, err := uc.OrderRepo.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if o.StoreID != in.StoreID {
+			return nil, errx.New(errx.CodeNotFound, "order not found")
+		}
+
+		if err := o.TransitionTo(to, entity.OrderActorStore, in.UserID, uc.Clock.Now()); err != nil {
+			return nil, err
+		}
+
+		if err := uc.OrderRepo.Update(ctx, o); err != nil {
+			return nil, err
+		}
+		return toOrderDTO(o), nil
+	})
+}
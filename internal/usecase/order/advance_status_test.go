@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvanceOrderStatus(t *testing.T) {
+	uuid := pkg.NewUUID()
+	orderRepo := memoryorder.New(pkg.NewClock())
+	storeRepo := memorystore.New()
+	userRepo := memoryuser.New(pkg.NewClock())
+
+	ownerID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner)
+	storeID := uuid.Generate()
+	require.NoError(t, storeRepo.Create(t.Context(), &entity.Store{ID: storeID, Name: "Loja", Slug: "loja", OwnerID: ownerID}))
+
+	seed := func(t *testing.T, status entity.OrderStatus) string {
+		orderID := uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: storeID, UserID: uuid.Generate(), Status: status,
+		}))
+		return orderID
+	}
+
+	uc := NewAdvanceOrderStatusUsecase(orderRepo, policy.New(userRepo, storeRepo, memorystoremember.New(pkg.NewClock())), uuid, pkg.NewClock())
+
+	t.Run("test store staff accepts a paid order", func(t *testing.T) {
+		orderID := seed(t, entity.OrderPaid)
+
+		out, err := uc.Execute(t.Context(), AdvanceOrderStatusInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Status: "ACCEPTED"})
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderAccepted, out.Status)
+		require.Len(t, out.Transitions, 1)
+		require.Equal(t, entity.OrderActorStore, out.Transitions[0].Actor)
+		require.Equal(t, ownerID, out.Transitions[0].ActorID)
+	})
+
+	t.Run("test store staff skips a state", func(t *testing.T) {
+		orderID := seed(t, entity.OrderPaid)
+
+		_, err := uc.Execute(t.Context(), AdvanceOrderStatusInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Status: "READY"})
+		require.Error(t, err)
+		require.Equal(t, "conflict: order cannot go from PAID to READY", err.Error())
+	})
+
+	t.Run("test store staff marks an order as paid", func(t *testing.T) {
+		orderID := seed(t, entity.OrderPlaced)
+
+		_, err := uc.Execute(t.Context(), AdvanceOrderStatusInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Status: "PAID"})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: store cannot move order from PLACED to PAID", err.Error())
+	})
+
+	t.Run("test user that is not staff of the store", func(t *testing.T) {
+		orderID := seed(t, entity.OrderPaid)
+
+		_, err := uc.Execute(t.Context(), AdvanceOrderStatusInput{StoreID: storeID, OrderID: orderID, UserID: testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner), Status: "ACCEPTED"})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: user is not staff of this store", err.Error())
+	})
+
+	t.Run("test order of another store", func(t *testing.T) {
+		otherStoreID := uuid.Generate()
+		require.NoError(t, storeRepo.Create(t.Context(), &entity.Store{ID: otherStoreID, Name: "Outra", Slug: "outra", OwnerID: ownerID}))
+		orderID := seed(t, entity.OrderPaid)
+
+		_, err := uc.Execute(t.Context(), AdvanceOrderStatusInput{StoreID: otherStoreID, OrderID: orderID, UserID: ownerID, Status: "ACCEPTED"})
+		require.Error(t, err)
+		require.Equal(t, "not_found: order not found", err.Error())
+	})
+
+	t.Run("test store staff rejects through the status endpoint", func(t *testing.T) {
+		orderID := seed(t, entity.OrderPaid)
+
+		_, err := uc.Execute(t.Context(), AdvanceOrderStatusInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Status: "REJECTED"})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: status REJECTED cannot be set directly", err.Error())
+	})
+
+	t.Run("test invalid status", func(t *testing.T) {
+		orderID := seed(t, entity.OrderPaid)
+
+		_, err := uc.Execute(t.Context(), AdvanceOrderStatusInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Status: "EATEN"})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: invalid status", err.Error())
+	})
+}
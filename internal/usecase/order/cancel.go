@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	paymentuc "github.com/FabioRocha231/saas-core/internal/usecase/payment"
+)
+
+const maxCancelReasonLen = 500
+
+type CancelOrderInput struct {
+	OrderID string
+	UserID  string
+	Reason  string // opcional para o cliente
+}
+
+// CancelOrderUsecase é o cancelamento feito pelo cliente. A máquina de estados
+// só permite enquanto a loja não aceitou o pedido.
+type CancelOrderUsecase struct {
+	OrderRepo   repository.OrderRepository
+	PaymentRepo repository.PaymentRepository
+	RefundRepo  repository.RefundRepository
+	Gateways    ports.PaymentGateways
+	Tx          ports.TxManager
+	UUID        ports.UUIDInterface
+	Clock       ports.Clock
+}
+
+func NewCancelOrderUsecase(
+	orderRepo repository.OrderRepository,
+	paymentRepo repository.PaymentRepository,
+	refundRepo repository.RefundRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+) *CancelOrderUsecase {
+	return &CancelOrderUsecase{
+		OrderRepo:   orderRepo,
+		PaymentRepo: paymentRepo,
+		RefundRepo:  refundRepo,
+		Gateways:    gateways,
+		Tx:          tx,
+		UUID:        uuid,
+		Clock:       clock,
+	}
+}
+
+func (uc *CancelOrderUsecase) Execute(ctx context.Context, in CancelOrderInput) (*Order, error) {
+	if in.OrderID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing orderId")
+	}
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	if isValidUUID := uc.UUID.Validate(in.OrderID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid order id")
+	}
+	if isValidUUID := uc.UUID.Validate(in.UserID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid user id")
+	}
+
+	reason := strings.TrimSpace(in.Reason)
+	if len(reason) > maxCancelReasonLen {
+		return nil, errx.New(errx.CodeInvalid, "reason is too long")
+	}
+
+	release := paymentuc.NewReleaseOrderPaymentsUsecase(uc.OrderRepo, uc.PaymentRepo, uc.RefundRepo, uc.Gateways, uc.Tx, uc.UUID, uc.Clock)
+	var (
+		o        *entity.Order
+		released *paymentuc.ReleaseOrderPaymentsOutput
+	)
+	err := uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		o, err = uc.OrderRepo.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return err
+		}
+		if o.UserID != in.UserID {
+			return errx.New(errx.CodeForbidden, "order does not belong to user")
+		}
+
+		released, err = cancelAndRelease(ctx, uc.OrderRepo, release, o, entity.OrderActorCustomer, in.UserID, reason, uc.Clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// o pedido já está cancelado; o que o provider não aceitar agora fica
+	// PENDING e o job de estornos reenvia
+	_ = release.Settle(ctx, released)
+
+	return toOrderDTO(o), nil
+}
+
+// cancelAndRelease cancela o pedido e grava a liberação dos pagamentos dele
+// pela camada de pagamento. Se algo vai ser estornado o pedido termina em
+// REFUNDED. Precisa rodar dentro de uma tx (pedido e pagamentos mudam juntos);
+// o provider só é chamado no Settle, depois do commit.
+func cancelAndRelease(
+	ctx context.Context,
+	orders repository.OrderRepository,
+	release *paymentuc.ReleaseOrderPaymentsUsecase,
+	o *entity.Order,
+	actor entity.OrderActor,
+	actorID, reason string,
+	now time.Time,
+) (*paymentuc.ReleaseOrderPaymentsOutput, error) {
+	if err := o.Cancel(actor, actorID, reason, now); err != nil {
+		return nil, err
+	}
+
+	released, err := release.Execute(ctx, o.ID, reason)
+	if err != nil {
+		return nil, err
+	}
+	if len(released.Refunded) > 0 {
+		if err := o.TransitionTo(entity.OrderRefunded, entity.OrderActorSystem, "", now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := orders.Update(ctx, o); err != nil {
+		return nil, err
+	}
+	return released, nil
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	paymentuc "github.com/FabioRocha231/saas-core/internal/usecase/payment"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+// failingPaymentRepo simula uma falha ao estornar/cancelar o pagamento.
+type failingPaymentRepo struct {
+	repository.PaymentRepository
+}
+
+func (r *failingPaymentRepo) Update(ctx context.Context, p *entity.Payment) error {
+	return errx.New(errx.CodeInternal, "payment update failed")
+}
+
+// downGateways simula o provider fora do ar nos estornos enquanto down
+// estiver ligado e guarda as chaves de idempotência recebidas.
+type downGateways struct {
+	ports.PaymentGateways
+	down bool
+	keys []string
+}
+
+func (g *downGateways) ForProvider(provider entity.PaymentProvider) (ports.PaymentGateway, error) {
+	gw, err := g.PaymentGateways.ForProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	return &downGateway{PaymentGateway: gw, owner: g}, nil
+}
+
+type downGateway struct {
+	ports.PaymentGateway
+	owner *downGateways
+}
+
+func (g *downGateway) Refund(ctx context.Context, req ports.GatewayRefundRequest) (*ports.GatewayRefundResult, error) {
+	g.owner.keys = append(g.owner.keys, req.IdempotencyKey)
+	if g.owner.down {
+		return nil, errx.New(errx.CodeInternal, "payment provider unavailable")
+	}
+	return g.PaymentGateway.Refund(ctx, req)
+}
+
+func TestCancelAndRejectOrder(t *testing.T) {
+	uuid := pkg.NewUUID()
+	orderRepo := memoryorder.New(pkg.NewClock())
+	paymentRepo := memorypayment.New(pkg.NewClock())
+	refundRepo := memoryrefund.New(pkg.NewClock())
+	storeRepo := memorystore.New()
+	userRepo := memoryuser.New(pkg.NewClock())
+	tx := memorytx.New()
+
+	ownerID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner)
+	storeID := uuid.Generate()
+	require.NoError(t, storeRepo.Create(t.Context(), &entity.Store{ID: storeID, Name: "Loja", Slug: "loja", OwnerID: ownerID}))
+
+	customerID := uuid.Generate()
+	seed := func(t *testing.T, status entity.OrderStatus, payment entity.PaymentStatus) (orderID, paymentID string) {
+		orderID = uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: storeID, UserID: customerID, Status: status,
+		}))
+		if payment != "" {
+			paymentID = uuid.Generate()
+			require.NoError(t, paymentRepo.Create(t.Context(), &entity.Payment{
+				ID: paymentID, OrderID: orderID, UserID: customerID, StoreID: storeID, Status: payment, Amount: 1000,
+				Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + paymentID,
+			}))
+		}
+		return
+	}
+	paymentStatus := func(t *testing.T, id string) entity.PaymentStatus {
+		p, err := paymentRepo.GetByID(t.Context(), id)
+		require.NoError(t, err)
+		return p.Status
+	}
+
+	gateways := payment.NewGateways(payment.Config{}, pkg.NewClock())
+	authz := policy.New(userRepo, storeRepo, memorystoremember.New(pkg.NewClock()))
+
+	cancel := NewCancelOrderUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+	reject := NewRejectOrderUsecase(orderRepo, authz, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+	t.Run("test customer cancels a placed order and its pending payment", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderPlaced, entity.PaymentStatusPending)
+
+		out, err := cancel.Execute(t.Context(), CancelOrderInput{OrderID: orderID, UserID: customerID, Reason: "demorou"})
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderCanceled, out.Status)
+		require.Equal(t, entity.OrderActorCustomer, out.Cancellation.Actor)
+		require.Equal(t, customerID, out.Cancellation.ActorID)
+		require.Equal(t, "demorou", out.Cancellation.Reason)
+		require.Equal(t, entity.PaymentStatusCanceled, paymentStatus(t, paymentID))
+	})
+
+	t.Run("test customer cancels a paid order and gets a refund", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderPaid, entity.PaymentStatusPaid)
+
+		out, err := cancel.Execute(t.Context(), CancelOrderInput{OrderID: orderID, UserID: customerID})
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderRefunded, out.Status)
+		require.Equal(t, entity.OrderActorCustomer, out.Cancellation.Actor)
+		require.Len(t, out.Transitions, 2)
+		require.Equal(t, entity.PaymentStatusRefunded, paymentStatus(t, paymentID))
+
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		require.Equal(t, int64(1000), refunds[0].Amount)
+		require.Empty(t, refunds[0].RequestedBy)
+	})
+
+	t.Run("test customer cancels an order the store already accepted", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderAccepted, entity.PaymentStatusPaid)
+
+		_, err := cancel.Execute(t.Context(), CancelOrderInput{OrderID: orderID, UserID: customerID})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: customer cannot move order from ACCEPTED to CANCELED", err.Error())
+		require.Equal(t, entity.PaymentStatusPaid, paymentStatus(t, paymentID))
+	})
+
+	t.Run("test customer cancels an order of another user", func(t *testing.T) {
+		orderID, _ := seed(t, entity.OrderPlaced, "")
+
+		_, err := cancel.Execute(t.Context(), CancelOrderInput{OrderID: orderID, UserID: uuid.Generate()})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: order does not belong to user", err.Error())
+	})
+
+	t.Run("test store rejects a paid order with a reason", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderPaid, entity.PaymentStatusPaid)
+
+		out, err := reject.Execute(t.Context(), RejectOrderInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Reason: "sem estoque"})
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderRefunded, out.Status)
+		require.Equal(t, entity.OrderRejected, out.Transitions[0].To)
+		require.Equal(t, entity.OrderActorStore, out.Cancellation.Actor)
+		require.Equal(t, "sem estoque", out.Cancellation.Reason)
+		require.Equal(t, entity.PaymentStatusRefunded, paymentStatus(t, paymentID))
+	})
+
+	t.Run("test store cancels an order in preparation", func(t *testing.T) {
+		orderID, _ := seed(t, entity.OrderPreparing, "")
+
+		out, err := reject.Execute(t.Context(), RejectOrderInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Reason: "acabou o gás"})
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderCanceled, out.Status)
+	})
+
+	t.Run("test store rejects without a reason", func(t *testing.T) {
+		orderID, _ := seed(t, entity.OrderPlaced, "")
+
+		_, err := reject.Execute(t.Context(), RejectOrderInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Reason: "  "})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: reason is required", err.Error())
+	})
+
+	t.Run("test reject rolls back the order when the refund fails", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderPaid, entity.PaymentStatusPaid)
+		uc := NewRejectOrderUsecase(orderRepo, authz, &failingPaymentRepo{paymentRepo}, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+		_, err := uc.Execute(t.Context(), RejectOrderInput{StoreID: storeID, OrderID: orderID, UserID: ownerID, Reason: "sem estoque"})
+		require.Error(t, err)
+		require.Equal(t, "internal: payment update failed", err.Error())
+
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPaid, o.Status)
+		require.Nil(t, o.Cancellation)
+		require.Equal(t, entity.PaymentStatusPaid, paymentStatus(t, paymentID))
+
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Empty(t, refunds)
+	})
+
+	t.Run("test cancel commits when the provider is down and the job sends the refund later", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderPaid, entity.PaymentStatusPaid)
+		down := &downGateways{PaymentGateways: gateways, down: true}
+		uc := NewCancelOrderUsecase(orderRepo, paymentRepo, refundRepo, down, tx, uuid, pkg.NewClock())
+
+		out, err := uc.Execute(t.Context(), CancelOrderInput{OrderID: orderID, UserID: customerID})
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderRefunded, out.Status)
+
+		// pedido cancelado e estorno reservado, esperando o provider
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		require.Equal(t, entity.RefundStatusPending, refunds[0].Status)
+		require.Equal(t, entity.PaymentStatusPaid, paymentStatus(t, paymentID))
+
+		down.down = false
+		retry := paymentuc.NewRetryRefundsUsecase(orderRepo, paymentRepo, refundRepo, down, tx, pkg.NewFakeClock(time.Now().Add(2*time.Minute)))
+		settled, err := retry.Execute(t.Context())
+		require.NoError(t, err)
+		require.Len(t, settled.Settled, 1)
+		require.Equal(t, "SUCCEEDED", settled.Settled[0].Status)
+		require.Equal(t, entity.PaymentStatusRefunded, paymentStatus(t, paymentID))
+
+		// mesma chave nas duas tentativas, amarrada ao pedido e ao pagamento
+		require.Equal(t, []string{paymentID + ":release:" + orderID, paymentID + ":release:" + orderID}, down.keys)
+	})
+}
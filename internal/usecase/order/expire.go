@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	paymentuc "github.com/FabioRocha231/saas-core/internal/usecase/payment"
+)
+
+const (
+	// quantos pedidos cada execução processa no máximo
+	expireOrdersBatch = 100
+
+	ExpiredOrderReason = "payment not received"
+)
+
+type ExpireOrdersOutput struct {
+	Canceled []*Order `json:"canceled"`
+}
+
+// ExpireOrdersUsecase cancela (como sistema) os pedidos que ficaram PLACED
+// por mais de TTL sem pagamento, liberando as cobranças ainda em aberto.
+type ExpireOrdersUsecase struct {
+	OrderRepo   repository.OrderRepository
+	PaymentRepo repository.PaymentRepository
+	RefundRepo  repository.RefundRepository
+	Gateways    ports.PaymentGateways
+	Tx          ports.TxManager
+	UUID        ports.UUIDInterface
+	Clock       ports.Clock
+	TTL         time.Duration
+}
+
+func NewExpireOrdersUsecase(
+	orderRepo repository.OrderRepository,
+	paymentRepo repository.PaymentRepository,
+	refundRepo repository.RefundRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+	ttl time.Duration,
+) *ExpireOrdersUsecase {
+	return &ExpireOrdersUsecase{
+		OrderRepo:   orderRepo,
+		PaymentRepo: paymentRepo,
+		RefundRepo:  refundRepo,
+		Gateways:    gateways,
+		Tx:          tx,
+		UUID:        uuid,
+		Clock:       clock,
+		TTL:         ttl,
+	}
+}
+
+func (uc *ExpireOrdersUsecase) Execute(ctx context.Context) (*ExpireOrdersOutput, error) {
+	if uc.TTL <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "order ttl must be > 0")
+	}
+
+	now := uc.Clock.Now()
+	placedBefore := now.Add(-uc.TTL)
+
+	candidates, err := uc.OrderRepo.ListStale(ctx, entity.OrderPlaced, placedBefore, expireOrdersBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	release := paymentuc.NewReleaseOrderPaymentsUsecase(uc.OrderRepo, uc.PaymentRepo, uc.RefundRepo, uc.Gateways, uc.Tx, uc.UUID, uc.Clock)
+	out := &ExpireOrdersOutput{Canceled: make([]*Order, 0, len(candidates))}
+	var firstErr error
+	for _, c := range candidates {
+		var (
+			o        *entity.Order
+			released *paymentuc.ReleaseOrderPaymentsOutput
+		)
+		// uma tx por pedido: um pedido com problema não segura os outros
+		err := uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			o, err = uc.OrderRepo.GetByID(ctx, c.ID)
+			if err != nil {
+				return err
+			}
+			// pago (ou mexido) desde a listagem
+			if o.Status != entity.OrderPlaced || o.UpdatedAt.After(placedBefore) {
+				o = nil
+				return nil
+			}
+
+			released, err = cancelAndRelease(ctx, uc.OrderRepo, release, o, entity.OrderActorSystem, "", ExpiredOrderReason, now)
+			return err
+		})
+		if err != nil {
+			// corrida com o pagamento: fica para a próxima rodada
+			if !errx.Is(err, errx.CodeConflict) && firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if o == nil {
+			continue
+		}
+		out.Canceled = append(out.Canceled, toOrderDTO(o))
+		// pedido já cancelado; o que falhar no provider fica para o job de estornos
+		if err := release.Settle(ctx, released); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return out, firstErr
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpireOrders(t *testing.T) {
+	uuid := pkg.NewUUID()
+	clock := pkg.NewFakeClock(time.Now())
+	orderRepo := memoryorder.New(clock)
+	paymentRepo := memorypayment.New(clock)
+	refundRepo := memoryrefund.New(clock)
+	tx := memorytx.New()
+	gateways := payment.NewGateways(payment.Config{}, clock)
+
+	uc := NewExpireOrdersUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, clock, time.Hour)
+
+	customerID := uuid.Generate()
+	seed := func(t *testing.T, status entity.OrderStatus, payment entity.PaymentStatus) (orderID, paymentID string) {
+		orderID = uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: uuid.Generate(), UserID: customerID, Status: status,
+		}))
+		if payment != "" {
+			paymentID = uuid.Generate()
+			require.NoError(t, paymentRepo.Create(t.Context(), &entity.Payment{
+				ID: paymentID, OrderID: orderID, UserID: customerID, StoreID: "store", Status: payment, Amount: 1000,
+				Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + paymentID,
+			}))
+		}
+		return
+	}
+	orderStatus := func(t *testing.T, id string) entity.OrderStatus {
+		o, err := orderRepo.GetByID(t.Context(), id)
+		require.NoError(t, err)
+		return o.Status
+	}
+
+	t.Run("test cancel placed orders left unpaid past the ttl", func(t *testing.T) {
+		unpaid, paymentID := seed(t, entity.OrderPlaced, entity.PaymentStatusPending)
+		paid, _ := seed(t, entity.OrderPaid, entity.PaymentStatusPaid)
+		draft, _ := seed(t, entity.OrderCreated, "")
+
+		out, err := uc.Execute(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, out.Canceled)
+
+		clock.Advance(time.Hour + time.Minute)
+		out, err = uc.Execute(t.Context())
+		require.NoError(t, err)
+		require.Len(t, out.Canceled, 1)
+		require.Equal(t, unpaid, out.Canceled[0].ID)
+		require.Equal(t, entity.OrderActorSystem, out.Canceled[0].Cancellation.Actor)
+		require.Equal(t, ExpiredOrderReason, out.Canceled[0].Cancellation.Reason)
+
+		require.Equal(t, entity.OrderCanceled, orderStatus(t, unpaid))
+		require.Equal(t, entity.OrderPaid, orderStatus(t, paid))
+		require.Equal(t, entity.OrderCreated, orderStatus(t, draft))
+
+		p, err := paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusCanceled, p.Status)
+	})
+
+	t.Run("test expire orders again is a no-op", func(t *testing.T) {
+		out, err := uc.Execute(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, out.Canceled)
+	})
+}
@@ -53,6 +53,21 @@ type Item struct {
 	Note string `json:"note"`
 }
 
+type Cancellation struct {
+	Actor   entity.OrderActor `json:"actor"`
+	ActorID string            `json:"actor_id,omitempty"`
+	Reason  string            `json:"reason"`
+	At      time.Time         `json:"at"`
+}
+
+type Transition struct {
+	From    entity.OrderStatus `json:"from"`
+	To      entity.OrderStatus `json:"to"`
+	Actor   entity.OrderActor  `json:"actor"`
+	ActorID string             `json:"actor_id,omitempty"`
+	At      time.Time          `json:"at"`
+}
+
 type Order struct {
 	ID      string             `json:"id"`
 	StoreID string             `json:"store_id"`
@@ -65,6 +80,12 @@ type Order struct {
 	Fees     int64 `json:"fees"`
 	Total    int64 `json:"total"`
 
+	Version int64 `json:"version"`
+
+	Transitions  []Transition  `json:"transitions"`
+	Cancellation *Cancellation `json:"cancellation,omitempty"`
+	ScheduledFor *time.Time    `json:"scheduled_for,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -76,19 +97,25 @@ type GetOrCreateDraftOutput struct {
 
 type GetOrCreateDraftUsecase struct {
 	ordersRepo repository.OrderRepository
+	storeRepo  repository.StoreRepository
 	uuid       ports.UUIDInterface
 	context    context.Context
+	clock      ports.Clock
 }
 
 func NewGetOrCreateDraftUsecase(
 	orders repository.OrderRepository,
+	stores repository.StoreRepository,
 	uuid ports.UUIDInterface,
 	ctx context.Context,
+	clock ports.Clock,
 ) *GetOrCreateDraftUsecase {
 	return &GetOrCreateDraftUsecase{
 		ordersRepo: orders,
+		storeRepo:  stores,
 		uuid:       uuid,
 		context:    ctx,
+		clock:      clock,
 	}
 }
 
@@ -108,6 +135,14 @@ func (uc *GetOrCreateDraftUsecase) Execute(in GetOrCreateDraftInput) (*GetOrCrea
 		return nil, errx.New(errx.CodeInvalid, "invalid store id")
 	}
 
+	store, err := uc.storeRepo.GetByID(uc.context, in.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureAcceptingOrders(store); err != nil {
+		return nil, err
+	}
+
 	// 1) tenta pegar draft ativo
 	o, err := uc.ordersRepo.GetActiveDraftByUserIDAndStoreID(uc.context, in.UserID, in.StoreID)
 	if err == nil && o != nil {
@@ -118,7 +153,7 @@ func (uc *GetOrCreateDraftUsecase) Execute(in GetOrCreateDraftInput) (*GetOrCrea
 	}
 
 	// 2) cria novo draft
-	now := time.Now()
+	now := uc.clock.Now()
 	newOrder := &entity.Order{
 		ID:      uc.uuid.Generate(),
 		StoreID: in.StoreID,
@@ -193,17 +228,31 @@ func toOrderDTO(e *entity.Order) *Order {
 		}
 	}
 
+	transitions := make([]Transition, len(e.Transitions))
+	for i, tr := range e.Transitions {
+		transitions[i] = Transition{From: tr.From, To: tr.To, Actor: tr.Actor, ActorID: tr.ActorID, At: tr.At}
+	}
+
+	var cancellation *Cancellation
+	if c := e.Cancellation; c != nil {
+		cancellation = &Cancellation{Actor: c.Actor, ActorID: c.ActorID, Reason: c.Reason, At: c.At}
+	}
+
 	return &Order{
-		ID:        e.ID,
-		StoreID:   e.StoreID,
-		MenuID:    e.MenuID,
-		UserID:    e.UserID,
-		Status:    e.Status,
-		Items:     items,
-		Subtotal:  int64(e.Subtotal),
-		Fees:      int64(e.Fees),
-		Total:     int64(e.Total),
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
+		ID:           e.ID,
+		StoreID:      e.StoreID,
+		MenuID:       e.MenuID,
+		UserID:       e.UserID,
+		Status:       e.Status,
+		Items:        items,
+		Subtotal:     int64(e.Subtotal),
+		Fees:         int64(e.Fees),
+		Total:        int64(e.Total),
+		Version:      e.Version,
+		Transitions:  transitions,
+		Cancellation: cancellation,
+		ScheduledFor: e.ScheduledFor,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
 	}
 }
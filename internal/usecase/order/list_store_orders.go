@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+)
+
+type ListStoreOrdersInput struct {
+	StoreID string
+	UserID  string
+	Status  string // opcional
+}
+
+type ListStoreOrdersUsecase struct {
+	OrderRepo repository.OrderRepository
+	Policy    *policy.Policy
+	UUID      ports.UUIDInterface
+}
+
+func NewListStoreOrdersUsecase(
+	orderRepo repository.OrderRepository,
+	authz *policy.Policy,
+	uuid ports.UUIDInterface,
+) *ListStoreOrdersUsecase {
+	return &ListStoreOrdersUsecase{OrderRepo: orderRepo, Policy: authz, UUID: uuid}
+}
+
+func (uc *ListStoreOrdersUsecase) Execute(ctx context.Context, in ListStoreOrdersInput) ([]*Order, error) {
+	if in.StoreID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing storeId")
+	}
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	if isValidUUID := uc.UUID.Validate(in.StoreID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid store id")
+	}
+	if isValidUUID := uc.UUID.Validate(in.UserID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid user id")
+	}
+
+	var status entity.OrderStatus
+	if in.Status != "" {
+		s, ok := entity.OrderStatusMap[in.Status]
+		if !ok {
+			return nil, errx.New(errx.CodeInvalid, "invalid status")
+		}
+		status = s
+	}
+
+	if err := ensureStoreStaff(ctx, uc.Policy, in.StoreID, in.UserID); err != nil {
+		return nil, err
+	}
+
+	orders, err := uc.OrderRepo.ListByStoreID(ctx, in.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Order, 0, len(orders))
+	for _, o := range orders {
+		// carrinhos em aberto não são da conta da loja
+		if o.Status == entity.OrderCreated {
+			continue
+		}
+		if status != "" && o.Status != status {
+			continue
+		}
+		out = append(out, toOrderDTO(o))
+	}
+	return out, nil
+}
@@ -13,15 +13,20 @@ import (
 type PlaceOrderInput struct {
 	OrderID string
 	UserID  string
+	// opcional: agenda o pedido para um horário em que a loja atende
+	ScheduledFor *time.Time
 }
 
 type PlaceOrderUsecase struct {
 	OrderRepo repository.OrderRepository
+	StoreRepo repository.StoreRepository
+	Tx        ports.TxManager
 	UUID      ports.UUIDInterface
+	Clock     ports.Clock
 }
 
-func NewPlaceOrderUsecase(orderRepo repository.OrderRepository, uuid ports.UUIDInterface) *PlaceOrderUsecase {
-	return &PlaceOrderUsecase{OrderRepo: orderRepo, UUID: uuid}
+func NewPlaceOrderUsecase(orderRepo repository.OrderRepository, storeRepo repository.StoreRepository, tx ports.TxManager, uuid ports.UUIDInterface, clock ports.Clock) *PlaceOrderUsecase {
+	return &PlaceOrderUsecase{OrderRepo: orderRepo, StoreRepo: storeRepo, Tx: tx, UUID: uuid, Clock: clock}
 }
 
 func (uc *PlaceOrderUsecase) Execute(ctx context.Context, in PlaceOrderInput) (*Order, error) {
@@ -40,32 +45,44 @@ func (uc *PlaceOrderUsecase) Execute(ctx context.Context, in PlaceOrderInput) (*
 		return nil, errx.New(errx.CodeInvalid, "invalid user id")
 	}
 
-	o, err := uc.OrderRepo.GetByID(ctx, in.OrderID)
+	var o *entity.Order
+	err := uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		o, err = uc.OrderRepo.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if o.UserID != in.UserID {
+			return errx.New(errx.CodeForbidden, "order does not belong to user")
+		}
+
+		if len(o.Items) == 0 {
+			return errx.New(errx.CodeInvalid, "order has no items")
+		}
+
+		store, err := uc.StoreRepo.GetByID(ctx, o.StoreID)
+		if err != nil {
+			return err
+		}
+		now := uc.Clock.Now()
+		if err := ensureOpenFor(store, now, in.ScheduledFor); err != nil {
+			return err
+		}
+		o.ScheduledFor = in.ScheduledFor
+
+		// garante totals corretos no backend
+		o.RecalculateTotals()
+
+		if err := o.TransitionTo(entity.OrderPlaced, entity.OrderActorCustomer, in.UserID, now); err != nil {
+			return err
+		}
+
+		return uc.OrderRepo.Update(ctx, o)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if o.UserID != in.UserID {
-		return nil, errx.New(errx.CodeForbidden, "order does not belong to user")
-	}
-
-	if o.Status != entity.OrderCreated {
-		return nil, errx.New(errx.CodeConflict, "order cannot be placed")
-	}
-
-	if len(o.Items) == 0 {
-		return nil, errx.New(errx.CodeInvalid, "order has no items")
-	}
-
-	// garante totals corretos no backend
-	o.RecalculateTotals()
-
-	o.Status = entity.OrderPlaced
-	o.UpdatedAt = time.Now()
-
-	if err := uc.OrderRepo.Update(ctx, o); err != nil {
-		return nil, err
-	}
-
 	return toOrderDTO(o), nil
 }
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrderRespectsStoreHours(t *testing.T) {
+	uuid := pkg.NewUUID()
+	// sexta, 2026-10-16 12:00 em São Paulo
+	clock := pkg.NewFakeClock(time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC))
+	orderRepo := memoryorder.New(clock)
+	storeRepo := memorystore.New()
+	tx := memorytx.New()
+
+	store := &entity.Store{
+		ID: uuid.Generate(), Name: "Loja", Slug: "loja", IsOpen: true, Timezone: "America/Sao_Paulo",
+		Hours: entity.OpeningHours{Weekly: []entity.WeeklyWindow{
+			{Weekday: time.Friday, Opens: 11 * 60, Closes: 15 * 60},
+			{Weekday: time.Friday, Opens: 18 * 60, Closes: 23 * 60},
+		}},
+	}
+	require.NoError(t, storeRepo.Create(t.Context(), store))
+
+	// um carrinho por cliente e loja: cada pedido do teste é de um cliente novo
+	draft := func(t *testing.T) PlaceOrderInput {
+		in := PlaceOrderInput{OrderID: uuid.Generate(), UserID: uuid.Generate()}
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: in.OrderID, StoreID: store.ID, UserID: in.UserID, Status: entity.OrderCreated,
+			Items: []entity.OrderItem{{ID: uuid.Generate(), ItemID: uuid.Generate(), Name: "Coca", Qty: 1, BasePrice: 500}},
+		}))
+		return in
+	}
+	scheduled := func(t *testing.T, at *time.Time) PlaceOrderInput {
+		in := draft(t)
+		in.ScheduledFor = at
+		return in
+	}
+	setStore := func(t *testing.T, change func(s *entity.Store)) {
+		s, err := storeRepo.GetByID(t.Context(), store.ID)
+		require.NoError(t, err)
+		change(s)
+		require.NoError(t, storeRepo.Update(t.Context(), s))
+	}
+	at := func(hour, minute int) *time.Time {
+		v := time.Date(2026, 10, 16, hour, minute, 0, 0, store.Location())
+		return &v
+	}
+
+	place := NewPlaceOrderUsecase(orderRepo, storeRepo, tx, uuid, clock)
+
+	t.Run("test place an order while the store is open", func(t *testing.T) {
+		out, err := place.Execute(t.Context(), draft(t))
+
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPlaced, out.Status)
+		require.Nil(t, out.ScheduledFor)
+	})
+
+	t.Run("test place an order outside the opening hours", func(t *testing.T) {
+		clock.Set(time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC)) // 16:00 local
+		defer clock.Set(time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC))
+
+		_, err := place.Execute(t.Context(), draft(t))
+
+		require.Error(t, err)
+		require.Equal(t, "conflict: store is closed", err.Error())
+	})
+
+	t.Run("test schedule an order for a later open window", func(t *testing.T) {
+		clock.Set(time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC)) // 16:00 local
+		defer clock.Set(time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC))
+
+		out, err := place.Execute(t.Context(), scheduled(t, at(19, 30)))
+
+		require.NoError(t, err)
+		require.NotNil(t, out.ScheduledFor)
+		require.True(t, at(19, 30).Equal(*out.ScheduledFor))
+	})
+
+	t.Run("test schedule an order outside the opening hours", func(t *testing.T) {
+		_, err := place.Execute(t.Context(), scheduled(t, at(16, 30)))
+
+		require.Error(t, err)
+		require.Equal(t, "conflict: store is closed at the scheduled time", err.Error())
+	})
+
+	t.Run("test schedule an order too soon or too far ahead", func(t *testing.T) {
+		_, err := place.Execute(t.Context(), scheduled(t, at(12, 5)))
+		require.Equal(t, errx.CodeInvalid, errx.CodeOf(err))
+
+		far := at(12, 0).Add(8 * 24 * time.Hour)
+		_, err = place.Execute(t.Context(), scheduled(t, &far))
+		require.Equal(t, errx.CodeInvalid, errx.CodeOf(err))
+	})
+
+	t.Run("test a paused store only takes orders scheduled after the pause", func(t *testing.T) {
+		until := clock.Now().Add(time.Hour)
+		setStore(t, func(s *entity.Store) { s.PausedUntil = &until })
+		defer setStore(t, func(s *entity.Store) { s.PausedUntil = nil })
+
+		_, err := place.Execute(t.Context(), draft(t))
+		require.Error(t, err)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+		require.Contains(t, err.Error(), "store is paused until")
+
+		_, err = place.Execute(t.Context(), scheduled(t, at(12, 30)))
+		require.Equal(t, "conflict: store is closed at the scheduled time", err.Error())
+
+		_, err = place.Execute(t.Context(), scheduled(t, at(13, 30)))
+		require.NoError(t, err)
+
+		clock.Advance(time.Hour)
+		defer clock.Set(time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC))
+		_, err = place.Execute(t.Context(), draft(t))
+		require.NoError(t, err)
+	})
+
+	t.Run("test a store switched off takes no new carts", func(t *testing.T) {
+		setStore(t, func(s *entity.Store) { s.IsOpen = false })
+		defer setStore(t, func(s *entity.Store) { s.IsOpen = true })
+
+		_, err := NewGetOrCreateDraftUsecase(orderRepo, storeRepo, uuid, t.Context(), clock).Execute(GetOrCreateDraftInput{
+			UserID: uuid.Generate(), StoreID: store.ID,
+		})
+		require.Error(t, err)
+		require.Equal(t, "conflict: store is not accepting orders", err.Error())
+
+		_, err = place.Execute(t.Context(), scheduled(t, at(19, 0)))
+		require.Equal(t, "conflict: store is closed at the scheduled time", err.Error())
+	})
+}
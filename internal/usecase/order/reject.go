@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	paymentuc "github.com/FabioRocha231/saas-core/internal/usecase/payment"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+)
+
+type RejectOrderInput struct {
+	StoreID string
+	OrderID string
+	UserID  string
+	Reason  string
+}
+
+// RejectOrderUsecase é a loja desistindo do pedido: recusa o que ainda não
+// aceitou e cancela o que já estava em preparo. O motivo é obrigatório.
+type RejectOrderUsecase struct {
+	OrderRepo   repository.OrderRepository
+	Policy      *policy.Policy
+	PaymentRepo repository.PaymentRepository
+	RefundRepo  repository.RefundRepository
+	Gateways    ports.PaymentGateways
+	Tx          ports.TxManager
+	UUID        ports.UUIDInterface
+	Clock       ports.Clock
+}
+
+func NewRejectOrderUsecase(
+	orderRepo repository.OrderRepository,
+	authz *policy.Policy,
+	paymentRepo repository.PaymentRepository,
+	refundRepo repository.RefundRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+) *RejectOrderUsecase {
+	return &RejectOrderUsecase{
+		OrderRepo:   orderRepo,
+		Policy:      authz,
+		PaymentRepo: paymentRepo,
+		RefundRepo:  refundRepo,
+		Gateways:    gateways,
+		Tx:          tx,
+		UUID:        uuid,
+		Clock:       clock,
+	}
+}
+
+func (uc *RejectOrderUsecase) Execute(ctx context.Context, in RejectOrderInput) (*Order, error) {
+	if in.StoreID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing storeId")
+	}
+	if in.OrderID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing orderId")
+	}
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	if isValidUUID := uc.UUID.Validate(in.StoreID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid store id")
+	}
+	if isValidUUID := uc.UUID.Validate(in.OrderID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid order id")
+	}
+	if isValidUUID := uc.UUID.Validate(in.UserID); !isValidUUID {
+		return nil, errx.New(errx.CodeInvalid, "invalid user id")
+	}
+
+	reason := strings.TrimSpace(in.Reason)
+	if reason == "" {
+		return nil, errx.New(errx.CodeInvalid, "reason is required")
+	}
+	if len(reason) > maxCancelReasonLen {
+		return nil, errx.New(errx.CodeInvalid, "reason is too long")
+	}
+
+	if err := ensureStoreStaff(ctx, uc.Policy, in.StoreID, in.UserID); err != nil {
+		return nil, err
+	}
+
+	release := paymentuc.NewReleaseOrderPaymentsUsecase(uc.OrderRepo, uc.PaymentRepo, uc.RefundRepo, uc.Gateways, uc.Tx, uc.UUID, uc.Clock)
+	var (
+		o        *entity.Order
+		released *paymentuc.ReleaseOrderPaymentsOutput
+	)
+	err := uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		o, err = uc.OrderRepo.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return err
+		}
+		if o.StoreID != in.StoreID {
+			return errx.New(errx.CodeNotFound, "order not found")
+		}
+
+		released, err = cancelAndRelease(ctx, uc.OrderRepo, release, o, entity.OrderActorStore, in.UserID, reason, uc.Clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// o pedido já está cancelado; o que o provider não aceitar agora fica
+	// PENDING e o job de estornos reenvia
+	_ = release.Settle(ctx, released)
+
+	return toOrderDTO(o), nil
+}
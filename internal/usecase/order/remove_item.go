@@ -2,9 +2,7 @@ package usecase
 
 import (
 	"context"
-	"time"
 
-	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
@@ -19,10 +17,11 @@ type RemoveItemInput struct {
 type RemoveItemUsecase struct {
 	OrderRepo repository.OrderRepository
 	UUID      ports.UUIDInterface
+	Clock     ports.Clock
 }
 
-func NewRemoveItemUsecase(orderRepo repository.OrderRepository, uuid ports.UUIDInterface) *RemoveItemUsecase {
-	return &RemoveItemUsecase{OrderRepo: orderRepo, UUID: uuid}
+func NewRemoveItemUsecase(orderRepo repository.OrderRepository, uuid ports.UUIDInterface, clock ports.Clock) *RemoveItemUsecase {
+	return &RemoveItemUsecase{OrderRepo: orderRepo, UUID: uuid, Clock: clock}
 }
 
 func (uc *RemoveItemUsecase) Execute(ctx context.Context, in RemoveItemInput) (*Order, error) {
@@ -48,36 +47,38 @@ func (uc *RemoveItemUsecase) Execute(ctx context.Context, in RemoveItemInput) (*
 		return nil, errx.New(errx.CodeInvalid, "invalid user id")
 	}
 
-	o, err := uc.OrderRepo.GetByID(ctx, in.OrderID)
-	if err != nil {
-		return nil, err
-	}
-	if o.UserID != in.UserID {
-		return nil, errx.New(errx.CodeForbidden, "order does not belong to user")
-	}
-	if o.Status != entity.OrderCreated {
-		return nil, errx.New(errx.CodeConflict, "order is not editable")
-	}
+	return retryOnStale(func() (*Order, error) {
+		o, err := uc.OrderRepo.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if o.UserID != in.UserID {
+			return nil, errx.New(errx.CodeForbidden, "order does not belong to user")
+		}
+		if !o.IsEditable() {
+			return nil, errx.New(errx.CodeConflict, "order is not editable")
+		}
 
-	idx := -1
-	for i := range o.Items {
-		if o.Items[i].ID == in.ItemID {
-			idx = i
-			break
+		idx := -1
+		for i := range o.Items {
+			if o.Items[i].ID == in.ItemID {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return nil, errx.New(errx.CodeNotFound, "order item not found")
 		}
-	}
-	if idx == -1 {
-		return nil, errx.New(errx.CodeNotFound, "order item not found")
-	}
 
-	o.Items = append(o.Items[:idx], o.Items[idx+1:]...)
+		o.Items = append(o.Items[:idx], o.Items[idx+1:]...)
 
-	o.UpdatedAt = time.Now()
-	o.RecalculateTotals()
+		o.UpdatedAt = uc.Clock.Now()
+		o.RecalculateTotals()
 
-	if err := uc.OrderRepo.Update(ctx, o); err != nil {
-		return nil, err
-	}
+		if err := uc.OrderRepo.Update(ctx, o); err != nil {
+			return nil, err
+		}
 
-	return toOrderDTO(o), nil
+		return toOrderDTO(o), nil
+	})
 }
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+)
+
+// maxStaleRetries limita quantas vezes um usecase relê o pedido e reaplica a
+// alteração quando outro request gravou antes (versão desatualizada).
+const maxStaleRetries = 5
+
+// retryOnStale executa fn (que deve reler o pedido a cada chamada) até gravar
+// ou até esgotar as tentativas; aí o conflito sobe como 409.
+func retryOnStale(fn func() (*Order, error)) (*Order, error) {
+	var (
+		out *Order
+		err error
+	)
+	for range maxStaleRetries {
+		out, err = fn()
+		if !errors.Is(err, errx.ErrStaleVersion) {
+			return out, err
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+)
+
+// Janela aceita para agendar um pedido a partir de agora.
+const (
+	ScheduleMinLead  = 15 * time.Minute
+	ScheduleMaxAhead = 7 * 24 * time.Hour
+)
+
+// ensureAcceptingOrders barra o carrinho quando o lojista desligou a loja.
+// Fora do horário ou em pausa o carrinho continua liberado: o cliente pode
+// agendar, e o horário é conferido no place.
+func ensureAcceptingOrders(store *entity.Store) error {
+	if !store.IsOpen {
+		return errx.New(errx.CodeConflict, "store is not accepting orders")
+	}
+	return nil
+}
+
+// ensureOpenFor confere se a loja atende no momento do pedido: agora ou no
+// horário agendado.
+func ensureOpenFor(store *entity.Store, now time.Time, scheduledFor *time.Time) error {
+	if scheduledFor == nil {
+		if store.IsOpen && store.IsPausedAt(now) {
+			return errx.F(errx.CodeConflict, "store is paused until %s", store.PausedUntil.Format(time.RFC3339))
+		}
+		if !store.IsOpenAt(now) {
+			return errx.New(errx.CodeConflict, "store is closed")
+		}
+		return nil
+	}
+
+	at := *scheduledFor
+	if at.Before(now.Add(ScheduleMinLead)) {
+		return errx.F(errx.CodeInvalid, "scheduled time must be at least %s ahead", ScheduleMinLead)
+	}
+	if at.After(now.Add(ScheduleMaxAhead)) {
+		return errx.F(errx.CodeInvalid, "scheduled time must be within %s", ScheduleMaxAhead)
+	}
+	if !store.IsOpenAt(at) {
+		return errx.New(errx.CodeConflict, "store is closed at the scheduled time")
+	}
+	return nil
+}
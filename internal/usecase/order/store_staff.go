@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+)
+
+// ensureStoreStaff garante que o usuário opera a fila de pedidos da loja.
+func ensureStoreStaff(ctx context.Context, authz *policy.Policy, storeID, userID string) error {
+	return authz.Authorize(ctx, userID, policy.ActionOrdersManage, storeID)
+}
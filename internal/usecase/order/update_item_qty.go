@@ -2,9 +2,7 @@ package usecase
 
 import (
 	"context"
-	"time"
 
-	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
@@ -20,10 +18,11 @@ type UpdateItemQtyInput struct {
 type UpdateItemQtyUsecase struct {
 	OrderRepo repository.OrderRepository
 	UUID      ports.UUIDInterface
+	Clock     ports.Clock
 }
 
-func NewUpdateItemQtyUsecase(orderRepo repository.OrderRepository, uuid ports.UUIDInterface) *UpdateItemQtyUsecase {
-	return &UpdateItemQtyUsecase{OrderRepo: orderRepo, UUID: uuid}
+func NewUpdateItemQtyUsecase(orderRepo repository.OrderRepository, uuid ports.UUIDInterface, clock ports.Clock) *UpdateItemQtyUsecase {
+	return &UpdateItemQtyUsecase{OrderRepo: orderRepo, UUID: uuid, Clock: clock}
 }
 
 func (uc *UpdateItemQtyUsecase) Execute(ctx context.Context, in UpdateItemQtyInput) (*Order, error) {
@@ -54,35 +53,37 @@ func (uc *UpdateItemQtyUsecase) Execute(ctx context.Context, in UpdateItemQtyInp
 		return nil, errx.New(errx.CodeInvalid, "qty must be > 0")
 	}
 
-	o, err := uc.OrderRepo.GetByID(ctx, in.OrderID)
-	if err != nil {
-		return nil, err
-	}
-	if o.UserID != in.UserID {
-		return nil, errx.New(errx.CodeForbidden, "order does not belong to user")
-	}
-	if o.Status != entity.OrderCreated {
-		return nil, errx.New(errx.CodeConflict, "order is not editable")
-	}
+	return retryOnStale(func() (*Order, error) {
+		o, err := uc.OrderRepo.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if o.UserID != in.UserID {
+			return nil, errx.New(errx.CodeForbidden, "order does not belong to user")
+		}
+		if !o.IsEditable() {
+			return nil, errx.New(errx.CodeConflict, "order is not editable")
+		}
 
-	found := false
-	for i := range o.Items {
-		if o.Items[i].ID == in.ItemID {
-			o.Items[i].Qty = in.Qty
-			found = true
-			break
+		found := false
+		for i := range o.Items {
+			if o.Items[i].ID == in.ItemID {
+				o.Items[i].Qty = in.Qty
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errx.New(errx.CodeNotFound, "order item not found")
 		}
-	}
-	if !found {
-		return nil, errx.New(errx.CodeNotFound, "order item not found")
-	}
 
-	o.UpdatedAt = time.Now()
-	o.RecalculateTotals()
+		o.UpdatedAt = uc.Clock.Now()
+		o.RecalculateTotals()
 
-	if err := uc.OrderRepo.Update(ctx, o); err != nil {
-		return nil, err
-	}
+		if err := uc.OrderRepo.Update(ctx, o); err != nil {
+			return nil, err
+		}
 
-	return toOrderDTO(o), nil
+		return toOrderDTO(o), nil
+	})
 }
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+// racingOrderRepo simula outro request gravando o pedido logo depois de cada
+// leitura, deixando a cópia do usecase com a versão desatualizada.
+type racingOrderRepo struct {
+	repository.OrderRepository
+	races int
+}
+
+func (r *racingOrderRepo) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	o, err := r.OrderRepository.GetByID(ctx, id)
+	if err != nil || r.races == 0 {
+		return o, err
+	}
+	r.races--
+
+	other, err := r.OrderRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	other.Items = append(other.Items, entity.OrderItem{ID: id + "-race-" + strconv.Itoa(r.races), Name: "Concurrent", Qty: 1})
+	if err := r.OrderRepository.Update(ctx, other); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func TestUpdateItemQty(t *testing.T) {
+	uuid := pkg.NewUUID()
+	orderRepo := memoryorder.New(pkg.NewClock())
+	userID := uuid.Generate()
+
+	seed := func(t *testing.T) (orderID, itemID string) {
+		orderID = uuid.Generate()
+		itemID = uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: uuid.Generate(), UserID: userID, Status: entity.OrderCreated,
+			Items: []entity.OrderItem{{ID: itemID, ItemID: uuid.Generate(), Name: "Coca", Qty: 1, BasePrice: 500}},
+		}))
+		return
+	}
+
+	t.Run("test update item qty bumps the order version", func(t *testing.T) {
+		orderID, itemID := seed(t)
+		uc := NewUpdateItemQtyUsecase(orderRepo, uuid, pkg.NewClock())
+
+		out, err := uc.Execute(t.Context(), UpdateItemQtyInput{OrderID: orderID, UserID: userID, ItemID: itemID, Qty: 3})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), out.Items[0].Qty)
+		require.Equal(t, int64(2), out.Version)
+	})
+
+	t.Run("test update item qty retries after a concurrent write", func(t *testing.T) {
+		orderID, itemID := seed(t)
+		uc := NewUpdateItemQtyUsecase(&racingOrderRepo{OrderRepository: orderRepo, races: 2}, uuid, pkg.NewClock())
+
+		out, err := uc.Execute(t.Context(), UpdateItemQtyInput{OrderID: orderID, UserID: userID, ItemID: itemID, Qty: 4})
+		require.NoError(t, err)
+		require.Equal(t, int64(4), out.Items[0].Qty)
+
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Len(t, o.Items, 3)
+		require.Equal(t, int64(4), o.Items[0].Qty)
+		require.Equal(t, int64(4), o.Version)
+	})
+
+	t.Run("test update item qty gives up after too many concurrent writes", func(t *testing.T) {
+		orderID, itemID := seed(t)
+		uc := NewUpdateItemQtyUsecase(&racingOrderRepo{OrderRepository: orderRepo, races: maxStaleRetries}, uuid, pkg.NewClock())
+
+		_, err := uc.Execute(t.Context(), UpdateItemQtyInput{OrderID: orderID, UserID: userID, ItemID: itemID, Qty: 4})
+		require.Error(t, err)
+		require.ErrorIs(t, err, errx.ErrStaleVersion)
+		require.Equal(t, errx.CodeConflict, errx.CodeOf(err))
+	})
+}
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
@@ -15,10 +14,17 @@ type ConfirmPaymentInput struct {
 	UserID    string
 }
 
+// ConfirmPaymentUsecase simula o cliente concluindo o pagamento no provider.
+// Só vale para cobranças do método MOCK: PIX e cartão são confirmados pelo
+// webhook, mesmo quando o provider por trás é o mock.
 type ConfirmPaymentUsecase struct {
 	OrderRepo   repository.OrderRepository
 	PaymentRepo repository.PaymentRepository
+	RefundRepo  repository.RefundRepository
+	Gateways    ports.PaymentGateways
+	Tx          ports.TxManager
 	UUID        ports.UUIDInterface
+	Clock       ports.Clock
 }
 
 type ConfirmPaymentOutput struct {
@@ -28,12 +34,20 @@ type ConfirmPaymentOutput struct {
 func NewConfirmPaymentUsecase(
 	orders repository.OrderRepository,
 	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *ConfirmPaymentUsecase {
 	return &ConfirmPaymentUsecase{
 		OrderRepo:   orders,
 		PaymentRepo: payments,
+		RefundRepo:  refunds,
+		Gateways:    gateways,
+		Tx:          tx,
 		UUID:        uuid,
+		Clock:       clock,
 	}
 }
 
@@ -52,39 +66,55 @@ func (uc *ConfirmPaymentUsecase) Execute(ctx context.Context, in ConfirmPaymentI
 		return nil, errx.New(errx.CodeInvalid, "invalid user id")
 	}
 
-	p, err := uc.PaymentRepo.GetByID(ctx, in.PaymentID)
-	if err != nil {
-		return nil, err
-	}
-	if p.UserID != in.UserID {
-		return nil, errx.New(errx.CodeForbidden, "payment does not belong to user")
-	}
-	if p.Status == entity.PaymentStatusPaid {
-		return &ConfirmPaymentOutput{Payment: ToPaymentDTO(p)}, nil
-	}
-	if p.Status != entity.PaymentStatusPending {
-		return nil, errx.New(errx.CodeConflict, "payment must be PENDING to confirm")
-	}
-
-	now := time.Now()
-	p.Status = entity.PaymentStatusPaid
-	p.PaidAt = &now
-	p.UpdatedAt = now
+	var (
+		p         *entity.Payment
+		unclaimed *entity.Refund
+	)
+	// pagamento e pedido mudam juntos: se o pedido falhar, o pagamento volta
+	err := uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = uc.PaymentRepo.GetByID(ctx, in.PaymentID)
+		if err != nil {
+			return err
+		}
+		if p.UserID != in.UserID {
+			return errx.New(errx.CodeForbidden, "payment does not belong to user")
+		}
+		if p.Status == entity.PaymentStatusPaid {
+			return nil
+		}
+		if p.Status != entity.PaymentStatusPending {
+			return errx.New(errx.CodeConflict, "payment must be PENDING to confirm")
+		}
+		// o job de expiração pode ainda não ter passado por ela
+		if p.IsExpired(uc.Clock.Now()) {
+			return errx.New(errx.CodeConflict, "payment expired")
+		}
+		if !isSimulated(p) {
+			return errx.New(errx.CodeConflict, "payment status is driven by the provider")
+		}
 
-	if err := uc.PaymentRepo.Update(ctx, p); err != nil {
-		return nil, err
-	}
+		gw, err := uc.Gateways.ForProvider(p.Provider)
+		if err != nil {
+			return err
+		}
+		res, err := gw.Capture(ctx, p.ProviderRef, p.Amount)
+		if err != nil {
+			return err
+		}
 
-	// marca pedido como PAID
-	o, err := uc.OrderRepo.GetByID(ctx, p.OrderID)
+		unclaimed, err = applyGatewayResult(ctx, uc.OrderRepo, uc.PaymentRepo, uc.RefundRepo, uc.UUID, p, res, uc.Clock.Now())
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	if o.Status == entity.OrderPlaced {
-		o.Status = entity.OrderPaid
-		o.UpdatedAt = now
-		if err := uc.OrderRepo.Update(ctx, o); err != nil {
-			return nil, err
+	// pedido já pago por outra cobrança: esta é devolvida; se o provider
+	// falhar o estorno fica PENDING para o job
+	if unclaimed != nil {
+		settler := refundSettler{Orders: uc.OrderRepo, Payments: uc.PaymentRepo, Refunds: uc.RefundRepo, Gateways: uc.Gateways, Tx: uc.Tx, Clock: uc.Clock}
+		if _, settled, err := settler.settle(ctx, p, unclaimed); err == nil {
+			p = settled
 		}
 	}
 
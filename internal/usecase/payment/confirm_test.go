@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+// failingOrderRepo simula uma falha na escrita do pedido depois do pagamento.
+type failingOrderRepo struct {
+	repository.OrderRepository
+}
+
+func (r *failingOrderRepo) Update(ctx context.Context, o *entity.Order) error {
+	return errx.New(errx.CodeInternal, "order update failed")
+}
+
+func TestConfirmPayment(t *testing.T) {
+	uuid := pkg.NewUUID()
+	orderRepo := memoryorder.New(pkg.NewClock())
+	paymentRepo := memorypayment.New(pkg.NewClock())
+	refundRepo := memoryrefund.New(pkg.NewClock())
+	tx := memorytx.New()
+	gateways := payment.NewGateways(payment.Config{}, pkg.NewClock())
+
+	userID := uuid.Generate()
+	seed := func(t *testing.T) (orderID, paymentID string) {
+		orderID = uuid.Generate()
+		paymentID = uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: uuid.Generate(), UserID: userID, Status: entity.OrderPlaced,
+		}))
+		require.NoError(t, paymentRepo.Create(t.Context(), &entity.Payment{
+			ID: paymentID, OrderID: orderID, UserID: userID, StoreID: "store", Status: entity.PaymentStatusPending, Amount: 1000,
+			Method: entity.PaymentMethodMock, Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + paymentID,
+		}))
+		return
+	}
+
+	t.Run("test confirm a payment marks the order as paid", func(t *testing.T) {
+		orderID, paymentID := seed(t)
+		uc := NewConfirmPaymentUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+		out, err := uc.Execute(t.Context(), ConfirmPaymentInput{PaymentID: paymentID, UserID: userID})
+		require.NoError(t, err)
+		require.Equal(t, "PAID", out.Payment.Status)
+
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPaid, o.Status)
+	})
+
+	t.Run("test confirm a payment rolls back when the order update fails", func(t *testing.T) {
+		_, paymentID := seed(t)
+		uc := NewConfirmPaymentUsecase(&failingOrderRepo{orderRepo}, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+		_, err := uc.Execute(t.Context(), ConfirmPaymentInput{PaymentID: paymentID, UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "internal: order update failed", err.Error())
+
+		p, err := paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusPending, p.Status)
+		require.Nil(t, p.PaidAt)
+	})
+
+	t.Run("test confirm a payment of another user", func(t *testing.T) {
+		_, paymentID := seed(t)
+		uc := NewConfirmPaymentUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+		_, err := uc.Execute(t.Context(), ConfirmPaymentInput{PaymentID: paymentID, UserID: uuid.Generate()})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: payment does not belong to user", err.Error())
+	})
+
+	t.Run("test confirm a payment handled by a real provider", func(t *testing.T) {
+		_, paymentID := seed(t)
+		p, err := paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		p.Provider = "PSP"
+		require.NoError(t, paymentRepo.Update(t.Context(), p))
+		uc := NewConfirmPaymentUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+		_, err = uc.Execute(t.Context(), ConfirmPaymentInput{PaymentID: paymentID, UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "conflict: payment status is driven by the provider", err.Error())
+	})
+
+	t.Run("test confirm or fail a card payment charged by the mock provider", func(t *testing.T) {
+		_, paymentID := seed(t)
+		p, err := paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		p.Method = entity.PaymentMethodCreditCard
+		require.NoError(t, paymentRepo.Update(t.Context(), p))
+
+		_, err = NewConfirmPaymentUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock()).
+			Execute(t.Context(), ConfirmPaymentInput{PaymentID: paymentID, UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "conflict: payment status is driven by the provider", err.Error())
+
+		_, err = NewFailPaymentUsecase(paymentRepo, uuid, pkg.NewClock()).
+			Execute(t.Context(), FailPaymentInput{PaymentID: paymentID, UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "conflict: payment status is driven by the provider", err.Error())
+
+		p, err = paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusPending, p.Status)
+	})
+
+	t.Run("test confirm a second charge of an order already paid refunds it", func(t *testing.T) {
+		orderID, first := seed(t)
+		second := uuid.Generate()
+		require.NoError(t, paymentRepo.Create(t.Context(), &entity.Payment{
+			ID: second, OrderID: orderID, UserID: userID, StoreID: "store", Status: entity.PaymentStatusPending, Amount: 1000,
+			Method: entity.PaymentMethodMock, Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + second,
+		}))
+		uc := NewConfirmPaymentUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+		_, err := uc.Execute(t.Context(), ConfirmPaymentInput{PaymentID: first, UserID: userID})
+		require.NoError(t, err)
+		out, err := uc.Execute(t.Context(), ConfirmPaymentInput{PaymentID: second, UserID: userID})
+		require.NoError(t, err)
+		require.Equal(t, "REFUNDED", out.Payment.Status)
+
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), second)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		require.Equal(t, entity.RefundStatusSucceeded, refunds[0].Status)
+		require.Equal(t, int64(1000), refunds[0].Amount)
+		require.Equal(t, "payment captured while order was PAID", refunds[0].Reason)
+
+		// o pedido segue com a primeira cobrança
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPaid, o.Status)
+	})
+}
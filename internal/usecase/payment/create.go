@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
 	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
@@ -14,7 +15,7 @@ type CreatePaymentInput struct {
 	OrderID string
 	UserID  string
 
-	Method         entity.PaymentMethod // sempre MOCK por agora
+	Method         entity.PaymentMethod // escolhe o gateway (MOCK quando vazio)
 	IdempotencyKey string               // opcional
 }
 
@@ -25,34 +26,65 @@ type PaymentDTO struct {
 	StoreID        string     `json:"store_id"`
 	Method         string     `json:"method"`
 	Provider       string     `json:"provider"`
+	ProviderRef    string     `json:"provider_ref"`
 	Status         string     `json:"status"`
 	Amount         int64      `json:"amount"`
 	Currency       string     `json:"currency"`
 	IdempotencyKey string     `json:"idempotency_key"`
+	Version        int64      `json:"version"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 	PaidAt         *time.Time `json:"paid_at"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+// PixDTO é o que o cliente precisa para pagar: o "copia e cola" e o QR code
+// (PNG em base64) com o mesmo conteúdo.
+type PixDTO struct {
+	TxID      string     `json:"txid"`
+	CopyPaste string     `json:"copy_paste"`
+	QRCodePNG string     `json:"qr_code_png"`
+	ExpiresAt *time.Time `json:"expires_at"`
 }
 
 type CreatePaymentOutput struct {
 	Payment PaymentDTO `json:"payment"`
+	Pix     *PixDTO    `json:"pix,omitempty"`
 }
 
+// tamanho do PNG do QR code, em pixels
+const pixQRCodeSize = 256
+
 type CreatePaymentUsecase struct {
 	Orders   repository.OrderRepository
 	Payments repository.PaymentRepository
+	Refunds  repository.RefundRepository
+	Gateways ports.PaymentGateways
+	QRCode   ports.QRCodeInterface
+	Tx       ports.TxManager
 	UUID     ports.UUIDInterface
+	Clock    ports.Clock
 }
 
 func NewCreatePaymentUsecase(
 	orders repository.OrderRepository,
 	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	gateways ports.PaymentGateways,
+	qr ports.QRCodeInterface,
+	tx ports.TxManager,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *CreatePaymentUsecase {
 	return &CreatePaymentUsecase{
 		Orders:   orders,
 		Payments: payments,
+		Refunds:  refunds,
+		Gateways: gateways,
+		QRCode:   qr,
+		Tx:       tx,
 		UUID:     uuid,
+		Clock:    clock,
 	}
 }
 
@@ -74,61 +106,220 @@ func (uc *CreatePaymentUsecase) Execute(ctx context.Context, in CreatePaymentInp
 	if in.Method == "" {
 		in.Method = entity.PaymentMethodMock
 	}
-
-	o, err := uc.Orders.GetByID(ctx, in.OrderID)
+	gw, err := uc.Gateways.ForMethod(in.Method)
 	if err != nil {
 		return nil, err
 	}
-	if o.UserID != in.UserID {
-		return nil, errx.New(errx.CodeForbidden, "order does not belong to user")
+
+	var p *entity.Payment
+	// leitura do pedido e criação do pagamento na mesma unidade de trabalho
+	err = uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		o, err := uc.Orders.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return err
+		}
+		if o.UserID != in.UserID {
+			return errx.New(errx.CodeForbidden, "order does not belong to user")
+		}
+		if !o.Status.CanTransitionTo(entity.OrderPaid, entity.OrderActorSystem) {
+			return errx.New(errx.CodeConflict, "order must be PLACED to create payment")
+		}
+		if len(o.Items) == 0 {
+			return errx.New(errx.CodeInvalid, "order has no items")
+		}
+
+		// idempotência
+		if in.IdempotencyKey != "" {
+			existing, e := uc.Payments.GetByOrderAndKey(ctx, o.ID, in.IdempotencyKey)
+			if e == nil && existing != nil {
+				p = existing
+				return nil
+			}
+			if e != nil && !errx.Is(e, errx.CodeNotFound) {
+				return e
+			}
+		}
+
+		o.RecalculateTotals() // garante amount correto
+		now := uc.Clock.Now()
+
+		p = &entity.Payment{
+			ID:             uc.UUID.Generate(),
+			OrderID:        o.ID,
+			UserID:         o.UserID,
+			StoreID:        o.StoreID,
+			Method:         in.Method,
+			Provider:       gw.Provider(),
+			Status:         entity.PaymentStatusCreated,
+			Amount:         int64(o.Total),
+			Currency:       "BRL",
+			IdempotencyKey: in.IdempotencyKey,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+
+		return uc.Payments.Create(ctx, p)
+	})
+	if err != nil {
+		// corrida na mesma chave: devolve o pagamento que ganhou
+		if errx.Is(err, errx.CodeConflict) && in.IdempotencyKey != "" {
+			existing, e := uc.Payments.GetByOrderAndKey(ctx, in.OrderID, in.IdempotencyKey)
+			if e == nil && existing != nil {
+				p = existing
+				err = nil
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
-	if o.Status != entity.OrderPlaced {
-		return nil, errx.New(errx.CodeConflict, "order must be PLACED to create payment")
+
+	// já passou pelo provider (retry com a mesma chave): nada a fazer
+	if p.Status != entity.PaymentStatusCreated {
+		return uc.output(p)
 	}
-	if len(o.Items) == 0 {
-		return nil, errx.New(errx.CodeInvalid, "order has no items")
+
+	// o provider é chamado fora da tx para a latência dele não segurar o banco;
+	// se falhar, o pagamento fica CREATED e a mesma chave tenta de novo
+	res, err := gw.Authorize(ctx, ports.GatewayAuthorizeRequest{
+		PaymentID:      p.ID,
+		OrderID:        p.OrderID,
+		Method:         p.Method,
+		Amount:         p.Amount,
+		Currency:       p.Currency,
+		IdempotencyKey: p.ID,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// idempotência
-	if in.IdempotencyKey != "" {
-		existing, e := uc.Payments.GetByOrderAndKey(ctx, o.ID, in.IdempotencyKey)
-		if e == nil && existing != nil {
-			return &CreatePaymentOutput{Payment: ToPaymentDTO(existing)}, nil
+	var unclaimed *entity.Refund
+	err = uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = uc.Payments.GetByID(ctx, p.ID)
+		if err != nil {
+			return err
 		}
-		if e != nil && !errx.Is(e, errx.CodeNotFound) {
-			return nil, e
+		if p.Status != entity.PaymentStatusCreated {
+			return nil
 		}
+		unclaimed, err = applyGatewayResult(ctx, uc.Orders, uc.Payments, uc.Refunds, uc.UUID, p, res, uc.Clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if unclaimed != nil {
+		// o pedido foi cancelado enquanto o provider cobrava
+		p = uc.refundUnclaimed(ctx, p, unclaimed)
 	}
 
-	o.RecalculateTotals() // garante amount correto
-	now := time.Now()
+	return uc.output(p)
+}
 
-	p := &entity.Payment{
-		ID:             uc.UUID.Generate(),
-		OrderID:        o.ID,
-		UserID:         o.UserID,
-		StoreID:        o.StoreID,
-		Method:         in.Method,
-		Provider:       entity.PaymentProviderMock,
-		Status:         entity.PaymentStatusPending,
-		Amount:         int64(o.Total),
-		Currency:       "BRL",
-		IdempotencyKey: in.IdempotencyKey,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+// output monta a resposta; o QR code é gerado a cada chamada a partir do
+// "copia e cola" gravado, então o retry com a mesma chave devolve o mesmo QR.
+func (uc *CreatePaymentUsecase) output(p *entity.Payment) (*CreatePaymentOutput, error) {
+	out := &CreatePaymentOutput{Payment: ToPaymentDTO(p)}
+	// QR vencido não é devolvido: o cliente precisa de uma cobrança nova
+	if p.PixCode == "" || p.Status != entity.PaymentStatusPending || p.IsExpired(uc.Clock.Now()) {
+		return out, nil
 	}
 
-	if err := uc.Payments.Create(ctx, p); err != nil {
-		if errx.Is(err, errx.CodeConflict) && in.IdempotencyKey != "" {
-			existing, e := uc.Payments.GetByOrderAndKey(ctx, o.ID, in.IdempotencyKey)
-			if e == nil && existing != nil {
-				return &CreatePaymentOutput{Payment: ToPaymentDTO(existing)}, nil
-			}
-		}
+	png, err := uc.QRCode.PNG(p.PixCode, pixQRCodeSize)
+	if err != nil {
+		return nil, errx.Wrap(errx.CodeInternal, "failed to generate pix qr code", err)
+	}
+	out.Pix = &PixDTO{
+		TxID:      p.PixTxID,
+		CopyPaste: p.PixCode,
+		QRCodePNG: base64.StdEncoding.EncodeToString(png),
+		ExpiresAt: p.ExpiresAt,
+	}
+	return out, nil
+}
+
+// refundUnclaimed manda ao provider o estorno de uma cobrança que o pedido não
+// aceitou. Falha aqui não volta para o cliente: o estorno fica PENDING e o job
+// de estornos reenvia.
+func (uc *CreatePaymentUsecase) refundUnclaimed(ctx context.Context, p *entity.Payment, rf *entity.Refund) *entity.Payment {
+	settler := refundSettler{Orders: uc.Orders, Payments: uc.Payments, Refunds: uc.Refunds, Gateways: uc.Gateways, Tx: uc.Tx, Clock: uc.Clock}
+	if _, settled, err := settler.settle(ctx, p, rf); err == nil {
+		return settled
+	}
+	return p
+}
+
+// applyGatewayResult grava o que o provider respondeu; quando a cobrança já
+// vem paga, o pedido vai para PAID junto. Devolve o estorno reservado quando o
+// pedido não tinha mais como receber o pagamento (ver markPaid).
+func applyGatewayResult(
+	ctx context.Context,
+	orders repository.OrderRepository,
+	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	uuid ports.UUIDInterface,
+	p *entity.Payment,
+	res *ports.GatewayPaymentResult,
+	now time.Time,
+) (*entity.Refund, error) {
+	if res.Ref != "" {
+		p.ProviderRef = res.Ref
+	}
+	if res.Pix != nil {
+		p.PixTxID = res.Pix.TxID
+		p.PixCode = res.Pix.Code
+	}
+	if res.ExpiresAt != nil {
+		p.ExpiresAt = res.ExpiresAt
+	}
+	if res.Status == entity.PaymentStatusPaid {
+		return markPaid(ctx, orders, payments, refunds, uuid, p, now)
+	}
+
+	p.Status = res.Status
+	p.UpdatedAt = now
+	return nil, payments.Update(ctx, p)
+}
+
+// unclaimedRefundKey identifica o estorno de uma cobrança que o pedido não
+// aceitou; cada pagamento só é capturado uma vez.
+const unclaimedRefundKey = "unclaimed"
+
+// markPaid confirma o pagamento e leva o pedido para PAID se ele ainda
+// estiver esperando o pagamento. Se não estiver (cancelado, expirado ou já
+// pago por outra cobrança) o dinheiro entrou mesmo assim: o valor todo é
+// reservado num estorno, devolvido para o chamador mandar ao provider depois
+// do commit.
+func markPaid(
+	ctx context.Context,
+	orders repository.OrderRepository,
+	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	uuid ports.UUIDInterface,
+	p *entity.Payment,
+	now time.Time,
+) (*entity.Refund, error) {
+	p.Status = entity.PaymentStatusPaid
+	p.PaidAt = &now
+	p.UpdatedAt = now
+
+	if err := payments.Update(ctx, p); err != nil {
 		return nil, err
 	}
 
-	return &CreatePaymentOutput{Payment: ToPaymentDTO(p)}, nil
+	o, err := orders.GetByID(ctx, p.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !o.Status.CanTransitionTo(entity.OrderPaid, entity.OrderActorSystem) {
+		reason := "payment captured while order was " + string(o.Status)
+		return reserveRefund(ctx, refunds, payments, uuid, p, p.Amount, reason, unclaimedRefundKey, "", now)
+	}
+	if err := o.TransitionTo(entity.OrderPaid, entity.OrderActorSystem, "", now); err != nil {
+		return nil, err
+	}
+	return nil, orders.Update(ctx, o)
 }
 
 func ToPaymentDTO(p *entity.Payment) PaymentDTO {
@@ -139,12 +330,15 @@ func ToPaymentDTO(p *entity.Payment) PaymentDTO {
 		StoreID:        p.StoreID,
 		Method:         p.Method.String(),
 		Provider:       p.Provider.String(),
+		ProviderRef:    p.ProviderRef,
 		Status:         p.Status.String(),
 		Amount:         p.Amount,
 		Currency:       p.Currency,
 		IdempotencyKey: p.IdempotencyKey,
+		Version:        p.Version,
 		CreatedAt:      p.CreatedAt,
 		UpdatedAt:      p.UpdatedAt,
 		PaidAt:         p.PaidAt,
+		ExpiresAt:      p.ExpiresAt,
 	}
 }
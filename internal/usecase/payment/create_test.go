@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	mockgateway "github.com/FabioRocha231/saas-core/internal/infra/payment/mock"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePayment(t *testing.T) {
+	uuid := pkg.NewUUID()
+	orderRepo := memoryorder.New(pkg.NewClock())
+	paymentRepo := memorypayment.New(pkg.NewClock())
+	refundRepo := memoryrefund.New(pkg.NewClock())
+	tx := memorytx.New()
+
+	userID := uuid.Generate()
+	seed := func(t *testing.T) string {
+		orderID := uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: uuid.Generate(), UserID: userID, Status: entity.OrderPlaced,
+			Items: []entity.OrderItem{{ID: uuid.Generate(), ItemID: uuid.Generate(), Name: "Coca", Qty: 2, BasePrice: 500}},
+		}))
+		return orderID
+	}
+	newUsecase := func(outcome mockgateway.Outcome) *CreatePaymentUsecase {
+		gateways := payment.NewGateways(payment.Config{Mock: mockgateway.Config{
+			Outcome:         outcome,
+			PixKey:          "pix@loja.com",
+			PixMerchantName: "Loja Teste",
+			PixMerchantCity: "São Paulo",
+			PixExpiration:   15 * time.Minute,
+		}}, pkg.NewClock())
+		return NewCreatePaymentUsecase(orderRepo, paymentRepo, refundRepo, gateways, pkg.NewQRCode(), tx, uuid, pkg.NewClock())
+	}
+	orderStatus := func(t *testing.T, id string) entity.OrderStatus {
+		o, err := orderRepo.GetByID(t.Context(), id)
+		require.NoError(t, err)
+		return o.Status
+	}
+
+	t.Run("test create a payment left pending by the provider", func(t *testing.T) {
+		orderID := seed(t)
+
+		out, err := newUsecase(mockgateway.OutcomePending).Execute(t.Context(), CreatePaymentInput{OrderID: orderID, UserID: userID, Method: entity.PaymentMethodPix})
+		require.NoError(t, err)
+		require.Equal(t, "PENDING", out.Payment.Status)
+		require.Equal(t, "MOCK", out.Payment.Provider)
+		require.Equal(t, "PIX", out.Payment.Method)
+		require.Equal(t, "mock_"+out.Payment.ID, out.Payment.ProviderRef)
+		require.Equal(t, int64(1000), out.Payment.Amount)
+		require.Equal(t, entity.OrderPlaced, orderStatus(t, orderID))
+	})
+
+	t.Run("test create a pix payment returns the br code and qr code", func(t *testing.T) {
+		orderID := seed(t)
+		in := CreatePaymentInput{OrderID: orderID, UserID: userID, Method: entity.PaymentMethodPix, IdempotencyKey: "pix-1"}
+
+		before := time.Now()
+		out, err := newUsecase(mockgateway.OutcomePending).Execute(t.Context(), in)
+		require.NoError(t, err)
+		require.NotNil(t, out.Pix)
+
+		txid := strings.ReplaceAll(out.Payment.ID, "-", "")[:25]
+		require.Equal(t, txid, out.Pix.TxID)
+		require.True(t, strings.HasPrefix(out.Pix.CopyPaste, "000201010212"))
+		require.Contains(t, out.Pix.CopyPaste, "0112pix@loja.com")
+		require.Contains(t, out.Pix.CopyPaste, "540510.00")
+		require.Contains(t, out.Pix.CopyPaste, "0525"+txid)
+
+		png, err := base64.StdEncoding.DecodeString(out.Pix.QRCodePNG)
+		require.NoError(t, err)
+		require.Equal(t, "\x89PNG", string(png[:4]))
+
+		require.NotNil(t, out.Pix.ExpiresAt)
+		require.WithinDuration(t, before.Add(15*time.Minute), *out.Pix.ExpiresAt, 5*time.Second)
+		require.Equal(t, out.Pix.ExpiresAt, out.Payment.ExpiresAt)
+
+		// retry com a mesma chave devolve a mesma cobrança
+		again, err := newUsecase(mockgateway.OutcomePending).Execute(t.Context(), in)
+		require.NoError(t, err)
+		require.Equal(t, out.Payment.ID, again.Payment.ID)
+		require.Equal(t, out.Pix.CopyPaste, again.Pix.CopyPaste)
+	})
+
+	t.Run("test create a card payment has no pix data", func(t *testing.T) {
+		orderID := seed(t)
+
+		out, err := newUsecase(mockgateway.OutcomePending).Execute(t.Context(), CreatePaymentInput{OrderID: orderID, UserID: userID, Method: entity.PaymentMethodCreditCard})
+		require.NoError(t, err)
+		require.Nil(t, out.Pix)
+		require.Nil(t, out.Payment.ExpiresAt)
+	})
+
+	t.Run("test create a payment approved by the provider", func(t *testing.T) {
+		orderID := seed(t)
+
+		out, err := newUsecase(mockgateway.OutcomeApprove).Execute(t.Context(), CreatePaymentInput{OrderID: orderID, UserID: userID})
+		require.NoError(t, err)
+		require.Equal(t, "PAID", out.Payment.Status)
+		require.NotNil(t, out.Payment.PaidAt)
+		require.Equal(t, entity.OrderPaid, orderStatus(t, orderID))
+	})
+
+	t.Run("test create a payment declined by the provider", func(t *testing.T) {
+		orderID := seed(t)
+
+		out, err := newUsecase(mockgateway.OutcomeDecline).Execute(t.Context(), CreatePaymentInput{OrderID: orderID, UserID: userID})
+		require.NoError(t, err)
+		require.Equal(t, "FAILED", out.Payment.Status)
+		require.Equal(t, entity.OrderPlaced, orderStatus(t, orderID))
+	})
+
+	t.Run("test retry a payment after the provider was unavailable", func(t *testing.T) {
+		orderID := seed(t)
+		in := CreatePaymentInput{OrderID: orderID, UserID: userID, IdempotencyKey: "checkout-1"}
+
+		_, err := newUsecase(mockgateway.OutcomeError).Execute(t.Context(), in)
+		require.Error(t, err)
+		require.Equal(t, "internal: payment provider unavailable", err.Error())
+
+		pending, err := paymentRepo.GetByOrderAndKey(t.Context(), orderID, "checkout-1")
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusCreated, pending.Status)
+
+		out, err := newUsecase(mockgateway.OutcomeApprove).Execute(t.Context(), in)
+		require.NoError(t, err)
+		require.Equal(t, pending.ID, out.Payment.ID)
+		require.Equal(t, "PAID", out.Payment.Status)
+
+		payments, err := paymentRepo.ListByOrderID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Len(t, payments, 1)
+	})
+
+	t.Run("test create a payment with an unsupported method", func(t *testing.T) {
+		orderID := seed(t)
+
+		_, err := newUsecase(mockgateway.OutcomePending).Execute(t.Context(), CreatePaymentInput{OrderID: orderID, UserID: userID, Method: "BOLETO"})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: unsupported payment method BOLETO", err.Error())
+	})
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+// quantas cobranças cada execução processa no máximo
+const expirePaymentsBatch = 100
+
+type ExpirePaymentsOutput struct {
+	Expired []PaymentDTO `json:"expired"`
+}
+
+// ExpirePaymentsUsecase cancela as cobranças em aberto que venceram: as que
+// têm prazo próprio (ex.: o QR do PIX) quando ele passa, as demais TTL depois
+// de criadas. O pedido continua PLACED: o cliente pode gerar outra.
+type ExpirePaymentsUsecase struct {
+	PaymentRepo repository.PaymentRepository
+	Gateways    ports.PaymentGateways
+	Tx          ports.TxManager
+	Clock       ports.Clock
+	TTL         time.Duration
+}
+
+func NewExpirePaymentsUsecase(
+	payments repository.PaymentRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	clock ports.Clock,
+	ttl time.Duration,
+) *ExpirePaymentsUsecase {
+	return &ExpirePaymentsUsecase{
+		PaymentRepo: payments,
+		Gateways:    gateways,
+		Tx:          tx,
+		Clock:       clock,
+		TTL:         ttl,
+	}
+}
+
+func (uc *ExpirePaymentsUsecase) Execute(ctx context.Context) (*ExpirePaymentsOutput, error) {
+	if uc.TTL <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "payment ttl must be > 0")
+	}
+
+	now := uc.Clock.Now()
+	createdBefore := now.Add(-uc.TTL)
+
+	candidates, err := uc.PaymentRepo.ListExpired(ctx, now, createdBefore, expirePaymentsBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ExpirePaymentsOutput{Expired: make([]PaymentDTO, 0, len(candidates))}
+	var firstErr error
+	for _, c := range candidates {
+		var p *entity.Payment
+		// uma tx por cobrança: uma falha no provider não trava as outras
+		err := uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			p, err = uc.PaymentRepo.GetByID(ctx, c.ID)
+			if err != nil {
+				return err
+			}
+			// pago ou cancelado por um webhook desde a listagem
+			if !isOpen(p) || !isDue(p, now, createdBefore) {
+				p = nil
+				return nil
+			}
+
+			if p.ProviderRef != "" {
+				gw, err := uc.Gateways.ForProvider(p.Provider)
+				if err != nil {
+					return err
+				}
+				if _, err := gw.Cancel(ctx, p.ProviderRef); err != nil {
+					return err
+				}
+			}
+
+			p.Status = entity.PaymentStatusCanceled
+			p.UpdatedAt = now
+			return uc.PaymentRepo.Update(ctx, p)
+		})
+		if err != nil {
+			// alguém mexeu na cobrança no meio do caminho: fica para a próxima rodada
+			if !errx.Is(err, errx.CodeConflict) && firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if p != nil {
+			out.Expired = append(out.Expired, ToPaymentDTO(p))
+		}
+	}
+
+	return out, firstErr
+}
+
+func isOpen(p *entity.Payment) bool {
+	return p.Status == entity.PaymentStatusCreated || p.Status == entity.PaymentStatusPending
+}
+
+func isDue(p *entity.Payment, now, createdBefore time.Time) bool {
+	if p.ExpiresAt != nil {
+		return p.IsExpired(now)
+	}
+	return !p.CreatedAt.After(createdBefore)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpirePayments(t *testing.T) {
+	uuid := pkg.NewUUID()
+	now := time.Now()
+	clock := pkg.NewFakeClock(now)
+	orderRepo := memoryorder.New(clock)
+	paymentRepo := memorypayment.New(clock)
+	refundRepo := memoryrefund.New(clock)
+	tx := memorytx.New()
+	gateways := payment.NewGateways(payment.Config{}, clock)
+	uc := NewExpirePaymentsUsecase(paymentRepo, gateways, tx, clock, time.Hour)
+
+	userID := uuid.Generate()
+	seed := func(t *testing.T, status entity.PaymentStatus, expiresAt *time.Time) (orderID, paymentID string) {
+		orderID = uuid.Generate()
+		paymentID = uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: uuid.Generate(), UserID: userID, Status: entity.OrderPlaced,
+		}))
+		require.NoError(t, paymentRepo.Create(t.Context(), &entity.Payment{
+			ID: paymentID, OrderID: orderID, UserID: userID, StoreID: "store", Status: status, Amount: 1000,
+			Method: entity.PaymentMethodPix, Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + paymentID,
+			ExpiresAt: expiresAt,
+		}))
+		return
+	}
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	paymentStatus := func(t *testing.T, id string) entity.PaymentStatus {
+		p, err := paymentRepo.GetByID(t.Context(), id)
+		require.NoError(t, err)
+		return p.Status
+	}
+
+	t.Run("test expire pending pix payments past their deadline", func(t *testing.T) {
+		orderID, expired := seed(t, entity.PaymentStatusPending, at(-time.Minute))
+		_, valid := seed(t, entity.PaymentStatusPending, at(3*time.Hour))
+		_, paid := seed(t, entity.PaymentStatusPaid, at(-time.Minute))
+
+		out, err := uc.Execute(t.Context())
+		require.NoError(t, err)
+		require.Len(t, out.Expired, 1)
+		require.Equal(t, expired, out.Expired[0].ID)
+		require.Equal(t, "CANCELED", out.Expired[0].Status)
+
+		require.Equal(t, entity.PaymentStatusCanceled, paymentStatus(t, expired))
+		require.Equal(t, entity.PaymentStatusPending, paymentStatus(t, valid))
+		require.Equal(t, entity.PaymentStatusPaid, paymentStatus(t, paid))
+
+		// o pedido continua aberto para uma nova cobrança
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPlaced, o.Status)
+	})
+
+	t.Run("test expire payments again is a no-op", func(t *testing.T) {
+		out, err := uc.Execute(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, out.Expired)
+	})
+
+	t.Run("test expire open payments without a deadline after the ttl", func(t *testing.T) {
+		_, pending := seed(t, entity.PaymentStatusPending, nil)
+		_, created := seed(t, entity.PaymentStatusCreated, nil)
+
+		clock.Advance(59 * time.Minute)
+		out, err := uc.Execute(t.Context())
+		require.NoError(t, err)
+		require.Empty(t, out.Expired)
+
+		clock.Advance(2 * time.Minute)
+		out, err = uc.Execute(t.Context())
+		require.NoError(t, err)
+		require.Len(t, out.Expired, 2)
+		require.Equal(t, entity.PaymentStatusCanceled, paymentStatus(t, pending))
+		require.Equal(t, entity.PaymentStatusCanceled, paymentStatus(t, created))
+	})
+
+	t.Run("test confirm an expired payment", func(t *testing.T) {
+		_, paymentID := seed(t, entity.PaymentStatusPending, at(-time.Second))
+		confirm := NewConfirmPaymentUsecase(orderRepo, paymentRepo, refundRepo, gateways, tx, uuid, pkg.NewClock())
+
+		_, err := confirm.Execute(t.Context(), ConfirmPaymentInput{PaymentID: paymentID, UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "conflict: payment expired", err.Error())
+		require.Equal(t, entity.PaymentStatusPending, paymentStatus(t, paymentID))
+	})
+}
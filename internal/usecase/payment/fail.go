@@ -20,13 +20,16 @@ type FailPaymentOutput struct {
 	Payment PaymentDTO `json:"payment"`
 }
 
+// FailPaymentUsecase simula uma recusa do provider. Só vale para cobranças do
+// método MOCK.
 type FailPaymentUsecase struct {
 	PaymentRepo repository.PaymentRepository
 	UUID        ports.UUIDInterface
+	Clock       ports.Clock
 }
 
-func NewFailPaymentUsecase(paymentRepo repository.PaymentRepository, uuid ports.UUIDInterface) *FailPaymentUsecase {
-	return &FailPaymentUsecase{PaymentRepo: paymentRepo, UUID: uuid}
+func NewFailPaymentUsecase(paymentRepo repository.PaymentRepository, uuid ports.UUIDInterface, clock ports.Clock) *FailPaymentUsecase {
+	return &FailPaymentUsecase{PaymentRepo: paymentRepo, UUID: uuid, Clock: clock}
 }
 
 func (uc *FailPaymentUsecase) Execute(ctx context.Context, in FailPaymentInput) (*FailPaymentOutput, error) {
@@ -54,12 +57,25 @@ func (uc *FailPaymentUsecase) Execute(ctx context.Context, in FailPaymentInput)
 	if p.Status != entity.PaymentStatusPending {
 		return nil, errx.New(errx.CodeConflict, "payment must be PENDING to fail")
 	}
+	if !isSimulated(p) {
+		return nil, errx.New(errx.CodeConflict, "payment status is driven by the provider")
+	}
 
-	p.Status = entity.PaymentStatusFailed
-	p.UpdatedAt = time.Now()
-
-	if err := uc.PaymentRepo.Update(ctx, p); err != nil {
+	if err := markFailed(ctx, uc.PaymentRepo, p, uc.Clock.Now()); err != nil {
 		return nil, err
 	}
 	return &FailPaymentOutput{Payment: ToPaymentDTO(p)}, nil
 }
+
+// isSimulated diz se o próprio cliente pode decidir o resultado da cobrança:
+// só o método MOCK, que não movimenta dinheiro. O provider mock também atende
+// PIX e cartão e esses seguem o webhook como um provider real.
+func isSimulated(p *entity.Payment) bool {
+	return p.Provider == entity.PaymentProviderMock && p.Method == entity.PaymentMethodMock
+}
+
+func markFailed(ctx context.Context, payments repository.PaymentRepository, p *entity.Payment, now time.Time) error {
+	p.Status = entity.PaymentStatusFailed
+	p.UpdatedAt = now
+	return payments.Update(ctx, p)
+}
@@ -0,0 +1,450 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+)
+
+type RefundPaymentInput struct {
+	PaymentID string
+	UserID    string
+
+	Amount int64 // centavos
+	Reason string
+
+	IdempotencyKey string // opcional
+}
+
+type RefundDTO struct {
+	ID             string    `json:"id"`
+	PaymentID      string    `json:"payment_id"`
+	OrderID        string    `json:"order_id"`
+	StoreID        string    `json:"store_id"`
+	Amount         int64     `json:"amount"`
+	Currency       string    `json:"currency"`
+	Reason         string    `json:"reason"`
+	Status         string    `json:"status"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	ProviderRef    string    `json:"provider_ref"`
+	RequestedBy    string    `json:"requested_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type RefundPaymentOutput struct {
+	Refund  RefundDTO  `json:"refund"`
+	Payment PaymentDTO `json:"payment"`
+}
+
+// RefundPaymentUsecase é o estorno (total ou parcial) pedido pelo dono da loja.
+type RefundPaymentUsecase struct {
+	Orders   repository.OrderRepository
+	Payments repository.PaymentRepository
+	Refunds  repository.RefundRepository
+	Policy   *policy.Policy
+	Gateways ports.PaymentGateways
+	Tx       ports.TxManager
+	UUID     ports.UUIDInterface
+	Clock    ports.Clock
+}
+
+func NewRefundPaymentUsecase(
+	orders repository.OrderRepository,
+	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	authz *policy.Policy,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+) *RefundPaymentUsecase {
+	return &RefundPaymentUsecase{
+		Orders:   orders,
+		Payments: payments,
+		Refunds:  refunds,
+		Policy:   authz,
+		Gateways: gateways,
+		Tx:       tx,
+		UUID:     uuid,
+		Clock:    clock,
+	}
+}
+
+func (uc *RefundPaymentUsecase) Execute(ctx context.Context, in RefundPaymentInput) (*RefundPaymentOutput, error) {
+	if in.PaymentID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing payment id")
+	}
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	if isValid := uc.UUID.Validate(in.PaymentID); !isValid {
+		return nil, errx.New(errx.CodeInvalid, "invalid payment id")
+	}
+	if isValid := uc.UUID.Validate(in.UserID); !isValid {
+		return nil, errx.New(errx.CodeInvalid, "invalid user id")
+	}
+
+	if in.Amount <= 0 {
+		return nil, errx.New(errx.CodeInvalid, "amount must be > 0")
+	}
+	in.Reason = strings.TrimSpace(in.Reason)
+
+	var (
+		p  *entity.Payment
+		rf *entity.Refund
+	)
+	// reserva o estorno como PENDING; o provider só é chamado depois do
+	// commit, para um rollback nunca deixar dinheiro devolvido sem registro
+	err := uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = uc.Payments.GetByID(ctx, in.PaymentID)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.Policy.Authorize(ctx, in.UserID, policy.ActionPaymentsRefund, p.StoreID); err != nil {
+			return err
+		}
+
+		// idempotência
+		if in.IdempotencyKey != "" {
+			existing, e := uc.Refunds.GetByPaymentAndKey(ctx, p.ID, in.IdempotencyKey)
+			if e == nil && existing != nil {
+				rf = existing
+				return ensureSameRefund(existing, in)
+			}
+			if e != nil && !errx.Is(e, errx.CodeNotFound) {
+				return e
+			}
+		}
+
+		now := uc.Clock.Now()
+		if err := uc.ensureOrderRefundable(ctx, p, in.Amount, in.UserID, in.Reason, now); err != nil {
+			return err
+		}
+		rf, err = reserveRefund(ctx, uc.Refunds, uc.Payments, uc.UUID, p, in.Amount, in.Reason, in.IdempotencyKey, in.UserID, now)
+		return err
+	})
+	if err != nil {
+		// corrida na mesma chave: segue com o estorno que ganhou
+		if !errx.Is(err, errx.CodeConflict) || in.IdempotencyKey == "" {
+			return nil, err
+		}
+		existing, e := uc.Refunds.GetByPaymentAndKey(ctx, in.PaymentID, in.IdempotencyKey)
+		if e != nil {
+			return nil, err
+		}
+		if err := ensureSameRefund(existing, in); err != nil {
+			return nil, err
+		}
+		if p, e = uc.Payments.GetByID(ctx, in.PaymentID); e != nil {
+			return nil, err
+		}
+		rf = existing
+	}
+
+	// ainda não confirmado pelo provider (primeira chamada ou retry depois de
+	// uma falha): a chave é a mesma, então o provider não estorna duas vezes
+	if rf.Status == entity.RefundStatusPending {
+		settler := refundSettler{Orders: uc.Orders, Payments: uc.Payments, Refunds: uc.Refunds, Gateways: uc.Gateways, Tx: uc.Tx, Clock: uc.Clock}
+		rf, p, err = settler.settle(ctx, p, rf)
+		if err != nil {
+			return nil, err
+		}
+	} else if cur, e := uc.Payments.GetByID(ctx, p.ID); e == nil {
+		p = cur
+	}
+	if rf.Status == entity.RefundStatusFailed {
+		return nil, errx.New(errx.CodeConflict, "payment provider declined the refund")
+	}
+
+	return &RefundPaymentOutput{Refund: ToRefundDTO(rf), Payment: ToPaymentDTO(p)}, nil
+}
+
+// ensureSameRefund barra a chave de idempotência reaproveitada para outro
+// pedido de estorno: devolver o original faria o cliente achar que o novo
+// valor foi estornado.
+func ensureSameRefund(existing *entity.Refund, in RefundPaymentInput) error {
+	if existing.Amount != in.Amount || existing.Reason != in.Reason {
+		return errx.New(errx.CodeConflict, "idempotency key reused with different parameters")
+	}
+	return nil
+}
+
+// ensureOrderRefundable barra, antes de reservar, o estorno que zeraria um
+// pedido que não pode terminar em REFUNDED: depois do provider já seria tarde.
+func (uc *RefundPaymentUsecase) ensureOrderRefundable(ctx context.Context, p *entity.Payment, amount int64, userID, reason string, now time.Time) error {
+	if !p.Status.IsRefundable() {
+		return nil // reserveRefund responde
+	}
+	refunded, err := refundedAmount(ctx, uc.Refunds, p.ID)
+	if err != nil {
+		return err
+	}
+	if amount != p.Amount-refunded {
+		return nil
+	}
+	pending, err := hasPaidBalance(ctx, uc.Payments, uc.Refunds, p.OrderID, p.ID)
+	if err != nil || pending {
+		return err
+	}
+
+	o, err := uc.Orders.GetByID(ctx, p.OrderID)
+	if err != nil {
+		return err
+	}
+	// só simula: o pedido muda quando o provider confirmar
+	return closeRefundedOrder(o, userID, reason, now)
+}
+
+// closeRefundedOrder leva o pedido para REFUNDED quando não sobrou nenhum
+// valor pago nele. Pedido ainda em andamento é encerrado pela loja antes.
+func closeRefundedOrder(o *entity.Order, userID, reason string, now time.Time) error {
+	if o.Status == entity.OrderRefunded {
+		return nil
+	}
+
+	if !o.Status.CanTransitionTo(entity.OrderRefunded, entity.OrderActorStore) {
+		if err := o.Cancel(entity.OrderActorStore, userID, reason, now); err != nil {
+			return errx.F(errx.CodeConflict, "order cannot be refunded while %s", o.Status)
+		}
+	}
+	return o.TransitionTo(entity.OrderRefunded, entity.OrderActorStore, userID, now)
+}
+
+// hasPaidBalance diz se algum pagamento do pedido ainda tem valor pago que não
+// foi estornado nem reservado para estorno. skipID fica de fora da conta.
+func hasPaidBalance(ctx context.Context, payments repository.PaymentRepository, refunds repository.RefundRepository, orderID, skipID string) (bool, error) {
+	list, err := payments.ListByOrderID(ctx, orderID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range list {
+		if p.ID == skipID || !p.Status.IsRefundable() {
+			continue
+		}
+		refunded, err := refundedAmount(ctx, refunds, p.ID)
+		if err != nil {
+			return false, err
+		}
+		if p.Amount > refunded {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reserveRefund confere o saldo de p e grava um estorno PENDING de `amount`.
+// Roda dentro da tx; o pagamento é regravado para que dois estornos
+// simultâneos não reservem o mesmo saldo (o segundo perde na versão).
+func reserveRefund(
+	ctx context.Context,
+	refunds repository.RefundRepository,
+	payments repository.PaymentRepository,
+	uuid ports.UUIDInterface,
+	p *entity.Payment,
+	amount int64,
+	reason, key, requestedBy string,
+	now time.Time,
+) (*entity.Refund, error) {
+	if !p.Status.IsRefundable() {
+		return nil, errx.New(errx.CodeConflict, "payment must be PAID to refund")
+	}
+
+	refunded, err := refundedAmount(ctx, refunds, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := p.Amount - refunded
+	if amount > remaining {
+		return nil, errx.F(errx.CodeInvalid, "amount exceeds refundable balance of %d", remaining)
+	}
+
+	rf := &entity.Refund{
+		ID:             uuid.Generate(),
+		PaymentID:      p.ID,
+		OrderID:        p.OrderID,
+		StoreID:        p.StoreID,
+		Amount:         amount,
+		Currency:       p.Currency,
+		Reason:         reason,
+		Status:         entity.RefundStatusPending,
+		IdempotencyKey: key,
+		RequestedBy:    requestedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := refunds.Create(ctx, rf); err != nil {
+		return nil, err
+	}
+
+	p.UpdatedAt = now
+	if err := payments.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// gatewayRefundKey é a chave de idempotência repassada ao provider. Sai do
+// pagamento e da chave do estorno (ou do id dele, que já está gravado), então
+// qualquer reenvio do mesmo estorno usa a mesma chave.
+func gatewayRefundKey(rf *entity.Refund) string {
+	if rf.IdempotencyKey == "" {
+		return rf.ID
+	}
+	return rf.PaymentID + ":" + rf.IdempotencyKey
+}
+
+// refundSettler manda ao provider um estorno já reservado e grava a resposta.
+type refundSettler struct {
+	Orders   repository.OrderRepository
+	Payments repository.PaymentRepository
+	Refunds  repository.RefundRepository
+	Gateways ports.PaymentGateways
+	Tx       ports.TxManager
+	Clock    ports.Clock
+}
+
+// settle roda fora de qualquer tx. Se o provider falhar o estorno continua
+// PENDING e pode ser reenviado; depois de confirmado, pagamento e pedido são
+// atualizados numa tx própria.
+func (s refundSettler) settle(ctx context.Context, p *entity.Payment, rf *entity.Refund) (*entity.Refund, *entity.Payment, error) {
+	gw, err := s.Gateways.ForProvider(p.Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := gw.Refund(ctx, ports.GatewayRefundRequest{
+		PaymentRef:     p.ProviderRef,
+		RefundID:       rf.ID,
+		Amount:         rf.Amount,
+		IdempotencyKey: gatewayRefundKey(rf),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		rf, err = s.Refunds.GetByID(ctx, rf.ID)
+		if err != nil {
+			return err
+		}
+		p, err = s.Payments.GetByID(ctx, rf.PaymentID)
+		if err != nil {
+			return err
+		}
+		// outra chamada gravou primeiro
+		if rf.Status != entity.RefundStatusPending {
+			return nil
+		}
+		return s.record(ctx, p, rf, res)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rf, p, nil
+}
+
+func (s refundSettler) record(ctx context.Context, p *entity.Payment, rf *entity.Refund, res *ports.GatewayRefundResult) error {
+	now := s.Clock.Now()
+
+	rf.Status = res.Status
+	rf.ProviderRef = res.Ref
+	rf.UpdatedAt = now
+	if err := s.Refunds.Update(ctx, rf); err != nil {
+		return err
+	}
+	if rf.Status != entity.RefundStatusSucceeded {
+		return nil
+	}
+
+	list, err := s.Refunds.ListByPaymentID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	var succeeded int64
+	for _, r := range list {
+		if r.Status == entity.RefundStatusSucceeded {
+			succeeded += r.Amount
+		}
+	}
+
+	p.Status = entity.PaymentStatusPartiallyRefunded
+	if succeeded >= p.Amount {
+		p.Status = entity.PaymentStatusRefunded
+	}
+	p.UpdatedAt = now
+	if err := s.Payments.Update(ctx, p); err != nil {
+		return err
+	}
+	// estorno do sistema (cancelamento, cobrança que o pedido não aceitou) não
+	// mexe no pedido: quem reservou já cuidou dele
+	if p.Status != entity.PaymentStatusRefunded || rf.RequestedBy == "" {
+		return nil
+	}
+
+	payments, err := s.Payments.ListByOrderID(ctx, p.OrderID)
+	if err != nil {
+		return err
+	}
+	for _, other := range payments {
+		if other.Status.IsRefundable() {
+			return nil
+		}
+	}
+	o, err := s.Orders.GetByID(ctx, p.OrderID)
+	if err != nil {
+		return err
+	}
+	if o.Status == entity.OrderRefunded {
+		return nil
+	}
+	// o dinheiro já saiu: se o pedido andou desde a reserva, o estorno fica
+	// gravado mesmo assim e o pedido segue como está
+	if err := closeRefundedOrder(o, rf.RequestedBy, rf.Reason, now); err != nil {
+		return nil
+	}
+	return s.Orders.Update(ctx, o)
+}
+
+// refundedAmount soma os estornos do pagamento que não falharam.
+func refundedAmount(ctx context.Context, refunds repository.RefundRepository, paymentID string) (int64, error) {
+	list, err := refunds.ListByPaymentID(ctx, paymentID)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, rf := range list {
+		if rf.Status != entity.RefundStatusFailed {
+			total += rf.Amount
+		}
+	}
+	return total, nil
+}
+
+func ToRefundDTO(rf *entity.Refund) RefundDTO {
+	return RefundDTO{
+		ID:             rf.ID,
+		PaymentID:      rf.PaymentID,
+		OrderID:        rf.OrderID,
+		StoreID:        rf.StoreID,
+		Amount:         rf.Amount,
+		Currency:       rf.Currency,
+		Reason:         rf.Reason,
+		Status:         rf.Status.String(),
+		IdempotencyKey: rf.IdempotencyKey,
+		ProviderRef:    rf.ProviderRef,
+		RequestedBy:    rf.RequestedBy,
+		CreatedAt:      rf.CreatedAt,
+		UpdatedAt:      rf.UpdatedAt,
+	}
+}
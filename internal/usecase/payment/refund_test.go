@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingGateways guarda as chaves de idempotência enviadas nos estornos.
+type recordingGateways struct {
+	ports.PaymentGateways
+	keys []string
+}
+
+func (g *recordingGateways) ForProvider(provider entity.PaymentProvider) (ports.PaymentGateway, error) {
+	gw, err := g.PaymentGateways.ForProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingGateway{PaymentGateway: gw, owner: g}, nil
+}
+
+type recordingGateway struct {
+	ports.PaymentGateway
+	owner *recordingGateways
+}
+
+func (g *recordingGateway) Refund(ctx context.Context, req ports.GatewayRefundRequest) (*ports.GatewayRefundResult, error) {
+	g.owner.keys = append(g.owner.keys, req.IdempotencyKey)
+	return g.PaymentGateway.Refund(ctx, req)
+}
+
+// flakyPaymentRepo falha ao gravar o pagamento já estornado enquanto fail
+// estiver ligado (o banco cai depois do provider confirmar).
+type flakyPaymentRepo struct {
+	repository.PaymentRepository
+	fail bool
+}
+
+func (r *flakyPaymentRepo) Update(ctx context.Context, p *entity.Payment) error {
+	if r.fail && p.Status != entity.PaymentStatusPaid {
+		return errx.New(errx.CodeInternal, "payment update failed")
+	}
+	return r.PaymentRepository.Update(ctx, p)
+}
+
+func TestRefundPayment(t *testing.T) {
+	uuid := pkg.NewUUID()
+	orderRepo := memoryorder.New(pkg.NewClock())
+	paymentRepo := memorypayment.New(pkg.NewClock())
+	refundRepo := memoryrefund.New(pkg.NewClock())
+	storeRepo := memorystore.New()
+	userRepo := memoryuser.New(pkg.NewClock())
+	tx := memorytx.New()
+
+	ownerID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner)
+	storeID := uuid.Generate()
+	require.NoError(t, storeRepo.Create(t.Context(), &entity.Store{ID: storeID, Name: "Loja", Slug: "loja", OwnerID: ownerID}))
+
+	customerID := testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)
+	seed := func(t *testing.T, order entity.OrderStatus, payment entity.PaymentStatus) (orderID, paymentID string) {
+		orderID = uuid.Generate()
+		paymentID = uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: storeID, UserID: customerID, Status: order,
+		}))
+		require.NoError(t, paymentRepo.Create(t.Context(), &entity.Payment{
+			ID: paymentID, OrderID: orderID, UserID: customerID, StoreID: storeID, Status: payment, Amount: 1000,
+			Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + paymentID,
+		}))
+		return
+	}
+	orderStatus := func(t *testing.T, id string) entity.OrderStatus {
+		o, err := orderRepo.GetByID(t.Context(), id)
+		require.NoError(t, err)
+		return o.Status
+	}
+
+	uc := NewRefundPaymentUsecase(orderRepo, paymentRepo, refundRepo, policy.New(userRepo, storeRepo, memorystoremember.New(pkg.NewClock())), payment.NewGateways(payment.Config{}, pkg.NewClock()), tx, uuid, pkg.NewClock())
+
+	t.Run("test partial refunds up to the paid amount", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderDelivered, entity.PaymentStatusPaid)
+
+		out, err := uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 400, Reason: "faltou a batata"})
+		require.NoError(t, err)
+		require.Equal(t, int64(400), out.Refund.Amount)
+		require.Equal(t, "SUCCEEDED", out.Refund.Status)
+		require.Equal(t, ownerID, out.Refund.RequestedBy)
+		require.Equal(t, "PARTIALLY_REFUNDED", out.Payment.Status)
+		require.Equal(t, entity.OrderDelivered, orderStatus(t, orderID))
+
+		_, err = uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 700})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: amount exceeds refundable balance of 600", err.Error())
+
+		out, err = uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 600})
+		require.NoError(t, err)
+		require.Equal(t, "REFUNDED", out.Payment.Status)
+		require.Equal(t, entity.OrderRefunded, orderStatus(t, orderID))
+
+		_, err = uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 1})
+		require.Error(t, err)
+		require.Equal(t, "conflict: payment must be PAID to refund", err.Error())
+	})
+
+	t.Run("test refund with the same idempotency key", func(t *testing.T) {
+		_, paymentID := seed(t, entity.OrderDelivered, entity.PaymentStatusPaid)
+		in := RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 300, IdempotencyKey: "refund-1"}
+
+		first, err := uc.Execute(t.Context(), in)
+		require.NoError(t, err)
+		second, err := uc.Execute(t.Context(), in)
+		require.NoError(t, err)
+		require.Equal(t, first.Refund.ID, second.Refund.ID)
+
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+	})
+
+	t.Run("test idempotency key reused with different parameters", func(t *testing.T) {
+		_, paymentID := seed(t, entity.OrderDelivered, entity.PaymentStatusPaid)
+		in := RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 300, Reason: "item faltando", IdempotencyKey: "refund-2"}
+
+		_, err := uc.Execute(t.Context(), in)
+		require.NoError(t, err)
+
+		other := in
+		other.Amount = 500
+		_, err = uc.Execute(t.Context(), other)
+		require.EqualError(t, err, "conflict: idempotency key reused with different parameters")
+
+		other = in
+		other.Reason = "cliente desistiu"
+		_, err = uc.Execute(t.Context(), other)
+		require.EqualError(t, err, "conflict: idempotency key reused with different parameters")
+
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		require.Equal(t, int64(300), refunds[0].Amount)
+	})
+
+	t.Run("test full refund of an order the store has not accepted", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderPaid, entity.PaymentStatusPaid)
+
+		_, err := uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 1000, Reason: "sem estoque"})
+		require.NoError(t, err)
+
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderRefunded, o.Status)
+		require.Equal(t, entity.OrderRejected, o.Transitions[0].To)
+		require.Equal(t, "sem estoque", o.Cancellation.Reason)
+	})
+
+	t.Run("test full refund of an order out for delivery", func(t *testing.T) {
+		orderID, paymentID := seed(t, entity.OrderOutForDelivery, entity.PaymentStatusPaid)
+
+		_, err := uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 1000})
+		require.Error(t, err)
+		require.Equal(t, "conflict: order cannot be refunded while OUT_FOR_DELIVERY", err.Error())
+
+		// nada foi gravado
+		p, err := paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusPaid, p.Status)
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Empty(t, refunds)
+		require.Equal(t, entity.OrderOutForDelivery, orderStatus(t, orderID))
+	})
+
+	t.Run("test refund a payment that is not paid", func(t *testing.T) {
+		_, paymentID := seed(t, entity.OrderPlaced, entity.PaymentStatusPending)
+
+		_, err := uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 100})
+		require.Error(t, err)
+		require.Equal(t, "conflict: payment must be PAID to refund", err.Error())
+	})
+
+	t.Run("test refund requested by someone other than the store owner", func(t *testing.T) {
+		_, paymentID := seed(t, entity.OrderDelivered, entity.PaymentStatusPaid)
+
+		_, err := uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: customerID, Amount: 100})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: role costumer cannot perform store:payments.refund", err.Error())
+
+		_, err = uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner), Amount: 100})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: user is not staff of this store", err.Error())
+	})
+
+	t.Run("test refund with an invalid amount", func(t *testing.T) {
+		_, paymentID := seed(t, entity.OrderDelivered, entity.PaymentStatusPaid)
+
+		_, err := uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 0})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: amount must be > 0", err.Error())
+	})
+
+	t.Run("test retry after a failure reuses the provider idempotency key", func(t *testing.T) {
+		_, paymentID := seed(t, entity.OrderDelivered, entity.PaymentStatusPaid)
+		gateways := &recordingGateways{PaymentGateways: payment.NewGateways(payment.Config{}, pkg.NewClock())}
+		payments := &flakyPaymentRepo{PaymentRepository: paymentRepo, fail: true}
+		uc := NewRefundPaymentUsecase(orderRepo, payments, refundRepo, policy.New(userRepo, storeRepo, memorystoremember.New(pkg.NewClock())), gateways, tx, uuid, pkg.NewClock())
+		in := RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 250, IdempotencyKey: "refund-retry"}
+
+		_, err := uc.Execute(t.Context(), in)
+		require.Error(t, err)
+		require.Equal(t, "internal: payment update failed", err.Error())
+
+		// o provider já estornou: o estorno continua reservado como PENDING
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		require.Equal(t, entity.RefundStatusPending, refunds[0].Status)
+
+		payments.fail = false
+		out, err := uc.Execute(t.Context(), in)
+		require.NoError(t, err)
+		require.Equal(t, refunds[0].ID, out.Refund.ID)
+		require.Equal(t, "SUCCEEDED", out.Refund.Status)
+		require.Equal(t, "PARTIALLY_REFUNDED", out.Payment.Status)
+
+		require.Equal(t, []string{paymentID + ":refund-retry", paymentID + ":refund-retry"}, gateways.keys)
+		refunds, err = refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+	})
+
+	t.Run("test pending refund reserves the balance", func(t *testing.T) {
+		_, paymentID := seed(t, entity.OrderDelivered, entity.PaymentStatusPaid)
+		payments := &flakyPaymentRepo{PaymentRepository: paymentRepo, fail: true}
+		uc := NewRefundPaymentUsecase(orderRepo, payments, refundRepo, policy.New(userRepo, storeRepo, memorystoremember.New(pkg.NewClock())), payment.NewGateways(payment.Config{}, pkg.NewClock()), tx, uuid, pkg.NewClock())
+
+		_, err := uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 800})
+		require.Error(t, err)
+
+		payments.fail = false
+		_, err = uc.Execute(t.Context(), RefundPaymentInput{PaymentID: paymentID, UserID: ownerID, Amount: 300})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: amount exceeds refundable balance of 200", err.Error())
+	})
+}
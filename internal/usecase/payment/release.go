@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type ReleaseOrderPaymentsOutput struct {
+	Canceled []PaymentDTO `json:"canceled"`
+	Refunded []RefundDTO  `json:"refunded"`
+}
+
+// ReleaseOrderPaymentsUsecase libera o dinheiro de um pedido que não vai ser
+// entregue: cobranças em aberto são canceladas e o saldo das pagas é
+// estornado. Execute roda na mesma tx que cancela o pedido e só grava; Settle
+// roda depois do commit e leva tudo ao provider.
+type ReleaseOrderPaymentsUsecase struct {
+	OrderRepo   repository.OrderRepository
+	PaymentRepo repository.PaymentRepository
+	RefundRepo  repository.RefundRepository
+	Gateways    ports.PaymentGateways
+	Tx          ports.TxManager
+	UUID        ports.UUIDInterface
+	Clock       ports.Clock
+}
+
+func NewReleaseOrderPaymentsUsecase(
+	orderRepo repository.OrderRepository,
+	paymentRepo repository.PaymentRepository,
+	refundRepo repository.RefundRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+) *ReleaseOrderPaymentsUsecase {
+	return &ReleaseOrderPaymentsUsecase{
+		OrderRepo:   orderRepo,
+		PaymentRepo: paymentRepo,
+		RefundRepo:  refundRepo,
+		Gateways:    gateways,
+		Tx:          tx,
+		UUID:        uuid,
+		Clock:       clock,
+	}
+}
+
+// releaseRefundKey amarra o estorno ao pedido: o mesmo pedido nunca reserva
+// dois estornos do mesmo pagamento.
+func releaseRefundKey(orderID string) string {
+	return "release:" + orderID
+}
+
+func (uc *ReleaseOrderPaymentsUsecase) Execute(ctx context.Context, orderID, reason string) (*ReleaseOrderPaymentsOutput, error) {
+	if orderID == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing order id")
+	}
+
+	payments, err := uc.PaymentRepo.ListByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ReleaseOrderPaymentsOutput{Canceled: []PaymentDTO{}, Refunded: []RefundDTO{}}
+	now := uc.Clock.Now()
+
+	for _, p := range payments {
+		switch {
+		case isOpen(p):
+			p.Status = entity.PaymentStatusCanceled
+			p.UpdatedAt = now
+			if err := uc.PaymentRepo.Update(ctx, p); err != nil {
+				return nil, err
+			}
+			out.Canceled = append(out.Canceled, ToPaymentDTO(p))
+
+		case p.Status.IsRefundable():
+			refunded, err := refundedAmount(ctx, uc.RefundRepo, p.ID)
+			if err != nil {
+				return nil, err
+			}
+			if p.Amount-refunded <= 0 {
+				continue
+			}
+			rf, err := reserveRefund(ctx, uc.RefundRepo, uc.PaymentRepo, uc.UUID, p, p.Amount-refunded, reason, releaseRefundKey(orderID), "", now)
+			if err != nil {
+				return nil, err
+			}
+			out.Refunded = append(out.Refunded, ToRefundDTO(rf))
+		}
+	}
+
+	return out, nil
+}
+
+// Settle cancela no provider as cobranças e envia os estornos que Execute
+// gravou. Tem que rodar depois do commit: se o banco voltasse atrás o dinheiro
+// já teria se mexido. Uma falha não desfaz nada: o estorno fica PENDING para o
+// job de estornos reenviar com a mesma chave, e uma cobrança que o provider
+// ainda cobrar cai no webhook, que estorna pagamento cancelado.
+func (uc *ReleaseOrderPaymentsUsecase) Settle(ctx context.Context, released *ReleaseOrderPaymentsOutput) error {
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, p := range released.Canceled {
+		// CREATED ainda não chegou no provider, não há o que cancelar lá
+		if p.ProviderRef == "" {
+			continue
+		}
+		gw, err := uc.Gateways.ForProvider(entity.PaymentProvider(p.Provider))
+		if err != nil {
+			keep(err)
+			continue
+		}
+		_, err = gw.Cancel(ctx, p.ProviderRef)
+		keep(err)
+	}
+
+	settler := refundSettler{Orders: uc.OrderRepo, Payments: uc.PaymentRepo, Refunds: uc.RefundRepo, Gateways: uc.Gateways, Tx: uc.Tx, Clock: uc.Clock}
+	for i, dto := range released.Refunded {
+		rf, err := uc.RefundRepo.GetByID(ctx, dto.ID)
+		if err != nil {
+			keep(err)
+			continue
+		}
+		if rf.Status != entity.RefundStatusPending {
+			released.Refunded[i] = ToRefundDTO(rf)
+			continue
+		}
+		p, err := uc.PaymentRepo.GetByID(ctx, rf.PaymentID)
+		if err != nil {
+			keep(err)
+			continue
+		}
+		rf, _, err = settler.settle(ctx, p, rf)
+		if err != nil {
+			keep(err)
+			continue
+		}
+		released.Refunded[i] = ToRefundDTO(rf)
+	}
+
+	return firstErr
+}
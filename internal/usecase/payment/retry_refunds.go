@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+// quantos estornos cada execução reenvia no máximo
+const retryRefundsBatch = 100
+
+// estorno mais novo que isso pode estar com a primeira chamada em andamento
+const pendingRefundGrace = time.Minute
+
+type RetryRefundsOutput struct {
+	Settled []RefundDTO `json:"settled"`
+}
+
+// RetryRefundsUsecase reenvia ao provider os estornos que ficaram PENDING
+// (provider fora do ar ou processo derrubado entre a reserva e a resposta).
+// A chave de idempotência é a mesma da primeira tentativa.
+type RetryRefundsUsecase struct {
+	OrderRepo   repository.OrderRepository
+	PaymentRepo repository.PaymentRepository
+	RefundRepo  repository.RefundRepository
+	Gateways    ports.PaymentGateways
+	Tx          ports.TxManager
+	Clock       ports.Clock
+}
+
+func NewRetryRefundsUsecase(
+	orders repository.OrderRepository,
+	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	clock ports.Clock,
+) *RetryRefundsUsecase {
+	return &RetryRefundsUsecase{
+		OrderRepo:   orders,
+		PaymentRepo: payments,
+		RefundRepo:  refunds,
+		Gateways:    gateways,
+		Tx:          tx,
+		Clock:       clock,
+	}
+}
+
+func (uc *RetryRefundsUsecase) Execute(ctx context.Context) (*RetryRefundsOutput, error) {
+	candidates, err := uc.RefundRepo.ListPending(ctx, uc.Clock.Now().Add(-pendingRefundGrace), retryRefundsBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	settler := refundSettler{Orders: uc.OrderRepo, Payments: uc.PaymentRepo, Refunds: uc.RefundRepo, Gateways: uc.Gateways, Tx: uc.Tx, Clock: uc.Clock}
+	out := &RetryRefundsOutput{Settled: make([]RefundDTO, 0, len(candidates))}
+	var firstErr error
+	for _, rf := range candidates {
+		p, err := uc.PaymentRepo.GetByID(ctx, rf.PaymentID)
+		if err == nil {
+			rf, _, err = settler.settle(ctx, p, rf)
+		}
+		if err != nil {
+			// alguém mexeu no pagamento no meio do caminho: fica para a próxima rodada
+			if !errx.Is(err, errx.CodeConflict) && firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if rf.Status != entity.RefundStatusPending {
+			out.Settled = append(out.Settled, ToRefundDTO(rf))
+		}
+	}
+
+	return out, firstErr
+}
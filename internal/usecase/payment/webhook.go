@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type HandlePaymentWebhookInput struct {
+	Provider string
+	Payload  []byte
+	Header   http.Header
+}
+
+type HandlePaymentWebhookOutput struct {
+	EventID   string      `json:"event_id"`
+	Duplicate bool        `json:"duplicate"`
+	Result    string      `json:"result"`
+	Payment   *PaymentDTO `json:"payment,omitempty"`
+}
+
+// HandlePaymentWebhookUsecase recebe as notificações do provider: confere a
+// assinatura (no gateway), descarta eventos repetidos, aplica o novo status
+// como o confirm/fail fariam e guarda o evento cru para auditoria.
+type HandlePaymentWebhookUsecase struct {
+	Orders   repository.OrderRepository
+	Payments repository.PaymentRepository
+	Refunds  repository.RefundRepository
+	Events   repository.PaymentEventRepository
+	Gateways ports.PaymentGateways
+	Tx       ports.TxManager
+	UUID     ports.UUIDInterface
+	Clock    ports.Clock
+}
+
+func NewHandlePaymentWebhookUsecase(
+	orders repository.OrderRepository,
+	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	events repository.PaymentEventRepository,
+	gateways ports.PaymentGateways,
+	tx ports.TxManager,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+) *HandlePaymentWebhookUsecase {
+	return &HandlePaymentWebhookUsecase{
+		Orders:   orders,
+		Payments: payments,
+		Refunds:  refunds,
+		Events:   events,
+		Gateways: gateways,
+		Tx:       tx,
+		UUID:     uuid,
+		Clock:    clock,
+	}
+}
+
+func (uc *HandlePaymentWebhookUsecase) Execute(ctx context.Context, in HandlePaymentWebhookInput) (*HandlePaymentWebhookOutput, error) {
+	provider := entity.PaymentProvider(strings.ToUpper(strings.TrimSpace(in.Provider)))
+	if provider == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing provider")
+	}
+
+	gw, err := uc.Gateways.ForProvider(provider)
+	if err != nil {
+		return nil, errx.New(errx.CodeNotFound, "unknown payment provider")
+	}
+
+	// nada é gravado antes da assinatura ser validada
+	ev, err := gw.ParseWebhook(ctx, in.Payload, in.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &HandlePaymentWebhookOutput{EventID: ev.ID}
+	var unclaimed *entity.Refund
+	err = uc.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.Events.GetByProviderEventID(ctx, provider, ev.ID)
+		if err == nil {
+			out.Duplicate = true
+			out.Result = existing.Result
+			return nil
+		}
+		if !errx.Is(err, errx.CodeNotFound) {
+			return err
+		}
+
+		now := uc.Clock.Now()
+		record := &entity.PaymentEvent{
+			ID:         uc.UUID.Generate(),
+			Provider:   provider,
+			EventID:    ev.ID,
+			PaymentRef: ev.PaymentRef,
+			Status:     ev.Status,
+			Amount:     ev.Amount,
+			Payload:    string(in.Payload),
+			OccurredAt: ev.OccurredAt,
+			ReceivedAt: now,
+		}
+
+		p, err := uc.Payments.GetByProviderRef(ctx, provider, ev.PaymentRef)
+		switch {
+		case errx.Is(err, errx.CodeNotFound):
+			// responde 200 mesmo assim: o provider não deve ficar reenviando
+			record.Result = "ignored: payment not found"
+		case err != nil:
+			return err
+		default:
+			record.PaymentID = p.ID
+			record.Result, unclaimed, err = uc.apply(ctx, p, ev, now)
+			if err != nil {
+				return err
+			}
+			dto := ToPaymentDTO(p)
+			out.Payment = &dto
+		}
+
+		out.Result = record.Result
+		return uc.Events.Create(ctx, record)
+	})
+	if err != nil {
+		// o mesmo evento chegou em paralelo e o outro request gravou primeiro
+		if errx.Is(err, errx.CodeConflict) {
+			if existing, e := uc.Events.GetByProviderEventID(ctx, provider, ev.ID); e == nil {
+				return &HandlePaymentWebhookOutput{EventID: ev.ID, Duplicate: true, Result: existing.Result}, nil
+			}
+		}
+		return nil, err
+	}
+
+	// dinheiro que o pedido não aceitou volta depois do commit; se o provider
+	// falhar o estorno fica PENDING para o job
+	if unclaimed != nil {
+		settler := refundSettler{Orders: uc.Orders, Payments: uc.Payments, Refunds: uc.Refunds, Gateways: uc.Gateways, Tx: uc.Tx, Clock: uc.Clock}
+		if p, err := uc.Payments.GetByID(ctx, unclaimed.PaymentID); err == nil {
+			if _, settled, err := settler.settle(ctx, p, unclaimed); err == nil {
+				dto := ToPaymentDTO(settled)
+				out.Payment = &dto
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// apply leva o pagamento ao status informado pelo provider. Eventos que não
+// fazem sentido para o estado atual são guardados, mas ignorados. Cobrança
+// paga que o pedido não aceita mais (cancelada, expirada ou pedido já pago)
+// vira um estorno reservado, devolvido para ir ao provider depois do commit.
+func (uc *HandlePaymentWebhookUsecase) apply(ctx context.Context, p *entity.Payment, ev *ports.GatewayEvent, now time.Time) (string, *entity.Refund, error) {
+	if ev.Amount != 0 && ev.Amount != p.Amount {
+		return "ignored: amount mismatch", nil, nil
+	}
+
+	// cancelamos a cobrança, mas o cliente pagou antes do provider saber
+	if p.Status == entity.PaymentStatusCanceled && ev.Status == entity.PaymentStatusPaid {
+		return uc.markPaid(ctx, p, now)
+	}
+	if p.Status != entity.PaymentStatusCreated && p.Status != entity.PaymentStatusPending {
+		return "ignored: payment is " + p.Status.String(), nil, nil
+	}
+
+	var err error
+	switch ev.Status {
+	case entity.PaymentStatusPaid:
+		return uc.markPaid(ctx, p, now)
+	case entity.PaymentStatusFailed:
+		err = markFailed(ctx, uc.Payments, p, now)
+	case entity.PaymentStatusCanceled:
+		p.Status = entity.PaymentStatusCanceled
+		p.UpdatedAt = now
+		err = uc.Payments.Update(ctx, p)
+	default:
+		return "ignored: unsupported status " + ev.Status.String(), nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return "applied", nil, nil
+}
+
+func (uc *HandlePaymentWebhookUsecase) markPaid(ctx context.Context, p *entity.Payment, now time.Time) (string, *entity.Refund, error) {
+	rf, err := markPaid(ctx, uc.Orders, uc.Payments, uc.Refunds, uc.UUID, p, now)
+	if err != nil {
+		return "", nil, err
+	}
+	if rf != nil {
+		return "refund issued: " + rf.Reason, rf, nil
+	}
+	return "applied", nil, nil
+}
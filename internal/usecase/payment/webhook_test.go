@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memoryorder "github.com/FabioRocha231/saas-core/internal/infra/db/repository/order"
+	memorypayment "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment"
+	memorypaymentevent "github.com/FabioRocha231/saas-core/internal/infra/db/repository/payment_event"
+	memoryrefund "github.com/FabioRocha231/saas-core/internal/infra/db/repository/refund"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	"github.com/FabioRocha231/saas-core/internal/infra/payment"
+	mockgateway "github.com/FabioRocha231/saas-core/internal/infra/payment/mock"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlePaymentWebhook(t *testing.T) {
+	const secret = "whsec-test"
+
+	uuid := pkg.NewUUID()
+	orderRepo := memoryorder.New(pkg.NewClock())
+	paymentRepo := memorypayment.New(pkg.NewClock())
+	eventRepo := memorypaymentevent.New(pkg.NewClock())
+	refundRepo := memoryrefund.New(pkg.NewClock())
+	tx := memorytx.New()
+	gateways := payment.NewGateways(payment.Config{Mock: mockgateway.Config{WebhookSecret: secret}}, pkg.NewClock())
+	uc := NewHandlePaymentWebhookUsecase(orderRepo, paymentRepo, refundRepo, eventRepo, gateways, tx, uuid, pkg.NewClock())
+
+	userID := uuid.Generate()
+	seed := func(t *testing.T) (orderID, paymentID string) {
+		orderID = uuid.Generate()
+		paymentID = uuid.Generate()
+		require.NoError(t, orderRepo.Create(t.Context(), &entity.Order{
+			ID: orderID, StoreID: uuid.Generate(), UserID: userID, Status: entity.OrderPlaced,
+		}))
+		require.NoError(t, paymentRepo.Create(t.Context(), &entity.Payment{
+			ID: paymentID, OrderID: orderID, UserID: userID, StoreID: "store", Status: entity.PaymentStatusPending, Amount: 1000,
+			Provider: entity.PaymentProviderMock, ProviderRef: "mock_" + paymentID,
+		}))
+		return
+	}
+	// provider de mentira: assina o corpo como o mock espera
+	deliver := func(t *testing.T, payload string) (*HandlePaymentWebhookOutput, error) {
+		h := http.Header{}
+		h.Set(mockgateway.SignatureHeader, mockgateway.SignWebhook(secret, []byte(payload), time.Now()))
+		return uc.Execute(t.Context(), HandlePaymentWebhookInput{Provider: "mock", Payload: []byte(payload), Header: h})
+	}
+
+	t.Run("test paid event confirms the payment and the order", func(t *testing.T) {
+		orderID, paymentID := seed(t)
+		payload := `{"id":"evt-paid-1","payment_ref":"mock_` + paymentID + `","status":"PAID","amount":1000}`
+
+		out, err := deliver(t, payload)
+		require.NoError(t, err)
+		require.False(t, out.Duplicate)
+		require.Equal(t, "applied", out.Result)
+		require.Equal(t, "PAID", out.Payment.Status)
+
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPaid, o.Status)
+
+		events, err := eventRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, payload, events[0].Payload)
+
+		// reenvio do provider não aplica de novo
+		out, err = deliver(t, payload)
+		require.NoError(t, err)
+		require.True(t, out.Duplicate)
+		require.Equal(t, "applied", out.Result)
+
+		events, err = eventRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+	})
+
+	t.Run("test failed event fails the payment", func(t *testing.T) {
+		orderID, paymentID := seed(t)
+
+		out, err := deliver(t, `{"id":"evt-failed-1","payment_ref":"mock_`+paymentID+`","status":"FAILED"}`)
+		require.NoError(t, err)
+		require.Equal(t, "FAILED", out.Payment.Status)
+
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderPlaced, o.Status)
+	})
+
+	t.Run("test events that do not apply are stored and ignored", func(t *testing.T) {
+		_, paymentID := seed(t)
+
+		out, err := deliver(t, `{"id":"evt-amount-1","payment_ref":"mock_`+paymentID+`","status":"PAID","amount":1}`)
+		require.NoError(t, err)
+		require.Equal(t, "ignored: amount mismatch", out.Result)
+
+		out, err = deliver(t, `{"id":"evt-unknown-1","payment_ref":"mock_unknown","status":"PAID"}`)
+		require.NoError(t, err)
+		require.Equal(t, "ignored: payment not found", out.Result)
+		ev, err := eventRepo.GetByProviderEventID(t.Context(), entity.PaymentProviderMock, "evt-unknown-1")
+		require.NoError(t, err)
+		require.Empty(t, ev.PaymentID)
+
+		p, err := paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Equal(t, entity.PaymentStatusPending, p.Status)
+	})
+
+	t.Run("test unsigned events are rejected and not stored", func(t *testing.T) {
+		_, paymentID := seed(t)
+		payload := `{"id":"evt-forged-1","payment_ref":"mock_` + paymentID + `","status":"PAID"}`
+
+		_, err := uc.Execute(t.Context(), HandlePaymentWebhookInput{Provider: "mock", Payload: []byte(payload), Header: http.Header{}})
+		require.Error(t, err)
+		require.Equal(t, "unauthorized: missing webhook signature", err.Error())
+
+		_, err = eventRepo.GetByProviderEventID(t.Context(), entity.PaymentProviderMock, "evt-forged-1")
+		require.Error(t, err)
+	})
+
+	t.Run("test events from an unknown provider", func(t *testing.T) {
+		_, err := uc.Execute(t.Context(), HandlePaymentWebhookInput{Provider: "stripe", Payload: []byte(`{}`)})
+		require.Error(t, err)
+		require.Equal(t, "not_found: unknown payment provider", err.Error())
+	})
+
+	t.Run("test paid event for a canceled payment refunds it", func(t *testing.T) {
+		orderID, paymentID := seed(t)
+		p, err := paymentRepo.GetByID(t.Context(), paymentID)
+		require.NoError(t, err)
+		p.Status = entity.PaymentStatusCanceled
+		require.NoError(t, paymentRepo.Update(t.Context(), p))
+		o, err := orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.NoError(t, o.Cancel(entity.OrderActorCustomer, userID, "desisti", time.Now()))
+		require.NoError(t, orderRepo.Update(t.Context(), o))
+
+		out, err := deliver(t, `{"id":"evt-late-1","payment_ref":"mock_`+paymentID+`","status":"PAID","amount":1000}`)
+		require.NoError(t, err)
+		require.Equal(t, "refund issued: payment captured while order was CANCELED", out.Result)
+		require.Equal(t, "REFUNDED", out.Payment.Status)
+
+		refunds, err := refundRepo.ListByPaymentID(t.Context(), paymentID)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		require.Equal(t, entity.RefundStatusSucceeded, refunds[0].Status)
+		require.Equal(t, int64(1000), refunds[0].Amount)
+
+		// o evento fica guardado com o que foi feito
+		ev, err := eventRepo.GetByProviderEventID(t.Context(), entity.PaymentProviderMock, "evt-late-1")
+		require.NoError(t, err)
+		require.Equal(t, out.Result, ev.Result)
+
+		o, err = orderRepo.GetByID(t.Context(), orderID)
+		require.NoError(t, err)
+		require.Equal(t, entity.OrderCanceled, o.Status)
+	})
+}
@@ -0,0 +1,92 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+// StoreLookup descobre a loja dona de um recurso a partir do id da rota.
+type StoreLookup func(ctx context.Context, id string) (storeID string, err error)
+
+// SameID é o lookup das rotas que já recebem o id da loja.
+func SameID(_ context.Context, id string) (string, error) {
+	return id, nil
+}
+
+// Locator sobe a hierarquia do cardápio (opção → grupo → item → categoria →
+// cardápio → loja) até achar a loja.
+type Locator struct {
+	menus         repository.StoreMenuRepository
+	categories    repository.MenuCategoryRepository
+	items         repository.CategoryItemRepository
+	addonGroups   repository.ItemAddonGroupRepository
+	variantGroups repository.ItemVariantGroupRepository
+	payments      repository.PaymentRepository
+}
+
+func NewLocator(
+	menus repository.StoreMenuRepository,
+	categories repository.MenuCategoryRepository,
+	items repository.CategoryItemRepository,
+	addonGroups repository.ItemAddonGroupRepository,
+	variantGroups repository.ItemVariantGroupRepository,
+	payments repository.PaymentRepository,
+) *Locator {
+	return &Locator{
+		menus:         menus,
+		categories:    categories,
+		items:         items,
+		addonGroups:   addonGroups,
+		variantGroups: variantGroups,
+		payments:      payments,
+	}
+}
+
+func (l *Locator) StoreOfMenu(ctx context.Context, menuID string) (string, error) {
+	m, err := l.menus.GetByID(ctx, menuID)
+	if err != nil {
+		return "", err
+	}
+	return m.StoreID, nil
+}
+
+func (l *Locator) StoreOfCategory(ctx context.Context, categoryID string) (string, error) {
+	c, err := l.categories.GetByID(ctx, categoryID)
+	if err != nil {
+		return "", err
+	}
+	return l.StoreOfMenu(ctx, c.MenuID)
+}
+
+func (l *Locator) StoreOfItem(ctx context.Context, itemID string) (string, error) {
+	i, err := l.items.GetByID(ctx, itemID)
+	if err != nil {
+		return "", err
+	}
+	return l.StoreOfCategory(ctx, i.CategoryID)
+}
+
+func (l *Locator) StoreOfAddonGroup(ctx context.Context, groupID string) (string, error) {
+	g, err := l.addonGroups.GetByID(ctx, groupID)
+	if err != nil {
+		return "", err
+	}
+	return l.StoreOfItem(ctx, g.CategoryItemID)
+}
+
+func (l *Locator) StoreOfVariantGroup(ctx context.Context, groupID string) (string, error) {
+	g, err := l.variantGroups.GetByID(ctx, groupID)
+	if err != nil {
+		return "", err
+	}
+	return l.StoreOfItem(ctx, g.CategoryItemID)
+}
+
+func (l *Locator) StoreOfPayment(ctx context.Context, paymentID string) (string, error) {
+	p, err := l.payments.GetByID(ctx, paymentID)
+	if err != nil {
+		return "", err
+	}
+	return p.StoreID, nil
+}
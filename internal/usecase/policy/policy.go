@@ -0,0 +1,215 @@
+// Package policy decide se um usuário pode executar uma ação, opcionalmente
+// dentro de uma loja. É consultado pelo middleware das rotas e pelos usecases
+// que mexem em dados da loja.
+package policy
+
+import (
+	"context"
+	"slices"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type Action string
+
+const (
+	// abrir uma loja nova
+	ActionStoreCreate Action = "store:create"
+	// perfil da loja: nome, slug, endereço, horário e a chave de aberta/fechada
+	ActionStoreManage Action = "store:profile.manage"
+	// cardápio, categorias, itens, adicionais e variações
+	ActionCatalogManage Action = "store:catalog.manage"
+	// fila de pedidos da loja: listar, avançar status, recusar
+	ActionOrdersManage Action = "store:orders.manage"
+	// estornos de pagamentos da loja
+	ActionPaymentsRefund Action = "store:payments.refund"
+	// convidar e remover funcionários
+	ActionStaffManage Action = "store:staff.manage"
+	// carrinho, pedido e pagamento do próprio cliente
+	ActionOrderPlace Action = "order:place"
+	// fechar o pedido e pagar; além do papel, pode exigir contato verificado
+	ActionOrderCheckout Action = "order:checkout"
+)
+
+// Verification diz quais contatos o cliente precisa ter verificado antes de
+// fechar um pedido. O zero value não exige nada.
+type Verification struct {
+	Email bool
+	Phone bool
+}
+
+type rule struct {
+	roles []entity.UserRole
+	// exige loja: além do papel, o usuário precisa ser da equipe dela
+	storeScoped bool
+	// papéis de funcionário da loja que também podem (o dono sempre pode)
+	memberRoles []entity.StoreMemberRole
+	// sujeita a ação ao Verification configurado
+	verified bool
+}
+
+// admin pode tudo e fica fora da tabela; support é só leitura.
+var rules = map[Action]rule{
+	ActionStoreCreate: {roles: []entity.UserRole{entity.UserRoleStoreOwner}},
+	ActionStoreManage: {
+		roles: []entity.UserRole{entity.UserRoleStoreOwner}, storeScoped: true,
+		memberRoles: []entity.StoreMemberRole{entity.StoreMemberRoleManager},
+	},
+	ActionCatalogManage: {
+		roles: []entity.UserRole{entity.UserRoleStoreOwner}, storeScoped: true,
+		memberRoles: []entity.StoreMemberRole{entity.StoreMemberRoleManager},
+	},
+	ActionOrdersManage: {
+		roles: []entity.UserRole{entity.UserRoleStoreOwner, entity.UserRoleStoreEmployee}, storeScoped: true,
+		memberRoles: []entity.StoreMemberRole{
+			entity.StoreMemberRoleManager, entity.StoreMemberRoleCashier, entity.StoreMemberRoleKitchen,
+		},
+	},
+	ActionPaymentsRefund: {
+		roles: []entity.UserRole{entity.UserRoleStoreOwner}, storeScoped: true,
+		memberRoles: []entity.StoreMemberRole{entity.StoreMemberRoleManager},
+	},
+	ActionStaffManage: {roles: []entity.UserRole{entity.UserRoleStoreOwner}, storeScoped: true},
+	ActionOrderPlace: {roles: []entity.UserRole{
+		entity.UserRoleCostumer, entity.UserRoleStoreOwner, entity.UserRoleStoreEmployee,
+	}},
+	ActionOrderCheckout: {
+		roles: []entity.UserRole{
+			entity.UserRoleCostumer, entity.UserRoleStoreOwner, entity.UserRoleStoreEmployee,
+		},
+		verified: true,
+	},
+}
+
+type Policy struct {
+	users   repository.UserRepository
+	stores  repository.StoreRepository
+	members repository.StoreMemberRepository
+	require Verification
+	// papéis que só agem com 2FA ativo
+	twoFactor      repository.TwoFactorRepository
+	twoFactorRoles []entity.UserRole
+}
+
+func New(
+	users repository.UserRepository,
+	stores repository.StoreRepository,
+	members repository.StoreMemberRepository,
+) *Policy {
+	return &Policy{users: users, stores: stores, members: members}
+}
+
+// RequireVerified liga a exigência de contato verificado nas ações marcadas
+// (hoje, fechar pedido).
+func (p *Policy) RequireVerified(v Verification) *Policy {
+	p.require = v
+	return p
+}
+
+// RequireTwoFactor bloqueia todas as ações dos papéis listados enquanto o
+// usuário não ativar o 2FA. O cadastro do 2FA não passa pela policy.
+func (p *Policy) RequireTwoFactor(repo repository.TwoFactorRepository, roles []entity.UserRole) *Policy {
+	p.twoFactor = repo
+	p.twoFactorRoles = roles
+	return p
+}
+
+// Authorize responde "userID pode executar action na loja storeID". storeID
+// é ignorado nas ações que não são da loja. O papel vem do cadastro e não do
+// token, então uma mudança de papel vale na hora.
+func (p *Policy) Authorize(ctx context.Context, userID string, action Action, storeID string) error {
+	r, ok := rules[action]
+	if !ok {
+		return errx.F(errx.CodeInternal, "unknown action %q", action)
+	}
+	if userID == "" {
+		return errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	user, err := p.users.GetByID(ctx, userID)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return errx.New(errx.CodeUnauthorized, "invalid user")
+		}
+		return err
+	}
+	if user.Status == entity.UserStatusBlocked {
+		return errx.New(errx.CodeForbidden, "user is blocked")
+	}
+	if err := p.ensureTwoFactor(ctx, user); err != nil {
+		return err
+	}
+
+	if user.Role == entity.UserRoleAdmin {
+		return nil
+	}
+	if !r.storeScoped {
+		if !slices.Contains(r.roles, user.Role) {
+			return errx.F(errx.CodeForbidden, "role %s cannot perform %s", user.Role, action)
+		}
+		if r.verified {
+			return p.ensureVerified(user)
+		}
+		return nil
+	}
+
+	if storeID == "" {
+		return errx.New(errx.CodeInvalid, "missing store id")
+	}
+	store, err := p.stores.GetByID(ctx, storeID)
+	if err != nil {
+		return err
+	}
+	return p.ensureStaff(ctx, user, store, action, r)
+}
+
+// ensureStaff: o dono da loja é sempre equipe; os demais precisam de vínculo
+// (StoreMember) com um papel aceito pela ação. O vínculo vale mais que o papel
+// global, então um cliente convidado para a cozinha opera só aquela loja.
+func (p *Policy) ensureStaff(ctx context.Context, user *entity.User, store *entity.Store, action Action, r rule) error {
+	member, err := p.members.GetByStoreAndUser(ctx, store.ID, user.ID)
+	switch {
+	case err == nil:
+		if slices.Contains(r.memberRoles, member.Role) {
+			return nil
+		}
+		return errx.F(errx.CodeForbidden, "store role %s cannot perform %s", member.Role, action)
+	case !errx.Is(err, errx.CodeNotFound):
+		return err
+	}
+
+	if !slices.Contains(r.roles, user.Role) {
+		return errx.F(errx.CodeForbidden, "role %s cannot perform %s", user.Role, action)
+	}
+	if store.OwnerID == user.ID {
+		return nil
+	}
+	return errx.New(errx.CodeForbidden, "user is not staff of this store")
+}
+
+func (p *Policy) ensureVerified(user *entity.User) error {
+	if p.require.Email && user.EmailVerifiedAt == nil {
+		return errx.New(errx.CodeForbidden, "email not verified")
+	}
+	if p.require.Phone && user.PhoneVerifiedAt == nil {
+		return errx.New(errx.CodeForbidden, "phone not verified")
+	}
+	return nil
+}
+
+func (p *Policy) ensureTwoFactor(ctx context.Context, user *entity.User) error {
+	if p.twoFactor == nil || !slices.Contains(p.twoFactorRoles, user.Role) {
+		return nil
+	}
+
+	tf, err := p.twoFactor.GetByUserID(ctx, user.ID)
+	switch {
+	case err == nil && tf.IsEnabled():
+		return nil
+	case err != nil && !errx.Is(err, errx.CodeNotFound):
+		return err
+	}
+	return errx.F(errx.CodeForbidden, "two-factor authentication required for role %s", user.Role)
+}
@@ -0,0 +1,179 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memorytwofactor "github.com/FabioRocha231/saas-core/internal/infra/db/repository/two_factor"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	userRepo := memoryuser.New(pkg.NewClock())
+	storeRepo := memorystore.New()
+	memberRepo := memorystoremember.New(pkg.NewClock())
+	p := New(userRepo, storeRepo, memberRepo)
+
+	ownerID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner)
+	storeID := pkg.NewUUID().Generate()
+	require.NoError(t, storeRepo.Create(t.Context(), &entity.Store{ID: storeID, Name: "Loja", Slug: "loja", OwnerID: ownerID}))
+
+	t.Run("test owner manages its own store", func(t *testing.T) {
+		for _, action := range []Action{ActionStoreManage, ActionCatalogManage, ActionOrdersManage, ActionPaymentsRefund} {
+			require.NoError(t, p.Authorize(t.Context(), ownerID, action, storeID))
+		}
+		require.NoError(t, p.Authorize(t.Context(), ownerID, ActionStoreCreate, ""))
+	})
+
+	t.Run("test owner of another store", func(t *testing.T) {
+		otherID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner)
+
+		err := p.Authorize(t.Context(), otherID, ActionCatalogManage, storeID)
+		require.Error(t, err)
+		require.Equal(t, "forbidden: user is not staff of this store", err.Error())
+	})
+
+	t.Run("test customer only places orders", func(t *testing.T) {
+		customerID := testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)
+		require.NoError(t, p.Authorize(t.Context(), customerID, ActionOrderPlace, ""))
+
+		err := p.Authorize(t.Context(), customerID, ActionStoreCreate, "")
+		require.Error(t, err)
+		require.Equal(t, "forbidden: role costumer cannot perform store:create", err.Error())
+	})
+
+	t.Run("test employee cannot refund", func(t *testing.T) {
+		employeeID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreEmployee)
+
+		err := p.Authorize(t.Context(), employeeID, ActionPaymentsRefund, storeID)
+		require.Error(t, err)
+		require.Equal(t, "forbidden: role store_employee cannot perform store:payments.refund", err.Error())
+	})
+
+	t.Run("test store members act according to their store role", func(t *testing.T) {
+		member := func(role entity.StoreMemberRole) string {
+			userID := testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)
+			require.NoError(t, memberRepo.Create(t.Context(), &entity.StoreMember{
+				ID: pkg.NewUUID().Generate(), StoreID: storeID, UserID: userID, Role: role,
+			}))
+			return userID
+		}
+		managerID := member(entity.StoreMemberRoleManager)
+		kitchenID := member(entity.StoreMemberRoleKitchen)
+
+		require.NoError(t, p.Authorize(t.Context(), managerID, ActionCatalogManage, storeID))
+		require.NoError(t, p.Authorize(t.Context(), managerID, ActionPaymentsRefund, storeID))
+		require.NoError(t, p.Authorize(t.Context(), managerID, ActionStoreManage, storeID))
+		require.NoError(t, p.Authorize(t.Context(), kitchenID, ActionOrdersManage, storeID))
+
+		err := p.Authorize(t.Context(), kitchenID, ActionCatalogManage, storeID)
+		require.Error(t, err)
+		require.Equal(t, "forbidden: store role kitchen cannot perform store:catalog.manage", err.Error())
+
+		err = p.Authorize(t.Context(), managerID, ActionStaffManage, storeID)
+		require.Error(t, err)
+		require.Equal(t, "forbidden: store role manager cannot perform store:staff.manage", err.Error())
+
+		// o vínculo é por loja
+		otherStoreID := pkg.NewUUID().Generate()
+		require.NoError(t, storeRepo.Create(t.Context(), &entity.Store{ID: otherStoreID, Name: "Outra", Slug: "outra", OwnerID: ownerID}))
+		err = p.Authorize(t.Context(), managerID, ActionOrdersManage, otherStoreID)
+		require.Error(t, err)
+		require.Equal(t, "forbidden: role costumer cannot perform store:orders.manage", err.Error())
+	})
+
+	t.Run("test admin can do anything and support is read only", func(t *testing.T) {
+		adminID := testkit.CreateUser(t, userRepo, entity.UserRoleAdmin)
+		require.NoError(t, p.Authorize(t.Context(), adminID, ActionPaymentsRefund, storeID))
+
+		supportID := testkit.CreateUser(t, userRepo, entity.UserRoleSupport)
+		for _, action := range []Action{ActionStoreCreate, ActionCatalogManage, ActionOrdersManage, ActionPaymentsRefund, ActionOrderPlace} {
+			err := p.Authorize(t.Context(), supportID, action, storeID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "forbidden: role support")
+		}
+	})
+
+	t.Run("test blocked and unknown users", func(t *testing.T) {
+		blockedID := pkg.NewUUID().Generate()
+		require.NoError(t, userRepo.Create(t.Context(), &entity.User{
+			ID: blockedID, Email: "bloqueado@example.com", Cpf: blockedID,
+			Role: entity.UserRoleStoreOwner, Status: entity.UserStatusBlocked,
+		}))
+
+		err := p.Authorize(t.Context(), blockedID, ActionStoreCreate, "")
+		require.Error(t, err)
+		require.Equal(t, "forbidden: user is blocked", err.Error())
+
+		err = p.Authorize(t.Context(), "nao-existe", ActionOrderPlace, "")
+		require.Error(t, err)
+		require.Equal(t, "unauthorized: invalid user", err.Error())
+	})
+
+	t.Run("test store scoped action on a missing store", func(t *testing.T) {
+		err := p.Authorize(t.Context(), ownerID, ActionOrdersManage, "nao-existe")
+		require.Error(t, err)
+		require.Equal(t, "not_found: store not found", err.Error())
+	})
+
+	t.Run("test checkout requires verified contacts when configured", func(t *testing.T) {
+		strict := New(userRepo, storeRepo, memberRepo).RequireVerified(Verification{Email: true, Phone: true})
+		customerID := testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)
+
+		// sem exigência configurada, só o papel conta
+		require.NoError(t, p.Authorize(t.Context(), customerID, ActionOrderCheckout, ""))
+
+		err := strict.Authorize(t.Context(), customerID, ActionOrderCheckout, "")
+		require.Error(t, err)
+		require.Equal(t, "forbidden: email not verified", err.Error())
+
+		// o carrinho continua liberado
+		require.NoError(t, strict.Authorize(t.Context(), customerID, ActionOrderPlace, ""))
+
+		u, err := userRepo.GetByID(t.Context(), customerID)
+		require.NoError(t, err)
+		now := pkg.NewClock().Now()
+		u.EmailVerifiedAt = &now
+		require.NoError(t, userRepo.Update(t.Context(), u))
+
+		err = strict.Authorize(t.Context(), customerID, ActionOrderCheckout, "")
+		require.Error(t, err)
+		require.Equal(t, "forbidden: phone not verified", err.Error())
+
+		u.PhoneVerifiedAt = &now
+		require.NoError(t, userRepo.Update(t.Context(), u))
+		require.NoError(t, strict.Authorize(t.Context(), customerID, ActionOrderCheckout, ""))
+	})
+
+	t.Run("test required roles need two-factor enabled", func(t *testing.T) {
+		twoFactorRepo := memorytwofactor.New(pkg.NewClock())
+		strict := New(userRepo, storeRepo, memberRepo).
+			RequireTwoFactor(twoFactorRepo, []entity.UserRole{entity.UserRoleStoreOwner, entity.UserRoleAdmin})
+		customerID := testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)
+		adminID := testkit.CreateUser(t, userRepo, entity.UserRoleAdmin)
+
+		err := strict.Authorize(t.Context(), ownerID, ActionCatalogManage, storeID)
+		require.Error(t, err)
+		require.Equal(t, "forbidden: two-factor authentication required for role store_owner", err.Error())
+
+		// nem o admin passa direto
+		err = strict.Authorize(t.Context(), adminID, ActionStoreCreate, "")
+		require.Error(t, err)
+		require.Equal(t, "forbidden: two-factor authentication required for role admin", err.Error())
+
+		// papéis fora da lista não mudam
+		require.NoError(t, strict.Authorize(t.Context(), customerID, ActionOrderPlace, ""))
+
+		// cadastro pendente ainda não vale
+		require.NoError(t, twoFactorRepo.SavePending(t.Context(), &entity.TwoFactor{UserID: ownerID, Secret: "SECRET"}))
+		require.Error(t, strict.Authorize(t.Context(), ownerID, ActionCatalogManage, storeID))
+
+		require.NoError(t, twoFactorRepo.Enable(t.Context(), ownerID, pkg.NewClock().Now()))
+		require.NoError(t, strict.Authorize(t.Context(), ownerID, ActionCatalogManage, storeID))
+	})
+}
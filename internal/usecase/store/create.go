@@ -25,7 +25,8 @@ type CreateStoreInput struct {
 }
 
 type CreateStoreOutput struct {
-	ID string `json:"id"`
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
 }
 
 func NewCreateStoreUsecase(storeRepository repositoryPorts.StoreRepository, userRepo repositoryPorts.UserRepository, uuid ports.UUIDInterface) *CreateStoreUsecase {
@@ -62,18 +63,32 @@ func (uc *CreateStoreUsecase) Execute(ctx context.Context, input CreateStoreInpu
 	}
 
 	store := &entity.Store{
-		Name:    storeName,
-		Cnpj:    cnpj.Digits(),
-		ID:      uc.uuid.Generate(),
-		Slug:    storeName, // TODO: gerar slug de verdade
-		IsOpen:  true,
-		OwnerID: storeOwnerID,
+		Name:     storeName,
+		Cnpj:     cnpj.Digits(),
+		ID:       uc.uuid.Generate(),
+		IsOpen:   true,
+		OwnerID:  storeOwnerID,
+		Timezone: entity.DefaultStoreTimezone,
 	}
 
-	err := uc.storeRepository.Create(ctx, store)
-	if err != nil {
-		return nil, err
+	lost := map[string]bool{}
+	for {
+		slug, err := uniqueSlug(ctx, uc.storeRepository, storeName, "", lost)
+		if err != nil {
+			return nil, err
+		}
+		store.Slug = slug
+
+		err = uc.storeRepository.Create(ctx, store)
+		if err == nil {
+			break
+		}
+		// outra loja levou o slug entre a checagem e o insert: tenta o próximo
+		if !errx.Is(err, errx.CodeConflict) {
+			return nil, err
+		}
+		lost[slug] = true
 	}
 
-	return &CreateStoreOutput{ID: store.ID}, nil
+	return &CreateStoreOutput{ID: store.ID, Slug: store.Slug}, nil
 }
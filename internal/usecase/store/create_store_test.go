@@ -4,11 +4,24 @@ import (
 	"context"
 	"testing"
 
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
 	"github.com/FabioRocha231/saas-core/test/testkit"
 	"github.com/stretchr/testify/assert"
 )
 
+// staleSlugRepo simula a leitura feita antes de outra criação concorrente
+// gravar: nenhum slug aparece como ocupado, só o insert acusa o conflito.
+type staleSlugRepo struct {
+	repository.StoreRepository
+}
+
+func (r *staleSlugRepo) GetBySlug(ctx context.Context, slug string) (*entity.Store, error) {
+	return nil, errx.New(errx.CodeNotFound, "store not found")
+}
+
 func TestCreateStoreUsecase(t *testing.T) {
 	testEnv := testkit.NewEnv()
 	userID, mockUserError := testEnv.SeedUser(context.Background())
@@ -64,4 +77,30 @@ func TestCreateStoreUsecase(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, output.ID, "store id should not be empty")
 	})
+
+	t.Run("Should generate a slug without accents and with a suffix when taken", func(t *testing.T) {
+		first, err := uc.Execute(context.Background(), CreateStoreInput{Name: "Açaí do João", Cnpj: "65.921.814/0001-04", OwnerID: userID})
+		assert.NoError(t, err)
+		assert.Equal(t, "acai-do-joao", first.Slug)
+
+		second, err := uc.Execute(context.Background(), CreateStoreInput{Name: "Acai do Joao", Cnpj: "65.921.814/0001-04", OwnerID: userID})
+		assert.NoError(t, err)
+		assert.Equal(t, "acai-do-joao-2", second.Slug)
+	})
+
+	t.Run("Should fall back to a default slug when the name has no letters", func(t *testing.T) {
+		output, err := uc.Execute(context.Background(), CreateStoreInput{Name: "!!!", Cnpj: "65.921.814/0001-04", OwnerID: userID})
+		assert.NoError(t, err)
+		assert.Equal(t, "loja", output.Slug)
+	})
+
+	t.Run("Should retry with the next suffix when a concurrent create takes the slug", func(t *testing.T) {
+		assert.NoError(t, storeRepo.Create(context.Background(), &entity.Store{ID: testEnv.UUID.Generate(), Name: "Disputada", Slug: "disputada"}))
+		assert.NoError(t, storeRepo.Create(context.Background(), &entity.Store{ID: testEnv.UUID.Generate(), Name: "Disputada", Slug: "disputada-2"}))
+
+		racing := NewCreateStoreUsecase(&staleSlugRepo{StoreRepository: storeRepo}, testEnv.UserRepo, testEnv.UUID)
+		output, err := racing.Execute(context.Background(), CreateStoreInput{Name: "Disputada", Cnpj: "65.921.814/0001-04", OwnerID: userID})
+		assert.NoError(t, err)
+		assert.Equal(t, "disputada-3", output.Slug)
+	})
 }
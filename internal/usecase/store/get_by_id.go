@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"strings"
+	"time"
 
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 	ports "github.com/FabioRocha231/saas-core/internal/port"
 	"github.com/FabioRocha231/saas-core/internal/port/repository"
@@ -12,6 +14,7 @@ import (
 type GetStoreByIDUsecase struct {
 	storeRepo repository.StoreRepository
 	UUID      ports.UUIDInterface
+	clock     ports.Clock
 }
 
 type GetStoreByIDInput struct {
@@ -19,12 +22,43 @@ type GetStoreByIDInput struct {
 }
 
 type StoreDTO struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Slug    string `json:"slug"`
-	IsOpen  bool   `json:"is_open"`
-	Cnpj    string `json:"cnpj"`
-	OwnerID string `json:"owner_id"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	// aberta agora: chave manual + horário de funcionamento
+	IsOpen bool `json:"is_open"`
+	// chave manual do lojista
+	OpenEnabled bool            `json:"open_enabled"`
+	Cnpj        string          `json:"cnpj"`
+	OwnerID     string          `json:"owner_id"`
+	Phone       string          `json:"phone"`
+	LogoURL     string          `json:"logo_url"`
+	Address     AddressDTO      `json:"address"`
+	Timezone    string          `json:"timezone"`
+	Hours       OpeningHoursDTO `json:"hours"`
+	// só aparece enquanto a pausa está valendo
+	PausedUntil *time.Time `json:"paused_until,omitempty"`
+}
+
+func newStoreDTO(s *entity.Store, now time.Time) StoreDTO {
+	dto := StoreDTO{
+		ID:          s.ID,
+		Name:        s.Name,
+		Slug:        s.Slug,
+		IsOpen:      s.IsOpenAt(now),
+		OpenEnabled: s.IsOpen,
+		Cnpj:        s.Cnpj,
+		OwnerID:     s.OwnerID,
+		Phone:       s.Phone,
+		LogoURL:     s.LogoURL,
+		Address:     newAddressDTO(s.Address),
+		Timezone:    s.Location().String(),
+		Hours:       newOpeningHoursDTO(s.Hours),
+	}
+	if s.IsPausedAt(now) {
+		dto.PausedUntil = s.PausedUntil
+	}
+	return dto
 }
 
 type GetStoreByIDOutput struct {
@@ -34,10 +68,12 @@ type GetStoreByIDOutput struct {
 func NewGetStoreByIDUsecase(
 	storeRepo repository.StoreRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *GetStoreByIDUsecase {
 	return &GetStoreByIDUsecase{
 		storeRepo: storeRepo,
 		UUID:      uuid,
+		clock:     clock,
 	}
 }
 
@@ -58,14 +94,5 @@ func (uc *GetStoreByIDUsecase) Execute(ctx context.Context, input GetStoreByIDIn
 		return nil, err
 	}
 
-	return &GetStoreByIDOutput{
-		Store: StoreDTO{
-			ID:      store.ID,
-			Name:    store.Name,
-			Slug:    store.Slug,
-			IsOpen:  store.IsOpen,
-			Cnpj:    store.Cnpj,
-			OwnerID: store.OwnerID,
-		},
-	}, nil
+	return &GetStoreByIDOutput{Store: newStoreDTO(store, uc.clock.Now())}, nil
 }
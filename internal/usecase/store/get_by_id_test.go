@@ -15,7 +15,7 @@ func TestGetStoreByIDUsecase(t *testing.T) {
 	storeID, mockStoreError := testEnv.SeedStore(context.Background(), userID)
 	assert.NoError(t, mockStoreError)
 
-	uc := NewGetStoreByIDUsecase(testEnv.StoreRepo, testEnv.UUID)
+	uc := NewGetStoreByIDUsecase(testEnv.StoreRepo, testEnv.UUID, testEnv.Clock)
 
 	t.Run("Should return error if the id is not provided", func(t *testing.T) {
 		_, err := uc.Execute(context.Background(), GetStoreByIDInput{})
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type GetStoreBySlugUsecase struct {
+	storeRepo repository.StoreRepository
+	clock     ports.Clock
+}
+
+type GetStoreBySlugInput struct {
+	Slug string
+}
+
+func NewGetStoreBySlugUsecase(storeRepo repository.StoreRepository, clock ports.Clock) *GetStoreBySlugUsecase {
+	return &GetStoreBySlugUsecase{storeRepo: storeRepo, clock: clock}
+}
+
+func (uc *GetStoreBySlugUsecase) Execute(ctx context.Context, input GetStoreBySlugInput) (*GetStoreByIDOutput, error) {
+	slug := strings.ToLower(strings.TrimSpace(input.Slug))
+	if slug == "" {
+		return nil, errx.New(errx.CodeInvalid, "store slug are required")
+	}
+
+	store, err := uc.storeRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetStoreByIDOutput{Store: newStoreDTO(store, uc.clock.Now())}, nil
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+// MaxStorePause limita a pausa; fechar por mais tempo é com a chave manual ou uma exceção no horário.
+const MaxStorePause = 24 * time.Hour
+
+// PauseStoreUsecase fecha a loja temporariamente; ela reabre sozinha em PausedUntil.
+type PauseStoreUsecase struct {
+	storeRepo repository.StoreRepository
+	uuid      ports.UUIDInterface
+	clock     ports.Clock
+}
+
+type PauseStoreInput struct {
+	StoreID string
+	// informe Minutes ou Until
+	Minutes int
+	Until   *time.Time
+}
+
+func NewPauseStoreUsecase(storeRepo repository.StoreRepository, uuid ports.UUIDInterface, clock ports.Clock) *PauseStoreUsecase {
+	return &PauseStoreUsecase{storeRepo: storeRepo, uuid: uuid, clock: clock}
+}
+
+func (uc *PauseStoreUsecase) Execute(ctx context.Context, input PauseStoreInput) (*GetStoreByIDOutput, error) {
+	storeID := strings.TrimSpace(input.StoreID)
+	if !uc.uuid.Validate(storeID) {
+		return nil, errx.New(errx.CodeInvalid, "invalid store id")
+	}
+
+	now := uc.clock.Now()
+	var until time.Time
+	switch {
+	case input.Until != nil && input.Minutes != 0:
+		return nil, errx.New(errx.CodeInvalid, "send either minutes or until")
+	case input.Until != nil:
+		until = input.Until.UTC()
+	case input.Minutes > 0:
+		until = now.Add(time.Duration(input.Minutes) * time.Minute)
+	default:
+		return nil, errx.New(errx.CodeInvalid, "pause minutes or until are required")
+	}
+
+	if !until.After(now) {
+		return nil, errx.New(errx.CodeInvalid, "pause must end in the future")
+	}
+	if until.After(now.Add(MaxStorePause)) {
+		return nil, errx.F(errx.CodeInvalid, "pause must be at most %s", MaxStorePause)
+	}
+
+	store, err := uc.storeRepo.GetByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	store.PausedUntil = &until
+	if err := uc.storeRepo.Update(ctx, store); err != nil {
+		return nil, err
+	}
+
+	return &GetStoreByIDOutput{Store: newStoreDTO(store, now)}, nil
+}
+
+// ResumeStoreUsecase encerra a pausa antes da hora.
+type ResumeStoreUsecase struct {
+	storeRepo repository.StoreRepository
+	uuid      ports.UUIDInterface
+	clock     ports.Clock
+}
+
+func NewResumeStoreUsecase(storeRepo repository.StoreRepository, uuid ports.UUIDInterface, clock ports.Clock) *ResumeStoreUsecase {
+	return &ResumeStoreUsecase{storeRepo: storeRepo, uuid: uuid, clock: clock}
+}
+
+func (uc *ResumeStoreUsecase) Execute(ctx context.Context, input GetStoreByIDInput) (*GetStoreByIDOutput, error) {
+	storeID := strings.TrimSpace(input.StoreID)
+	if !uc.uuid.Validate(storeID) {
+		return nil, errx.New(errx.CodeInvalid, "invalid store id")
+	}
+
+	store, err := uc.storeRepo.GetByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if store.PausedUntil != nil {
+		store.PausedUntil = nil
+		if err := uc.storeRepo.Update(ctx, store); err != nil {
+			return nil, err
+		}
+	}
+
+	return &GetStoreByIDOutput{Store: newStoreDTO(store, uc.clock.Now())}, nil
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+)
+
+// Mesmo formato na entrada (PATCH) e na saída: horários "HH:MM" e dia da semana por extenso.
+type AddressDTO struct {
+	Street     string `json:"street"`
+	Number     string `json:"number"`
+	Complement string `json:"complement"`
+	District   string `json:"district"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	ZipCode    string `json:"zip_code"`
+}
+
+type WeeklyWindowDTO struct {
+	Weekday string `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
+
+type HoursExceptionDTO struct {
+	Date   string `json:"date"`
+	Closed bool   `json:"closed"`
+	Opens  string `json:"opens,omitempty"`
+	Closes string `json:"closes,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+type OpeningHoursDTO struct {
+	Weekly     []WeeklyWindowDTO   `json:"weekly"`
+	Exceptions []HoursExceptionDTO `json:"exceptions"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func newAddressDTO(a entity.StoreAddress) AddressDTO {
+	return AddressDTO{
+		Street:     a.Street,
+		Number:     a.Number,
+		Complement: a.Complement,
+		District:   a.District,
+		City:       a.City,
+		State:      a.State,
+		ZipCode:    a.ZipCode,
+	}
+}
+
+func newOpeningHoursDTO(h entity.OpeningHours) OpeningHoursDTO {
+	out := OpeningHoursDTO{
+		Weekly:     make([]WeeklyWindowDTO, 0, len(h.Weekly)),
+		Exceptions: make([]HoursExceptionDTO, 0, len(h.Exceptions)),
+	}
+	for _, w := range h.Weekly {
+		out.Weekly = append(out.Weekly, WeeklyWindowDTO{
+			Weekday: strings.ToLower(w.Weekday.String()),
+			Opens:   entity.FormatClock(w.Opens),
+			Closes:  entity.FormatClock(w.Closes),
+		})
+	}
+	for _, e := range h.Exceptions {
+		dto := HoursExceptionDTO{Date: e.Date, Closed: e.Closed, Note: e.Note}
+		if !e.Closed {
+			dto.Opens = entity.FormatClock(e.Opens)
+			dto.Closes = entity.FormatClock(e.Closes)
+		}
+		out.Exceptions = append(out.Exceptions, dto)
+	}
+	return out
+}
+
+func parseOpeningHours(in OpeningHoursDTO) (entity.OpeningHours, error) {
+	var h entity.OpeningHours
+	for _, w := range in.Weekly {
+		weekday, ok := weekdays[strings.ToLower(strings.TrimSpace(w.Weekday))]
+		if !ok {
+			return h, errx.F(errx.CodeInvalid, "invalid weekday %q", w.Weekday)
+		}
+		opens, closes, err := parseSpan(w.Opens, w.Closes)
+		if err != nil {
+			return h, err
+		}
+		h.Weekly = append(h.Weekly, entity.WeeklyWindow{Weekday: weekday, Opens: opens, Closes: closes})
+	}
+	for _, e := range in.Exceptions {
+		ex := entity.HoursException{Date: strings.TrimSpace(e.Date), Closed: e.Closed, Note: strings.TrimSpace(e.Note)}
+		if !e.Closed {
+			opens, closes, err := parseSpan(e.Opens, e.Closes)
+			if err != nil {
+				return h, err
+			}
+			ex.Opens, ex.Closes = opens, closes
+		}
+		h.Exceptions = append(h.Exceptions, ex)
+	}
+	if err := h.Validate(); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+func parseSpan(opens, closes string) (int, int, error) {
+	o, err := entity.ParseClock(strings.TrimSpace(opens))
+	if err != nil {
+		return 0, 0, err
+	}
+	c, err := entity.ParseClock(strings.TrimSpace(closes))
+	if err != nil {
+		return 0, 0, err
+	}
+	return o, c, nil
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	valueobject "github.com/FabioRocha231/saas-core/internal/domain/value_object"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const (
+	fallbackSlug = "loja"
+	// tentativas de sufixo antes de desistir ("loja-2" ... "loja-100")
+	maxSlugSuffix = 100
+)
+
+// uniqueSlug gera o slug a partir do nome e desempata com -2, -3...
+// storeID ignora a própria loja na checagem (vazio na criação). lost são
+// slugs que já perderam a corrida no insert: contam como ocupados mesmo que a
+// outra loja ainda não apareça na leitura.
+func uniqueSlug(ctx context.Context, stores repository.StoreRepository, name, storeID string, lost map[string]bool) (string, error) {
+	base := valueobject.NewSlug(name).Truncate()
+	if base.Validate() != nil {
+		base = valueobject.NewSlug(fallbackSlug)
+	}
+
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = base.WithSuffix(n)
+		}
+		if lost[candidate.String()] {
+			continue
+		}
+		taken, err := slugTaken(ctx, stores, candidate.String(), storeID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate.String(), nil
+		}
+	}
+
+	return "", errx.F(errx.CodeConflict, "no slug available for %q", name)
+}
+
+func slugTaken(ctx context.Context, stores repository.StoreRepository, slug, storeID string) (bool, error) {
+	s, err := stores.GetBySlug(ctx, slug)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return s.ID != storeID, nil
+}
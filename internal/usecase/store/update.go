@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	valueobject "github.com/FabioRocha231/saas-core/internal/domain/value_object"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+// UpdateStoreUsecase aplica um PATCH no perfil: campo nil fica como está.
+// Endereço e horário são substituídos por inteiro quando enviados.
+type UpdateStoreUsecase struct {
+	storeRepo repository.StoreRepository
+	uuid      ports.UUIDInterface
+	clock     ports.Clock
+}
+
+type UpdateStoreInput struct {
+	StoreID  string
+	Name     *string
+	Slug     *string
+	IsOpen   *bool
+	Phone    *string
+	LogoURL  *string
+	Address  *AddressDTO
+	Timezone *string
+	Hours    *OpeningHoursDTO
+}
+
+func NewUpdateStoreUsecase(storeRepo repository.StoreRepository, uuid ports.UUIDInterface, clock ports.Clock) *UpdateStoreUsecase {
+	return &UpdateStoreUsecase{storeRepo: storeRepo, uuid: uuid, clock: clock}
+}
+
+func (uc *UpdateStoreUsecase) Execute(ctx context.Context, input UpdateStoreInput) (*GetStoreByIDOutput, error) {
+	storeID := strings.TrimSpace(input.StoreID)
+	if !uc.uuid.Validate(storeID) {
+		return nil, errx.New(errx.CodeInvalid, "invalid store id")
+	}
+
+	store, err := uc.storeRepo.GetByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, errx.New(errx.CodeInvalid, "store name are required")
+		}
+		// o slug não acompanha o nome para não quebrar links já divulgados
+		store.Name = name
+	}
+
+	if input.Slug != nil {
+		slug := valueobject.NewSlug(*input.Slug)
+		if err := slug.Validate(); err != nil {
+			return nil, errx.New(errx.CodeInvalid, err.Error())
+		}
+		store.Slug = slug.String()
+	}
+
+	if input.IsOpen != nil {
+		store.IsOpen = *input.IsOpen
+	}
+
+	if input.Phone != nil {
+		phone := digits(*input.Phone)
+		if phone != "" && (len(phone) < 10 || len(phone) > 13) {
+			return nil, errx.New(errx.CodeInvalid, "phone must have between 10 and 13 digits")
+		}
+		store.Phone = phone
+	}
+
+	if input.LogoURL != nil {
+		logo := strings.TrimSpace(*input.LogoURL)
+		if logo != "" {
+			u, err := url.Parse(logo)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, errx.New(errx.CodeInvalid, "logo url must be an http(s) url")
+			}
+		}
+		store.LogoURL = logo
+	}
+
+	if input.Address != nil {
+		a := *input.Address
+		store.Address.Street = strings.TrimSpace(a.Street)
+		store.Address.Number = strings.TrimSpace(a.Number)
+		store.Address.Complement = strings.TrimSpace(a.Complement)
+		store.Address.District = strings.TrimSpace(a.District)
+		store.Address.City = strings.TrimSpace(a.City)
+		store.Address.State = strings.ToUpper(strings.TrimSpace(a.State))
+		store.Address.ZipCode = digits(a.ZipCode)
+
+		if store.Address.State != "" && len(store.Address.State) != 2 {
+			return nil, errx.New(errx.CodeInvalid, "address state must be a 2-letter code")
+		}
+		if store.Address.ZipCode != "" && len(store.Address.ZipCode) != 8 {
+			return nil, errx.New(errx.CodeInvalid, "address zip code must have 8 digits")
+		}
+	}
+
+	if input.Timezone != nil {
+		tz := strings.TrimSpace(*input.Timezone)
+		if _, err := time.LoadLocation(tz); err != nil || tz == "" || tz == "Local" {
+			return nil, errx.F(errx.CodeInvalid, "invalid timezone %q", tz)
+		}
+		store.Timezone = tz
+	}
+
+	if input.Hours != nil {
+		hours, err := parseOpeningHours(*input.Hours)
+		if err != nil {
+			return nil, err
+		}
+		store.Hours = hours
+	}
+
+	if err := uc.storeRepo.Update(ctx, store); err != nil {
+		return nil, err
+	}
+
+	return &GetStoreByIDOutput{Store: newStoreDTO(store, uc.clock.Now())}, nil
+}
+
+func digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
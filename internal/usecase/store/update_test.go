@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateStoreUsecase(t *testing.T) {
+	testEnv := testkit.NewEnv()
+	userID, mockUserError := testEnv.SeedUser(context.Background())
+	assert.NoError(t, mockUserError)
+	storeID, mockStoreError := testEnv.SeedStore(context.Background(), userID)
+	assert.NoError(t, mockStoreError)
+	assert.NoError(t, testEnv.StoreRepo.Create(context.Background(), &entity.Store{ID: testEnv.UUID.Generate(), Name: "outra", Slug: "outra", OwnerID: userID}))
+
+	uc := NewUpdateStoreUsecase(testEnv.StoreRepo, testEnv.UUID, testEnv.Clock)
+	str := func(s string) *string { return &s }
+
+	t.Run("Should return error if the store does not exist", func(t *testing.T) {
+		_, err := uc.Execute(context.Background(), UpdateStoreInput{StoreID: "a2b24ebb-b79d-450c-a43c-5bfe2a9e7a01", Name: str("x")})
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), "not_found: store not found")
+	})
+
+	t.Run("Should keep the slug when only the name changes", func(t *testing.T) {
+		output, err := uc.Execute(context.Background(), UpdateStoreInput{StoreID: storeID, Name: str("Novo Nome")})
+		assert.NoError(t, err)
+		assert.Equal(t, "Novo Nome", output.Store.Name)
+		assert.Equal(t, "test", output.Store.Slug)
+	})
+
+	t.Run("Should return error if the slug is taken by another store", func(t *testing.T) {
+		_, err := uc.Execute(context.Background(), UpdateStoreInput{StoreID: storeID, Slug: str("Outra")})
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), "conflict: Slug outra already exists")
+	})
+
+	t.Run("Should return error if an opening time is malformed", func(t *testing.T) {
+		_, err := uc.Execute(context.Background(), UpdateStoreInput{StoreID: storeID, Hours: &OpeningHoursDTO{
+			Weekly: []WeeklyWindowDTO{{Weekday: "monday", Opens: "9h", Closes: "18:00"}},
+		}})
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), `invalid_argument: invalid time "9h", expected HH:MM`)
+	})
+
+	t.Run("Should compute is_open from the opening hours in the store timezone", func(t *testing.T) {
+		// 2026-10-16 é sexta; 21:00 UTC = 18:00 em São Paulo
+		testEnv.Clock.Set(time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC))
+
+		output, err := uc.Execute(context.Background(), UpdateStoreInput{StoreID: storeID, Timezone: str("America/Sao_Paulo"), Hours: &OpeningHoursDTO{
+			Weekly: []WeeklyWindowDTO{{Weekday: "Friday", Opens: "11:00", Closes: "17:00"}},
+		}})
+		assert.NoError(t, err)
+		assert.False(t, output.Store.IsOpen)
+		assert.True(t, output.Store.OpenEnabled)
+
+		testEnv.Clock.Set(time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC))
+		got, err := NewGetStoreByIDUsecase(testEnv.StoreRepo, testEnv.UUID, testEnv.Clock).Execute(context.Background(), GetStoreByIDInput{StoreID: storeID})
+		assert.NoError(t, err)
+		assert.True(t, got.Store.IsOpen)
+		assert.Equal(t, []WeeklyWindowDTO{{Weekday: "friday", Opens: "11:00", Closes: "17:00"}}, got.Store.Hours.Weekly)
+	})
+}
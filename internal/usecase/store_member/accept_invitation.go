@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type AcceptInvitationInput struct {
+	Token string
+	// usuário logado que aceita; o email dele precisa ser o do convite
+	UserID string
+}
+
+type MemberOutput struct {
+	StoreID   string `json:"store_id"`
+	UserID    string `json:"user_id"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Role      string `json:"role"`
+	InvitedBy string `json:"invited_by,omitempty"`
+}
+
+type AcceptInvitationUsecase struct {
+	invitations repository.StoreInvitationRepository
+	members     repository.StoreMemberRepository
+	users       repository.UserRepository
+	token       ports.TokenInterface
+	uuid        ports.UUIDInterface
+	tx          ports.TxManager
+	clock       ports.Clock
+}
+
+func NewAcceptInvitationUsecase(
+	invitations repository.StoreInvitationRepository,
+	members repository.StoreMemberRepository,
+	users repository.UserRepository,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+) *AcceptInvitationUsecase {
+	return &AcceptInvitationUsecase{
+		invitations: invitations,
+		members:     members,
+		users:       users,
+		token:       token,
+		uuid:        uuid,
+		tx:          tx,
+		clock:       clock,
+	}
+}
+
+func (uc *AcceptInvitationUsecase) Execute(ctx context.Context, in AcceptInvitationInput) (*MemberOutput, error) {
+	if in.Token == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing token")
+	}
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	invitation, err := uc.invitations.GetByTokenHash(ctx, uc.token.Hash(in.Token))
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errx.New(errx.CodeNotFound, "invitation not found")
+		}
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+	switch {
+	case invitation.IsAccepted():
+		return nil, errx.New(errx.CodeConflict, "invitation already accepted")
+	case invitation.IsRevoked():
+		return nil, errx.New(errx.CodeConflict, "invitation revoked")
+	case invitation.IsExpired(now):
+		return nil, errx.New(errx.CodeConflict, "invitation expired")
+	}
+
+	user, err := uc.users.GetByID(ctx, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// o token sozinho não basta: um link vazado não coloca outra conta na equipe
+	if normalizeEmail(user.Email) != invitation.Email {
+		return nil, errx.New(errx.CodeForbidden, "invitation was sent to another email")
+	}
+
+	member := &entity.StoreMember{
+		ID:        uc.uuid.Generate(),
+		StoreID:   invitation.StoreID,
+		UserID:    user.ID,
+		Role:      invitation.Role,
+		InvitedBy: invitation.InvitedBy,
+		CreatedAt: now,
+	}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.invitations.MarkAccepted(ctx, invitation.ID, user.ID, now); err != nil {
+			return err
+		}
+		return uc.members.Create(ctx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberOutput{
+		StoreID:   member.StoreID,
+		UserID:    member.UserID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      member.Role.String(),
+		InvitedBy: member.InvitedBy,
+	}, nil
+}
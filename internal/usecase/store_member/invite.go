@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "PENDING"
+	InvitationAccepted InvitationStatus = "ACCEPTED"
+	InvitationRevoked  InvitationStatus = "REVOKED"
+	InvitationExpired  InvitationStatus = "EXPIRED"
+)
+
+type InvitationOutput struct {
+	ID        string           `json:"id"`
+	StoreID   string           `json:"store_id"`
+	Email     string           `json:"email"`
+	Role      string           `json:"role"`
+	Status    InvitationStatus `json:"status"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+func toInvitationOutput(i *entity.StoreInvitation, now time.Time) InvitationOutput {
+	status := InvitationPending
+	switch {
+	case i.IsAccepted():
+		status = InvitationAccepted
+	case i.IsRevoked():
+		status = InvitationRevoked
+	case i.IsExpired(now):
+		status = InvitationExpired
+	}
+	return InvitationOutput{
+		ID:        i.ID,
+		StoreID:   i.StoreID,
+		Email:     i.Email,
+		Role:      i.Role.String(),
+		Status:    status,
+		ExpiresAt: i.ExpiresAt,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+type InviteStoreMemberInput struct {
+	StoreID string
+	// quem convida
+	UserID string
+	Email  string
+	Role   string
+}
+
+// InviteStoreMemberUsecase convida um email para a equipe da loja com um papel
+// (manager, cashier, kitchen). O token em claro só vai no email ao convidado;
+// quem convida não o vê. O convite expira depois de ttl.
+type InviteStoreMemberUsecase struct {
+	invitations repository.StoreInvitationRepository
+	policy      *policy.Policy
+	sender      ports.MessageSender
+	token       ports.TokenInterface
+	uuid        ports.UUIDInterface
+	clock       ports.Clock
+	ttl         time.Duration
+}
+
+func NewInviteStoreMemberUsecase(
+	invitations repository.StoreInvitationRepository,
+	authz *policy.Policy,
+	sender ports.MessageSender,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+	ttl time.Duration,
+) *InviteStoreMemberUsecase {
+	return &InviteStoreMemberUsecase{
+		invitations: invitations,
+		policy:      authz,
+		sender:      sender,
+		token:       token,
+		uuid:        uuid,
+		clock:       clock,
+		ttl:         ttl,
+	}
+}
+
+func (uc *InviteStoreMemberUsecase) Execute(ctx context.Context, in InviteStoreMemberInput) (*InvitationOutput, error) {
+	email := normalizeEmail(in.Email)
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, errx.New(errx.CodeInvalid, "invalid email")
+	}
+	role, ok := entity.StoreMemberRoleMap[strings.ToLower(strings.TrimSpace(in.Role))]
+	if !ok {
+		return nil, errx.New(errx.CodeInvalid, "invalid store member role")
+	}
+
+	if err := uc.policy.Authorize(ctx, in.UserID, policy.ActionStaffManage, in.StoreID); err != nil {
+		return nil, err
+	}
+
+	raw, err := uc.token.Generate()
+	if err != nil {
+		return nil, errx.Wrap(errx.CodeInternal, "generate invitation token", err)
+	}
+
+	now := uc.clock.Now()
+	invitation := &entity.StoreInvitation{
+		ID:        uc.uuid.Generate(),
+		StoreID:   in.StoreID,
+		Email:     email,
+		Role:      role,
+		TokenHash: uc.token.Hash(raw),
+		InvitedBy: in.UserID,
+		ExpiresAt: now.Add(uc.ttl),
+		CreatedAt: now,
+	}
+	if err := uc.invitations.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	if err := uc.sender.Send(ctx, ports.Message{
+		Channel: ports.MessageChannelEmail,
+		To:      email,
+		Subject: "Convite para a equipe da loja",
+		Body: fmt.Sprintf(
+			"Você foi convidado para a equipe da loja como %s. Use o token %s para aceitar o convite. Ele expira em %d horas.",
+			role.String(), raw, int(uc.ttl.Hours()),
+		),
+	}); err != nil {
+		return nil, err
+	}
+
+	out := toInvitationOutput(invitation, now)
+	return &out, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
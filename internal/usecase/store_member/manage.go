@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+)
+
+type StoreStaffInput struct {
+	StoreID string
+	// quem está pedindo (dono da loja)
+	UserID string
+}
+
+// ListStoreStaffUsecase devolve a equipe e os convites da loja.
+type ListStoreStaffUsecase struct {
+	members     repository.StoreMemberRepository
+	invitations repository.StoreInvitationRepository
+	users       repository.UserRepository
+	policy      *policy.Policy
+	clock       ports.Clock
+}
+
+type ListStoreStaffOutput struct {
+	Members     []MemberOutput     `json:"members"`
+	Invitations []InvitationOutput `json:"invitations"`
+}
+
+func NewListStoreStaffUsecase(
+	members repository.StoreMemberRepository,
+	invitations repository.StoreInvitationRepository,
+	users repository.UserRepository,
+	authz *policy.Policy,
+	clock ports.Clock,
+) *ListStoreStaffUsecase {
+	return &ListStoreStaffUsecase{members: members, invitations: invitations, users: users, policy: authz, clock: clock}
+}
+
+func (uc *ListStoreStaffUsecase) Execute(ctx context.Context, in StoreStaffInput) (*ListStoreStaffOutput, error) {
+	if err := uc.policy.Authorize(ctx, in.UserID, policy.ActionStaffManage, in.StoreID); err != nil {
+		return nil, err
+	}
+
+	members, err := uc.members.ListByStoreID(ctx, in.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := uc.invitations.ListByStoreID(ctx, in.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ListStoreStaffOutput{
+		Members:     make([]MemberOutput, 0, len(members)),
+		Invitations: make([]InvitationOutput, 0, len(invitations)),
+	}
+	for _, m := range members {
+		item := MemberOutput{StoreID: m.StoreID, UserID: m.UserID, Role: m.Role.String(), InvitedBy: m.InvitedBy}
+		if u, err := uc.users.GetByID(ctx, m.UserID); err == nil {
+			item.Name, item.Email = u.Name, u.Email
+		} else if !errx.Is(err, errx.CodeNotFound) {
+			return nil, err
+		}
+		out.Members = append(out.Members, item)
+	}
+
+	now := uc.clock.Now()
+	for _, i := range invitations {
+		out.Invitations = append(out.Invitations, toInvitationOutput(i, now))
+	}
+	return out, nil
+}
+
+type RevokeInvitationInput struct {
+	StoreStaffInput
+	InvitationID string
+}
+
+type RevokeInvitationUsecase struct {
+	invitations repository.StoreInvitationRepository
+	policy      *policy.Policy
+	clock       ports.Clock
+}
+
+func NewRevokeInvitationUsecase(
+	invitations repository.StoreInvitationRepository,
+	authz *policy.Policy,
+	clock ports.Clock,
+) *RevokeInvitationUsecase {
+	return &RevokeInvitationUsecase{invitations: invitations, policy: authz, clock: clock}
+}
+
+func (uc *RevokeInvitationUsecase) Execute(ctx context.Context, in RevokeInvitationInput) error {
+	if err := uc.policy.Authorize(ctx, in.UserID, policy.ActionStaffManage, in.StoreID); err != nil {
+		return err
+	}
+
+	invitation, err := uc.invitations.GetByID(ctx, in.InvitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.StoreID != in.StoreID {
+		return errx.New(errx.CodeNotFound, "store invitation not found")
+	}
+
+	return uc.invitations.MarkRevoked(ctx, invitation.ID, uc.clock.Now())
+}
+
+type RemoveMemberInput struct {
+	StoreStaffInput
+	MemberUserID string
+}
+
+// RemoveMemberUsecase tira o funcionário da equipe; o acesso cai na próxima
+// request porque a policy consulta o vínculo a cada chamada.
+type RemoveMemberUsecase struct {
+	members repository.StoreMemberRepository
+	policy  *policy.Policy
+}
+
+func NewRemoveMemberUsecase(members repository.StoreMemberRepository, authz *policy.Policy) *RemoveMemberUsecase {
+	return &RemoveMemberUsecase{members: members, policy: authz}
+}
+
+func (uc *RemoveMemberUsecase) Execute(ctx context.Context, in RemoveMemberInput) error {
+	if in.MemberUserID == "" {
+		return errx.New(errx.CodeInvalid, "missing member user id")
+	}
+	if err := uc.policy.Authorize(ctx, in.UserID, policy.ActionStaffManage, in.StoreID); err != nil {
+		return err
+	}
+	return uc.members.Delete(ctx, in.StoreID, in.MemberUserID)
+}
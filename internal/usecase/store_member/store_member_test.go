@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoreinvitation "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_invitation"
+	memorystoremember "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_member"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	"github.com/FabioRocha231/saas-core/internal/usecase/policy"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreMembership(t *testing.T) {
+	uuid := pkg.NewUUID()
+	token := pkg.NewToken()
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+
+	userRepo := memoryuser.New(clock)
+	storeRepo := memorystore.New()
+	memberRepo := memorystoremember.New(clock)
+	invitationRepo := memorystoreinvitation.New(clock)
+	tx := memorytx.New()
+	authz := policy.New(userRepo, storeRepo, memberRepo)
+
+	ownerID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreOwner)
+	storeID := uuid.Generate()
+	require.NoError(t, storeRepo.Create(t.Context(), &entity.Store{ID: storeID, Name: "Loja", Slug: "loja", OwnerID: ownerID}))
+
+	outbox := testkit.NewOutbox()
+	invite := NewInviteStoreMemberUsecase(invitationRepo, authz, outbox, token, uuid, clock, 72*time.Hour)
+	accept := NewAcceptInvitationUsecase(invitationRepo, memberRepo, userRepo, token, uuid, tx, clock)
+	revoke := NewRevokeInvitationUsecase(invitationRepo, authz, clock)
+	remove := NewRemoveMemberUsecase(memberRepo, authz)
+	list := NewListStoreStaffUsecase(memberRepo, invitationRepo, userRepo, authz, clock)
+
+	emailOf := func(t *testing.T, userID string) string {
+		u, err := userRepo.GetByID(t.Context(), userID)
+		require.NoError(t, err)
+		return u.Email
+	}
+	owner := StoreStaffInput{StoreID: storeID, UserID: ownerID}
+
+	t.Run("test owner invites and the invited user accepts", func(t *testing.T) {
+		userID := testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)
+
+		inv, err := invite.Execute(t.Context(), InviteStoreMemberInput{StoreID: storeID, UserID: ownerID, Email: " " + emailOf(t, userID) + " ", Role: "Cashier"})
+		require.NoError(t, err)
+		invited := outbox.LastToken(t, emailOf(t, userID))
+		require.Equal(t, InvitationPending, inv.Status)
+		require.Equal(t, "cashier", inv.Role)
+		require.Equal(t, clock.Now().Add(72*time.Hour), inv.ExpiresAt)
+
+		member, err := accept.Execute(t.Context(), AcceptInvitationInput{Token: invited, UserID: userID})
+		require.NoError(t, err)
+		require.Equal(t, storeID, member.StoreID)
+		require.Equal(t, "cashier", member.Role)
+		require.NoError(t, authz.Authorize(t.Context(), userID, policy.ActionOrdersManage, storeID))
+
+		_, err = accept.Execute(t.Context(), AcceptInvitationInput{Token: invited, UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "conflict: invitation already accepted", err.Error())
+
+		staff, err := list.Execute(t.Context(), owner)
+		require.NoError(t, err)
+		require.Len(t, staff.Members, 1)
+		require.Equal(t, emailOf(t, userID), staff.Members[0].Email)
+		require.Equal(t, InvitationAccepted, staff.Invitations[0].Status)
+
+		require.NoError(t, remove.Execute(t.Context(), RemoveMemberInput{StoreStaffInput: owner, MemberUserID: userID}))
+		require.Error(t, authz.Authorize(t.Context(), userID, policy.ActionOrdersManage, storeID))
+	})
+
+	t.Run("test invitation for another email", func(t *testing.T) {
+		_, err := invite.Execute(t.Context(), InviteStoreMemberInput{StoreID: storeID, UserID: ownerID, Email: "alguem@example.com", Role: "kitchen"})
+		require.NoError(t, err)
+
+		_, err = accept.Execute(t.Context(), AcceptInvitationInput{Token: outbox.LastToken(t, "alguem@example.com"), UserID: testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: invitation was sent to another email", err.Error())
+	})
+
+	t.Run("test expired and revoked invitations", func(t *testing.T) {
+		userID := testkit.CreateUser(t, userRepo, entity.UserRoleCostumer)
+
+		_, err := invite.Execute(t.Context(), InviteStoreMemberInput{StoreID: storeID, UserID: ownerID, Email: emailOf(t, userID), Role: "kitchen"})
+		require.NoError(t, err)
+		expired := outbox.LastToken(t, emailOf(t, userID))
+		clock.Advance(72 * time.Hour)
+		_, err = accept.Execute(t.Context(), AcceptInvitationInput{Token: expired, UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "conflict: invitation expired", err.Error())
+
+		revoked, err := invite.Execute(t.Context(), InviteStoreMemberInput{StoreID: storeID, UserID: ownerID, Email: emailOf(t, userID), Role: "kitchen"})
+		require.NoError(t, err)
+		require.NoError(t, revoke.Execute(t.Context(), RevokeInvitationInput{StoreStaffInput: owner, InvitationID: revoked.ID}))
+		_, err = accept.Execute(t.Context(), AcceptInvitationInput{Token: outbox.LastToken(t, emailOf(t, userID)), UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "conflict: invitation revoked", err.Error())
+
+		_, err = accept.Execute(t.Context(), AcceptInvitationInput{Token: "nao-existe", UserID: userID})
+		require.Error(t, err)
+		require.Equal(t, "not_found: invitation not found", err.Error())
+	})
+
+	t.Run("test only the owner manages the staff", func(t *testing.T) {
+		managerID := testkit.CreateUser(t, userRepo, entity.UserRoleStoreEmployee)
+		require.NoError(t, memberRepo.Create(t.Context(), &entity.StoreMember{ID: uuid.Generate(), StoreID: storeID, UserID: managerID, Role: entity.StoreMemberRoleManager}))
+
+		_, err := invite.Execute(t.Context(), InviteStoreMemberInput{StoreID: storeID, UserID: managerID, Email: "novo@example.com", Role: "manager"})
+		require.Error(t, err)
+		require.Equal(t, "forbidden: store role manager cannot perform store:staff.manage", err.Error())
+
+		_, err = invite.Execute(t.Context(), InviteStoreMemberInput{StoreID: storeID, UserID: ownerID, Email: "novo@example.com", Role: "owner"})
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: invalid store member role", err.Error())
+	})
+}
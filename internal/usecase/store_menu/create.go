@@ -3,7 +3,6 @@ package usecase
 import (
 	"context"
 	"strings"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/entity"
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
@@ -15,6 +14,7 @@ type CreateStoreMenuUsecase struct {
 	storeRepository     repository.StoreRepository
 	storeMenuRepository repository.StoreMenuRepository
 	uuid                ports.UUIDInterface
+	clock               ports.Clock
 }
 
 type CreateStoreMenuInput struct {
@@ -30,11 +30,13 @@ func NewCreateStoreMenuUsecase(
 	storeRepository repository.StoreRepository,
 	storeMenuRepository repository.StoreMenuRepository,
 	uuid ports.UUIDInterface,
+	clock ports.Clock,
 ) *CreateStoreMenuUsecase {
 	return &CreateStoreMenuUsecase{
 		storeRepository:     storeRepository,
 		storeMenuRepository: storeMenuRepository,
 		uuid:                uuid,
+		clock:               clock,
 	}
 }
 
@@ -64,8 +66,8 @@ func (uc *CreateStoreMenuUsecase) Execute(context context.Context, input CreateS
 		Name:      menuName,
 		StoreID:   store.ID,
 		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: uc.clock.Now(),
+		UpdatedAt: uc.clock.Now(),
 	}
 
 	if err := uc.storeMenuRepository.Create(context, storeMenu); err != nil {
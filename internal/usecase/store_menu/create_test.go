@@ -13,7 +13,7 @@ func TestCreateStoreMenuUsecase(t *testing.T) {
 	storeID, mockStoreErr := testEnv.SeedStore(context.Background(), testEnv.UUID.Generate())
 	assert.NoError(t, mockStoreErr)
 
-	uc := NewCreateStoreMenuUsecase(testEnv.StoreRepo, testEnv.StoreMenuRepo, testEnv.UUID)
+	uc := NewCreateStoreMenuUsecase(testEnv.StoreRepo, testEnv.StoreMenuRepo, testEnv.UUID, testEnv.Clock)
 
 	t.Run("Should return error if the name is not provided", func(t *testing.T) {
 		_, err := uc.Execute(context.Background(), CreateStoreMenuInput{})
@@ -47,5 +47,9 @@ func TestCreateStoreMenuUsecase(t *testing.T) {
 		output, err := uc.Execute(context.Background(), input)
 		assert.NoError(t, err)
 		assert.NotNil(t, output, "expected not nil but nil returned")
+
+		menu, err := testEnv.StoreMenuRepo.GetByID(context.Background(), output.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testEnv.Clock.Now(), menu.CreatedAt)
 	})
 }
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type VariantOption struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	PriceDelta int64  `json:"price_delta"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+type VariantGroup struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Required  bool            `json:"required"`
+	MinSelect int             `json:"min_select"`
+	MaxSelect int             `json:"max_select"`
+	Options   []VariantOption `json:"options"`
+}
+
+type AddonOption struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Price int64  `json:"price"`
+}
+
+type AddonGroup struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Required  bool          `json:"required"`
+	MinSelect int           `json:"min_select"`
+	MaxSelect int           `json:"max_select"`
+	Options   []AddonOption `json:"options"`
+}
+
+type Item struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	BasePrice     int64          `json:"base_price"`
+	ImageURL      string         `json:"image_url"`
+	VariantGroups []VariantGroup `json:"variant_groups"`
+	AddonGroups   []AddonGroup   `json:"addon_groups"`
+}
+
+type Category struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Items []Item `json:"items"`
+}
+
+type Menu struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Categories []Category `json:"categories"`
+}
+
+type GetMenuOutput struct {
+	Store PublicStore `json:"store"`
+	Menus []Menu      `json:"menus"`
+}
+
+// GetMenuUsecase monta a árvore inteira do cardápio numa resposta só.
+// Fica de fora tudo que está inativo, além de grupos sem opção ativa e
+// categorias vazias; item com grupo obrigatório sem opção não dá para
+// pedir, então sai também.
+type GetMenuUsecase struct {
+	storeRepo         repository.StoreRepository
+	menuRepo          repository.StoreMenuRepository
+	categoryRepo      repository.MenuCategoryRepository
+	itemRepo          repository.CategoryItemRepository
+	addonGroupRepo    repository.ItemAddonGroupRepository
+	addonOptionRepo   repository.AddonOptionRepository
+	variantGroupRepo  repository.ItemVariantGroupRepository
+	variantOptionRepo repository.VariantOptionRepository
+	clock             ports.Clock
+}
+
+func NewGetMenuUsecase(
+	storeRepo repository.StoreRepository,
+	menuRepo repository.StoreMenuRepository,
+	categoryRepo repository.MenuCategoryRepository,
+	itemRepo repository.CategoryItemRepository,
+	addonGroupRepo repository.ItemAddonGroupRepository,
+	addonOptionRepo repository.AddonOptionRepository,
+	variantGroupRepo repository.ItemVariantGroupRepository,
+	variantOptionRepo repository.VariantOptionRepository,
+	clock ports.Clock,
+) *GetMenuUsecase {
+	return &GetMenuUsecase{
+		storeRepo:         storeRepo,
+		menuRepo:          menuRepo,
+		categoryRepo:      categoryRepo,
+		itemRepo:          itemRepo,
+		addonGroupRepo:    addonGroupRepo,
+		addonOptionRepo:   addonOptionRepo,
+		variantGroupRepo:  variantGroupRepo,
+		variantOptionRepo: variantOptionRepo,
+		clock:             clock,
+	}
+}
+
+func (uc *GetMenuUsecase) Execute(ctx context.Context, input GetStoreInput) (*GetMenuOutput, error) {
+	store, err := getStore(ctx, uc.storeRepo, uc.clock, input.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	menus, err := uc.menuRepo.ListByStoreID(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GetMenuOutput{Store: *store, Menus: []Menu{}}
+	for _, m := range menus {
+		if !m.IsActive {
+			continue
+		}
+		categories, err := uc.categories(ctx, m.ID)
+		if err != nil {
+			return nil, err
+		}
+		out.Menus = append(out.Menus, Menu{ID: m.ID, Name: m.Name, Categories: categories})
+	}
+
+	return out, nil
+}
+
+func (uc *GetMenuUsecase) categories(ctx context.Context, menuID string) ([]Category, error) {
+	categories, err := uc.categoryRepo.ListByMenuID(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Category{}
+	for _, c := range categories {
+		if !c.IsActive {
+			continue
+		}
+		items, err := uc.items(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			continue
+		}
+		out = append(out, Category{ID: c.ID, Name: c.Name, Items: items})
+	}
+	return out, nil
+}
+
+func (uc *GetMenuUsecase) items(ctx context.Context, categoryID string) ([]Item, error) {
+	items, err := uc.itemRepo.ListByCategoryID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []Item{}
+	for _, it := range items {
+		if !it.IsActive {
+			continue
+		}
+		variantGroups, ok, err := uc.variantGroups(ctx, it.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		addonGroups, ok, err := uc.addonGroups(ctx, it.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		out = append(out, Item{
+			ID:            it.ID,
+			Name:          it.Name,
+			Description:   it.Description,
+			BasePrice:     it.BasePrice,
+			ImageURL:      it.ImageURL,
+			VariantGroups: variantGroups,
+			AddonGroups:   addonGroups,
+		})
+	}
+	return out, nil
+}
+
+// variantGroups devolve ok=false quando um grupo obrigatório ficou sem opção ativa.
+func (uc *GetMenuUsecase) variantGroups(ctx context.Context, itemID string) ([]VariantGroup, bool, error) {
+	groups, err := uc.variantGroupRepo.ListByCategoryItemID(ctx, itemID)
+	if err != nil {
+		return nil, false, err
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Order < groups[j].Order })
+
+	out := []VariantGroup{}
+	for _, g := range groups {
+		if !g.IsActive {
+			continue
+		}
+		options, err := uc.variantOptionRepo.ListByVariantGroupID(ctx, g.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		sort.SliceStable(options, func(i, j int) bool { return options[i].Order < options[j].Order })
+
+		active := []VariantOption{}
+		for _, o := range options {
+			if o.IsActive {
+				active = append(active, VariantOption{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta, IsDefault: o.IsDefault})
+			}
+		}
+		if len(active) == 0 {
+			if g.Required || g.MinSelect > 0 {
+				return nil, false, nil
+			}
+			continue
+		}
+		out = append(out, VariantGroup{
+			ID: g.ID, Name: g.Name, Required: g.Required, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect, Options: active,
+		})
+	}
+	return out, true, nil
+}
+
+// addonGroups segue a mesma regra de variantGroups.
+func (uc *GetMenuUsecase) addonGroups(ctx context.Context, itemID string) ([]AddonGroup, bool, error) {
+	groups, err := uc.addonGroupRepo.ListByCategoryItemID(ctx, itemID)
+	if err != nil {
+		return nil, false, err
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Order < groups[j].Order })
+
+	out := []AddonGroup{}
+	for _, g := range groups {
+		if !g.IsActive {
+			continue
+		}
+		options, err := uc.addonOptionRepo.ListByAddonGroupID(ctx, g.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		sort.SliceStable(options, func(i, j int) bool { return options[i].Order < options[j].Order })
+
+		active := []AddonOption{}
+		for _, o := range options {
+			if o.IsActive {
+				active = append(active, AddonOption{ID: o.ID, Name: o.Name, Price: o.Price})
+			}
+		}
+		if len(active) == 0 {
+			if g.Required || g.MinSelect > 0 {
+				return nil, false, nil
+			}
+			continue
+		}
+		out = append(out, AddonGroup{
+			ID: g.ID, Name: g.Name, Required: g.Required, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect, Options: active,
+		})
+	}
+	return out, true, nil
+}
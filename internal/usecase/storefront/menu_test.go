@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memoryaddonoption "github.com/FabioRocha231/saas-core/internal/infra/db/repository/addon_option"
+	memorycategoryitem "github.com/FabioRocha231/saas-core/internal/infra/db/repository/category_item"
+	memoryitemaddongroup "github.com/FabioRocha231/saas-core/internal/infra/db/repository/item_addon_group"
+	memoryitemvariantgroup "github.com/FabioRocha231/saas-core/internal/infra/db/repository/item_variant_group"
+	memorymenucategory "github.com/FabioRocha231/saas-core/internal/infra/db/repository/menu_category"
+	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
+	memorystoremenu "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_menu"
+	memoryvariantoption "github.com/FabioRocha231/saas-core/internal/infra/db/repository/variant_option"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMenuUsecase(t *testing.T) {
+	ctx := t.Context()
+	clock := pkg.NewFakeClock(time.Now())
+	stores := memorystore.New()
+	menus := memorystoremenu.New(clock)
+	categories := memorymenucategory.New(clock)
+	items := memorycategoryitem.New(clock)
+	addonGroups := memoryitemaddongroup.New(clock)
+	addonOptions := memoryaddonoption.New(clock)
+	variantGroups := memoryitemvariantgroup.New(clock)
+	variantOptions := memoryvariantoption.New(clock)
+
+	require.NoError(t, stores.Create(ctx, &entity.Store{ID: "store-1", Name: "Loja", Slug: "loja", IsOpen: true, Cnpj: "19131243000197", OwnerID: "owner-1"}))
+
+	require.NoError(t, menus.Create(ctx, &entity.StoreMenu{ID: "menu-1", StoreID: "store-1", Name: "Principal", IsActive: true}))
+	require.NoError(t, menus.Create(ctx, &entity.StoreMenu{ID: "menu-off", StoreID: "store-1", Name: "Antigo"}))
+
+	require.NoError(t, categories.Create(ctx, &entity.MenuCategory{ID: "cat-1", MenuID: "menu-1", Name: "Burgers", IsActive: true}))
+	require.NoError(t, categories.Create(ctx, &entity.MenuCategory{ID: "cat-off", MenuID: "menu-1", Name: "Sobremesas"}))
+	require.NoError(t, categories.Create(ctx, &entity.MenuCategory{ID: "cat-empty", MenuID: "menu-1", Name: "Vazia", IsActive: true}))
+	require.NoError(t, items.Create(ctx, &entity.CategoryItem{ID: "item-dessert", CategoryID: "cat-off", Name: "Pudim", IsActive: true}))
+
+	require.NoError(t, items.Create(ctx, &entity.CategoryItem{ID: "item-1", CategoryID: "cat-1", Name: "Classic", BasePrice: 2500, IsActive: true}))
+	require.NoError(t, items.Create(ctx, &entity.CategoryItem{ID: "item-off", CategoryID: "cat-1", Name: "Fora de linha"}))
+	require.NoError(t, items.Create(ctx, &entity.CategoryItem{ID: "item-broken", CategoryID: "cat-1", Name: "Sem ponto", IsActive: true}))
+	require.NoError(t, items.Create(ctx, &entity.CategoryItem{ID: "item-empty-cat", CategoryID: "cat-empty", Name: "Inativo"}))
+
+	require.NoError(t, variantGroups.Create(ctx, &entity.ItemVariantGroup{ID: "vg-1", CategoryItemID: "item-1", Name: "Ponto", Required: true, MinSelect: 1, MaxSelect: 1, IsActive: true}))
+	require.NoError(t, variantOptions.Create(ctx, &entity.VariantOption{ID: "vo-2", VariantGroupID: "vg-1", Name: "Bem passado", Order: 2, IsActive: true}))
+	require.NoError(t, variantOptions.Create(ctx, &entity.VariantOption{ID: "vo-1", VariantGroupID: "vg-1", Name: "Ao ponto", Order: 1, IsDefault: true, IsActive: true}))
+	require.NoError(t, variantOptions.Create(ctx, &entity.VariantOption{ID: "vo-off", VariantGroupID: "vg-1", Name: "Cru", Order: 3}))
+
+	require.NoError(t, addonGroups.Create(ctx, &entity.ItemAddonGroup{ID: "ag-2", CategoryItemID: "item-1", Name: "Molhos", MaxSelect: 2, Order: 2, IsActive: true}))
+	require.NoError(t, addonGroups.Create(ctx, &entity.ItemAddonGroup{ID: "ag-1", CategoryItemID: "item-1", Name: "Adicionais", MaxSelect: 3, Order: 1, IsActive: true}))
+	require.NoError(t, addonGroups.Create(ctx, &entity.ItemAddonGroup{ID: "ag-empty", CategoryItemID: "item-1", Name: "Sem opções", MaxSelect: 1, Order: 3, IsActive: true}))
+	require.NoError(t, addonOptions.Create(ctx, &entity.AddonOption{ID: "ao-1", AddonGroupID: "ag-1", Name: "Bacon", Price: 500, IsActive: true}))
+	require.NoError(t, addonOptions.Create(ctx, &entity.AddonOption{ID: "ao-2", AddonGroupID: "ag-2", Name: "Barbecue", Price: 200, IsActive: true}))
+	require.NoError(t, addonOptions.Create(ctx, &entity.AddonOption{ID: "ao-off", AddonGroupID: "ag-empty", Name: "Esgotado", Price: 100}))
+
+	require.NoError(t, variantGroups.Create(ctx, &entity.ItemVariantGroup{ID: "vg-broken", CategoryItemID: "item-broken", Name: "Ponto", Required: true, MinSelect: 1, MaxSelect: 1, IsActive: true}))
+	require.NoError(t, variantOptions.Create(ctx, &entity.VariantOption{ID: "vo-broken", VariantGroupID: "vg-broken", Name: "Ao ponto"}))
+
+	uc := NewGetMenuUsecase(stores, menus, categories, items, addonGroups, addonOptions, variantGroups, variantOptions, clock)
+
+	t.Run("test the tree only carries what a customer can order", func(t *testing.T) {
+		out, err := uc.Execute(ctx, GetStoreInput{Slug: "loja"})
+		require.NoError(t, err)
+
+		require.Equal(t, "store-1", out.Store.ID)
+		require.True(t, out.Store.IsOpen)
+		require.Len(t, out.Menus, 1)
+		require.Equal(t, "menu-1", out.Menus[0].ID)
+
+		require.Len(t, out.Menus[0].Categories, 1)
+		category := out.Menus[0].Categories[0]
+		require.Equal(t, "cat-1", category.ID)
+
+		require.Len(t, category.Items, 1)
+		item := category.Items[0]
+		require.Equal(t, "item-1", item.ID)
+		require.Equal(t, int64(2500), item.BasePrice)
+
+		require.Equal(t, []VariantGroup{{
+			ID: "vg-1", Name: "Ponto", Required: true, MinSelect: 1, MaxSelect: 1,
+			Options: []VariantOption{
+				{ID: "vo-1", Name: "Ao ponto", IsDefault: true},
+				{ID: "vo-2", Name: "Bem passado"},
+			},
+		}}, item.VariantGroups)
+
+		require.Len(t, item.AddonGroups, 2)
+		require.Equal(t, "ag-1", item.AddonGroups[0].ID)
+		require.Equal(t, "ag-2", item.AddonGroups[1].ID)
+	})
+
+	t.Run("test an unknown slug", func(t *testing.T) {
+		_, err := uc.Execute(ctx, GetStoreInput{Slug: "missing"})
+
+		require.Error(t, err)
+		require.Equal(t, errx.CodeNotFound, errx.CodeOf(err))
+	})
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	storeuc "github.com/FabioRocha231/saas-core/internal/usecase/store"
+)
+
+// PublicStore é a vitrine da loja: sem dono, cnpj nem a chave manual.
+type PublicStore struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Slug        string                  `json:"slug"`
+	IsOpen      bool                    `json:"is_open"`
+	PausedUntil *time.Time              `json:"paused_until,omitempty"`
+	Phone       string                  `json:"phone"`
+	LogoURL     string                  `json:"logo_url"`
+	Address     storeuc.AddressDTO      `json:"address"`
+	Timezone    string                  `json:"timezone"`
+	Hours       storeuc.OpeningHoursDTO `json:"hours"`
+}
+
+type GetStoreInput struct {
+	Slug string
+}
+
+type GetStoreOutput struct {
+	Store PublicStore `json:"store"`
+}
+
+type GetStoreUsecase struct {
+	storeRepo repository.StoreRepository
+	clock     ports.Clock
+}
+
+func NewGetStoreUsecase(storeRepo repository.StoreRepository, clock ports.Clock) *GetStoreUsecase {
+	return &GetStoreUsecase{storeRepo: storeRepo, clock: clock}
+}
+
+func (uc *GetStoreUsecase) Execute(ctx context.Context, input GetStoreInput) (*GetStoreOutput, error) {
+	store, err := getStore(ctx, uc.storeRepo, uc.clock, input.Slug)
+	if err != nil {
+		return nil, err
+	}
+	return &GetStoreOutput{Store: *store}, nil
+}
+
+func getStore(ctx context.Context, stores repository.StoreRepository, clock ports.Clock, slug string) (*PublicStore, error) {
+	out, err := storeuc.NewGetStoreBySlugUsecase(stores, clock).Execute(ctx, storeuc.GetStoreBySlugInput{Slug: slug})
+	if err != nil {
+		return nil, err
+	}
+
+	s := out.Store
+	return &PublicStore{
+		ID:          s.ID,
+		Name:        s.Name,
+		Slug:        s.Slug,
+		IsOpen:      s.IsOpen,
+		PausedUntil: s.PausedUntil,
+		Phone:       s.Phone,
+		LogoURL:     s.LogoURL,
+		Address:     s.Address,
+		Timezone:    s.Timezone,
+		Hours:       s.Hours,
+	}, nil
+}
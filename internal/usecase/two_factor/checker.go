@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const (
+	// códigos de recuperação entregues por vez
+	recoveryCodeCount = 10
+	// dígitos de cada código, exibidos em grupos de 4 (ex.: 1234-5678-9012)
+	recoveryCodeDigits = 12
+	totpCodeLength     = 6
+)
+
+var (
+	errNotEnabled  = errx.New(errx.CodeConflict, "two-factor authentication not enabled")
+	errInvalidCode = errx.New(errx.CodeInvalid, "invalid two-factor code")
+)
+
+// Checker confere os códigos do segundo fator. É usado pelo login e pelas
+// operações que mexem no próprio 2FA.
+type Checker struct {
+	repo  repository.TwoFactorRepository
+	totp  ports.TOTPInterface
+	token ports.TokenInterface
+	clock ports.Clock
+}
+
+func NewChecker(
+	repo repository.TwoFactorRepository,
+	totp ports.TOTPInterface,
+	token ports.TokenInterface,
+	clock ports.Clock,
+) *Checker {
+	return &Checker{repo: repo, totp: totp, token: token, clock: clock}
+}
+
+// Verify aceita o código do app ou um código de recuperação, que é gasto.
+// false significa código errado ou já usado.
+func (c *Checker) Verify(ctx context.Context, tf *entity.TwoFactor, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		return c.VerifyTOTP(ctx, tf, code)
+	}
+
+	err := c.repo.UseRecoveryCode(ctx, tf.UserID, hashRecoveryCode(c.token, tf.UserID, code), c.clock.Now())
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// VerifyTOTP aceita só o código do app. O passo usado fica registrado, então
+// o mesmo código não vale duas vezes.
+func (c *Checker) VerifyTOTP(ctx context.Context, tf *entity.TwoFactor, code string) (bool, error) {
+	step, ok := c.totp.Validate(tf.Secret, strings.TrimSpace(code), c.clock.Now())
+	if !ok {
+		return false, nil
+	}
+	if err := c.repo.UseStep(ctx, tf.UserID, step); err != nil {
+		if errx.Is(err, errx.CodeConflict) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totpCodeLength {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// getEnabled devolve o cadastro do usuário só se o 2FA estiver ativo.
+func getEnabled(ctx context.Context, repo repository.TwoFactorRepository, userID string) (*entity.TwoFactor, error) {
+	tf, err := repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errNotEnabled
+		}
+		return nil, err
+	}
+	if !tf.IsEnabled() {
+		return nil, errNotEnabled
+	}
+	return tf, nil
+}
+
+// newRecoveryCodes sorteia um lote novo. Os valores em claro vão para o usuário
+// uma única vez; o banco guarda só o hash.
+func newRecoveryCodes(token ports.TokenInterface, uuid ports.UUIDInterface, userID string) ([]string, []*entity.RecoveryCode, error) {
+	plain := make([]string, 0, recoveryCodeCount)
+	codes := make([]*entity.RecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		raw, err := token.GenerateCode(recoveryCodeDigits)
+		if err != nil {
+			return nil, nil, errx.Wrap(errx.CodeInternal, "generate recovery code", err)
+		}
+		plain = append(plain, raw[0:4]+"-"+raw[4:8]+"-"+raw[8:12])
+		codes = append(codes, &entity.RecoveryCode{
+			ID:       uuid.Generate(),
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(token, userID, raw),
+		})
+	}
+	return plain, codes, nil
+}
+
+// hashRecoveryCode ignora hífens e espaços; o userID entra no hash para que o
+// mesmo código em contas diferentes não gere o mesmo valor.
+func hashRecoveryCode(token ports.TokenInterface, userID, code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+	return token.Hash(userID + ":" + normalized)
+}
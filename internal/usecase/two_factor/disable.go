@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type DisableInput struct {
+	UserID   string
+	Password string
+	// código do app ou de recuperação
+	Code string
+}
+
+// DisableUsecase desliga o 2FA com senha e código. Papéis que exigem 2FA não
+// podem desligar.
+type DisableUsecase struct {
+	repo          repository.TwoFactorRepository
+	users         repository.UserRepository
+	checker       *Checker
+	passwordHash  ports.PasswordHashInterface
+	tx            ports.TxManager
+	requiredRoles []entity.UserRole
+}
+
+func NewDisableUsecase(
+	repo repository.TwoFactorRepository,
+	users repository.UserRepository,
+	checker *Checker,
+	passwordHash ports.PasswordHashInterface,
+	tx ports.TxManager,
+	requiredRoles []entity.UserRole,
+) *DisableUsecase {
+	return &DisableUsecase{
+		repo:          repo,
+		users:         users,
+		checker:       checker,
+		passwordHash:  passwordHash,
+		tx:            tx,
+		requiredRoles: requiredRoles,
+	}
+}
+
+func (uc *DisableUsecase) Execute(ctx context.Context, in DisableInput) error {
+	if in.UserID == "" {
+		return errx.New(errx.CodeUnauthorized, "missing user")
+	}
+	if in.Password == "" {
+		return errx.New(errx.CodeInvalid, "missing password")
+	}
+	if strings.TrimSpace(in.Code) == "" {
+		return errx.New(errx.CodeInvalid, "missing code")
+	}
+
+	user, err := uc.users.GetByID(ctx, in.UserID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(uc.requiredRoles, user.Role) {
+		return errx.F(errx.CodeForbidden, "two-factor authentication is required for role %s", user.Role)
+	}
+	if !uc.passwordHash.Verify(user.Password, in.Password) {
+		return errx.New(errx.CodeForbidden, "current password is incorrect")
+	}
+
+	tf, err := getEnabled(ctx, uc.repo, in.UserID)
+	if err != nil {
+		return err
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ok, err := uc.checker.Verify(ctx, tf, in.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errInvalidCode
+		}
+		return uc.repo.Delete(ctx, in.UserID)
+	})
+}
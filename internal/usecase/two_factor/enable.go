@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type CodeInput struct {
+	UserID string
+	Code   string
+}
+
+type RecoveryCodesOutput struct {
+	// mostrados só nesta resposta
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// EnableUsecase ativa o 2FA pendente com o primeiro código do app e entrega os
+// códigos de recuperação.
+type EnableUsecase struct {
+	repo    repository.TwoFactorRepository
+	checker *Checker
+	token   ports.TokenInterface
+	uuid    ports.UUIDInterface
+	tx      ports.TxManager
+	clock   ports.Clock
+}
+
+func NewEnableUsecase(
+	repo repository.TwoFactorRepository,
+	checker *Checker,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+) *EnableUsecase {
+	return &EnableUsecase{repo: repo, checker: checker, token: token, uuid: uuid, tx: tx, clock: clock}
+}
+
+func (uc *EnableUsecase) Execute(ctx context.Context, in CodeInput) (*RecoveryCodesOutput, error) {
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+	if strings.TrimSpace(in.Code) == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing code")
+	}
+
+	tf, err := uc.repo.GetByUserID(ctx, in.UserID)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errx.New(errx.CodeNotFound, "two-factor setup not started")
+		}
+		return nil, err
+	}
+	if tf.IsEnabled() {
+		return nil, errx.New(errx.CodeConflict, "two-factor authentication already enabled")
+	}
+
+	plain, codes, err := newRecoveryCodes(uc.token, uc.uuid, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ok, err := uc.checker.VerifyTOTP(ctx, tf, in.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errInvalidCode
+		}
+		if err := uc.repo.Enable(ctx, in.UserID, uc.clock.Now()); err != nil {
+			return err
+		}
+		return uc.repo.ReplaceRecoveryCodes(ctx, in.UserID, codes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RecoveryCodesOutput{RecoveryCodes: plain}, nil
+}
+
+// RegenerateRecoveryCodesUsecase invalida os códigos de recuperação atuais e
+// entrega um lote novo. Pede o código do app: quem só tem um código de
+// recuperação não deve conseguir gerar outros.
+type RegenerateRecoveryCodesUsecase struct {
+	repo    repository.TwoFactorRepository
+	checker *Checker
+	token   ports.TokenInterface
+	uuid    ports.UUIDInterface
+	tx      ports.TxManager
+}
+
+func NewRegenerateRecoveryCodesUsecase(
+	repo repository.TwoFactorRepository,
+	checker *Checker,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	tx ports.TxManager,
+) *RegenerateRecoveryCodesUsecase {
+	return &RegenerateRecoveryCodesUsecase{repo: repo, checker: checker, token: token, uuid: uuid, tx: tx}
+}
+
+func (uc *RegenerateRecoveryCodesUsecase) Execute(ctx context.Context, in CodeInput) (*RecoveryCodesOutput, error) {
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+	if strings.TrimSpace(in.Code) == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing code")
+	}
+
+	tf, err := getEnabled(ctx, uc.repo, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, codes, err := newRecoveryCodes(uc.token, uc.uuid, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ok, err := uc.checker.VerifyTOTP(ctx, tf, in.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errInvalidCode
+		}
+		return uc.repo.ReplaceRecoveryCodes(ctx, in.UserID, codes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RecoveryCodesOutput{RecoveryCodes: plain}, nil
+}
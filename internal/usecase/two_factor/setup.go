@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type SetupOutput struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// SetupUsecase gera o segredo e deixa o 2FA pendente até o primeiro código
+// (EnableUsecase). Chamar de novo antes disso troca o segredo.
+type SetupUsecase struct {
+	repo   repository.TwoFactorRepository
+	users  repository.UserRepository
+	totp   ports.TOTPInterface
+	issuer string
+}
+
+func NewSetupUsecase(
+	repo repository.TwoFactorRepository,
+	users repository.UserRepository,
+	totp ports.TOTPInterface,
+	issuer string,
+) *SetupUsecase {
+	return &SetupUsecase{repo: repo, users: users, totp: totp, issuer: issuer}
+}
+
+func (uc *SetupUsecase) Execute(ctx context.Context, userID string) (*SetupOutput, error) {
+	if userID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	user, err := uc.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := uc.totp.GenerateSecret()
+	if err != nil {
+		return nil, errx.Wrap(errx.CodeInternal, "generate two-factor secret", err)
+	}
+	if err := uc.repo.SavePending(ctx, &entity.TwoFactor{UserID: user.ID, Secret: secret}); err != nil {
+		return nil, err
+	}
+
+	return &SetupOutput{
+		Secret:     secret,
+		OTPAuthURI: uc.totp.URI(uc.issuer, user.Email, secret),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type StatusOutput struct {
+	Enabled bool `json:"enabled"`
+	// códigos de recuperação ainda não usados
+	RecoveryCodesLeft int `json:"recovery_codes_left"`
+}
+
+type StatusUsecase struct {
+	repo repository.TwoFactorRepository
+}
+
+func NewStatusUsecase(repo repository.TwoFactorRepository) *StatusUsecase {
+	return &StatusUsecase{repo: repo}
+}
+
+func (uc *StatusUsecase) Execute(ctx context.Context, userID string) (*StatusOutput, error) {
+	if userID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	tf, err := getEnabled(ctx, uc.repo, userID)
+	if err != nil {
+		if err == errNotEnabled {
+			return &StatusOutput{}, nil
+		}
+		return nil, err
+	}
+
+	left, err := uc.repo.CountRecoveryCodes(ctx, tf.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &StatusOutput{Enabled: true, RecoveryCodesLeft: left}, nil
+}
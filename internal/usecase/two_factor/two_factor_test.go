@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytwofactor "github.com/FabioRocha231/saas-core/internal/infra/db/repository/two_factor"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactor(t *testing.T) {
+	uuid := pkg.NewUUID()
+	token := pkg.NewToken()
+	totp := &pkg.TOTP{}
+	passwordHash := pkg.NewPasswordHash()
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+
+	userRepo := memoryuser.New(clock)
+	repo := memorytwofactor.New(clock)
+	tx := memorytx.New()
+	checker := NewChecker(repo, totp, token, clock)
+	required := []entity.UserRole{entity.UserRoleStoreOwner, entity.UserRoleAdmin}
+
+	setup := NewSetupUsecase(repo, userRepo, totp, "saas-core")
+	enable := NewEnableUsecase(repo, checker, token, uuid, tx, clock)
+	regenerate := NewRegenerateRecoveryCodesUsecase(repo, checker, token, uuid, tx)
+	disable := NewDisableUsecase(repo, userRepo, checker, passwordHash, tx, required)
+	status := NewStatusUsecase(repo)
+
+	newUser := func(t *testing.T, role entity.UserRole) *entity.User {
+		hash, err := passwordHash.Hash("123456")
+		require.NoError(t, err)
+		u := &entity.User{ID: uuid.Generate(), Name: "usuario", Role: role, Password: hash}
+		u.Email = u.ID + "@example.com"
+		u.Cpf = u.ID
+		require.NoError(t, userRepo.Create(t.Context(), u))
+		return u
+	}
+	code := func(t *testing.T, secret string) string {
+		c, err := totp.Code(secret, clock.Now())
+		require.NoError(t, err)
+		return c
+	}
+	enroll := func(t *testing.T, userID string) (string, []string) {
+		out, err := setup.Execute(t.Context(), userID)
+		require.NoError(t, err)
+		codes, err := enable.Execute(t.Context(), CodeInput{UserID: userID, Code: code(t, out.Secret)})
+		require.NoError(t, err)
+		return out.Secret, codes.RecoveryCodes
+	}
+
+	t.Run("test setup returns secret and otpauth uri", func(t *testing.T) {
+		u := newUser(t, entity.UserRoleCostumer)
+
+		out, err := setup.Execute(t.Context(), u.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, out.Secret)
+		require.Contains(t, out.OTPAuthURI, "otpauth://totp/saas-core:")
+		require.Contains(t, out.OTPAuthURI, "secret="+out.Secret)
+
+		// ainda pendente: não conta como ativo
+		st, err := status.Execute(t.Context(), u.ID)
+		require.NoError(t, err)
+		require.False(t, st.Enabled)
+	})
+
+	t.Run("test enable requires a valid code and returns recovery codes", func(t *testing.T) {
+		u := newUser(t, entity.UserRoleCostumer)
+
+		_, err := enable.Execute(t.Context(), CodeInput{UserID: u.ID, Code: "123456"})
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+
+		out, err := setup.Execute(t.Context(), u.ID)
+		require.NoError(t, err)
+
+		_, err = enable.Execute(t.Context(), CodeInput{UserID: u.ID, Code: "000000"})
+		require.EqualError(t, err, "invalid_argument: invalid two-factor code")
+
+		codes, err := enable.Execute(t.Context(), CodeInput{UserID: u.ID, Code: code(t, out.Secret)})
+		require.NoError(t, err)
+		require.Len(t, codes.RecoveryCodes, 10)
+		require.Regexp(t, `^\d{4}-\d{4}-\d{4}$`, codes.RecoveryCodes[0])
+
+		st, err := status.Execute(t.Context(), u.ID)
+		require.NoError(t, err)
+		require.Equal(t, &StatusOutput{Enabled: true, RecoveryCodesLeft: 10}, st)
+
+		_, err = setup.Execute(t.Context(), u.ID)
+		require.True(t, errx.Is(err, errx.CodeConflict))
+	})
+
+	t.Run("test a totp code is accepted only once", func(t *testing.T) {
+		u := newUser(t, entity.UserRoleCostumer)
+		secret, _ := enroll(t, u.ID)
+
+		// o código que ativou não serve para regerar os de recuperação
+		_, err := regenerate.Execute(t.Context(), CodeInput{UserID: u.ID, Code: code(t, secret)})
+		require.EqualError(t, err, "invalid_argument: invalid two-factor code")
+
+		clock.Advance(30 * time.Second)
+		out, err := regenerate.Execute(t.Context(), CodeInput{UserID: u.ID, Code: code(t, secret)})
+		require.NoError(t, err)
+		require.Len(t, out.RecoveryCodes, 10)
+	})
+
+	t.Run("test recovery codes are single use and regenerating invalidates them", func(t *testing.T) {
+		u := newUser(t, entity.UserRoleCostumer)
+		secret, recovery := enroll(t, u.ID)
+
+		tf, err := repo.GetByUserID(t.Context(), u.ID)
+		require.NoError(t, err)
+
+		ok, err := checker.Verify(t.Context(), tf, recovery[0])
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, err = checker.Verify(t.Context(), tf, recovery[0])
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		clock.Advance(30 * time.Second)
+		_, err = regenerate.Execute(t.Context(), CodeInput{UserID: u.ID, Code: code(t, secret)})
+		require.NoError(t, err)
+
+		ok, err = checker.Verify(t.Context(), tf, recovery[1])
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("test disable checks role, password and code", func(t *testing.T) {
+		owner := newUser(t, entity.UserRoleStoreOwner)
+		_, recovery := enroll(t, owner.ID)
+		err := disable.Execute(t.Context(), DisableInput{UserID: owner.ID, Password: "123456", Code: recovery[0]})
+		require.EqualError(t, err, "forbidden: two-factor authentication is required for role store_owner")
+
+		u := newUser(t, entity.UserRoleCostumer)
+		_, recovery = enroll(t, u.ID)
+
+		err = disable.Execute(t.Context(), DisableInput{UserID: u.ID, Password: "errada", Code: recovery[0]})
+		require.True(t, errx.Is(err, errx.CodeForbidden))
+		err = disable.Execute(t.Context(), DisableInput{UserID: u.ID, Password: "123456", Code: "0000-0000-0000"})
+		require.EqualError(t, err, "invalid_argument: invalid two-factor code")
+
+		require.NoError(t, disable.Execute(t.Context(), DisableInput{UserID: u.ID, Password: "123456", Code: recovery[0]}))
+
+		st, err := status.Execute(t.Context(), u.ID)
+		require.NoError(t, err)
+		require.False(t, st.Enabled)
+
+		err = disable.Execute(t.Context(), DisableInput{UserID: u.ID, Password: "123456", Code: recovery[1]})
+		require.True(t, errx.Is(err, errx.CodeConflict))
+	})
+}
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"time"
 
 	"github.com/FabioRocha231/saas-core/internal/domain/errx"
 
@@ -17,6 +16,7 @@ type CreateUserUsecase struct {
 	storeRepo    repository.StoreRepository
 	uuid         ports.UUIDInterface
 	passwordHash ports.PasswordHashInterface
+	clock        ports.Clock
 }
 
 type CreateUserInput struct {
@@ -38,12 +38,14 @@ func NewCreateUserUsecase(
 	storeRepo repository.StoreRepository,
 	uuid ports.UUIDInterface,
 	passwordHash ports.PasswordHashInterface,
+	clock ports.Clock,
 ) *CreateUserUsecase {
 	return &CreateUserUsecase{
 		userRepo:     userRepo,
 		storeRepo:    storeRepo,
 		uuid:         uuid,
 		passwordHash: passwordHash,
+		clock:        clock,
 	}
 }
 
@@ -82,9 +84,9 @@ func (uc *CreateUserUsecase) Execute(context context.Context, input CreateUserIn
 		Email:     input.Email,
 		Cpf:       cpf.Digits(),
 		Phone:     input.Phone,
-		Status:    entity.UserStatusActive,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Status:    entity.UserStatusPending, // até verificar email ou telefone
+		CreatedAt: uc.clock.Now(),
+		UpdatedAt: uc.clock.Now(),
 	}
 
 	switch input.UserType {
@@ -10,11 +10,11 @@ import (
 )
 
 func TestCreateUser(t *testing.T) {
-	userRepo := memoryuser.New()
+	userRepo := memoryuser.New(pkg.NewClock())
 	storeRepo := memorystore.New()
 	uuid := pkg.NewUUID()
 	passwordHash := pkg.NewPasswordHash()
-	u := NewCreateUserUsecase(userRepo, storeRepo, uuid, passwordHash)
+	u := NewCreateUserUsecase(userRepo, storeRepo, uuid, passwordHash, pkg.NewClock())
 
 	t.Run("test create a user with a empty input", func(t *testing.T) {
 		_, err := u.Execute(t.Context(), CreateUserInput{})
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+const codeDigits = 6
+
+type Settings struct {
+	// validade do código
+	TTL time.Duration
+	// palpites errados aceitos antes de exigir um código novo
+	MaxAttempts int
+	// intervalo mínimo entre dois envios para o mesmo usuário e propósito
+	ResendAfter time.Duration
+}
+
+type SendCodeInput struct {
+	UserID  string
+	Purpose entity.VerificationPurpose
+}
+
+type SendCodeOutput struct {
+	Channel   string    `json:"channel"`
+	Target    string    `json:"target"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type SendCodeUsecase struct {
+	codes    repository.VerificationCodeRepository
+	users    repository.UserRepository
+	sender   ports.MessageSender
+	token    ports.TokenInterface
+	uuid     ports.UUIDInterface
+	clock    ports.Clock
+	settings Settings
+}
+
+func NewSendCodeUsecase(
+	codes repository.VerificationCodeRepository,
+	users repository.UserRepository,
+	sender ports.MessageSender,
+	token ports.TokenInterface,
+	uuid ports.UUIDInterface,
+	clock ports.Clock,
+	settings Settings,
+) *SendCodeUsecase {
+	return &SendCodeUsecase{
+		codes:    codes,
+		users:    users,
+		sender:   sender,
+		token:    token,
+		uuid:     uuid,
+		clock:    clock,
+		settings: settings,
+	}
+}
+
+func (uc *SendCodeUsecase) Execute(ctx context.Context, in SendCodeInput) (*SendCodeOutput, error) {
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+
+	user, err := uc.users.GetByID(ctx, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := messageFor(user, in.Purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+
+	last, err := uc.codes.GetLatest(ctx, user.ID, in.Purpose)
+	switch {
+	case err == nil:
+		if !last.IsConsumed() && now.Before(last.CreatedAt.Add(uc.settings.ResendAfter)) {
+			return nil, errx.New(errx.CodeConflict, "verification code sent recently, try again later")
+		}
+	case !errx.Is(err, errx.CodeNotFound):
+		return nil, err
+	}
+
+	code, err := uc.token.GenerateCode(codeDigits)
+	if err != nil {
+		return nil, err
+	}
+
+	vc := &entity.VerificationCode{
+		ID:        uc.uuid.Generate(),
+		UserID:    user.ID,
+		Purpose:   in.Purpose,
+		Target:    msg.To,
+		ExpiresAt: now.Add(uc.settings.TTL),
+		CreatedAt: now,
+	}
+	vc.CodeHash = hashCode(uc.token, vc.ID, code)
+
+	if err := uc.codes.Create(ctx, vc); err != nil {
+		return nil, err
+	}
+
+	msg.Body = fmt.Sprintf("Seu código de verificação é %s. Ele expira em %d minutos.", code, int(uc.settings.TTL.Minutes()))
+	if err := uc.sender.Send(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return &SendCodeOutput{
+		Channel:   string(msg.Channel),
+		Target:    msg.To,
+		ExpiresAt: vc.ExpiresAt,
+	}, nil
+}
+
+// messageFor escolhe canal e destino pelo propósito e recusa o que já está
+// verificado.
+func messageFor(user *entity.User, purpose entity.VerificationPurpose) (ports.Message, error) {
+	switch purpose {
+	case entity.VerificationPurposeEmail:
+		if user.EmailVerifiedAt != nil {
+			return ports.Message{}, errx.New(errx.CodeConflict, "email already verified")
+		}
+		return ports.Message{Channel: ports.MessageChannelEmail, To: user.Email, Subject: "Confirme seu email"}, nil
+	case entity.VerificationPurposePhone:
+		if user.PhoneVerifiedAt != nil {
+			return ports.Message{}, errx.New(errx.CodeConflict, "phone already verified")
+		}
+		if user.Phone == "" {
+			return ports.Message{}, errx.New(errx.CodeInvalid, "user has no phone")
+		}
+		return ports.Message{Channel: ports.MessageChannelSMS, To: user.Phone}, nil
+	default:
+		return ports.Message{}, errx.F(errx.CodeInvalid, "invalid verification purpose %q", purpose)
+	}
+}
+
+// hashCode amarra o código ao registro: o mesmo código de 6 dígitos gera
+// hashes diferentes em registros diferentes.
+func hashCode(token ports.TokenInterface, id, code string) string {
+	return token.Hash(id + ":" + code)
+}
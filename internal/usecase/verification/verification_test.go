@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	memorytx "github.com/FabioRocha231/saas-core/internal/infra/db/repository/tx"
+	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
+	memoryverificationcode "github.com/FabioRocha231/saas-core/internal/infra/db/repository/verification_code"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/FabioRocha231/saas-core/test/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+// barrierCodeRepo segura cada GetLatest até todos os palpites terem lido o
+// código, para todos partirem da mesma contagem de tentativas.
+type barrierCodeRepo struct {
+	repository.VerificationCodeRepository
+	read *sync.WaitGroup
+}
+
+func (r *barrierCodeRepo) GetLatest(ctx context.Context, userID string, purpose entity.VerificationPurpose) (*entity.VerificationCode, error) {
+	c, err := r.VerificationCodeRepository.GetLatest(ctx, userID, purpose)
+	r.read.Done()
+	r.read.Wait()
+	return c, err
+}
+
+func TestVerificationCodes(t *testing.T) {
+	uuid := pkg.NewUUID()
+	token := pkg.NewToken()
+	clock := pkg.NewFakeClock(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC))
+	settings := Settings{TTL: 10 * time.Minute, MaxAttempts: 3, ResendAfter: time.Minute}
+
+	userRepo := memoryuser.New(clock)
+	codeRepo := memoryverificationcode.New(clock)
+	tx := memorytx.New()
+	outbox := testkit.NewOutbox()
+
+	send := NewSendCodeUsecase(codeRepo, userRepo, outbox, token, uuid, clock, settings)
+	verify := NewVerifyCodeUsecase(codeRepo, userRepo, token, tx, clock, settings)
+
+	newUser := func(t *testing.T) *entity.User {
+		u := &entity.User{
+			ID:     uuid.Generate(),
+			Name:   "usuario",
+			Phone:  "11999999999",
+			Role:   entity.UserRoleCostumer,
+			Status: entity.UserStatusPending,
+		}
+		u.Email = u.ID + "@example.com"
+		u.Cpf = u.ID
+		require.NoError(t, userRepo.Create(t.Context(), u))
+		return u
+	}
+
+	t.Run("test verify email activates a pending user", func(t *testing.T) {
+		u := newUser(t)
+
+		out, err := send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.NoError(t, err)
+		require.Equal(t, string(ports.MessageChannelEmail), out.Channel)
+		require.Equal(t, u.Email, out.Target)
+		require.Equal(t, clock.Now().Add(10*time.Minute), out.ExpiresAt)
+
+		res, err := verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: outbox.LastCode(t, u.Email)})
+		require.NoError(t, err)
+		require.True(t, res.EmailVerified)
+		require.False(t, res.PhoneVerified)
+		require.Equal(t, "active", res.Status)
+
+		got, err := userRepo.GetByID(t.Context(), u.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.EmailVerifiedAt)
+
+		_, err = send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.EqualError(t, err, "conflict: email already verified")
+	})
+
+	t.Run("test verify phone sends an sms", func(t *testing.T) {
+		u := newUser(t)
+
+		out, err := send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposePhone})
+		require.NoError(t, err)
+		require.Equal(t, string(ports.MessageChannelSMS), out.Channel)
+
+		res, err := verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposePhone, Code: outbox.LastCode(t, u.Phone)})
+		require.NoError(t, err)
+		require.True(t, res.PhoneVerified)
+		require.False(t, res.EmailVerified)
+	})
+
+	t.Run("test a code is used only once", func(t *testing.T) {
+		u := newUser(t)
+
+		_, err := send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposePhone})
+		require.NoError(t, err)
+		code := outbox.LastCode(t, u.Phone)
+
+		_, err = verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposePhone, Code: code})
+		require.NoError(t, err)
+
+		_, err = verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposePhone, Code: code})
+		require.EqualError(t, err, "conflict: verification code already used")
+	})
+
+	t.Run("test wrong codes are limited", func(t *testing.T) {
+		u := newUser(t)
+
+		_, err := send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.NoError(t, err)
+		code := outbox.LastCode(t, u.Email)
+
+		for range settings.MaxAttempts {
+			_, err = verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: "wrong"})
+			require.EqualError(t, err, "invalid_argument: invalid verification code")
+		}
+
+		// nem o código certo passa depois do limite
+		_, err = verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: code})
+		require.EqualError(t, err, "conflict: too many attempts, request a new code")
+	})
+
+	t.Run("test parallel wrong codes are limited", func(t *testing.T) {
+		u := newUser(t)
+
+		_, err := send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.NoError(t, err)
+
+		// rajada de palpites ao mesmo tempo: só MaxAttempts chegam a comparar
+		const guesses = 10
+		var read sync.WaitGroup
+		read.Add(guesses)
+		burst := NewVerifyCodeUsecase(&barrierCodeRepo{VerificationCodeRepository: codeRepo, read: &read}, userRepo, token, tx, clock, settings)
+
+		errs := make(chan error, guesses)
+		var wg sync.WaitGroup
+		for range guesses {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := burst.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: "wrong"})
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		compared := 0
+		for err := range errs {
+			if err.Error() == "invalid_argument: invalid verification code" {
+				compared++
+				continue
+			}
+			require.EqualError(t, err, "conflict: too many attempts, request a new code")
+		}
+		require.Equal(t, settings.MaxAttempts, compared)
+	})
+
+	t.Run("test expired code", func(t *testing.T) {
+		u := newUser(t)
+
+		_, err := send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.NoError(t, err)
+		code := outbox.LastCode(t, u.Email)
+
+		clock.Advance(11 * time.Minute)
+
+		_, err = verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: code})
+		require.EqualError(t, err, "conflict: verification code expired")
+	})
+
+	t.Run("test resend waits for the cooldown and replaces the old code", func(t *testing.T) {
+		u := newUser(t)
+
+		_, err := send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.NoError(t, err)
+		first := outbox.LastCode(t, u.Email)
+
+		_, err = send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.True(t, errx.Is(err, errx.CodeConflict))
+
+		clock.Advance(time.Minute)
+		_, err = send.Execute(t.Context(), SendCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail})
+		require.NoError(t, err)
+		second := outbox.LastCode(t, u.Email)
+
+		if first != second {
+			_, err = verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: first})
+			require.EqualError(t, err, "invalid_argument: invalid verification code")
+		}
+		_, err = verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: second})
+		require.NoError(t, err)
+	})
+
+	t.Run("test verify without a requested code", func(t *testing.T) {
+		u := newUser(t)
+
+		_, err := verify.Execute(t.Context(), VerifyCodeInput{UserID: u.ID, Purpose: entity.VerificationPurposeEmail, Code: "123456"})
+		require.True(t, errx.Is(err, errx.CodeNotFound))
+	})
+}
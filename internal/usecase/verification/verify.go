@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+)
+
+type VerifyCodeInput struct {
+	UserID  string
+	Purpose entity.VerificationPurpose
+	Code    string
+}
+
+type VerifyCodeOutput struct {
+	EmailVerified bool   `json:"email_verified"`
+	PhoneVerified bool   `json:"phone_verified"`
+	Status        string `json:"status"`
+}
+
+type VerifyCodeUsecase struct {
+	codes    repository.VerificationCodeRepository
+	users    repository.UserRepository
+	token    ports.TokenInterface
+	tx       ports.TxManager
+	clock    ports.Clock
+	settings Settings
+}
+
+func NewVerifyCodeUsecase(
+	codes repository.VerificationCodeRepository,
+	users repository.UserRepository,
+	token ports.TokenInterface,
+	tx ports.TxManager,
+	clock ports.Clock,
+	settings Settings,
+) *VerifyCodeUsecase {
+	return &VerifyCodeUsecase{
+		codes:    codes,
+		users:    users,
+		token:    token,
+		tx:       tx,
+		clock:    clock,
+		settings: settings,
+	}
+}
+
+func (uc *VerifyCodeUsecase) Execute(ctx context.Context, in VerifyCodeInput) (*VerifyCodeOutput, error) {
+	if in.UserID == "" {
+		return nil, errx.New(errx.CodeUnauthorized, "missing user")
+	}
+	if in.Code == "" {
+		return nil, errx.New(errx.CodeInvalid, "missing code")
+	}
+
+	vc, err := uc.codes.GetLatest(ctx, in.UserID, in.Purpose)
+	if err != nil {
+		if errx.Is(err, errx.CodeNotFound) {
+			return nil, errx.New(errx.CodeNotFound, "no verification code requested")
+		}
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+	switch {
+	case vc.IsConsumed():
+		return nil, errx.New(errx.CodeConflict, "verification code already used")
+	case vc.IsExpired(now):
+		return nil, errx.New(errx.CodeConflict, "verification code expired")
+	}
+
+	// conta a tentativa antes de comparar, fora da transação: palpites em
+	// paralelo recebem contagens diferentes e só MaxAttempts chegam ao hash
+	attempts, err := uc.codes.IncrementAttempts(ctx, vc.ID)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > uc.settings.MaxAttempts {
+		return nil, errx.New(errx.CodeConflict, "too many attempts, request a new code")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashCode(uc.token, vc.ID, in.Code)), []byte(vc.CodeHash)) != 1 {
+		return nil, errx.New(errx.CodeInvalid, "invalid verification code")
+	}
+
+	var out *VerifyCodeOutput
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.codes.MarkConsumed(ctx, vc.ID, now); err != nil {
+			return err
+		}
+
+		user, err := uc.users.GetByID(ctx, in.UserID)
+		if err != nil {
+			return err
+		}
+
+		switch in.Purpose {
+		case entity.VerificationPurposeEmail:
+			// o código vale para o email de quando foi enviado
+			if vc.Target != user.Email {
+				return errx.New(errx.CodeConflict, "email changed, request a new code")
+			}
+			user.EmailVerifiedAt = &now
+		case entity.VerificationPurposePhone:
+			if vc.Target != user.Phone {
+				return errx.New(errx.CodeConflict, "phone changed, request a new code")
+			}
+			user.PhoneVerifiedAt = &now
+		}
+		if user.Status == entity.UserStatusPending {
+			user.Status = entity.UserStatusActive
+		}
+
+		if err := uc.users.Update(ctx, user); err != nil {
+			return err
+		}
+
+		out = &VerifyCodeOutput{
+			EmailVerified: user.EmailVerifiedAt != nil,
+			PhoneVerified: user.PhoneVerifiedAt != nil,
+			Status:        string(user.Status),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"sync"
+	"time"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+type Clock struct{}
+
+func NewClock() ports.Clock {
+	return &Clock{}
+}
+
+func (c *Clock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock só anda quando mandam (Set/Advance). Usado nos testes.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	c.now = now
+	c.mu.Unlock()
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
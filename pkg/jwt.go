@@ -9,44 +9,50 @@ import (
 )
 
 type Service struct {
-	secret []byte
+	keys   *Keyset
 	ttl    time.Duration
 	issuer string
 	uuid   ports.UUIDInterface
+	clock  ports.Clock
 }
 
-func NewJwtService(secret string, ttl time.Duration, issuer string, uuid ports.UUIDInterface) ports.JwtInterface {
+func NewJwtService(keys *Keyset, ttl time.Duration, issuer string, uuid ports.UUIDInterface, clock ports.Clock) ports.JwtInterface {
 	return &Service{
-		secret: []byte(secret),
+		keys:   keys,
 		ttl:    ttl,
 		issuer: issuer,
 		uuid:   uuid,
+		clock:  clock,
 	}
 }
 
 func (s *Service) Sign(userID string, role string) (string, error) {
-	now := time.Now()
+	return s.SignWithID(s.uuid.Generate(), userID, role)
+}
+
+func (s *Service) SignWithID(jti string, userID string, role string) (string, error) {
+	now := s.clock.Now()
 	claims := ports.Claims{
 		UserID: userID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ID:        s.uuid.Generate(),
+			ID:        jti,
 			Issuer:    s.issuer,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
 		},
 	}
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return t.SignedString(s.secret)
+	key := s.keys.signing
+	t := jwt.NewWithClaims(key.Method, claims)
+	t.Header["kid"] = key.ID
+	return t.SignedString(key.Private)
 }
 
 func (s *Service) Parse(tokenStr string) (*ports.Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &ports.Claims{}, func(token *jwt.Token) (any, error) {
-		if token.Method != jwt.SigningMethodHS256 {
-			return nil, errors.New("unexpected signing method")
-		}
-		return s.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &ports.Claims{}, s.keys.verificationKey,
+		jwt.WithTimeFunc(s.clock.Now), // exp/iat conferidos no mesmo relógio do Sign
+		jwt.WithValidMethods([]string{"HS256", "RS256", "EdDSA"}),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -73,3 +79,7 @@ func (s *Service) GetExpiresAt(tokenStr string) time.Time {
 	}
 	return claims.ExpiresAt.Time
 }
+
+func (s *Service) JWKS() ports.JWKS {
+	return s.keys.JWKS()
+}
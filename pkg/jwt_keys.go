@@ -0,0 +1,216 @@
+package pkg
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSABits = 2048
+
+// JwtKey é uma chave do keyset. Sem Private ela só verifica (chave aposentada
+// ainda aceita enquanto houver token vivo assinado com ela).
+type JwtKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private any
+	Public  any
+}
+
+func (k JwtKey) CanSign() bool {
+	return k.Private != nil
+}
+
+// NewHMACKey embrulha o segredo HS256 legado. Tokens sem kid caem nele.
+func NewHMACKey(kid, secret string) (JwtKey, error) {
+	if secret == "" {
+		return JwtKey{}, errors.New("empty HMAC secret")
+	}
+	return JwtKey{ID: kid, Method: jwt.SigningMethodHS256, Private: []byte(secret), Public: []byte(secret)}, nil
+}
+
+// ParsePEMKey aceita chave privada RSA (PKCS#1/PKCS#8), Ed25519 (PKCS#8) ou
+// a chave pública (PKIX) de qualquer uma delas.
+func ParsePEMKey(kid string, data []byte) (JwtKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return JwtKey{}, fmt.Errorf("key %q: no PEM block", kid)
+	}
+
+	var (
+		parsed any
+		err    error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return JwtKey{}, fmt.Errorf("key %q: unsupported PEM block %q", kid, block.Type)
+	}
+	if err != nil {
+		return JwtKey{}, fmt.Errorf("key %q: %w", kid, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return JwtKey{}, fmt.Errorf("key %q: RSA key must have at least %d bits", kid, minRSABits)
+		}
+		return JwtKey{ID: kid, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSABits {
+			return JwtKey{}, fmt.Errorf("key %q: RSA key must have at least %d bits", kid, minRSABits)
+		}
+		return JwtKey{ID: kid, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return JwtKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return JwtKey{ID: kid, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	default:
+		return JwtKey{}, fmt.Errorf("key %q: unsupported key type %T", kid, parsed)
+	}
+}
+
+// LoadKeyDir lê todos os *.pem do diretório; o nome do arquivo é o kid.
+func LoadKeyDir(dir string) ([]JwtKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	keys := make([]JwtKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParsePEMKey(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Keyset guarda a chave que assina os tokens novos e todas as que ainda
+// verificam. Rotacionar = adicionar a chave nova, trocar o signing kid e só
+// remover a antiga depois que os tokens dela expirarem.
+type Keyset struct {
+	signing JwtKey
+	byKID   map[string]JwtKey
+	// chave usada para tokens sem kid (emitidos antes do keyset)
+	legacy *JwtKey
+}
+
+func NewKeyset(keys []JwtKey, signingKID string) (*Keyset, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no JWT key configured")
+	}
+
+	ks := &Keyset{byKID: make(map[string]JwtKey, len(keys))}
+	for _, k := range keys {
+		if k.ID == "" {
+			return nil, errors.New("JWT key without kid")
+		}
+		if _, ok := ks.byKID[k.ID]; ok {
+			return nil, fmt.Errorf("duplicated JWT kid %q", k.ID)
+		}
+		ks.byKID[k.ID] = k
+		if k.Method == jwt.SigningMethodHS256 && ks.legacy == nil {
+			legacy := k
+			ks.legacy = &legacy
+		}
+	}
+
+	if signingKID == "" {
+		if len(keys) > 1 {
+			return nil, errors.New("signing kid is required when more than one JWT key is configured")
+		}
+		signingKID = keys[0].ID
+	}
+	signing, ok := ks.byKID[signingKID]
+	if !ok {
+		return nil, fmt.Errorf("signing kid %q not found", signingKID)
+	}
+	if !signing.CanSign() {
+		return nil, fmt.Errorf("signing kid %q has no private key", signingKID)
+	}
+	ks.signing = signing
+
+	return ks, nil
+}
+
+// verificationKey devolve a chave do kid, conferindo o alg para não aceitar
+// um token HS256 assinado com a chave pública RSA.
+func (ks *Keyset) verificationKey(token *jwt.Token) (any, error) {
+	var key JwtKey
+	kid, _ := token.Header["kid"].(string)
+	switch {
+	case kid != "":
+		k, ok := ks.byKID[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		key = k
+	case ks.legacy != nil:
+		key = *ks.legacy
+	default:
+		return nil, errors.New("missing kid")
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.Public, nil
+}
+
+// JWKS publica só as chaves assimétricas; o segredo HS256 nunca sai daqui.
+func (ks *Keyset) JWKS() ports.JWKS {
+	kids := make([]string, 0, len(ks.byKID))
+	for kid := range ks.byKID {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	out := ports.JWKS{Keys: []ports.JWK{}}
+	for _, kid := range kids {
+		k := ks.byKID[kid]
+		switch pub := k.Public.(type) {
+		case *rsa.PublicKey:
+			out.Keys = append(out.Keys, ports.JWK{
+				Kty: "RSA", Kid: kid, Use: "sig", Alg: k.Method.Alg(),
+				N: b64(pub.N.Bytes()),
+				E: b64(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			out.Keys = append(out.Keys, ports.JWK{
+				Kty: "OKP", Kid: kid, Use: "sig", Alg: k.Method.Alg(),
+				Crv: "Ed25519",
+				X:   b64(pub),
+			})
+		}
+	}
+	return out
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,168 @@
+package pkg
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKey(t *testing.T, kid string) JwtKey {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	key, err := ParsePEMKey(kid, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T, kid string) JwtKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	key, err := ParsePEMKey(kid, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	return key
+}
+
+func newService(t *testing.T, keys []JwtKey, signingKID string) *Service {
+	t.Helper()
+	ks, err := NewKeyset(keys, signingKID)
+	require.NoError(t, err)
+	return NewJwtService(ks, 15*time.Minute, "saas-core", NewUUID(), NewClock()).(*Service)
+}
+
+func TestJwtService(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa-1")
+	edKey := newEd25519Key(t, "ed-1")
+	hmacKey, err := NewHMACKey("hs256", "secret")
+	require.NoError(t, err)
+
+	for _, key := range []JwtKey{rsaKey, edKey, hmacKey} {
+		t.Run("test sign and parse with "+key.Method.Alg(), func(t *testing.T) {
+			svc := newService(t, []JwtKey{key}, "")
+
+			token, err := svc.Sign("user-1", "costumer")
+			require.NoError(t, err)
+
+			claims, err := svc.Parse(token)
+			require.NoError(t, err)
+			require.Equal(t, "user-1", claims.UserID)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+			require.NoError(t, err)
+			require.Equal(t, key.ID, parsed.Header["kid"])
+			require.Equal(t, key.Method.Alg(), parsed.Header["alg"])
+		})
+	}
+
+	t.Run("test rotation keeps tokens of the previous key valid", func(t *testing.T) {
+		before := newService(t, []JwtKey{rsaKey}, "")
+		token, err := before.Sign("user-1", "costumer")
+		require.NoError(t, err)
+
+		after := newService(t, []JwtKey{rsaKey, edKey}, "ed-1")
+		_, err = after.Parse(token)
+		require.NoError(t, err)
+
+		fresh, err := after.Sign("user-1", "costumer")
+		require.NoError(t, err)
+		_, err = before.Parse(fresh)
+		require.Error(t, err, "old instance does not know the new kid")
+	})
+
+	t.Run("test retired key with only the public part still verifies", func(t *testing.T) {
+		token, err := newService(t, []JwtKey{edKey}, "").Sign("user-1", "costumer")
+		require.NoError(t, err)
+
+		retired := JwtKey{ID: edKey.ID, Method: edKey.Method, Public: edKey.Public}
+		svc := newService(t, []JwtKey{retired, rsaKey}, "rsa-1")
+		_, err = svc.Parse(token)
+		require.NoError(t, err)
+
+		_, err = NewKeyset([]JwtKey{retired}, "ed-1")
+		require.Error(t, err)
+	})
+
+	t.Run("test token without kid is checked against the legacy secret", func(t *testing.T) {
+		legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		})
+		token, err := legacy.SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		_, err = newService(t, []JwtKey{rsaKey, hmacKey}, "rsa-1").Parse(token)
+		require.NoError(t, err)
+
+		_, err = newService(t, []JwtKey{rsaKey}, "").Parse(token)
+		require.Error(t, err)
+	})
+
+	t.Run("test HS256 signed with the public key is rejected", func(t *testing.T) {
+		pubDER, err := x509.MarshalPKIXPublicKey(rsaKey.Public)
+		require.NoError(t, err)
+
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		})
+		forged.Header["kid"] = "rsa-1"
+		token, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+		require.NoError(t, err)
+
+		_, err = newService(t, []JwtKey{rsaKey}, "").Parse(token)
+		require.Error(t, err)
+	})
+
+	t.Run("test unknown kid is rejected", func(t *testing.T) {
+		token, err := newService(t, []JwtKey{newEd25519Key(t, "other")}, "").Sign("user-1", "costumer")
+		require.NoError(t, err)
+
+		_, err = newService(t, []JwtKey{edKey}, "").Parse(token)
+		require.Error(t, err)
+	})
+
+	t.Run("test jwks publishes only asymmetric public keys", func(t *testing.T) {
+		jwks := newService(t, []JwtKey{rsaKey, edKey, hmacKey}, "rsa-1").JWKS()
+
+		require.Len(t, jwks.Keys, 2)
+		require.Equal(t, "ed-1", jwks.Keys[0].Kid)
+		require.Equal(t, "OKP", jwks.Keys[0].Kty)
+		require.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+		require.NotEmpty(t, jwks.Keys[0].X)
+		require.Equal(t, "rsa-1", jwks.Keys[1].Kid)
+		require.Equal(t, "RSA", jwks.Keys[1].Kty)
+		require.Equal(t, "RS256", jwks.Keys[1].Alg)
+		require.Equal(t, "AQAB", jwks.Keys[1].E)
+	})
+
+	t.Run("test keyset requires a signing kid when ambiguous", func(t *testing.T) {
+		_, err := NewKeyset([]JwtKey{rsaKey, edKey}, "")
+		require.Error(t, err)
+
+		_, err = NewKeyset(nil, "")
+		require.Error(t, err)
+
+		_, err = NewHMACKey("hs256", "")
+		require.Error(t, err)
+	})
+
+	t.Run("test small RSA keys are refused", func(t *testing.T) {
+		priv, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+
+		_, err = ParsePEMKey("weak", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,144 @@
+// Package pix monta o payload EMV (BR Code) de uma cobrança PIX, o texto do
+// "copia e cola" que também vai dentro do QR code.
+package pix
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/errx"
+	"golang.org/x/text/unicode/norm"
+)
+
+// IDs dos campos EMV usados (Manual do BR Code, Bacen).
+const (
+	idPayloadFormat    = "00"
+	idPointOfInitiate  = "01"
+	idMerchantAccount  = "26"
+	idMerchantCategory = "52"
+	idCurrency         = "53"
+	idAmount           = "54"
+	idCountry          = "58"
+	idMerchantName     = "59"
+	idMerchantCity     = "60"
+	idAdditionalData   = "62"
+	idCRC              = "63"
+
+	idGUI  = "00"
+	idKey  = "01"
+	idTxID = "05"
+
+	gui = "br.gov.bcb.pix"
+
+	maxKeyLen  = 77
+	maxNameLen = 25
+	maxCityLen = 15
+	maxTxIDLen = 25
+)
+
+// Charge é uma cobrança PIX com valor e txid fixos.
+type Charge struct {
+	Key          string // chave PIX do recebedor
+	MerchantName string
+	MerchantCity string
+	TxID         string
+	Amount       int64 // centavos
+}
+
+// BRCode devolve o payload pronto para o "copia e cola", com CRC16 no final.
+func (c Charge) BRCode() (string, error) {
+	key := strings.TrimSpace(c.Key)
+	if key == "" {
+		return "", errx.New(errx.CodeInvalid, "missing pix key")
+	}
+	if len(key) > maxKeyLen {
+		return "", errx.New(errx.CodeInvalid, "pix key is too long")
+	}
+	if c.Amount <= 0 {
+		return "", errx.New(errx.CodeInvalid, "amount must be > 0")
+	}
+	name := clean(c.MerchantName, maxNameLen)
+	if name == "" {
+		return "", errx.New(errx.CodeInvalid, "missing merchant name")
+	}
+	city := clean(c.MerchantCity, maxCityLen)
+	if city == "" {
+		return "", errx.New(errx.CodeInvalid, "missing merchant city")
+	}
+	txid := TxID(c.TxID)
+	if txid == "" {
+		txid = "***" // sem txid, conforme o manual
+	}
+
+	var b strings.Builder
+	b.WriteString(field(idPayloadFormat, "01"))
+	// 12 = QR de uso único: não deve ser pago de novo depois de liquidado
+	b.WriteString(field(idPointOfInitiate, "12"))
+	b.WriteString(field(idMerchantAccount, field(idGUI, gui)+field(idKey, key)))
+	b.WriteString(field(idMerchantCategory, "0000"))
+	b.WriteString(field(idCurrency, "986"))
+	b.WriteString(field(idAmount, fmt.Sprintf("%d.%02d", c.Amount/100, c.Amount%100)))
+	b.WriteString(field(idCountry, "BR"))
+	b.WriteString(field(idMerchantName, name))
+	b.WriteString(field(idMerchantCity, city))
+	b.WriteString(field(idAdditionalData, field(idTxID, txid)))
+
+	// o CRC cobre o próprio cabeçalho do campo 63
+	b.WriteString(idCRC + "04")
+	payload := b.String()
+	return payload + CRC16(payload), nil
+}
+
+// TxID normaliza um id (ex.: o UUID do pagamento) para o formato aceito no
+// campo txid: só letras e números, até 25 caracteres.
+func TxID(id string) string {
+	var b strings.Builder
+	for _, r := range id {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+		if b.Len() == maxTxIDLen {
+			break
+		}
+	}
+	return b.String()
+}
+
+// CRC16 calcula o CRC16-CCITT (polinômio 0x1021, início 0xFFFF) exigido no
+// campo 63, em 4 dígitos hexadecimais maiúsculos.
+func CRC16(payload string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(payload); i++ {
+		crc ^= uint16(payload[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// clean tira acentos e qualquer caractere fora do ASCII imprimível, já que os
+// apps de banco leem nome e cidade como ASCII puro.
+func clean(s string, max int) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.TrimSpace(s)) {
+		if unicode.Is(unicode.Mn, r) || r < ' ' || r > '~' {
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	out := strings.TrimSpace(b.String())
+	if len(out) > max {
+		out = strings.TrimSpace(out[:max])
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package pix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBRCode(t *testing.T) {
+	t.Run("test crc16 matches the example of the bacen manual", func(t *testing.T) {
+		payload := "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-426655440000" +
+			"5204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***6304"
+
+		require.Equal(t, "1D3D", CRC16(payload))
+		require.Equal(t, "29B1", CRC16("123456789"))
+	})
+
+	t.Run("test build a charge with amount and txid", func(t *testing.T) {
+		code, err := Charge{
+			Key:          "loja@example.com",
+			MerchantName: "Lanchonete São João",
+			MerchantCity: "São Paulo",
+			TxID:         "6f1c2a4e-0b7d-4c3e-9a51-2f8e7d6c5b4a",
+			Amount:       2550,
+		}.BRCode()
+		require.NoError(t, err)
+
+		require.True(t, strings.HasPrefix(code, "000201010212"))
+		require.Contains(t, code, "26380014br.gov.bcb.pix0116loja@example.com")
+		require.Contains(t, code, "540525.50")
+		require.Contains(t, code, "5919LANCHONETE SAO JOAO")
+		require.Contains(t, code, "6009SAO PAULO")
+		require.Contains(t, code, "62290525"+"6f1c2a4e0b7d4c3e9a512f8e7")
+		require.Equal(t, CRC16(code[:len(code)-4]), code[len(code)-4:])
+	})
+
+	t.Run("test txid keeps only letters and digits", func(t *testing.T) {
+		require.Equal(t, "6f1c2a4e0b7d4c3e9a512f8e7", TxID("6f1c2a4e-0b7d-4c3e-9a51-2f8e7d6c5b4a"))
+		require.Equal(t, "abc", TxID("a-b_c"))
+	})
+
+	t.Run("test build a charge without key", func(t *testing.T) {
+		_, err := Charge{MerchantName: "Loja", MerchantCity: "Recife", Amount: 100}.BRCode()
+
+		require.Error(t, err)
+		require.Equal(t, "invalid_argument: missing pix key", err.Error())
+	})
+}
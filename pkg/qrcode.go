@@ -0,0 +1,17 @@
+package pkg
+
+import (
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+type QRCode struct{}
+
+func NewQRCode() ports.QRCodeInterface {
+	return &QRCode{}
+}
+
+func (q *QRCode) PNG(content string, size int) ([]byte, error) {
+	// nível M: o padrão dos apps de banco para BR Code
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
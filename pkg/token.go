@@ -0,0 +1,48 @@
+package pkg
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+const tokenBytes = 32
+
+type Token struct{}
+
+func NewToken() ports.TokenInterface {
+	return &Token{}
+}
+
+// Generate devolve 256 bits aleatórios em base64 url-safe.
+func (t *Token) Generate() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GenerateCode sorteia um número uniforme em [0, 10^digits) com zeros à esquerda.
+func (t *Token) GenerateCode(digits int) (string, error) {
+	if digits <= 0 || digits > 18 {
+		return "", errors.New("invalid code length")
+	}
+	n, err := rand.Int(rand.Reader, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// Hash é SHA-256: o token já tem entropia suficiente, não precisa de bcrypt.
+func (t *Token) Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,68 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// segredo ASCII "12345678901234567890" dos vetores da RFC 6238 (SHA-1)
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTP_RFC6238Vectors(t *testing.T) {
+	totp := &TOTP{}
+
+	// a RFC usa 8 dígitos; com 6 ficam os 6 últimos
+	for unix, code := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		step, ok := totp.Validate(rfcSecret, code, time.Unix(unix, 0))
+		require.True(t, ok, "t=%d", unix)
+		require.Equal(t, unix/30, step)
+	}
+}
+
+func TestTOTP_Code(t *testing.T) {
+	totp := &TOTP{}
+
+	code, err := totp.Code(rfcSecret, time.Unix(1111111109, 0))
+	require.NoError(t, err)
+	require.Equal(t, "081804", code)
+
+	_, err = totp.Code("not base32!", time.Unix(59, 0))
+	require.Error(t, err)
+}
+
+func TestTOTP_Validate_Skew(t *testing.T) {
+	totp := &TOTP{}
+	at := time.Unix(59, 0)
+
+	// o código de t=59 vale um passo antes e um depois, não dois
+	_, ok := totp.Validate(rfcSecret, "287082", at.Add(30*time.Second))
+	require.True(t, ok)
+	_, ok = totp.Validate(rfcSecret, "287082", at.Add(90*time.Second))
+	require.False(t, ok)
+
+	_, ok = totp.Validate(rfcSecret, "000000", at)
+	require.False(t, ok)
+	_, ok = totp.Validate("not base32!", "287082", at)
+	require.False(t, ok)
+}
+
+func TestTOTP_SecretAndURI(t *testing.T) {
+	totp := &TOTP{}
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	uri := totp.URI("saas-core", "dono@example.com", secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/saas-core:dono@example.com?"))
+	require.Contains(t, uri, "secret="+secret)
+	require.Contains(t, uri, "issuer=saas-core")
+}
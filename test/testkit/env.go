@@ -1,6 +1,8 @@
 package testkit
 
 import (
+	"time"
+
 	memorystore "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store"
 	memorystoremenu "github.com/FabioRocha231/saas-core/internal/infra/db/repository/store_menu"
 	memoryuser "github.com/FabioRocha231/saas-core/internal/infra/db/repository/user"
@@ -10,18 +12,22 @@ import (
 )
 
 type Env struct {
-	UUID          ports.UUIDInterface
+	UUID ports.UUIDInterface
+	// relógio parado no início do teste; avance com Clock.Advance
+	Clock         *pkg.FakeClock
 	UserRepo      repository.UserRepository
 	StoreRepo     repository.StoreRepository
 	StoreMenuRepo repository.StoreMenuRepository
 }
 
 func NewEnv() *Env {
-	userRepo := memoryuser.New()
+	clock := pkg.NewFakeClock(time.Now())
+	userRepo := memoryuser.New(clock)
 	storeRepo := memorystore.New()
-	storeMenuRepo := memorystoremenu.New()
+	storeMenuRepo := memorystoremenu.New(clock)
 	return &Env{
 		UUID:          pkg.NewUUID(),
+		Clock:         clock,
 		UserRepo:      userRepo,
 		StoreRepo:     storeRepo,
 		StoreMenuRepo: storeMenuRepo,
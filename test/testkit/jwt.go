@@ -0,0 +1,23 @@
+package testkit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// NewJwtKeyset gera um keyset Ed25519 efêmero com uma única chave "test".
+func NewJwtKeyset(t *testing.T) *pkg.Keyset {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ks, err := pkg.NewKeyset([]pkg.JwtKey{{ID: "test", Method: jwt.SigningMethodEdDSA, Private: priv, Public: pub}}, "")
+	require.NoError(t, err)
+	return ks
+}
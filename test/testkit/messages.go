@@ -0,0 +1,83 @@
+package testkit
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	ports "github.com/FabioRocha231/saas-core/internal/port"
+)
+
+// Outbox é um MessageSender que guarda as mensagens em memória.
+type Outbox struct {
+	mu       sync.Mutex
+	messages []ports.Message
+}
+
+func NewOutbox() *Outbox {
+	return &Outbox{}
+}
+
+func (o *Outbox) Send(ctx context.Context, msg ports.Message) error {
+	_ = ctx
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append(o.messages, msg)
+	return nil
+}
+
+func (o *Outbox) Messages() []ports.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]ports.Message(nil), o.messages...)
+}
+
+// LastMessage devolve a última mensagem enviada para to.
+func (o *Outbox) LastMessage(t *testing.T, to string) ports.Message {
+	t.Helper()
+	msgs := o.Messages()
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].To == to {
+			return msgs[i]
+		}
+	}
+	t.Fatalf("no message sent to %s", to)
+	return ports.Message{}
+}
+
+var codePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+// LastCode devolve o código de 6 dígitos da última mensagem enviada para to.
+func (o *Outbox) LastCode(t *testing.T, to string) string {
+	t.Helper()
+	msgs := o.Messages()
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].To != to {
+			continue
+		}
+		if code := codePattern.FindString(msgs[i].Body); code != "" {
+			return code
+		}
+	}
+	t.Fatalf("no code sent to %s", to)
+	return ""
+}
+
+var tokenPattern = regexp.MustCompile(`token (\S+) `)
+
+// LastToken devolve o token da última mensagem enviada para to.
+func (o *Outbox) LastToken(t *testing.T, to string) string {
+	t.Helper()
+	msgs := o.Messages()
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].To != to {
+			continue
+		}
+		if m := tokenPattern.FindStringSubmatch(msgs[i].Body); m != nil {
+			return m[1]
+		}
+	}
+	t.Fatalf("no token sent to %s", to)
+	return ""
+}
@@ -0,0 +1,73 @@
+package testkit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/infra/db/migrations"
+	"github.com/FabioRocha231/saas-core/internal/infra/db/sqldb"
+)
+
+// NewTestDB abre o Postgres de TEST_DATABASE_URL (com as tabelas limpas) ou,
+// sem ele, um SQLite em arquivo temporário.
+func NewTestDB(t *testing.T) *sqldb.DB {
+	t.Helper()
+
+	dialect := sqldb.DialectSQLite
+	dsn := sqldb.SQLiteDSN(filepath.Join(t.TempDir(), "test.db"))
+	if url := os.Getenv("TEST_DATABASE_URL"); url != "" {
+		dialect = sqldb.DialectPostgres
+		dsn = url
+	}
+
+	db, err := sqldb.Open(dialect, dsn)
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(t.Context()); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	if dialect == sqldb.DialectPostgres {
+		truncateAll(t, db)
+	}
+
+	return db
+}
+
+// truncateAll limpa os dados de todas as tabelas, preservando schema_migrations.
+func truncateAll(t *testing.T, db *sqldb.DB) {
+	t.Helper()
+
+	rows, err := db.QueryContext(t.Context(), `
+		SELECT tablename FROM pg_tables
+		WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'`)
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("list tables: %v", err)
+		}
+		tables = append(tables, name)
+	}
+
+	if len(tables) == 0 {
+		return
+	}
+	if _, err := db.ExecContext(t.Context(), "TRUNCATE "+strings.Join(tables, ", ")+" CASCADE"); err != nil {
+		t.Fatalf("truncate tables: %v", err)
+	}
+}
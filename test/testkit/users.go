@@ -0,0 +1,26 @@
+package testkit
+
+import (
+	"testing"
+
+	"github.com/FabioRocha231/saas-core/internal/domain/entity"
+	"github.com/FabioRocha231/saas-core/internal/port/repository"
+	"github.com/FabioRocha231/saas-core/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+// CreateUser cadastra um usuário ativo com o papel informado e devolve o id.
+// Email e cpf derivam do id para não colidir entre chamadas.
+func CreateUser(t *testing.T, users repository.UserRepository, role entity.UserRole) string {
+	t.Helper()
+	id := pkg.NewUUID().Generate()
+	require.NoError(t, users.Create(t.Context(), &entity.User{
+		ID:     id,
+		Name:   "usuario " + role.String(),
+		Email:  id + "@example.com",
+		Cpf:    id,
+		Role:   role,
+		Status: entity.UserStatusActive,
+	}))
+	return id
+}